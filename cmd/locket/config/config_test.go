@@ -9,6 +9,9 @@ import (
 	"code.cloudfoundry.org/durationjson"
 	"code.cloudfoundry.org/lager/v3/lagerflags"
 	"code.cloudfoundry.org/locket/cmd/locket/config"
+	"code.cloudfoundry.org/locket/grpcserver"
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/tracing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -21,6 +24,7 @@ var _ = Describe("LocketConfig", func() {
 		configData = `{
 			"log_level": "debug",
 			"listen_address": "1.2.3.4:9090",
+			"http_gateway_listen_address": "1.2.3.4:9091",
 			"database_driver": "mysql",
 			"max_open_database_connections": 1000,
 			"db_connection_timeout": "30s",
@@ -36,6 +40,42 @@ var _ = Describe("LocketConfig", func() {
 			"sql_ca_cert_file": "/var/vcap/jobs/locket/config/sql.ca",
 			"sql_enable_identity_verification": true,
       "report_interval":"1s",
+			"resource_types": [
+				{
+					"name": "maintenance-window",
+					"min_ttl_in_seconds": 60,
+					"max_ttl_in_seconds": 3600,
+					"active_metric": "ActiveMaintenanceWindows",
+					"expired_metric": "MaintenanceWindowsExpired"
+				}
+			],
+			"max_payload_size": 65536,
+			"namespaces": [
+				{
+					"name": "diego",
+					"max_resources": 10000
+				}
+			],
+			"authorization_rules": [
+				{
+					"identities": ["bbs.service.cf.internal"],
+					"operations": ["Lock", "Release", "Fetch"],
+					"key_prefixes": ["bbs"]
+				}
+			],
+			"rate_limits": [
+				{
+					"operations": ["Lock"],
+					"requests_per_second": 5,
+					"burst": 10,
+					"max_in_flight": 2
+				}
+			],
+			"tracing": {
+				"otlp_endpoint": "otel-collector.service.cf.internal:4317",
+				"ca_cert_file": "/var/vcap/jobs/locket/config/otel-ca.crt",
+				"sample_ratio": 0.25
+			},
 			"loggregator": {
 				"loggregator_api_port": 1234,
 				"loggregator_ca_path": "/var/ca_cert",
@@ -71,6 +111,7 @@ var _ = Describe("LocketConfig", func() {
 		config := config.LocketConfig{
 			DatabaseDriver:                "mysql",
 			ListenAddress:                 "1.2.3.4:9090",
+			HTTPGatewayListenAddress:      "1.2.3.4:9091",
 			DatabaseConnectionString:      "stuff",
 			DBConnectionTimeout:           durationjson.Duration(30 * time.Second),
 			DBReadTimeout:                 durationjson.Duration(600 * time.Second),
@@ -99,6 +140,39 @@ var _ = Describe("LocketConfig", func() {
 				InstanceID: "1",
 			},
 			ReportInterval: durationjson.Duration(time.Second),
+			ResourceTypes: []models.ResourceType{
+				{
+					Name:            "maintenance-window",
+					MinTTLInSeconds: 60,
+					MaxTTLInSeconds: 3600,
+					ActiveMetric:    "ActiveMaintenanceWindows",
+					ExpiredMetric:   "MaintenanceWindowsExpired",
+				},
+			},
+			MaxPayloadSize: 65536,
+			Namespaces: []models.Namespace{
+				{Name: "diego", MaxResources: 10000},
+			},
+			AuthorizationRules: []grpcserver.AuthorizationRule{
+				{
+					Identities:  []string{"bbs.service.cf.internal"},
+					Operations:  []string{"Lock", "Release", "Fetch"},
+					KeyPrefixes: []string{"bbs"},
+				},
+			},
+			RateLimits: []grpcserver.RateLimit{
+				{
+					Operations:        []string{"Lock"},
+					RequestsPerSecond: 5,
+					Burst:             10,
+					MaxInFlight:       2,
+				},
+			},
+			Tracing: tracing.Config{
+				OTLPEndpoint: "otel-collector.service.cf.internal:4317",
+				CACertFile:   "/var/vcap/jobs/locket/config/otel-ca.crt",
+				SampleRatio:  0.25,
+			},
 		}
 
 		Expect(locketConfig).To(Equal(config))
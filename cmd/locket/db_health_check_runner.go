@@ -11,12 +11,14 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/locket/db"
+	"code.cloudfoundry.org/locket/grpcserver"
 )
 
 type DBHealthCheckRunner struct {
 	logger                      lager.Logger
 	sqlDB                       db.LocketHealthCheckDB
 	clock                       clock.Clock
+	healthServer                *grpcserver.HealthServer
 	lock                        sync.Mutex
 	isRunning                   bool
 	HealthCheckFailureThreshold int
@@ -24,7 +26,11 @@ type DBHealthCheckRunner struct {
 	HealthCheckInterval         time.Duration
 }
 
-func NewDBHealthCheckRunner(logger lager.Logger, sqlDB db.LocketHealthCheckDB, clock clock.Clock, failureCount int, timeout, interval time.Duration) *DBHealthCheckRunner {
+// NewDBHealthCheckRunner returns a runner that checks the database every
+// interval, and exits after failureCount failed attempts in a row. Every
+// attempt is reported to the health server, so that clients can stop sending
+// requests to the instance before it exits.
+func NewDBHealthCheckRunner(logger lager.Logger, sqlDB db.LocketHealthCheckDB, clock clock.Clock, failureCount int, timeout, interval time.Duration, healthServer *grpcserver.HealthServer) *DBHealthCheckRunner {
 	if failureCount == 0 {
 		failureCount = 3
 	}
@@ -38,6 +44,7 @@ func NewDBHealthCheckRunner(logger lager.Logger, sqlDB db.LocketHealthCheckDB, c
 		logger:                      logger.Session("db-health-check-runner"),
 		sqlDB:                       sqlDB,
 		clock:                       clock,
+		healthServer:                healthServer,
 		HealthCheckFailureThreshold: failureCount,
 		HealthCheckTimeout:          timeout,
 		HealthCheckInterval:         interval,
@@ -85,6 +92,7 @@ func (runner *DBHealthCheckRunner) ExecuteTimedHealthCheckWithRetries(resultChan
 		logger := runner.logger.WithData(lager.Data{"attempt": i})
 		logger.Debug("executing-timed-health-check")
 		err := runner.ExecuteTimedHealthCheck()
+		runner.healthServer.ReportHealth("db-health-check", err)
 		if err != nil {
 			logger.Error("failed-health-check", err)
 			errs = append(errs, err)
@@ -16,22 +16,26 @@ import (
 	"code.cloudfoundry.org/lager/v3/lagertest"
 	locket "code.cloudfoundry.org/locket/cmd/locket"
 	"code.cloudfoundry.org/locket/db/dbfakes"
+	"code.cloudfoundry.org/locket/grpcserver"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 var _ = Describe("DBHealthCheckRunner", func() {
 	var (
-		fakeClock  *fakeclock.FakeClock
-		fakeDB     *dbfakes.FakeLocketHealthCheckDB
-		fakeLogger *lagertest.TestLogger
-		runner     *locket.DBHealthCheckRunner
-		process    ifrit.Process
+		fakeClock    *fakeclock.FakeClock
+		fakeDB       *dbfakes.FakeLocketHealthCheckDB
+		fakeLogger   *lagertest.TestLogger
+		runner       *locket.DBHealthCheckRunner
+		healthServer *grpcserver.HealthServer
+		process      ifrit.Process
 	)
 	BeforeEach(func() {
 		fakeClock = fakeclock.NewFakeClock(time.Now())
 		fakeLogger = lagertest.NewTestLogger("test")
 		fakeDB = &dbfakes.FakeLocketHealthCheckDB{}
 		fakeDB.PerformLocketHealthCheckReturns(nil)
-		runner = locket.NewDBHealthCheckRunner(fakeLogger, fakeDB, fakeClock, 4, 100*time.Millisecond, 200*time.Millisecond)
+		healthServer = grpcserver.NewHealthServer(fakeLogger)
+		runner = locket.NewDBHealthCheckRunner(fakeLogger, fakeDB, fakeClock, 4, 100*time.Millisecond, 200*time.Millisecond, healthServer)
 	})
 	JustBeforeEach(func() {
 		process = ginkgomon.Invoke(runner)
@@ -43,9 +47,15 @@ var _ = Describe("DBHealthCheckRunner", func() {
 		Eventually(waitCh).Should(Receive())
 	})
 
+	servingStatus := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: grpcserver.LocketServiceName})
+		Expect(err).NotTo(HaveOccurred())
+		return resp.Status
+	}
+
 	Context("when using empty values for health check settings", func() {
 		BeforeEach(func() {
-			runner = locket.NewDBHealthCheckRunner(fakeLogger, fakeDB, fakeClock, 0, 0, 0)
+			runner = locket.NewDBHealthCheckRunner(fakeLogger, fakeDB, fakeClock, 0, 0, 0, healthServer)
 		})
 		It("sets default values", func() {
 			Expect(runner.HealthCheckFailureThreshold).To(Equal(3))
@@ -121,6 +131,7 @@ var _ = Describe("DBHealthCheckRunner", func() {
 					Eventually(waitCh).Should(Receive(MatchError("meow\nmeow\nmeow\nmeow")))
 					Eventually(fakeLogger).Should(gbytes.Say("database-failure-detected-restarting-locket"))
 					Expect(fakeDB.PerformLocketHealthCheckCallCount()).To(Equal(4))
+					Expect(servingStatus()).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
 
 				})
 			})
@@ -135,6 +146,7 @@ var _ = Describe("DBHealthCheckRunner", func() {
 					waitCh := process.Wait()
 					Consistently(waitCh).ShouldNot(Receive())
 					Eventually(fakeLogger).Should(gbytes.Say("health-check-succeeded"))
+					Expect(servingStatus()).To(Equal(healthpb.HealthCheckResponse_SERVING))
 				})
 			})
 		})
@@ -173,7 +185,7 @@ var _ = Describe("DBHealthCheckRunner", func() {
 			})
 			Context("when using the default timeout", func() {
 				BeforeEach(func() {
-					runner = locket.NewDBHealthCheckRunner(fakeLogger, fakeDB, fakeClock, 0, 0, 0)
+					runner = locket.NewDBHealthCheckRunner(fakeLogger, fakeDB, fakeClock, 0, 0, 0, healthServer)
 					fakeDB.PerformLocketHealthCheckCalls(func(ctx context.Context, logger lager.Logger, t time.Time) error {
 						fakeClock.Increment(6 * time.Second)
 						time.Sleep(200 * time.Millisecond)
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/grpcserver"
+)
+
+type DBPinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// DBPingRunner pings the database every interval and reports whether it is
+// reachable to the health server. The database is pinged once before the
+// runner is ready, so that a server whose database is down does not report
+// that it is serving until the first interval. Unlike the DB health check, it
+// does not exit when the database is unreachable, requests fail until it is
+// back.
+type DBPingRunner struct {
+	logger       lager.Logger
+	db           DBPinger
+	clock        clock.Clock
+	healthServer *grpcserver.HealthServer
+	PingTimeout  time.Duration
+	PingInterval time.Duration
+}
+
+func NewDBPingRunner(logger lager.Logger, db DBPinger, clock clock.Clock, timeout, interval time.Duration, healthServer *grpcserver.HealthServer) *DBPingRunner {
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	if interval == 0 {
+		interval = 10 * time.Second
+	}
+	return &DBPingRunner{
+		logger:       logger.Session("db-ping-runner"),
+		db:           db,
+		clock:        clock,
+		healthServer: healthServer,
+		PingTimeout:  timeout,
+		PingInterval: interval,
+	}
+}
+
+func (runner *DBPingRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	runner.logger.Info("starting")
+	defer runner.logger.Info("exiting")
+
+	runner.Ping()
+	close(ready)
+
+	ticker := runner.clock.NewTicker(runner.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-signals:
+			return nil
+		case <-ticker.C():
+			runner.Ping()
+		}
+	}
+}
+
+func (runner *DBPingRunner) Ping() {
+	ctx, cancel := context.WithTimeout(context.Background(), runner.PingTimeout)
+	defer cancel()
+
+	err := runner.db.PingContext(ctx)
+	if err != nil {
+		runner.logger.Error("failed-to-ping-database", err)
+	}
+	runner.healthServer.ReportHealth("db-ping", err)
+}
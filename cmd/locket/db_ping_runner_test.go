@@ -0,0 +1,116 @@
+package main_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
+	ginkgomon "github.com/tedsuo/ifrit/ginkgomon_v2"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	locket "code.cloudfoundry.org/locket/cmd/locket"
+	"code.cloudfoundry.org/locket/grpcserver"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakePinger struct {
+	lock  sync.Mutex
+	err   error
+	calls int
+}
+
+func (p *fakePinger) PingContext(ctx context.Context) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.calls++
+	return p.err
+}
+
+func (p *fakePinger) setError(err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.err = err
+}
+
+func (p *fakePinger) callCount() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.calls
+}
+
+var _ = Describe("DBPingRunner", func() {
+	var (
+		fakeClock    *fakeclock.FakeClock
+		fakeLogger   *lagertest.TestLogger
+		pinger       *fakePinger
+		healthServer *grpcserver.HealthServer
+		runner       *locket.DBPingRunner
+		process      ifrit.Process
+	)
+
+	servingStatus := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		return resp.Status
+	}
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		fakeLogger = lagertest.NewTestLogger("test")
+		pinger = &fakePinger{}
+		healthServer = grpcserver.NewHealthServer(fakeLogger)
+		runner = locket.NewDBPingRunner(fakeLogger, pinger, fakeClock, 0, time.Second, healthServer)
+	})
+
+	JustBeforeEach(func() {
+		process = ginkgomon.Invoke(runner)
+	})
+
+	AfterEach(func() {
+		ginkgomon.Interrupt(process)
+	})
+
+	It("sets default values", func() {
+		Expect(runner.PingTimeout).To(Equal(5 * time.Second))
+		Expect(runner.PingInterval).To(Equal(time.Second))
+	})
+
+	It("pings the database before it is ready", func() {
+		Expect(pinger.callCount()).To(Equal(1))
+		Expect(servingStatus()).To(Equal(healthpb.HealthCheckResponse_SERVING))
+	})
+
+	It("pings the database every interval", func() {
+		fakeClock.WaitForWatcherAndIncrement(time.Second)
+		Eventually(pinger.callCount).Should(Equal(2))
+		fakeClock.WaitForWatcherAndIncrement(time.Second)
+		Eventually(pinger.callCount).Should(Equal(3))
+	})
+
+	Context("when the database is down when it starts", func() {
+		BeforeEach(func() {
+			pinger.setError(errors.New("connection refused"))
+		})
+
+		It("is not serving once it is ready", func() {
+			Expect(servingStatus()).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+		})
+	})
+
+	It("stops serving while the database cannot be pinged", func() {
+		pinger.setError(errors.New("connection refused"))
+		fakeClock.WaitForWatcherAndIncrement(time.Second)
+		Eventually(servingStatus).Should(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+		Expect(fakeLogger).To(gbytes.Say("failed-to-ping-database"))
+
+		pinger.setError(nil)
+		fakeClock.WaitForWatcherAndIncrement(time.Second)
+		Eventually(servingStatus).Should(Equal(healthpb.HealthCheckResponse_SERVING))
+	})
+})
@@ -23,10 +23,14 @@ import (
 	"code.cloudfoundry.org/locket/handlers"
 	"code.cloudfoundry.org/locket/metrics"
 	metrics_helpers "code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/tracing"
+	"code.cloudfoundry.org/locket/watch"
 	"code.cloudfoundry.org/tlsconfig"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
 	"github.com/tedsuo/ifrit/sigmon"
+	"go.opentelemetry.io/otel"
 )
 
 var configFilePath = flag.String(
@@ -51,6 +55,44 @@ func main() {
 		os.Exit(1)
 	}
 
+	// spans are exported in batches, the tracer provider is shut down before
+	// exiting so that the queued ones are not lost. os.Exit skips the deferred
+	// calls, so the exits with an error shut it down explicitly.
+	shutdownTracing := func() {}
+	defer func() { shutdownTracing() }()
+	fatal := func(action string, err error) {
+		shutdownTracing()
+		logger.Fatal(action, err)
+	}
+
+	resourceTypes, err := models.NewResourceTypes(cfg.ResourceTypes)
+	if err != nil {
+		fatal("invalid-resource-types", err)
+	}
+
+	namespaces, err := models.NewNamespaces(cfg.Namespaces)
+	if err != nil {
+		fatal("invalid-namespaces", err)
+	}
+
+	if cfg.Tracing.OTLPEndpoint != "" {
+		tracerProvider, err := tracing.NewTracerProvider(context.Background(), "locket", cfg.Tracing)
+		if err != nil {
+			fatal("invalid-tracing-config", err)
+		}
+		shutdownTracing = func() {
+			err := tracerProvider.Shutdown(context.Background())
+			if err != nil {
+				logger.Error("failed-to-shut-down-tracer-provider", err)
+			}
+		}
+
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+			logger.Error("failed-to-export-spans", err)
+		}))
+	}
+
 	clock := clock.NewClock()
 
 	dbParams := &helpers.ConnectParams{
@@ -69,7 +111,7 @@ func main() {
 	)
 
 	if err != nil {
-		logger.Fatal("failed-to-open-sql", err)
+		fatal("failed-to-open-sql", err)
 	}
 	defer sqlConn.Close()
 
@@ -79,7 +121,7 @@ func main() {
 
 	err = sqlConn.Ping()
 	if err != nil {
-		logger.Fatal("sql-failed-to-connect", err)
+		fatal("sql-failed-to-connect", err)
 	}
 
 	dbMonitor := monitor.New()
@@ -89,17 +131,19 @@ func main() {
 		monitoredDB,
 		cfg.DatabaseDriver,
 		guidprovider.DefaultGuidProvider,
+		clock,
+		namespaces,
 	)
 
 	err = sqlDB.CreateLockTable(context.Background(), logger)
 	if err != nil {
-		logger.Fatal("failed-to-create-lock-table", err)
+		fatal("failed-to-create-lock-table", err)
 	}
 
 	if cfg.EnableDBHealthCheck {
 		err = sqlDB.CreateHealthCheckTable(context.Background(), logger)
 		if err != nil {
-			logger.Fatal("failed-to-create-health-check-table", err)
+			fatal("failed-to-create-health-check-table", err)
 		}
 	}
 
@@ -108,14 +152,15 @@ func main() {
 		tlsconfig.WithIdentityFromFile(cfg.CertFile, cfg.KeyFile),
 	).Server(tlsconfig.WithClientAuthenticationFromFile(cfg.CaFile))
 	if err != nil {
-		logger.Fatal("invalid-tls-config", err)
+		fatal("invalid-tls-config", err)
 	}
 
-	lockMetricsNotifier := metrics.NewLockMetricsNotifier(logger, clock, metronClient, time.Duration(cfg.ReportInterval), sqlDB)
+	lockMetricsNotifier := metrics.NewLockMetricsNotifier(logger, clock, metronClient, time.Duration(cfg.ReportInterval), sqlDB, resourceTypes, namespaces)
 	dbMetricsNotifier := metrics.NewDBMetricsNotifier(logger, clock, metronClient, time.Duration(cfg.ReportInterval), sqlDB, dbMonitor)
-	requestNotifier := metrics_helpers.NewRequestMetricsNotifier(logger, clock, metronClient, time.Duration(cfg.ReportInterval), []string{"Lock", "Release", "Fetch", "FetchAll"})
-	lockPick := expiration.NewLockPick(sqlDB, clock, metronClient)
-	burglar := expiration.NewBurglar(logger, sqlDB, lockPick, clock, locket.RetryInterval, metronClient)
+	requestNotifier := metrics_helpers.NewRequestMetricsNotifier(logger, clock, metronClient, time.Duration(cfg.ReportInterval), grpcserver.Operations)
+	hub := watch.NewHub(watch.DefaultHistorySize, clock.Now().UnixNano())
+	lockPick := expiration.NewLockPick(sqlDB, hub, clock, metronClient, resourceTypes)
+	burglar := expiration.NewBurglar(logger, sqlDB, lockPick, hub, clock, locket.RetryInterval, metronClient)
 	exitCh := make(chan struct{})
 
 	dbOperationTimeout := handlers.DefaultDBOperationTimeout
@@ -123,8 +168,39 @@ func main() {
 		dbOperationTimeout = time.Duration(cfg.DBOperationTimeout)
 	}
 
-	handler := handlers.NewLocketHandler(logger, sqlDB, lockPick, requestNotifier, exitCh, dbOperationTimeout)
-	server := grpcserver.NewGRPCServer(logger, cfg.ListenAddress, tlsConfig, handler)
+	maxPayloadSize := handlers.DefaultMaxPayloadSize
+	if cfg.MaxPayloadSize > 0 {
+		maxPayloadSize = cfg.MaxPayloadSize
+	}
+	err = models.ValidateMaxPayloadSize(maxPayloadSize)
+	if err != nil {
+		fatal("invalid-max-payload-size", err)
+	}
+
+	handler := handlers.NewLocketHandler(logger, sqlDB, lockPick, hub, clock, exitCh, dbOperationTimeout, resourceTypes, maxPayloadSize, namespaces)
+	interceptors := []grpcserver.Interceptor{
+		grpcserver.NewRequestTracer(),
+		grpcserver.NewRequestMonitor(requestNotifier, exitCh),
+	}
+
+	if len(cfg.RateLimits) > 0 {
+		rateLimiter, err := grpcserver.NewRateLimiter(clock, cfg.RateLimits)
+		if err != nil {
+			fatal("invalid-rate-limits", err)
+		}
+		interceptors = append(interceptors, rateLimiter)
+	}
+
+	if len(cfg.AuthorizationRules) > 0 {
+		authorizer, err := grpcserver.NewAuthorizer(cfg.AuthorizationRules)
+		if err != nil {
+			fatal("invalid-authorization-rules", err)
+		}
+		interceptors = append(interceptors, authorizer)
+	}
+
+	healthServer := grpcserver.NewHealthServer(logger)
+	server := grpcserver.NewGRPCServer(logger, cfg.ListenAddress, tlsConfig, handler, healthServer, interceptors, models.MaxMessageSize(maxPayloadSize))
 
 	var dbHealthCheckRunner ifrit.Runner
 	if cfg.EnableDBHealthCheck {
@@ -135,10 +211,23 @@ func main() {
 			cfg.HealthCheckFailureThreshold,
 			time.Duration(cfg.HealthCheckTimeout),
 			time.Duration(cfg.HealthCheckInterval),
+			healthServer,
 		)
 	}
 
+	dbPingRunner := NewDBPingRunner(
+		logger,
+		sqlConn,
+		clock,
+		time.Duration(cfg.HealthCheckTimeout),
+		time.Duration(cfg.HealthCheckInterval),
+		healthServer,
+	)
+
+	// the database is pinged before the server starts, so that the first
+	// health checks already report whether it can be reached
 	members := grouper.Members{
+		{Name: "db-ping", Runner: dbPingRunner},
 		{Name: "server", Runner: server},
 		{Name: "burglar", Runner: burglar},
 		{Name: "lock-metrics-notifier", Runner: lockMetricsNotifier},
@@ -146,6 +235,13 @@ func main() {
 		{Name: "request-metrics-notifier", Runner: requestNotifier},
 	}
 
+	if cfg.HTTPGatewayListenAddress != "" {
+		members = append(members, grouper.Member{
+			Name:   "http-gateway",
+			Runner: grpcserver.NewHTTPGateway(logger, cfg.HTTPGatewayListenAddress, tlsConfig, handler, interceptors),
+		})
+	}
+
 	if cfg.EnableDBHealthCheck {
 		members = append(grouper.Members{
 			{Name: "db-health-check", Runner: dbHealthCheckRunner},
@@ -172,6 +268,7 @@ func main() {
 	err = <-monitor.Wait()
 	if err != nil {
 		logger.Error("exited-with-failure", err)
+		shutdownTracing()
 		os.Exit(1)
 	}
 }
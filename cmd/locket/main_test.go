@@ -17,6 +17,10 @@ import (
 	"code.cloudfoundry.org/locket/cmd/locket/config"
 	"code.cloudfoundry.org/locket/cmd/locket/testrunner"
 	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/tracing"
+	"go.opentelemetry.io/otel/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -272,6 +276,51 @@ var _ = Describe("Locket", func() {
 			})
 		})
 
+		Context("tracing", func() {
+			var (
+				collector       *fakeCollector
+				collectorServer *grpc.Server
+			)
+
+			BeforeEach(func() {
+				port, err := portAllocator.ClaimPorts(1)
+				Expect(err).NotTo(HaveOccurred())
+				collectorAddress := fmt.Sprintf("127.0.0.1:%d", port)
+
+				lis, err := net.Listen("tcp", collectorAddress)
+				Expect(err).NotTo(HaveOccurred())
+				collector = &fakeCollector{}
+				collectorServer = grpc.NewServer()
+				collectortrace.RegisterTraceServiceServer(collectorServer, collector)
+				go collectorServer.Serve(lis)
+
+				configOverrides = append(configOverrides, func(cfg *config.LocketConfig) {
+					cfg.Tracing = tracing.Config{OTLPEndpoint: collectorAddress, Insecure: true}
+				})
+			})
+
+			AfterEach(func() {
+				collectorServer.Stop()
+			})
+
+			It("exports the spans of requests in the trace of the client", func() {
+				traceID := trace.TraceID{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x80, 0x31, 0x9c}
+				ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+					TraceID:    traceID,
+					SpanID:     trace.SpanID{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+					TraceFlags: trace.FlagsSampled,
+				}))
+
+				resource := &models.Resource{Key: "traced", Value: "test-data", Owner: "jim", TypeCode: models.LOCK}
+				_, err := locketClient.Lock(ctx, &models.LockRequest{Resource: resource, TtlInSeconds: 10})
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(func() []string {
+					return collector.spanNames(traceID)
+				}, 10*time.Second).Should(ContainElements("models.Locket/Lock", "locketHandler.Lock", "sql.transaction"))
+			})
+		})
+
 		Context("Lock", func() {
 			Context("if the table disappears", func() {
 				AfterEach(func() {
@@ -492,5 +541,104 @@ var _ = Describe("Locket", func() {
 				})
 			})
 		})
+
+		Context("Watch", func() {
+			var (
+				stream models.Locket_WatchClient
+				cancel context.CancelFunc
+			)
+
+			JustBeforeEach(func() {
+				var (
+					ctx context.Context
+					err error
+				)
+				ctx, cancel = context.WithCancel(context.Background())
+				stream, err = locketClient.Watch(ctx, &models.WatchRequest{KeyPrefix: "watched/"})
+				Expect(err).NotTo(HaveOccurred())
+
+				// the server sends the headers once the watch is established
+				_, err = stream.Header()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				cancel()
+			})
+
+			It("streams changes to matching resources", func() {
+				resource := &models.Resource{Key: "watched/test", Value: "test-data", Owner: "jim", TypeCode: models.LOCK}
+				unwatched := &models.Resource{Key: "test", Value: "test-data", Owner: "jim", TypeCode: models.LOCK}
+
+				_, err := locketClient.Lock(context.Background(), &models.LockRequest{Resource: unwatched, TtlInSeconds: 10})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = locketClient.Lock(context.Background(), &models.LockRequest{Resource: resource, TtlInSeconds: 10})
+				Expect(err).NotTo(HaveOccurred())
+
+				event, err := stream.Recv()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event.Type).To(Equal(models.CREATED))
+				Expect(event.Resource.Key).To(Equal("watched/test"))
+
+				_, err = locketClient.Release(context.Background(), &models.ReleaseRequest{Resource: resource})
+				Expect(err).NotTo(HaveOccurred())
+
+				next, err := stream.Recv()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(next.Type).To(Equal(models.DELETED))
+				Expect(next.Resource.Key).To(Equal("watched/test"))
+				Expect(next.Revision).To(BeNumerically(">", event.Revision))
+			})
+
+			It("reports expired resources", func() {
+				resource := &models.Resource{Key: "watched/presence", Value: "test-data", Owner: "jim", TypeCode: models.PRESENCE}
+				_, err := locketClient.Lock(context.Background(), &models.LockRequest{Resource: resource, TtlInSeconds: 1})
+				Expect(err).NotTo(HaveOccurred())
+
+				event, err := stream.Recv()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event.Type).To(Equal(models.CREATED))
+
+				event, err = stream.Recv()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event.Type).To(Equal(models.EXPIRED))
+				Expect(event.Resource.Key).To(Equal("watched/presence"))
+			})
+		})
 	})
 })
+
+// fakeCollector stands in for an OpenTelemetry collector, keeping the spans it
+// receives.
+type fakeCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	lock     sync.Mutex
+	received []*collectortrace.ExportTraceServiceRequest
+}
+
+func (c *fakeCollector) Export(ctx context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.received = append(c.received, req)
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func (c *fakeCollector) spanNames(traceID trace.TraceID) []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var names []string
+	for _, req := range c.received {
+		for _, resourceSpans := range req.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				for _, span := range scopeSpans.Spans {
+					if trace.TraceID(span.TraceId) == traceID {
+						names = append(names, span.Name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"sort"
+
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/models"
+)
+
+const forceReleaseAction = "force-release"
+
+// ForceRelease releases the lock held on key, or all of its shared holders,
+// regardless of their owner. Every released lock is recorded in the
+// lock_audit_log table along with the actor that released it and the reason it
+// gave. The released locks are returned.
+func (db *SQLDB) ForceRelease(ctx context.Context, logger lager.Logger, key, actor, reason string) ([]*Lock, error) {
+	logger = logger.Session("force-release-lock", lager.Data{"key": key, "actor": actor, "reason": reason})
+	var released []*Lock
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		released = nil
+
+		current, err := db.fetchLock(ctx, logger, tx, key)
+		if err != nil {
+			sqlErr := db.helper.ConvertSQLError(err)
+			if sqlErr == helpers.ErrResourceNotFound {
+				logger.Debug("lock-does-not-exist")
+				return models.ErrResourceNotFound
+			}
+			logger.Error("failed-to-fetch-lock", err)
+			return sqlErr
+		}
+
+		if current.Owner != "" {
+			released = append(released, current)
+		} else {
+			released, err = db.fetchSharedLocksForUpdate(ctx, logger, tx, key)
+			if err != nil {
+				return err
+			}
+
+			_, err = db.helper.Delete(ctx, logger, tx, "shared_locks", "path = ?", key)
+			if err != nil {
+				logger.Error("failed-to-release-shared-locks", err)
+				return err
+			}
+		}
+
+		_, err = db.helper.Delete(ctx, logger, tx, "locks", "path = ?", key)
+		if err != nil {
+			logger.Error("failed-to-release-lock", err)
+			return err
+		}
+
+		for _, lock := range released {
+			err = db.insertAuditRecord(ctx, logger, tx, lock, forceReleaseAction, actor, reason)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, db.helper.ConvertSQLError(err)
+	}
+
+	for _, lock := range released {
+		logger.Info("force-released-lock", lager.Data{"owner": lock.Owner, "mode": lock.Mode})
+	}
+	return released, nil
+}
+
+func (db *SQLDB) fetchSharedLocksForUpdate(ctx context.Context, logger lager.Logger, tx helpers.Tx, key string) ([]*Lock, error) {
+	rows, err := db.helper.All(ctx, logger, tx, "shared_locks",
+		lockColumns,
+		helpers.LockRow, "path = ?", key,
+	)
+	if err != nil {
+		logger.Error("failed-to-fetch-shared-locks", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	locks := scanLocks(logger, rows, true)
+	for _, lock := range locks {
+		lock.Mode = models.SHARED
+	}
+
+	sort.Slice(locks, func(i, j int) bool {
+		return locks[i].Owner < locks[j].Owner
+	})
+
+	return locks, nil
+}
+
+func (db *SQLDB) insertAuditRecord(ctx context.Context, logger lager.Logger, tx helpers.Tx, lock *Lock, action, actor, reason string) error {
+	id, err := db.guidProvider.NextGUID()
+	if err != nil {
+		logger.Error("failed-to-generate-guid", err)
+		return err
+	}
+
+	_, err = db.helper.Insert(ctx, logger, tx, "lock_audit_log",
+		helpers.SQLAttributes{
+			"id":         id,
+			"path":       lock.Key,
+			"owner":      lock.Owner,
+			"type":       lock.Type,
+			"action":     action,
+			"actor":      actor,
+			"reason":     reason,
+			"created_at": db.clock.Now().UnixNano(),
+		},
+	)
+	if err != nil {
+		logger.Error("failed-inserting-audit-record", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package db_test
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type auditRecord struct {
+	key, owner, action, actor, reason string
+	createdAt                         int64
+}
+
+func fetchAuditRecords(key string) []auditRecord {
+	query := helpers.RebindForFlavor(
+		"SELECT path, owner, action, actor, reason, created_at FROM lock_audit_log WHERE path = ? ORDER BY owner",
+		dbFlavor,
+	)
+	rows, err := rawDB.Query(query, key)
+	Expect(err).NotTo(HaveOccurred())
+	defer rows.Close()
+
+	var records []auditRecord
+	for rows.Next() {
+		var record auditRecord
+		Expect(rows.Scan(&record.key, &record.owner, &record.action, &record.actor, &record.reason, &record.createdAt)).To(Succeed())
+		records = append(records, record)
+	}
+	return records
+}
+
+var _ = Describe("ForceRelease", func() {
+	var resource *models.Resource
+
+	BeforeEach(func() {
+		guids := 0
+		fakeGUIDProvider.NextGUIDStub = func() (string, error) {
+			guids++
+			return fmt.Sprintf("guid-%d", guids), nil
+		}
+
+		resource = &models.Resource{
+			Key:   "quack",
+			Owner: "iamthelizardking",
+			Value: "i can do anything",
+			Type:  "lock",
+		}
+	})
+
+	Context("when the lock is held", func() {
+		BeforeEach(func() {
+			_, err := sqlDB.Lock(ctx, logger, resource, 10, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("releases the lock regardless of its owner", func() {
+			released, err := sqlDB.ForceRelease(ctx, logger, resource.Key, "locket-admin", "owner is wedged")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(HaveLen(1))
+			Expect(released[0].Owner).To(Equal(resource.Owner))
+
+			_, err = sqlDB.Fetch(ctx, logger, resource.Key)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+
+		It("records who released the lock and why", func() {
+			_, err := sqlDB.ForceRelease(ctx, logger, resource.Key, "locket-admin", "owner is wedged")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fetchAuditRecords(resource.Key)).To(Equal([]auditRecord{{
+				key:       resource.Key,
+				owner:     resource.Owner,
+				action:    "force-release",
+				actor:     "locket-admin",
+				reason:    "owner is wedged",
+				createdAt: fakeClock.Now().UnixNano(),
+			}}))
+		})
+	})
+
+	Context("when the lock is held in shared mode", func() {
+		BeforeEach(func() {
+			for _, owner := range []string{"reader-1", "reader-2"} {
+				_, err := sqlDB.LockShared(ctx, logger, &models.Resource{Key: resource.Key, Owner: owner, Type: "lock"}, 10, 0, "")
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("releases all the shared holders", func() {
+			released, err := sqlDB.ForceRelease(ctx, logger, resource.Key, "locket-admin", "readers are stuck")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(HaveLen(2))
+			Expect(released[0].Owner).To(Equal("reader-1"))
+			Expect(released[0].Mode).To(Equal(models.SHARED))
+			Expect(released[1].Owner).To(Equal("reader-2"))
+
+			holders, err := sqlDB.FetchSharedHolders(ctx, logger, resource.Key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(BeEmpty())
+
+			_, err = sqlDB.Fetch(ctx, logger, resource.Key)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+
+			Expect(fetchAuditRecords(resource.Key)).To(HaveLen(2))
+		})
+	})
+
+	Context("when the lock does not exist", func() {
+		It("returns a resource not found error", func() {
+			_, err := sqlDB.ForceRelease(ctx, logger, resource.Key, "locket-admin", "just in case")
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+			Expect(fetchAuditRecords(resource.Key)).To(BeEmpty())
+		})
+	})
+})
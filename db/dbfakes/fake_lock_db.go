@@ -11,12 +11,13 @@ import (
 )
 
 type FakeLockDB struct {
-	CountStub        func(context.Context, lager.Logger, string) (int, error)
+	CountStub        func(context.Context, lager.Logger, string, string) (int, error)
 	countMutex       sync.RWMutex
 	countArgsForCall []struct {
 		arg1 context.Context
 		arg2 lager.Logger
 		arg3 string
+		arg4 string
 	}
 	countReturns struct {
 		result1 int
@@ -26,6 +27,23 @@ type FakeLockDB struct {
 		result1 int
 		result2 error
 	}
+	ExpireLeaseStub        func(context.Context, lager.Logger, *db.Lease) ([]*db.Lock, bool, error)
+	expireLeaseMutex       sync.RWMutex
+	expireLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *db.Lease
+	}
+	expireLeaseReturns struct {
+		result1 []*db.Lock
+		result2 bool
+		result3 error
+	}
+	expireLeaseReturnsOnCall map[int]struct {
+		result1 []*db.Lock
+		result2 bool
+		result3 error
+	}
 	FetchStub        func(context.Context, lager.Logger, string) (*db.Lock, error)
 	fetchMutex       sync.RWMutex
 	fetchArgsForCall []struct {
@@ -56,6 +74,34 @@ type FakeLockDB struct {
 		result1 []*db.Lock
 		result2 error
 	}
+	FetchAllLeasesStub        func(context.Context, lager.Logger) ([]*db.Lease, error)
+	fetchAllLeasesMutex       sync.RWMutex
+	fetchAllLeasesArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+	}
+	fetchAllLeasesReturns struct {
+		result1 []*db.Lease
+		result2 error
+	}
+	fetchAllLeasesReturnsOnCall map[int]struct {
+		result1 []*db.Lease
+		result2 error
+	}
+	FetchAllSharedHoldersStub        func(context.Context, lager.Logger) ([]*db.Lock, error)
+	fetchAllSharedHoldersMutex       sync.RWMutex
+	fetchAllSharedHoldersArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+	}
+	fetchAllSharedHoldersReturns struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	fetchAllSharedHoldersReturnsOnCall map[int]struct {
+		result1 []*db.Lock
+		result2 error
+	}
 	FetchAndReleaseStub        func(context.Context, lager.Logger, *db.Lock) (bool, error)
 	fetchAndReleaseMutex       sync.RWMutex
 	fetchAndReleaseArgsForCall []struct {
@@ -71,13 +117,134 @@ type FakeLockDB struct {
 		result1 bool
 		result2 error
 	}
-	LockStub        func(context.Context, lager.Logger, *models.Resource, int64) (*db.Lock, error)
+	FetchLeaseOwnersStub        func(context.Context, lager.Logger, []string) (map[string]string, error)
+	fetchLeaseOwnersMutex       sync.RWMutex
+	fetchLeaseOwnersArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 []string
+	}
+	fetchLeaseOwnersReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	fetchLeaseOwnersReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	FetchPageStub        func(context.Context, lager.Logger, string, string, string, string, map[string]string, int) ([]*db.Lock, error)
+	fetchPageMutex       sync.RWMutex
+	fetchPageArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+		arg7 map[string]string
+		arg8 int
+	}
+	fetchPageReturns struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	fetchPageReturnsOnCall map[int]struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	FetchSharedHoldersStub        func(context.Context, lager.Logger, string) ([]*db.Lock, error)
+	fetchSharedHoldersMutex       sync.RWMutex
+	fetchSharedHoldersArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+	}
+	fetchSharedHoldersReturns struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	fetchSharedHoldersReturnsOnCall map[int]struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	FetchSharedPageStub        func(context.Context, lager.Logger, string, string, string, string, string, map[string]string, int) ([]*db.Lock, error)
+	fetchSharedPageMutex       sync.RWMutex
+	fetchSharedPageArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+		arg7 string
+		arg8 map[string]string
+		arg9 int
+	}
+	fetchSharedPageReturns struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	fetchSharedPageReturnsOnCall map[int]struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	ForceReleaseStub        func(context.Context, lager.Logger, string, string, string) ([]*db.Lock, error)
+	forceReleaseMutex       sync.RWMutex
+	forceReleaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	forceReleaseReturns struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	forceReleaseReturnsOnCall map[int]struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	GrantLeaseStub        func(context.Context, lager.Logger, int64, string) (*db.Lease, error)
+	grantLeaseMutex       sync.RWMutex
+	grantLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 int64
+		arg4 string
+	}
+	grantLeaseReturns struct {
+		result1 *db.Lease
+		result2 error
+	}
+	grantLeaseReturnsOnCall map[int]struct {
+		result1 *db.Lease
+		result2 error
+	}
+	KeepAliveLeaseStub        func(context.Context, lager.Logger, string, string) (*db.Lease, error)
+	keepAliveLeaseMutex       sync.RWMutex
+	keepAliveLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 string
+	}
+	keepAliveLeaseReturns struct {
+		result1 *db.Lease
+		result2 error
+	}
+	keepAliveLeaseReturnsOnCall map[int]struct {
+		result1 *db.Lease
+		result2 error
+	}
+	LockStub        func(context.Context, lager.Logger, *models.Resource, int64, string) (*db.Lock, error)
 	lockMutex       sync.RWMutex
 	lockArgsForCall []struct {
 		arg1 context.Context
 		arg2 lager.Logger
 		arg3 *models.Resource
 		arg4 int64
+		arg5 string
 	}
 	lockReturns struct {
 		result1 *db.Lock
@@ -87,6 +254,58 @@ type FakeLockDB struct {
 		result1 *db.Lock
 		result2 error
 	}
+	LockBatchStub        func(context.Context, lager.Logger, []*models.LockRequest, string) ([]*db.Lock, []error, error)
+	lockBatchMutex       sync.RWMutex
+	lockBatchArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 []*models.LockRequest
+		arg4 string
+	}
+	lockBatchReturns struct {
+		result1 []*db.Lock
+		result2 []error
+		result3 error
+	}
+	lockBatchReturnsOnCall map[int]struct {
+		result1 []*db.Lock
+		result2 []error
+		result3 error
+	}
+	LockMultiStub        func(context.Context, lager.Logger, []*models.LockRequest, string) ([]*db.Lock, error)
+	lockMultiMutex       sync.RWMutex
+	lockMultiArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 []*models.LockRequest
+		arg4 string
+	}
+	lockMultiReturns struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	lockMultiReturnsOnCall map[int]struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	LockSharedStub        func(context.Context, lager.Logger, *models.Resource, int64, int, string) (*db.Lock, error)
+	lockSharedMutex       sync.RWMutex
+	lockSharedArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *models.Resource
+		arg4 int64
+		arg5 int
+		arg6 string
+	}
+	lockSharedReturns struct {
+		result1 *db.Lock
+		result2 error
+	}
+	lockSharedReturnsOnCall map[int]struct {
+		result1 *db.Lock
+		result2 error
+	}
 	ReleaseStub        func(context.Context, lager.Logger, *models.Resource) error
 	releaseMutex       sync.RWMutex
 	releaseArgsForCall []struct {
@@ -100,24 +319,86 @@ type FakeLockDB struct {
 	releaseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ReleaseSharedStub        func(context.Context, lager.Logger, *models.Resource) error
+	releaseSharedMutex       sync.RWMutex
+	releaseSharedArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *models.Resource
+	}
+	releaseSharedReturns struct {
+		result1 error
+	}
+	releaseSharedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeLeaseStub        func(context.Context, lager.Logger, string, string) ([]*db.Lock, error)
+	revokeLeaseMutex       sync.RWMutex
+	revokeLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 string
+	}
+	revokeLeaseReturns struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	revokeLeaseReturnsOnCall map[int]struct {
+		result1 []*db.Lock
+		result2 error
+	}
+	TransferStub        func(context.Context, lager.Logger, *models.Resource, string) (*db.Lock, error)
+	transferMutex       sync.RWMutex
+	transferArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *models.Resource
+		arg4 string
+	}
+	transferReturns struct {
+		result1 *db.Lock
+		result2 error
+	}
+	transferReturnsOnCall map[int]struct {
+		result1 *db.Lock
+		result2 error
+	}
+	UpdateStub        func(context.Context, lager.Logger, *models.Resource, int64) (*db.Lock, error)
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *models.Resource
+		arg4 int64
+	}
+	updateReturns struct {
+		result1 *db.Lock
+		result2 error
+	}
+	updateReturnsOnCall map[int]struct {
+		result1 *db.Lock
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeLockDB) Count(arg1 context.Context, arg2 lager.Logger, arg3 string) (int, error) {
+func (fake *FakeLockDB) Count(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 string) (int, error) {
 	fake.countMutex.Lock()
 	ret, specificReturn := fake.countReturnsOnCall[len(fake.countArgsForCall)]
 	fake.countArgsForCall = append(fake.countArgsForCall, struct {
 		arg1 context.Context
 		arg2 lager.Logger
 		arg3 string
-	}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.CountStub
 	fakeReturns := fake.countReturns
-	fake.recordInvocation("Count", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("Count", []interface{}{arg1, arg2, arg3, arg4})
 	fake.countMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -131,17 +412,17 @@ func (fake *FakeLockDB) CountCallCount() int {
 	return len(fake.countArgsForCall)
 }
 
-func (fake *FakeLockDB) CountCalls(stub func(context.Context, lager.Logger, string) (int, error)) {
+func (fake *FakeLockDB) CountCalls(stub func(context.Context, lager.Logger, string, string) (int, error)) {
 	fake.countMutex.Lock()
 	defer fake.countMutex.Unlock()
 	fake.CountStub = stub
 }
 
-func (fake *FakeLockDB) CountArgsForCall(i int) (context.Context, lager.Logger, string) {
+func (fake *FakeLockDB) CountArgsForCall(i int) (context.Context, lager.Logger, string, string) {
 	fake.countMutex.RLock()
 	defer fake.countMutex.RUnlock()
 	argsForCall := fake.countArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeLockDB) CountReturns(result1 int, result2 error) {
@@ -170,6 +451,75 @@ func (fake *FakeLockDB) CountReturnsOnCall(i int, result1 int, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeLockDB) ExpireLease(arg1 context.Context, arg2 lager.Logger, arg3 *db.Lease) ([]*db.Lock, bool, error) {
+	fake.expireLeaseMutex.Lock()
+	ret, specificReturn := fake.expireLeaseReturnsOnCall[len(fake.expireLeaseArgsForCall)]
+	fake.expireLeaseArgsForCall = append(fake.expireLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *db.Lease
+	}{arg1, arg2, arg3})
+	stub := fake.ExpireLeaseStub
+	fakeReturns := fake.expireLeaseReturns
+	fake.recordInvocation("ExpireLease", []interface{}{arg1, arg2, arg3})
+	fake.expireLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeLockDB) ExpireLeaseCallCount() int {
+	fake.expireLeaseMutex.RLock()
+	defer fake.expireLeaseMutex.RUnlock()
+	return len(fake.expireLeaseArgsForCall)
+}
+
+func (fake *FakeLockDB) ExpireLeaseCalls(stub func(context.Context, lager.Logger, *db.Lease) ([]*db.Lock, bool, error)) {
+	fake.expireLeaseMutex.Lock()
+	defer fake.expireLeaseMutex.Unlock()
+	fake.ExpireLeaseStub = stub
+}
+
+func (fake *FakeLockDB) ExpireLeaseArgsForCall(i int) (context.Context, lager.Logger, *db.Lease) {
+	fake.expireLeaseMutex.RLock()
+	defer fake.expireLeaseMutex.RUnlock()
+	argsForCall := fake.expireLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLockDB) ExpireLeaseReturns(result1 []*db.Lock, result2 bool, result3 error) {
+	fake.expireLeaseMutex.Lock()
+	defer fake.expireLeaseMutex.Unlock()
+	fake.ExpireLeaseStub = nil
+	fake.expireLeaseReturns = struct {
+		result1 []*db.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLockDB) ExpireLeaseReturnsOnCall(i int, result1 []*db.Lock, result2 bool, result3 error) {
+	fake.expireLeaseMutex.Lock()
+	defer fake.expireLeaseMutex.Unlock()
+	fake.ExpireLeaseStub = nil
+	if fake.expireLeaseReturnsOnCall == nil {
+		fake.expireLeaseReturnsOnCall = make(map[int]struct {
+			result1 []*db.Lock
+			result2 bool
+			result3 error
+		})
+	}
+	fake.expireLeaseReturnsOnCall[i] = struct {
+		result1 []*db.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeLockDB) Fetch(arg1 context.Context, arg2 lager.Logger, arg3 string) (*db.Lock, error) {
 	fake.fetchMutex.Lock()
 	ret, specificReturn := fake.fetchReturnsOnCall[len(fake.fetchArgsForCall)]
@@ -302,6 +652,136 @@ func (fake *FakeLockDB) FetchAllReturnsOnCall(i int, result1 []*db.Lock, result2
 	}{result1, result2}
 }
 
+func (fake *FakeLockDB) FetchAllLeases(arg1 context.Context, arg2 lager.Logger) ([]*db.Lease, error) {
+	fake.fetchAllLeasesMutex.Lock()
+	ret, specificReturn := fake.fetchAllLeasesReturnsOnCall[len(fake.fetchAllLeasesArgsForCall)]
+	fake.fetchAllLeasesArgsForCall = append(fake.fetchAllLeasesArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+	}{arg1, arg2})
+	stub := fake.FetchAllLeasesStub
+	fakeReturns := fake.fetchAllLeasesReturns
+	fake.recordInvocation("FetchAllLeases", []interface{}{arg1, arg2})
+	fake.fetchAllLeasesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) FetchAllLeasesCallCount() int {
+	fake.fetchAllLeasesMutex.RLock()
+	defer fake.fetchAllLeasesMutex.RUnlock()
+	return len(fake.fetchAllLeasesArgsForCall)
+}
+
+func (fake *FakeLockDB) FetchAllLeasesCalls(stub func(context.Context, lager.Logger) ([]*db.Lease, error)) {
+	fake.fetchAllLeasesMutex.Lock()
+	defer fake.fetchAllLeasesMutex.Unlock()
+	fake.FetchAllLeasesStub = stub
+}
+
+func (fake *FakeLockDB) FetchAllLeasesArgsForCall(i int) (context.Context, lager.Logger) {
+	fake.fetchAllLeasesMutex.RLock()
+	defer fake.fetchAllLeasesMutex.RUnlock()
+	argsForCall := fake.fetchAllLeasesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLockDB) FetchAllLeasesReturns(result1 []*db.Lease, result2 error) {
+	fake.fetchAllLeasesMutex.Lock()
+	defer fake.fetchAllLeasesMutex.Unlock()
+	fake.FetchAllLeasesStub = nil
+	fake.fetchAllLeasesReturns = struct {
+		result1 []*db.Lease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) FetchAllLeasesReturnsOnCall(i int, result1 []*db.Lease, result2 error) {
+	fake.fetchAllLeasesMutex.Lock()
+	defer fake.fetchAllLeasesMutex.Unlock()
+	fake.FetchAllLeasesStub = nil
+	if fake.fetchAllLeasesReturnsOnCall == nil {
+		fake.fetchAllLeasesReturnsOnCall = make(map[int]struct {
+			result1 []*db.Lease
+			result2 error
+		})
+	}
+	fake.fetchAllLeasesReturnsOnCall[i] = struct {
+		result1 []*db.Lease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) FetchAllSharedHolders(arg1 context.Context, arg2 lager.Logger) ([]*db.Lock, error) {
+	fake.fetchAllSharedHoldersMutex.Lock()
+	ret, specificReturn := fake.fetchAllSharedHoldersReturnsOnCall[len(fake.fetchAllSharedHoldersArgsForCall)]
+	fake.fetchAllSharedHoldersArgsForCall = append(fake.fetchAllSharedHoldersArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+	}{arg1, arg2})
+	stub := fake.FetchAllSharedHoldersStub
+	fakeReturns := fake.fetchAllSharedHoldersReturns
+	fake.recordInvocation("FetchAllSharedHolders", []interface{}{arg1, arg2})
+	fake.fetchAllSharedHoldersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) FetchAllSharedHoldersCallCount() int {
+	fake.fetchAllSharedHoldersMutex.RLock()
+	defer fake.fetchAllSharedHoldersMutex.RUnlock()
+	return len(fake.fetchAllSharedHoldersArgsForCall)
+}
+
+func (fake *FakeLockDB) FetchAllSharedHoldersCalls(stub func(context.Context, lager.Logger) ([]*db.Lock, error)) {
+	fake.fetchAllSharedHoldersMutex.Lock()
+	defer fake.fetchAllSharedHoldersMutex.Unlock()
+	fake.FetchAllSharedHoldersStub = stub
+}
+
+func (fake *FakeLockDB) FetchAllSharedHoldersArgsForCall(i int) (context.Context, lager.Logger) {
+	fake.fetchAllSharedHoldersMutex.RLock()
+	defer fake.fetchAllSharedHoldersMutex.RUnlock()
+	argsForCall := fake.fetchAllSharedHoldersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLockDB) FetchAllSharedHoldersReturns(result1 []*db.Lock, result2 error) {
+	fake.fetchAllSharedHoldersMutex.Lock()
+	defer fake.fetchAllSharedHoldersMutex.Unlock()
+	fake.FetchAllSharedHoldersStub = nil
+	fake.fetchAllSharedHoldersReturns = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) FetchAllSharedHoldersReturnsOnCall(i int, result1 []*db.Lock, result2 error) {
+	fake.fetchAllSharedHoldersMutex.Lock()
+	defer fake.fetchAllSharedHoldersMutex.Unlock()
+	fake.FetchAllSharedHoldersStub = nil
+	if fake.fetchAllSharedHoldersReturnsOnCall == nil {
+		fake.fetchAllSharedHoldersReturnsOnCall = make(map[int]struct {
+			result1 []*db.Lock
+			result2 error
+		})
+	}
+	fake.fetchAllSharedHoldersReturnsOnCall[i] = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeLockDB) FetchAndRelease(arg1 context.Context, arg2 lager.Logger, arg3 *db.Lock) (bool, error) {
 	fake.fetchAndReleaseMutex.Lock()
 	ret, specificReturn := fake.fetchAndReleaseReturnsOnCall[len(fake.fetchAndReleaseArgsForCall)]
@@ -368,21 +848,25 @@ func (fake *FakeLockDB) FetchAndReleaseReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
-func (fake *FakeLockDB) Lock(arg1 context.Context, arg2 lager.Logger, arg3 *models.Resource, arg4 int64) (*db.Lock, error) {
-	fake.lockMutex.Lock()
-	ret, specificReturn := fake.lockReturnsOnCall[len(fake.lockArgsForCall)]
-	fake.lockArgsForCall = append(fake.lockArgsForCall, struct {
+func (fake *FakeLockDB) FetchLeaseOwners(arg1 context.Context, arg2 lager.Logger, arg3 []string) (map[string]string, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.fetchLeaseOwnersMutex.Lock()
+	ret, specificReturn := fake.fetchLeaseOwnersReturnsOnCall[len(fake.fetchLeaseOwnersArgsForCall)]
+	fake.fetchLeaseOwnersArgsForCall = append(fake.fetchLeaseOwnersArgsForCall, struct {
 		arg1 context.Context
 		arg2 lager.Logger
-		arg3 *models.Resource
-		arg4 int64
-	}{arg1, arg2, arg3, arg4})
-	stub := fake.LockStub
-	fakeReturns := fake.lockReturns
-	fake.recordInvocation("Lock", []interface{}{arg1, arg2, arg3, arg4})
-	fake.lockMutex.Unlock()
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.FetchLeaseOwnersStub
+	fakeReturns := fake.fetchLeaseOwnersReturns
+	fake.recordInvocation("FetchLeaseOwners", []interface{}{arg1, arg2, arg3Copy})
+	fake.fetchLeaseOwnersMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -390,23 +874,502 @@ func (fake *FakeLockDB) Lock(arg1 context.Context, arg2 lager.Logger, arg3 *mode
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeLockDB) LockCallCount() int {
-	fake.lockMutex.RLock()
-	defer fake.lockMutex.RUnlock()
-	return len(fake.lockArgsForCall)
+func (fake *FakeLockDB) FetchLeaseOwnersCallCount() int {
+	fake.fetchLeaseOwnersMutex.RLock()
+	defer fake.fetchLeaseOwnersMutex.RUnlock()
+	return len(fake.fetchLeaseOwnersArgsForCall)
 }
 
-func (fake *FakeLockDB) LockCalls(stub func(context.Context, lager.Logger, *models.Resource, int64) (*db.Lock, error)) {
+func (fake *FakeLockDB) FetchLeaseOwnersCalls(stub func(context.Context, lager.Logger, []string) (map[string]string, error)) {
+	fake.fetchLeaseOwnersMutex.Lock()
+	defer fake.fetchLeaseOwnersMutex.Unlock()
+	fake.FetchLeaseOwnersStub = stub
+}
+
+func (fake *FakeLockDB) FetchLeaseOwnersArgsForCall(i int) (context.Context, lager.Logger, []string) {
+	fake.fetchLeaseOwnersMutex.RLock()
+	defer fake.fetchLeaseOwnersMutex.RUnlock()
+	argsForCall := fake.fetchLeaseOwnersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLockDB) FetchLeaseOwnersReturns(result1 map[string]string, result2 error) {
+	fake.fetchLeaseOwnersMutex.Lock()
+	defer fake.fetchLeaseOwnersMutex.Unlock()
+	fake.FetchLeaseOwnersStub = nil
+	fake.fetchLeaseOwnersReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) FetchLeaseOwnersReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.fetchLeaseOwnersMutex.Lock()
+	defer fake.fetchLeaseOwnersMutex.Unlock()
+	fake.FetchLeaseOwnersStub = nil
+	if fake.fetchLeaseOwnersReturnsOnCall == nil {
+		fake.fetchLeaseOwnersReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.fetchLeaseOwnersReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) FetchPage(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 string, arg5 string, arg6 string, arg7 map[string]string, arg8 int) ([]*db.Lock, error) {
+	fake.fetchPageMutex.Lock()
+	ret, specificReturn := fake.fetchPageReturnsOnCall[len(fake.fetchPageArgsForCall)]
+	fake.fetchPageArgsForCall = append(fake.fetchPageArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+		arg7 map[string]string
+		arg8 int
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
+	stub := fake.FetchPageStub
+	fakeReturns := fake.fetchPageReturns
+	fake.recordInvocation("FetchPage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
+	fake.fetchPageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) FetchPageCallCount() int {
+	fake.fetchPageMutex.RLock()
+	defer fake.fetchPageMutex.RUnlock()
+	return len(fake.fetchPageArgsForCall)
+}
+
+func (fake *FakeLockDB) FetchPageCalls(stub func(context.Context, lager.Logger, string, string, string, string, map[string]string, int) ([]*db.Lock, error)) {
+	fake.fetchPageMutex.Lock()
+	defer fake.fetchPageMutex.Unlock()
+	fake.FetchPageStub = stub
+}
+
+func (fake *FakeLockDB) FetchPageArgsForCall(i int) (context.Context, lager.Logger, string, string, string, string, map[string]string, int) {
+	fake.fetchPageMutex.RLock()
+	defer fake.fetchPageMutex.RUnlock()
+	argsForCall := fake.fetchPageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8
+}
+
+func (fake *FakeLockDB) FetchPageReturns(result1 []*db.Lock, result2 error) {
+	fake.fetchPageMutex.Lock()
+	defer fake.fetchPageMutex.Unlock()
+	fake.FetchPageStub = nil
+	fake.fetchPageReturns = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) FetchPageReturnsOnCall(i int, result1 []*db.Lock, result2 error) {
+	fake.fetchPageMutex.Lock()
+	defer fake.fetchPageMutex.Unlock()
+	fake.FetchPageStub = nil
+	if fake.fetchPageReturnsOnCall == nil {
+		fake.fetchPageReturnsOnCall = make(map[int]struct {
+			result1 []*db.Lock
+			result2 error
+		})
+	}
+	fake.fetchPageReturnsOnCall[i] = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) FetchSharedHolders(arg1 context.Context, arg2 lager.Logger, arg3 string) ([]*db.Lock, error) {
+	fake.fetchSharedHoldersMutex.Lock()
+	ret, specificReturn := fake.fetchSharedHoldersReturnsOnCall[len(fake.fetchSharedHoldersArgsForCall)]
+	fake.fetchSharedHoldersArgsForCall = append(fake.fetchSharedHoldersArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.FetchSharedHoldersStub
+	fakeReturns := fake.fetchSharedHoldersReturns
+	fake.recordInvocation("FetchSharedHolders", []interface{}{arg1, arg2, arg3})
+	fake.fetchSharedHoldersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) FetchSharedHoldersCallCount() int {
+	fake.fetchSharedHoldersMutex.RLock()
+	defer fake.fetchSharedHoldersMutex.RUnlock()
+	return len(fake.fetchSharedHoldersArgsForCall)
+}
+
+func (fake *FakeLockDB) FetchSharedHoldersCalls(stub func(context.Context, lager.Logger, string) ([]*db.Lock, error)) {
+	fake.fetchSharedHoldersMutex.Lock()
+	defer fake.fetchSharedHoldersMutex.Unlock()
+	fake.FetchSharedHoldersStub = stub
+}
+
+func (fake *FakeLockDB) FetchSharedHoldersArgsForCall(i int) (context.Context, lager.Logger, string) {
+	fake.fetchSharedHoldersMutex.RLock()
+	defer fake.fetchSharedHoldersMutex.RUnlock()
+	argsForCall := fake.fetchSharedHoldersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLockDB) FetchSharedHoldersReturns(result1 []*db.Lock, result2 error) {
+	fake.fetchSharedHoldersMutex.Lock()
+	defer fake.fetchSharedHoldersMutex.Unlock()
+	fake.FetchSharedHoldersStub = nil
+	fake.fetchSharedHoldersReturns = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) FetchSharedHoldersReturnsOnCall(i int, result1 []*db.Lock, result2 error) {
+	fake.fetchSharedHoldersMutex.Lock()
+	defer fake.fetchSharedHoldersMutex.Unlock()
+	fake.FetchSharedHoldersStub = nil
+	if fake.fetchSharedHoldersReturnsOnCall == nil {
+		fake.fetchSharedHoldersReturnsOnCall = make(map[int]struct {
+			result1 []*db.Lock
+			result2 error
+		})
+	}
+	fake.fetchSharedHoldersReturnsOnCall[i] = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) FetchSharedPage(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 string, arg5 string, arg6 string, arg7 string, arg8 map[string]string, arg9 int) ([]*db.Lock, error) {
+	fake.fetchSharedPageMutex.Lock()
+	ret, specificReturn := fake.fetchSharedPageReturnsOnCall[len(fake.fetchSharedPageArgsForCall)]
+	fake.fetchSharedPageArgsForCall = append(fake.fetchSharedPageArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+		arg7 string
+		arg8 map[string]string
+		arg9 int
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
+	stub := fake.FetchSharedPageStub
+	fakeReturns := fake.fetchSharedPageReturns
+	fake.recordInvocation("FetchSharedPage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
+	fake.fetchSharedPageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) FetchSharedPageCallCount() int {
+	fake.fetchSharedPageMutex.RLock()
+	defer fake.fetchSharedPageMutex.RUnlock()
+	return len(fake.fetchSharedPageArgsForCall)
+}
+
+func (fake *FakeLockDB) FetchSharedPageCalls(stub func(context.Context, lager.Logger, string, string, string, string, string, map[string]string, int) ([]*db.Lock, error)) {
+	fake.fetchSharedPageMutex.Lock()
+	defer fake.fetchSharedPageMutex.Unlock()
+	fake.FetchSharedPageStub = stub
+}
+
+func (fake *FakeLockDB) FetchSharedPageArgsForCall(i int) (context.Context, lager.Logger, string, string, string, string, string, map[string]string, int) {
+	fake.fetchSharedPageMutex.RLock()
+	defer fake.fetchSharedPageMutex.RUnlock()
+	argsForCall := fake.fetchSharedPageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8, argsForCall.arg9
+}
+
+func (fake *FakeLockDB) FetchSharedPageReturns(result1 []*db.Lock, result2 error) {
+	fake.fetchSharedPageMutex.Lock()
+	defer fake.fetchSharedPageMutex.Unlock()
+	fake.FetchSharedPageStub = nil
+	fake.fetchSharedPageReturns = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) FetchSharedPageReturnsOnCall(i int, result1 []*db.Lock, result2 error) {
+	fake.fetchSharedPageMutex.Lock()
+	defer fake.fetchSharedPageMutex.Unlock()
+	fake.FetchSharedPageStub = nil
+	if fake.fetchSharedPageReturnsOnCall == nil {
+		fake.fetchSharedPageReturnsOnCall = make(map[int]struct {
+			result1 []*db.Lock
+			result2 error
+		})
+	}
+	fake.fetchSharedPageReturnsOnCall[i] = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) ForceRelease(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 string, arg5 string) ([]*db.Lock, error) {
+	fake.forceReleaseMutex.Lock()
+	ret, specificReturn := fake.forceReleaseReturnsOnCall[len(fake.forceReleaseArgsForCall)]
+	fake.forceReleaseArgsForCall = append(fake.forceReleaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ForceReleaseStub
+	fakeReturns := fake.forceReleaseReturns
+	fake.recordInvocation("ForceRelease", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.forceReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) ForceReleaseCallCount() int {
+	fake.forceReleaseMutex.RLock()
+	defer fake.forceReleaseMutex.RUnlock()
+	return len(fake.forceReleaseArgsForCall)
+}
+
+func (fake *FakeLockDB) ForceReleaseCalls(stub func(context.Context, lager.Logger, string, string, string) ([]*db.Lock, error)) {
+	fake.forceReleaseMutex.Lock()
+	defer fake.forceReleaseMutex.Unlock()
+	fake.ForceReleaseStub = stub
+}
+
+func (fake *FakeLockDB) ForceReleaseArgsForCall(i int) (context.Context, lager.Logger, string, string, string) {
+	fake.forceReleaseMutex.RLock()
+	defer fake.forceReleaseMutex.RUnlock()
+	argsForCall := fake.forceReleaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeLockDB) ForceReleaseReturns(result1 []*db.Lock, result2 error) {
+	fake.forceReleaseMutex.Lock()
+	defer fake.forceReleaseMutex.Unlock()
+	fake.ForceReleaseStub = nil
+	fake.forceReleaseReturns = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) ForceReleaseReturnsOnCall(i int, result1 []*db.Lock, result2 error) {
+	fake.forceReleaseMutex.Lock()
+	defer fake.forceReleaseMutex.Unlock()
+	fake.ForceReleaseStub = nil
+	if fake.forceReleaseReturnsOnCall == nil {
+		fake.forceReleaseReturnsOnCall = make(map[int]struct {
+			result1 []*db.Lock
+			result2 error
+		})
+	}
+	fake.forceReleaseReturnsOnCall[i] = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) GrantLease(arg1 context.Context, arg2 lager.Logger, arg3 int64, arg4 string) (*db.Lease, error) {
+	fake.grantLeaseMutex.Lock()
+	ret, specificReturn := fake.grantLeaseReturnsOnCall[len(fake.grantLeaseArgsForCall)]
+	fake.grantLeaseArgsForCall = append(fake.grantLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 int64
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GrantLeaseStub
+	fakeReturns := fake.grantLeaseReturns
+	fake.recordInvocation("GrantLease", []interface{}{arg1, arg2, arg3, arg4})
+	fake.grantLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) GrantLeaseCallCount() int {
+	fake.grantLeaseMutex.RLock()
+	defer fake.grantLeaseMutex.RUnlock()
+	return len(fake.grantLeaseArgsForCall)
+}
+
+func (fake *FakeLockDB) GrantLeaseCalls(stub func(context.Context, lager.Logger, int64, string) (*db.Lease, error)) {
+	fake.grantLeaseMutex.Lock()
+	defer fake.grantLeaseMutex.Unlock()
+	fake.GrantLeaseStub = stub
+}
+
+func (fake *FakeLockDB) GrantLeaseArgsForCall(i int) (context.Context, lager.Logger, int64, string) {
+	fake.grantLeaseMutex.RLock()
+	defer fake.grantLeaseMutex.RUnlock()
+	argsForCall := fake.grantLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeLockDB) GrantLeaseReturns(result1 *db.Lease, result2 error) {
+	fake.grantLeaseMutex.Lock()
+	defer fake.grantLeaseMutex.Unlock()
+	fake.GrantLeaseStub = nil
+	fake.grantLeaseReturns = struct {
+		result1 *db.Lease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) GrantLeaseReturnsOnCall(i int, result1 *db.Lease, result2 error) {
+	fake.grantLeaseMutex.Lock()
+	defer fake.grantLeaseMutex.Unlock()
+	fake.GrantLeaseStub = nil
+	if fake.grantLeaseReturnsOnCall == nil {
+		fake.grantLeaseReturnsOnCall = make(map[int]struct {
+			result1 *db.Lease
+			result2 error
+		})
+	}
+	fake.grantLeaseReturnsOnCall[i] = struct {
+		result1 *db.Lease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) KeepAliveLease(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 string) (*db.Lease, error) {
+	fake.keepAliveLeaseMutex.Lock()
+	ret, specificReturn := fake.keepAliveLeaseReturnsOnCall[len(fake.keepAliveLeaseArgsForCall)]
+	fake.keepAliveLeaseArgsForCall = append(fake.keepAliveLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.KeepAliveLeaseStub
+	fakeReturns := fake.keepAliveLeaseReturns
+	fake.recordInvocation("KeepAliveLease", []interface{}{arg1, arg2, arg3, arg4})
+	fake.keepAliveLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) KeepAliveLeaseCallCount() int {
+	fake.keepAliveLeaseMutex.RLock()
+	defer fake.keepAliveLeaseMutex.RUnlock()
+	return len(fake.keepAliveLeaseArgsForCall)
+}
+
+func (fake *FakeLockDB) KeepAliveLeaseCalls(stub func(context.Context, lager.Logger, string, string) (*db.Lease, error)) {
+	fake.keepAliveLeaseMutex.Lock()
+	defer fake.keepAliveLeaseMutex.Unlock()
+	fake.KeepAliveLeaseStub = stub
+}
+
+func (fake *FakeLockDB) KeepAliveLeaseArgsForCall(i int) (context.Context, lager.Logger, string, string) {
+	fake.keepAliveLeaseMutex.RLock()
+	defer fake.keepAliveLeaseMutex.RUnlock()
+	argsForCall := fake.keepAliveLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeLockDB) KeepAliveLeaseReturns(result1 *db.Lease, result2 error) {
+	fake.keepAliveLeaseMutex.Lock()
+	defer fake.keepAliveLeaseMutex.Unlock()
+	fake.KeepAliveLeaseStub = nil
+	fake.keepAliveLeaseReturns = struct {
+		result1 *db.Lease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) KeepAliveLeaseReturnsOnCall(i int, result1 *db.Lease, result2 error) {
+	fake.keepAliveLeaseMutex.Lock()
+	defer fake.keepAliveLeaseMutex.Unlock()
+	fake.KeepAliveLeaseStub = nil
+	if fake.keepAliveLeaseReturnsOnCall == nil {
+		fake.keepAliveLeaseReturnsOnCall = make(map[int]struct {
+			result1 *db.Lease
+			result2 error
+		})
+	}
+	fake.keepAliveLeaseReturnsOnCall[i] = struct {
+		result1 *db.Lease
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) Lock(arg1 context.Context, arg2 lager.Logger, arg3 *models.Resource, arg4 int64, arg5 string) (*db.Lock, error) {
+	fake.lockMutex.Lock()
+	ret, specificReturn := fake.lockReturnsOnCall[len(fake.lockArgsForCall)]
+	fake.lockArgsForCall = append(fake.lockArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *models.Resource
+		arg4 int64
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.LockStub
+	fakeReturns := fake.lockReturns
+	fake.recordInvocation("Lock", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.lockMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) LockCallCount() int {
+	fake.lockMutex.RLock()
+	defer fake.lockMutex.RUnlock()
+	return len(fake.lockArgsForCall)
+}
+
+func (fake *FakeLockDB) LockCalls(stub func(context.Context, lager.Logger, *models.Resource, int64, string) (*db.Lock, error)) {
 	fake.lockMutex.Lock()
 	defer fake.lockMutex.Unlock()
 	fake.LockStub = stub
 }
 
-func (fake *FakeLockDB) LockArgsForCall(i int) (context.Context, lager.Logger, *models.Resource, int64) {
+func (fake *FakeLockDB) LockArgsForCall(i int) (context.Context, lager.Logger, *models.Resource, int64, string) {
 	fake.lockMutex.RLock()
 	defer fake.lockMutex.RUnlock()
 	argsForCall := fake.lockArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeLockDB) LockReturns(result1 *db.Lock, result2 error) {
@@ -435,6 +1398,222 @@ func (fake *FakeLockDB) LockReturnsOnCall(i int, result1 *db.Lock, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeLockDB) LockBatch(arg1 context.Context, arg2 lager.Logger, arg3 []*models.LockRequest, arg4 string) ([]*db.Lock, []error, error) {
+	var arg3Copy []*models.LockRequest
+	if arg3 != nil {
+		arg3Copy = make([]*models.LockRequest, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.lockBatchMutex.Lock()
+	ret, specificReturn := fake.lockBatchReturnsOnCall[len(fake.lockBatchArgsForCall)]
+	fake.lockBatchArgsForCall = append(fake.lockBatchArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 []*models.LockRequest
+		arg4 string
+	}{arg1, arg2, arg3Copy, arg4})
+	stub := fake.LockBatchStub
+	fakeReturns := fake.lockBatchReturns
+	fake.recordInvocation("LockBatch", []interface{}{arg1, arg2, arg3Copy, arg4})
+	fake.lockBatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeLockDB) LockBatchCallCount() int {
+	fake.lockBatchMutex.RLock()
+	defer fake.lockBatchMutex.RUnlock()
+	return len(fake.lockBatchArgsForCall)
+}
+
+func (fake *FakeLockDB) LockBatchCalls(stub func(context.Context, lager.Logger, []*models.LockRequest, string) ([]*db.Lock, []error, error)) {
+	fake.lockBatchMutex.Lock()
+	defer fake.lockBatchMutex.Unlock()
+	fake.LockBatchStub = stub
+}
+
+func (fake *FakeLockDB) LockBatchArgsForCall(i int) (context.Context, lager.Logger, []*models.LockRequest, string) {
+	fake.lockBatchMutex.RLock()
+	defer fake.lockBatchMutex.RUnlock()
+	argsForCall := fake.lockBatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeLockDB) LockBatchReturns(result1 []*db.Lock, result2 []error, result3 error) {
+	fake.lockBatchMutex.Lock()
+	defer fake.lockBatchMutex.Unlock()
+	fake.LockBatchStub = nil
+	fake.lockBatchReturns = struct {
+		result1 []*db.Lock
+		result2 []error
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLockDB) LockBatchReturnsOnCall(i int, result1 []*db.Lock, result2 []error, result3 error) {
+	fake.lockBatchMutex.Lock()
+	defer fake.lockBatchMutex.Unlock()
+	fake.LockBatchStub = nil
+	if fake.lockBatchReturnsOnCall == nil {
+		fake.lockBatchReturnsOnCall = make(map[int]struct {
+			result1 []*db.Lock
+			result2 []error
+			result3 error
+		})
+	}
+	fake.lockBatchReturnsOnCall[i] = struct {
+		result1 []*db.Lock
+		result2 []error
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLockDB) LockMulti(arg1 context.Context, arg2 lager.Logger, arg3 []*models.LockRequest, arg4 string) ([]*db.Lock, error) {
+	var arg3Copy []*models.LockRequest
+	if arg3 != nil {
+		arg3Copy = make([]*models.LockRequest, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.lockMultiMutex.Lock()
+	ret, specificReturn := fake.lockMultiReturnsOnCall[len(fake.lockMultiArgsForCall)]
+	fake.lockMultiArgsForCall = append(fake.lockMultiArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 []*models.LockRequest
+		arg4 string
+	}{arg1, arg2, arg3Copy, arg4})
+	stub := fake.LockMultiStub
+	fakeReturns := fake.lockMultiReturns
+	fake.recordInvocation("LockMulti", []interface{}{arg1, arg2, arg3Copy, arg4})
+	fake.lockMultiMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) LockMultiCallCount() int {
+	fake.lockMultiMutex.RLock()
+	defer fake.lockMultiMutex.RUnlock()
+	return len(fake.lockMultiArgsForCall)
+}
+
+func (fake *FakeLockDB) LockMultiCalls(stub func(context.Context, lager.Logger, []*models.LockRequest, string) ([]*db.Lock, error)) {
+	fake.lockMultiMutex.Lock()
+	defer fake.lockMultiMutex.Unlock()
+	fake.LockMultiStub = stub
+}
+
+func (fake *FakeLockDB) LockMultiArgsForCall(i int) (context.Context, lager.Logger, []*models.LockRequest, string) {
+	fake.lockMultiMutex.RLock()
+	defer fake.lockMultiMutex.RUnlock()
+	argsForCall := fake.lockMultiArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeLockDB) LockMultiReturns(result1 []*db.Lock, result2 error) {
+	fake.lockMultiMutex.Lock()
+	defer fake.lockMultiMutex.Unlock()
+	fake.LockMultiStub = nil
+	fake.lockMultiReturns = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) LockMultiReturnsOnCall(i int, result1 []*db.Lock, result2 error) {
+	fake.lockMultiMutex.Lock()
+	defer fake.lockMultiMutex.Unlock()
+	fake.LockMultiStub = nil
+	if fake.lockMultiReturnsOnCall == nil {
+		fake.lockMultiReturnsOnCall = make(map[int]struct {
+			result1 []*db.Lock
+			result2 error
+		})
+	}
+	fake.lockMultiReturnsOnCall[i] = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) LockShared(arg1 context.Context, arg2 lager.Logger, arg3 *models.Resource, arg4 int64, arg5 int, arg6 string) (*db.Lock, error) {
+	fake.lockSharedMutex.Lock()
+	ret, specificReturn := fake.lockSharedReturnsOnCall[len(fake.lockSharedArgsForCall)]
+	fake.lockSharedArgsForCall = append(fake.lockSharedArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *models.Resource
+		arg4 int64
+		arg5 int
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.LockSharedStub
+	fakeReturns := fake.lockSharedReturns
+	fake.recordInvocation("LockShared", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.lockSharedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) LockSharedCallCount() int {
+	fake.lockSharedMutex.RLock()
+	defer fake.lockSharedMutex.RUnlock()
+	return len(fake.lockSharedArgsForCall)
+}
+
+func (fake *FakeLockDB) LockSharedCalls(stub func(context.Context, lager.Logger, *models.Resource, int64, int, string) (*db.Lock, error)) {
+	fake.lockSharedMutex.Lock()
+	defer fake.lockSharedMutex.Unlock()
+	fake.LockSharedStub = stub
+}
+
+func (fake *FakeLockDB) LockSharedArgsForCall(i int) (context.Context, lager.Logger, *models.Resource, int64, int, string) {
+	fake.lockSharedMutex.RLock()
+	defer fake.lockSharedMutex.RUnlock()
+	argsForCall := fake.lockSharedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeLockDB) LockSharedReturns(result1 *db.Lock, result2 error) {
+	fake.lockSharedMutex.Lock()
+	defer fake.lockSharedMutex.Unlock()
+	fake.LockSharedStub = nil
+	fake.lockSharedReturns = struct {
+		result1 *db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) LockSharedReturnsOnCall(i int, result1 *db.Lock, result2 error) {
+	fake.lockSharedMutex.Lock()
+	defer fake.lockSharedMutex.Unlock()
+	fake.LockSharedStub = nil
+	if fake.lockSharedReturnsOnCall == nil {
+		fake.lockSharedReturnsOnCall = make(map[int]struct {
+			result1 *db.Lock
+			result2 error
+		})
+	}
+	fake.lockSharedReturnsOnCall[i] = struct {
+		result1 *db.Lock
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeLockDB) Release(arg1 context.Context, arg2 lager.Logger, arg3 *models.Resource) error {
 	fake.releaseMutex.Lock()
 	ret, specificReturn := fake.releaseReturnsOnCall[len(fake.releaseArgsForCall)]
@@ -498,6 +1677,270 @@ func (fake *FakeLockDB) ReleaseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeLockDB) ReleaseShared(arg1 context.Context, arg2 lager.Logger, arg3 *models.Resource) error {
+	fake.releaseSharedMutex.Lock()
+	ret, specificReturn := fake.releaseSharedReturnsOnCall[len(fake.releaseSharedArgsForCall)]
+	fake.releaseSharedArgsForCall = append(fake.releaseSharedArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *models.Resource
+	}{arg1, arg2, arg3})
+	stub := fake.ReleaseSharedStub
+	fakeReturns := fake.releaseSharedReturns
+	fake.recordInvocation("ReleaseShared", []interface{}{arg1, arg2, arg3})
+	fake.releaseSharedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLockDB) ReleaseSharedCallCount() int {
+	fake.releaseSharedMutex.RLock()
+	defer fake.releaseSharedMutex.RUnlock()
+	return len(fake.releaseSharedArgsForCall)
+}
+
+func (fake *FakeLockDB) ReleaseSharedCalls(stub func(context.Context, lager.Logger, *models.Resource) error) {
+	fake.releaseSharedMutex.Lock()
+	defer fake.releaseSharedMutex.Unlock()
+	fake.ReleaseSharedStub = stub
+}
+
+func (fake *FakeLockDB) ReleaseSharedArgsForCall(i int) (context.Context, lager.Logger, *models.Resource) {
+	fake.releaseSharedMutex.RLock()
+	defer fake.releaseSharedMutex.RUnlock()
+	argsForCall := fake.releaseSharedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLockDB) ReleaseSharedReturns(result1 error) {
+	fake.releaseSharedMutex.Lock()
+	defer fake.releaseSharedMutex.Unlock()
+	fake.ReleaseSharedStub = nil
+	fake.releaseSharedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLockDB) ReleaseSharedReturnsOnCall(i int, result1 error) {
+	fake.releaseSharedMutex.Lock()
+	defer fake.releaseSharedMutex.Unlock()
+	fake.ReleaseSharedStub = nil
+	if fake.releaseSharedReturnsOnCall == nil {
+		fake.releaseSharedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseSharedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLockDB) RevokeLease(arg1 context.Context, arg2 lager.Logger, arg3 string, arg4 string) ([]*db.Lock, error) {
+	fake.revokeLeaseMutex.Lock()
+	ret, specificReturn := fake.revokeLeaseReturnsOnCall[len(fake.revokeLeaseArgsForCall)]
+	fake.revokeLeaseArgsForCall = append(fake.revokeLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.RevokeLeaseStub
+	fakeReturns := fake.revokeLeaseReturns
+	fake.recordInvocation("RevokeLease", []interface{}{arg1, arg2, arg3, arg4})
+	fake.revokeLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) RevokeLeaseCallCount() int {
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	return len(fake.revokeLeaseArgsForCall)
+}
+
+func (fake *FakeLockDB) RevokeLeaseCalls(stub func(context.Context, lager.Logger, string, string) ([]*db.Lock, error)) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = stub
+}
+
+func (fake *FakeLockDB) RevokeLeaseArgsForCall(i int) (context.Context, lager.Logger, string, string) {
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	argsForCall := fake.revokeLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeLockDB) RevokeLeaseReturns(result1 []*db.Lock, result2 error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = nil
+	fake.revokeLeaseReturns = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) RevokeLeaseReturnsOnCall(i int, result1 []*db.Lock, result2 error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = nil
+	if fake.revokeLeaseReturnsOnCall == nil {
+		fake.revokeLeaseReturnsOnCall = make(map[int]struct {
+			result1 []*db.Lock
+			result2 error
+		})
+	}
+	fake.revokeLeaseReturnsOnCall[i] = struct {
+		result1 []*db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) Transfer(arg1 context.Context, arg2 lager.Logger, arg3 *models.Resource, arg4 string) (*db.Lock, error) {
+	fake.transferMutex.Lock()
+	ret, specificReturn := fake.transferReturnsOnCall[len(fake.transferArgsForCall)]
+	fake.transferArgsForCall = append(fake.transferArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *models.Resource
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.TransferStub
+	fakeReturns := fake.transferReturns
+	fake.recordInvocation("Transfer", []interface{}{arg1, arg2, arg3, arg4})
+	fake.transferMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) TransferCallCount() int {
+	fake.transferMutex.RLock()
+	defer fake.transferMutex.RUnlock()
+	return len(fake.transferArgsForCall)
+}
+
+func (fake *FakeLockDB) TransferCalls(stub func(context.Context, lager.Logger, *models.Resource, string) (*db.Lock, error)) {
+	fake.transferMutex.Lock()
+	defer fake.transferMutex.Unlock()
+	fake.TransferStub = stub
+}
+
+func (fake *FakeLockDB) TransferArgsForCall(i int) (context.Context, lager.Logger, *models.Resource, string) {
+	fake.transferMutex.RLock()
+	defer fake.transferMutex.RUnlock()
+	argsForCall := fake.transferArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeLockDB) TransferReturns(result1 *db.Lock, result2 error) {
+	fake.transferMutex.Lock()
+	defer fake.transferMutex.Unlock()
+	fake.TransferStub = nil
+	fake.transferReturns = struct {
+		result1 *db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) TransferReturnsOnCall(i int, result1 *db.Lock, result2 error) {
+	fake.transferMutex.Lock()
+	defer fake.transferMutex.Unlock()
+	fake.TransferStub = nil
+	if fake.transferReturnsOnCall == nil {
+		fake.transferReturnsOnCall = make(map[int]struct {
+			result1 *db.Lock
+			result2 error
+		})
+	}
+	fake.transferReturnsOnCall[i] = struct {
+		result1 *db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) Update(arg1 context.Context, arg2 lager.Logger, arg3 *models.Resource, arg4 int64) (*db.Lock, error) {
+	fake.updateMutex.Lock()
+	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 *models.Resource
+		arg4 int64
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateStub
+	fakeReturns := fake.updateReturns
+	fake.recordInvocation("Update", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLockDB) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *FakeLockDB) UpdateCalls(stub func(context.Context, lager.Logger, *models.Resource, int64) (*db.Lock, error)) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = stub
+}
+
+func (fake *FakeLockDB) UpdateArgsForCall(i int) (context.Context, lager.Logger, *models.Resource, int64) {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	argsForCall := fake.updateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeLockDB) UpdateReturns(result1 *db.Lock, result2 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 *db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLockDB) UpdateReturnsOnCall(i int, result1 *db.Lock, result2 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	if fake.updateReturnsOnCall == nil {
+		fake.updateReturnsOnCall = make(map[int]struct {
+			result1 *db.Lock
+			result2 error
+		})
+	}
+	fake.updateReturnsOnCall[i] = struct {
+		result1 *db.Lock
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeLockDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
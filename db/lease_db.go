@@ -0,0 +1,393 @@
+package db
+
+import (
+	"context"
+	"sort"
+
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/models"
+)
+
+// Locks and shared holders attached to a lease reference it through their
+// lease_id column and use the TTL of the lease instead of their own. They are
+// released together when the lease is revoked or expires. Attaching a lock
+// locks the row of the lease before the row of the lock, and releasing the
+// locks of a lease does the same, so that the two cannot deadlock.
+//
+// Leases are owned by the identity of the client that granted them, and only
+// that identity can keep them alive or revoke them. Leases granted before
+// owners were stored have no owner, they are claimed by the first client that
+// keeps them alive.
+
+func (db *SQLDB) GrantLease(ctx context.Context, logger lager.Logger, ttl int64, owner string) (*Lease, error) {
+	logger = logger.Session("grant-lease", lager.Data{"ttl": ttl, "owner": owner})
+
+	id, err := db.guidProvider.NextGUID()
+	if err != nil {
+		logger.Error("failed-to-generate-guid", err)
+		return nil, err
+	}
+
+	now := db.clock.Now().UnixNano()
+	lease := &Lease{
+		ID:            id,
+		Owner:         owner,
+		TtlInSeconds:  ttl,
+		ModifiedIndex: 1,
+		GrantedAt:     now,
+		RenewedAt:     now,
+	}
+
+	_, err = db.helper.Insert(ctx, logger, db, "leases",
+		helpers.SQLAttributes{
+			"id":             lease.ID,
+			"owner":          lease.Owner,
+			"ttl":            lease.TtlInSeconds,
+			"modified_index": lease.ModifiedIndex,
+			"granted_at":     lease.GrantedAt,
+			"renewed_at":     lease.RenewedAt,
+		},
+	)
+	if err != nil {
+		logger.Error("failed-inserting-lease", err)
+		return nil, db.helper.ConvertSQLError(err)
+	}
+
+	logger.Info("granted-lease", lager.Data{"lease-id": lease.ID})
+	return lease, nil
+}
+
+func (db *SQLDB) KeepAliveLease(ctx context.Context, logger lager.Logger, id, owner string) (*Lease, error) {
+	logger = logger.Session("keep-alive-lease", lager.Data{"lease-id": id, "owner": owner})
+	var lease *Lease
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		var err error
+		lease, err = db.fetchOwnedLeaseForUpdate(ctx, logger, tx, id, owner)
+		if err != nil {
+			return err
+		}
+
+		lease.Owner = owner
+		lease.ModifiedIndex++
+		lease.RenewedAt = db.clock.Now().UnixNano()
+
+		_, err = db.helper.Update(ctx, logger, tx, "leases",
+			helpers.SQLAttributes{
+				"owner":          lease.Owner,
+				"modified_index": lease.ModifiedIndex,
+				"renewed_at":     lease.RenewedAt,
+			},
+			"id = ?", lease.ID,
+		)
+		if err != nil {
+			logger.Error("failed-updating-lease", err)
+			return err
+		}
+
+		return nil
+	})
+
+	return lease, db.helper.ConvertSQLError(err)
+}
+
+// RevokeLease deletes the lease and releases the locks attached to it. The
+// released locks are returned.
+func (db *SQLDB) RevokeLease(ctx context.Context, logger lager.Logger, id, owner string) ([]*Lock, error) {
+	logger = logger.Session("revoke-lease", lager.Data{"lease-id": id, "owner": owner})
+	var locks []*Lock
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		_, err := db.fetchOwnedLeaseForUpdate(ctx, logger, tx, id, owner)
+		if err != nil {
+			return err
+		}
+
+		locks, err = db.releaseLease(ctx, logger, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, db.helper.ConvertSQLError(err)
+	}
+
+	logger.Info("revoked-lease", lager.Data{"released-locks": len(locks)})
+	return locks, nil
+}
+
+// ExpireLease deletes the lease and releases the locks attached to it, unless
+// the lease was kept alive since it was fetched.
+func (db *SQLDB) ExpireLease(ctx context.Context, logger lager.Logger, lease *Lease) ([]*Lock, bool, error) {
+	logger = logger.Session("expire-lease", lager.Data{"lease-id": lease.ID})
+	var locks []*Lock
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		fetchedLease, err := db.fetchLeaseForUpdate(ctx, logger, tx, lease.ID)
+		if err != nil {
+			return err
+		}
+
+		if fetchedLease.ModifiedIndex != lease.ModifiedIndex {
+			logger.Error("expire-failed-index-mismatch", models.ErrLockCollision, lager.Data{"lease-modified-index": lease.ModifiedIndex, "fetched-modified-index": fetchedLease.ModifiedIndex})
+			return models.ErrLockCollision
+		}
+
+		locks, err = db.releaseLease(ctx, logger, tx, lease.ID)
+		return err
+	})
+
+	if err != nil {
+		if err == models.ErrLeaseNotFound {
+			return nil, false, nil
+		}
+		return nil, false, db.helper.ConvertSQLError(err)
+	}
+
+	logger.Info("expired-lease", lager.Data{"released-locks": len(locks)})
+	return locks, true, nil
+}
+
+func (db *SQLDB) FetchAllLeases(ctx context.Context, logger lager.Logger) ([]*Lease, error) {
+	logger = logger.Session("fetch-all-leases")
+	var leases []*Lease
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		rows, err := db.helper.All(ctx, logger, tx, "leases",
+			helpers.ColumnList{"id", "owner", "ttl", "modified_index", "granted_at", "renewed_at"},
+			helpers.NoLockRow, "",
+		)
+		if err != nil {
+			logger.Error("failed-to-fetch-leases", err)
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			lease := &Lease{}
+			err := rows.Scan(&lease.ID, &lease.Owner, &lease.TtlInSeconds, &lease.ModifiedIndex, &lease.GrantedAt, &lease.RenewedAt)
+			if err != nil {
+				logger.Error("failed-to-scan-lease", err)
+				continue
+			}
+			leases = append(leases, lease)
+		}
+
+		return nil
+	})
+
+	return leases, db.helper.ConvertSQLError(err)
+}
+
+// FetchLeaseOwners returns the owners of the leases with the given ids. Leases
+// that do not exist anymore are left out.
+func (db *SQLDB) FetchLeaseOwners(ctx context.Context, logger lager.Logger, ids []string) (map[string]string, error) {
+	logger = logger.Session("fetch-lease-owners", lager.Data{"count": len(ids)})
+	owners := map[string]string{}
+	if len(ids) == 0 {
+		return owners, nil
+	}
+
+	whereBindings := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		whereBindings = append(whereBindings, id)
+	}
+
+	rows, err := db.helper.All(ctx, logger, db, "leases",
+		helpers.ColumnList{"id", "owner"},
+		helpers.NoLockRow, "id IN ("+helpers.QuestionMarks(len(ids))+")", whereBindings...,
+	)
+	if err != nil {
+		logger.Error("failed-to-fetch-lease-owners", err)
+		return nil, db.helper.ConvertSQLError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, owner string
+		err := rows.Scan(&id, &owner)
+		if err != nil {
+			logger.Error("failed-to-scan-lease-owner", err)
+			continue
+		}
+		owners[id] = owner
+	}
+
+	return owners, nil
+}
+
+// releaseLease releases the locks and shared holders attached to the lease
+// and deletes it. The lease row must already be locked by the transaction.
+func (db *SQLDB) releaseLease(ctx context.Context, logger lager.Logger, tx helpers.Tx, id string) ([]*Lock, error) {
+	exclusiveLocks, err := db.fetchLeaseLocks(ctx, logger, tx, "locks", "lease_id = ? AND owner <> ?", id, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sharedLocks, err := db.fetchLeaseLocks(ctx, logger, tx, "shared_locks", "lease_id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var released []*Lock
+
+	for _, lock := range exclusiveLocks {
+		current, err := db.fetchLock(ctx, logger, tx, lock.Key)
+		if err != nil {
+			if db.helper.ConvertSQLError(err) == helpers.ErrResourceNotFound {
+				continue
+			}
+			logger.Error("failed-to-fetch-lock", err)
+			return nil, err
+		}
+
+		// the lock may have been released or detached from the lease since
+		// it was listed
+		if current.Owner != lock.Owner || current.LeaseId != id {
+			continue
+		}
+
+		_, err = db.helper.Delete(ctx, logger, tx, "locks", "path = ?", lock.Key)
+		if err != nil {
+			logger.Error("failed-to-release-lock", err)
+			return nil, err
+		}
+		released = append(released, current)
+	}
+
+	for _, lock := range sharedLocks {
+		current, err := db.fetchSharedLockForRelease(ctx, logger, tx, lock.Key, lock.Owner)
+		if err != nil {
+			if err == models.ErrResourceNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		if current.LeaseId != id {
+			continue
+		}
+
+		err = db.deleteSharedLock(ctx, logger, tx, current)
+		if err != nil {
+			return nil, err
+		}
+		released = append(released, current)
+	}
+
+	_, err = db.helper.Delete(ctx, logger, tx, "leases", "id = ?", id)
+	if err != nil {
+		logger.Error("failed-to-delete-lease", err)
+		return nil, err
+	}
+
+	return released, nil
+}
+
+// fetchLeaseLocks lists the locks of a lease without locking their rows.
+// They are ordered by key and owner, which is the order their rows are then
+// locked in.
+func (db *SQLDB) fetchLeaseLocks(ctx context.Context, logger lager.Logger, tx helpers.Tx, table, where string, whereBindings ...interface{}) ([]*Lock, error) {
+	rows, err := db.helper.All(ctx, logger, tx, table,
+		lockColumns,
+		helpers.NoLockRow, where, whereBindings...,
+	)
+	if err != nil {
+		logger.Error("failed-to-fetch-lease-locks", err, lager.Data{"table": table})
+		return nil, err
+	}
+	defer rows.Close()
+
+	locks := scanLocks(logger, rows, true)
+	sort.Slice(locks, func(i, j int) bool {
+		if locks[i].Key != locks[j].Key {
+			return locks[i].Key < locks[j].Key
+		}
+		return locks[i].Owner < locks[j].Owner
+	})
+
+	return locks, nil
+}
+
+// leaseTTL returns the TTL a lock of the resource should be stored with. The
+// row of the lease the resource is attached to, if any, stays locked until the
+// end of the transaction so that the lease cannot be released concurrently.
+// Locks can only be attached to the leases granted by leaseOwner.
+func (db *SQLDB) leaseTTL(ctx context.Context, logger lager.Logger, tx helpers.Tx, resource *models.Resource, ttl int64, leaseOwner string) (int64, error) {
+	if resource.LeaseId == "" {
+		return ttl, nil
+	}
+
+	lease, err := db.fetchOwnedLeaseForUpdate(ctx, logger, tx, resource.LeaseId, leaseOwner)
+	if err != nil {
+		return 0, err
+	}
+
+	return lease.TtlInSeconds, nil
+}
+
+// lockLeases locks the rows of the leases the requests are attached to, sorted
+// by id. Requests lock the row of their lease before the row of their key, so
+// requests of a batch attached to several leases have to lock all of them
+// first for concurrent batches not to deadlock each other. Missing leases are
+// left to the requests to report.
+func (db *SQLDB) lockLeases(ctx context.Context, logger lager.Logger, tx helpers.Tx, requests []*models.LockRequest) error {
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, req := range requests {
+		id := req.Resource.LeaseId
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		_, err := db.fetchLeaseForUpdate(ctx, logger, tx, id)
+		if err != nil && err != models.ErrLeaseNotFound {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchOwnedLeaseForUpdate locks the row of the lease like
+// fetchLeaseForUpdate, and returns ErrPermissionDenied when the lease is owned
+// by another identity than the given owner.
+func (db *SQLDB) fetchOwnedLeaseForUpdate(ctx context.Context, logger lager.Logger, tx helpers.Tx, id, owner string) (*Lease, error) {
+	lease, err := db.fetchLeaseForUpdate(ctx, logger, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if lease.Owner != "" && lease.Owner != owner {
+		logger.Info("lease-owned-by-another-identity", lager.Data{"lease-owner": lease.Owner})
+		return nil, models.ErrPermissionDenied
+	}
+
+	return lease, nil
+}
+
+func (db *SQLDB) fetchLeaseForUpdate(ctx context.Context, logger lager.Logger, tx helpers.Tx, id string) (*Lease, error) {
+	row := db.helper.One(ctx, logger, tx, "leases",
+		helpers.ColumnList{"owner", "ttl", "modified_index", "granted_at", "renewed_at"},
+		helpers.LockRow,
+		"id = ?", id,
+	)
+
+	lease := &Lease{ID: id}
+	err := row.Scan(&lease.Owner, &lease.TtlInSeconds, &lease.ModifiedIndex, &lease.GrantedAt, &lease.RenewedAt)
+	if err != nil {
+		sqlErr := db.helper.ConvertSQLError(err)
+		if sqlErr == helpers.ErrResourceNotFound {
+			logger.Debug("lease-does-not-exist")
+			return nil, models.ErrLeaseNotFound
+		}
+		logger.Error("failed-to-fetch-lease", err)
+		return nil, err
+	}
+
+	return lease, nil
+}
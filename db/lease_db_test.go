@@ -0,0 +1,315 @@
+package db_test
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/locket/db"
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lease", func() {
+	var lease *db.Lease
+
+	BeforeEach(func() {
+		fakeGUIDProvider.NextGUIDReturns("lease-guid", nil)
+
+		var err error
+		lease, err = sqlDB.GrantLease(ctx, logger, 10, "client")
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeGUIDProvider.NextGUIDReturns("new-guid", nil)
+	})
+
+	Context("GrantLease", func() {
+		It("inserts the lease", func() {
+			Expect(lease).To(Equal(&db.Lease{
+				ID:            "lease-guid",
+				Owner:         "client",
+				TtlInSeconds:  10,
+				ModifiedIndex: 1,
+				GrantedAt:     fakeClock.Now().UnixNano(),
+				RenewedAt:     fakeClock.Now().UnixNano(),
+			}))
+
+			leases, err := sqlDB.FetchAllLeases(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leases).To(Equal([]*db.Lease{lease}))
+		})
+	})
+
+	Context("KeepAliveLease", func() {
+		It("increments the modified index and updates the renewal time", func() {
+			fakeClock.Increment(time.Second)
+
+			keptAliveLease, err := sqlDB.KeepAliveLease(ctx, logger, lease.ID, "client")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keptAliveLease.ModifiedIndex).To(BeEquivalentTo(2))
+			Expect(keptAliveLease.GrantedAt).To(Equal(lease.GrantedAt))
+			Expect(keptAliveLease.RenewedAt).To(Equal(fakeClock.Now().UnixNano()))
+		})
+
+		Context("when the lease does not exist", func() {
+			It("returns a lease not found error", func() {
+				_, err := sqlDB.KeepAliveLease(ctx, logger, "unknown", "client")
+				Expect(err).To(Equal(models.ErrLeaseNotFound))
+			})
+		})
+
+		Context("when the lease was granted by another client", func() {
+			It("returns a permission denied error", func() {
+				_, err := sqlDB.KeepAliveLease(ctx, logger, lease.ID, "other-client")
+				Expect(err).To(Equal(models.ErrPermissionDenied))
+
+				leases, err := sqlDB.FetchAllLeases(ctx, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(leases).To(Equal([]*db.Lease{lease}))
+			})
+		})
+
+		Context("when the lease was granted before owners were stored", func() {
+			BeforeEach(func() {
+				query := helpers.RebindForFlavor("UPDATE leases SET owner = ? WHERE id = ?", dbFlavor)
+				_, err := rawDB.Exec(query, "", lease.ID)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("is claimed by the first client keeping it alive", func() {
+				keptAliveLease, err := sqlDB.KeepAliveLease(ctx, logger, lease.ID, "other-client")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keptAliveLease.Owner).To(Equal("other-client"))
+
+				_, err = sqlDB.KeepAliveLease(ctx, logger, lease.ID, "client")
+				Expect(err).To(Equal(models.ErrPermissionDenied))
+			})
+		})
+	})
+
+	Context("FetchLeaseOwners", func() {
+		It("returns the owners of the existing leases", func() {
+			fakeGUIDProvider.NextGUIDReturns("other-lease-guid", nil)
+			_, err := sqlDB.GrantLease(ctx, logger, 10, "other-client")
+			Expect(err).NotTo(HaveOccurred())
+
+			owners, err := sqlDB.FetchLeaseOwners(ctx, logger, []string{"lease-guid", "other-lease-guid", "unknown"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owners).To(Equal(map[string]string{
+				"lease-guid":       "client",
+				"other-lease-guid": "other-client",
+			}))
+		})
+	})
+
+	Context("when locks are attached to the lease", func() {
+		var exclusive, shared, unleased *models.Resource
+
+		BeforeEach(func() {
+			exclusive = &models.Resource{Key: "exclusive", Owner: "cell", TypeCode: models.LOCK, LeaseId: lease.ID}
+			shared = &models.Resource{Key: "shared", Owner: "cell", TypeCode: models.LOCK, LeaseId: lease.ID}
+			unleased = &models.Resource{Key: "unleased", Owner: "cell", TypeCode: models.PRESENCE}
+
+			_, err := sqlDB.Lock(ctx, logger, exclusive, 0, "client")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sqlDB.LockShared(ctx, logger, shared, 0, 0, "client")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sqlDB.Lock(ctx, logger, unleased, 20, "client")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("stores the locks with the lease and its ttl", func() {
+			lock, err := sqlDB.Fetch(ctx, logger, exclusive.Key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.LeaseId).To(Equal(lease.ID))
+			Expect(lock.TtlInSeconds).To(BeEquivalentTo(10))
+
+			holders, err := sqlDB.FetchSharedHolders(ctx, logger, shared.Key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(HaveLen(1))
+			Expect(holders[0].LeaseId).To(Equal(lease.ID))
+		})
+
+		Context("RevokeLease", func() {
+			It("releases the locks attached to the lease", func() {
+				locks, err := sqlDB.RevokeLease(ctx, logger, lease.ID, "client")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(locks).To(HaveLen(2))
+				Expect(locks[0].Key).To(Equal(exclusive.Key))
+				Expect(locks[1].Key).To(Equal(shared.Key))
+				Expect(locks[1].Mode).To(Equal(models.SHARED))
+
+				Expect(validateLockNotInDB(rawDB, exclusive)).To(Succeed())
+				Expect(validateLockNotInDB(rawDB, shared)).To(Succeed())
+
+				_, err = sqlDB.Fetch(ctx, logger, unleased.Key)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the lease was granted by another client", func() {
+				It("returns a permission denied error and keeps the locks", func() {
+					_, err := sqlDB.RevokeLease(ctx, logger, lease.ID, "other-client")
+					Expect(err).To(Equal(models.ErrPermissionDenied))
+
+					leases, err := sqlDB.FetchAllLeases(ctx, logger)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(leases).To(HaveLen(1))
+
+					lock, err := sqlDB.Fetch(ctx, logger, exclusive.Key)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lock.LeaseId).To(Equal(lease.ID))
+				})
+			})
+
+			It("deletes the lease", func() {
+				_, err := sqlDB.RevokeLease(ctx, logger, lease.ID, "client")
+				Expect(err).NotTo(HaveOccurred())
+
+				leases, err := sqlDB.FetchAllLeases(ctx, logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(leases).To(BeEmpty())
+
+				_, err = sqlDB.Lock(ctx, logger, exclusive, 0, "client")
+				Expect(err).To(Equal(models.ErrLeaseNotFound))
+			})
+
+			Context("when a lock was detached from the lease", func() {
+				BeforeEach(func() {
+					detached := *exclusive
+					detached.LeaseId = ""
+					_, err := sqlDB.Lock(ctx, logger, &detached, 20, "client")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not release it", func() {
+					locks, err := sqlDB.RevokeLease(ctx, logger, lease.ID, "client")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(locks).To(HaveLen(1))
+					Expect(locks[0].Key).To(Equal(shared.Key))
+
+					_, err = sqlDB.Fetch(ctx, logger, exclusive.Key)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Context("ExpireLease", func() {
+			It("releases the locks attached to the lease", func() {
+				locks, expired, err := sqlDB.ExpireLease(ctx, logger, lease)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(expired).To(BeTrue())
+				Expect(locks).To(HaveLen(2))
+
+				Expect(validateLockNotInDB(rawDB, exclusive)).To(Succeed())
+				Expect(validateLockNotInDB(rawDB, shared)).To(Succeed())
+			})
+
+			Context("when the lease was kept alive", func() {
+				BeforeEach(func() {
+					_, err := sqlDB.KeepAliveLease(ctx, logger, lease.ID, "client")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns a lock collision error", func() {
+					_, _, err := sqlDB.ExpireLease(ctx, logger, lease)
+					Expect(err).To(Equal(models.ErrLockCollision))
+
+					_, err = sqlDB.Fetch(ctx, logger, exclusive.Key)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when the lease was revoked", func() {
+				BeforeEach(func() {
+					_, err := sqlDB.RevokeLease(ctx, logger, lease.ID, "client")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not expire it", func() {
+					locks, expired, err := sqlDB.ExpireLease(ctx, logger, lease)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(expired).To(BeFalse())
+					Expect(locks).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Context("Lock", func() {
+		Context("when the lease does not exist", func() {
+			It("returns a lease not found error", func() {
+				resource := &models.Resource{Key: "key", Owner: "cell", TypeCode: models.LOCK, LeaseId: "unknown"}
+				_, err := sqlDB.Lock(ctx, logger, resource, 0, "client")
+				Expect(err).To(Equal(models.ErrLeaseNotFound))
+			})
+		})
+
+		Context("when the lease was granted by another client", func() {
+			It("returns a permission denied error and does not lock the resource", func() {
+				resource := &models.Resource{Key: "key", Owner: "cell", TypeCode: models.LOCK, LeaseId: lease.ID}
+				_, err := sqlDB.Lock(ctx, logger, resource, 0, "other-client")
+				Expect(err).To(Equal(models.ErrPermissionDenied))
+
+				_, err = sqlDB.LockShared(ctx, logger, resource, 0, 0, "other-client")
+				Expect(err).To(Equal(models.ErrPermissionDenied))
+
+				_, errs, err := sqlDB.LockBatch(ctx, logger, []*models.LockRequest{{Resource: resource}}, "other-client")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(errs).To(Equal([]error{models.ErrPermissionDenied}))
+
+				_, err = sqlDB.Fetch(ctx, logger, resource.Key)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+	})
+
+	Context("LockBatch", func() {
+		var otherLease *db.Lease
+
+		BeforeEach(func() {
+			fakeGUIDProvider.NextGUIDReturns("other-lease-guid", nil)
+
+			var err error
+			otherLease, err = sqlDB.GrantLease(ctx, logger, 10, "client")
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeGUIDProvider.NextGUIDReturns("new-guid", nil)
+		})
+
+		It("does not deadlock when concurrent batches attach keys to the leases in different orders", func() {
+			// in key order, the first batch reaches the other lease first and
+			// the second batch the lease first
+			batches := [][]*models.LockRequest{
+				{
+					{Resource: &models.Resource{Key: "a", Owner: "cell", TypeCode: models.LOCK, LeaseId: otherLease.ID}},
+					{Resource: &models.Resource{Key: "b", Owner: "cell", TypeCode: models.LOCK, LeaseId: lease.ID}},
+				},
+				{
+					{Resource: &models.Resource{Key: "c", Owner: "cell", TypeCode: models.LOCK, LeaseId: lease.ID}},
+					{Resource: &models.Resource{Key: "d", Owner: "cell", TypeCode: models.LOCK, LeaseId: otherLease.ID}},
+				},
+			}
+
+			errCh := make(chan error, 20*len(batches))
+			wg := &sync.WaitGroup{}
+			for i := 0; i < 20; i++ {
+				for _, batch := range batches {
+					wg.Add(1)
+					go func(batch []*models.LockRequest) {
+						defer GinkgoRecover()
+						defer wg.Done()
+						_, _, err := sqlDB.LockBatch(ctx, logger, batch, "client")
+						errCh <- err
+					}(batch)
+				}
+			}
+			wg.Wait()
+			close(errCh)
+
+			for err := range errCh {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	})
+})
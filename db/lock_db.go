@@ -2,10 +2,16 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 
 	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/locket/models"
+	"google.golang.org/grpc/status"
 )
 
 func lagerDataFromLock(resource *models.Resource) lager.Data {
@@ -16,91 +22,305 @@ func lagerDataFromLock(resource *models.Resource) lager.Data {
 	}
 }
 
-func (db *SQLDB) Lock(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64) (*Lock, error) {
+// Lock acquires or renews the lock of the resource. A resource attached to a
+// lease is only locked if leaseOwner granted the lease.
+func (db *SQLDB) Lock(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64, leaseOwner string) (*Lock, error) {
 	logger = logger.Session("lock", lagerDataFromLock(resource))
 	var lock *Lock
 
 	var newLock bool
 
 	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
-		newLock = false
-		res, index, id, _, err := db.fetchLock(ctx, logger, tx, resource.Key)
+		var err error
+		lock, newLock, err = db.lock(ctx, logger, tx, resource, ttl, leaseOwner)
+		return err
+	})
+
+	if err == nil && newLock {
+		logger.Info("acquired-lock")
+	}
+
+	return lock, db.helper.ConvertSQLError(err)
+}
+
+// LockBatch acquires or renews all the requested locks in a single
+// transaction. Errors of a request, such as lock collisions or missing leases,
+// are returned per request in errs, and the changes of that request are rolled
+// back to a savepoint, while database errors fail the whole batch. The rows of
+// the leases are locked first, in lease order, and then the rows of the keys,
+// in key order, so that concurrent batches cannot deadlock each other.
+func (db *SQLDB) LockBatch(ctx context.Context, logger lager.Logger, requests []*models.LockRequest, leaseOwner string) ([]*Lock, []error, error) {
+	logger = logger.Session("lock-batch", lager.Data{"count": len(requests)})
+	var locks []*Lock
+	var errs []error
+	var newLocks []bool
+
+	order := keyOrder(requests)
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		locks = make([]*Lock, len(requests))
+		errs = make([]error, len(requests))
+		newLocks = make([]bool, len(requests))
+
+		err := db.lockLeases(ctx, logger, tx, requests)
 		if err != nil {
-			sqlErr := db.helper.ConvertSQLError(err)
-			if sqlErr != helpers.ErrResourceNotFound {
-				logger.Error("failed-to-fetch-lock", err)
+			return err
+		}
+
+		for _, i := range order {
+			req := requests[i]
+			logger := logger.WithData(lagerDataFromLock(req.Resource))
+
+			_, err := tx.ExecContext(ctx, "SAVEPOINT lock_batch_request")
+			if err != nil {
+				logger.Error("failed-to-create-savepoint", err)
 				return err
 			}
-			newLock = true
-		} else if res.Owner != resource.Owner && res.Owner != "" {
-			logger.Debug("lock-already-exists")
-			return models.ErrLockCollision
-		}
 
-		index++
+			lock, newLock, err := db.lockRequest(ctx, logger, tx, req, leaseOwner)
+			if isRequestError(err) {
+				errs[i] = err
+				_, err = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT lock_batch_request")
+				if err != nil {
+					logger.Error("failed-to-rollback-to-savepoint", err)
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
 
-		modifiedId := id
-		if modifiedId == "" {
-			modifiedId, err = db.guidProvider.NextGUID()
+			_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT lock_batch_request")
 			if err != nil {
-				logger.Error("failed-to-generate-guid", err)
+				logger.Error("failed-to-release-savepoint", err)
 				return err
 			}
-		}
 
-		lock = &Lock{
-			Resource:      models.GetResource(resource),
-			ModifiedIndex: index,
-			ModifiedId:    modifiedId,
-			TtlInSeconds:  ttl,
+			locks[i] = lock
+			newLocks[i] = newLock
 		}
 
+		return nil
+	})
+	if err != nil {
+		return nil, nil, db.helper.ConvertSQLError(err)
+	}
+
+	for i, newLock := range newLocks {
 		if newLock {
-			_, err = db.helper.Insert(ctx, logger, tx, "locks",
-				helpers.SQLAttributes{
-					"path":           lock.Key,
-					"owner":          lock.Owner,
-					"value":          lock.Value,
-					"type":           lock.Type,
-					"modified_index": lock.ModifiedIndex,
-					"modified_id":    lock.ModifiedId,
-					"ttl":            lock.TtlInSeconds,
-				},
-			)
-		} else {
-			_, err = db.helper.Update(ctx, logger, tx, "locks",
-				helpers.SQLAttributes{
-					"owner":          lock.Owner,
-					"value":          lock.Value,
-					"type":           lock.Type,
-					"modified_index": lock.ModifiedIndex,
-					"modified_id":    lock.ModifiedId,
-					"ttl":            lock.TtlInSeconds,
-				},
-				"path = ?", lock.Key,
-			)
+			logger.Info("acquired-lock", lagerDataFromLock(requests[i].Resource))
 		}
+	}
 
+	return locks, errs, nil
+}
+
+// LockMulti acquires or renews all the requested locks in a single
+// transaction, or none of them. The first lock collision rolls the
+// transaction back and is returned. Rows are locked in the same order as
+// LockBatch so that concurrent requests cannot deadlock each other.
+func (db *SQLDB) LockMulti(ctx context.Context, logger lager.Logger, requests []*models.LockRequest, leaseOwner string) ([]*Lock, error) {
+	logger = logger.Session("lock-multi", lager.Data{"count": len(requests)})
+	var locks []*Lock
+	var newLocks []bool
+
+	order := keyOrder(requests)
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		locks = make([]*Lock, len(requests))
+		newLocks = make([]bool, len(requests))
+
+		err := db.lockLeases(ctx, logger, tx, requests)
 		if err != nil {
-			logger.Error("failed-updating-lock", err)
 			return err
 		}
 
+		for _, i := range order {
+			req := requests[i]
+			logger := logger.WithData(lagerDataFromLock(req.Resource))
+
+			lock, newLock, err := db.lockRequest(ctx, logger, tx, req, leaseOwner)
+			if err != nil {
+				return err
+			}
+
+			locks[i] = lock
+			newLocks[i] = newLock
+		}
+
 		return nil
 	})
+	if err != nil {
+		return nil, db.helper.ConvertSQLError(err)
+	}
 
-	if err == nil && newLock {
-		logger.Info("acquired-lock")
+	for i, newLock := range newLocks {
+		if newLock {
+			logger.Info("acquired-lock", lagerDataFromLock(requests[i].Resource))
+		}
 	}
 
-	return lock, db.helper.ConvertSQLError(err)
+	return locks, nil
+}
+
+// isRequestError reports whether err is an error of the request itself, as
+// opposed to an error of the database. Errors of the request are grpc status
+// errors, see models.
+func isRequestError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := status.FromError(err)
+	return ok
+}
+
+// keyOrder returns the indexes of the requests sorted by key.
+func keyOrder(requests []*models.LockRequest) []int {
+	order := make([]int, len(requests))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return requests[order[i]].Resource.Key < requests[order[j]].Resource.Key
+	})
+	return order
+}
+
+func (db *SQLDB) lockRequest(ctx context.Context, logger lager.Logger, tx helpers.Tx, req *models.LockRequest, leaseOwner string) (*Lock, bool, error) {
+	if req.IsShared() {
+		return db.lockShared(ctx, logger, tx, req.Resource, req.TtlInSeconds, int(req.SemaphoreLimit), leaseOwner)
+	}
+	return db.lock(ctx, logger, tx, req.Resource, req.TtlInSeconds, leaseOwner)
+}
+
+func (db *SQLDB) lock(ctx context.Context, logger lager.Logger, tx helpers.Tx, resource *models.Resource, ttl int64, leaseOwner string) (*Lock, bool, error) {
+	ttl, err := db.leaseTTL(ctx, logger, tx, resource, ttl, leaseOwner)
+	if err != nil {
+		return nil, false, err
+	}
+
+	newLock := false
+	current, err := db.fetchLock(ctx, logger, tx, resource.Key)
+	if err != nil {
+		sqlErr := db.helper.ConvertSQLError(err)
+		if sqlErr != helpers.ErrResourceNotFound {
+			logger.Error("failed-to-fetch-lock", err)
+			return nil, false, err
+		}
+		err = db.checkNamespaceQuota(ctx, logger, tx, resource.Key)
+		if err != nil {
+			return nil, false, err
+		}
+		newLock = true
+		current = &Lock{}
+	} else if current.Owner != resource.Owner && current.Owner != "" {
+		logger.Debug("lock-already-exists")
+		return nil, false, models.ErrLockCollision
+	}
+
+	if current.Owner == "" && !newLock {
+		count, err := db.countSharedHolders(ctx, logger, tx, resource.Key)
+		if err != nil {
+			return nil, false, err
+		}
+		if count > 0 {
+			logger.Debug("lock-held-in-shared-mode", lager.Data{"shared-holders": count})
+			return nil, false, models.ErrLockCollision
+		}
+
+		err = db.checkNamespaceQuota(ctx, logger, tx, resource.Key)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	modifiedId := current.ModifiedId
+	if modifiedId == "" {
+		modifiedId, err = db.guidProvider.NextGUID()
+		if err != nil {
+			logger.Error("failed-to-generate-guid", err)
+			return nil, false, err
+		}
+	}
+
+	// the fencing token only changes when the lock changes hands, renewals
+	// by the same owner keep it
+	fencingToken := current.FencingToken
+	if newLock || current.Owner == "" || fencingToken == 0 {
+		fencingToken, err = db.nextFencingToken(ctx, logger, tx)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	now := db.clock.Now().UnixNano()
+	acquiredAt := current.AcquiredAt
+	if newLock || current.Owner == "" {
+		acquiredAt = now
+	}
+
+	lock := &Lock{
+		Resource:      models.GetResource(resource),
+		ModifiedIndex: current.ModifiedIndex + 1,
+		ModifiedId:    modifiedId,
+		TtlInSeconds:  ttl,
+		FencingToken:  fencingToken,
+		AcquiredAt:    acquiredAt,
+		RenewedAt:     now,
+	}
+
+	if newLock {
+		_, err = db.helper.Insert(ctx, logger, tx, "locks",
+			helpers.SQLAttributes{
+				"path":           lock.Key,
+				"owner":          lock.Owner,
+				"value":          lock.Value,
+				"type":           lock.Type,
+				"modified_index": lock.ModifiedIndex,
+				"modified_id":    lock.ModifiedId,
+				"ttl":            lock.TtlInSeconds,
+				"fencing_token":  lock.FencingToken,
+				"acquired_at":    lock.AcquiredAt,
+				"renewed_at":     lock.RenewedAt,
+				"lease_id":       lock.LeaseId,
+				"labels":         encodeLabels(lock.Labels),
+				"payload":        lock.Payload,
+			},
+		)
+	} else {
+		_, err = db.helper.Update(ctx, logger, tx, "locks",
+			helpers.SQLAttributes{
+				"owner":          lock.Owner,
+				"value":          lock.Value,
+				"type":           lock.Type,
+				"modified_index": lock.ModifiedIndex,
+				"modified_id":    lock.ModifiedId,
+				"ttl":            lock.TtlInSeconds,
+				"fencing_token":  lock.FencingToken,
+				"acquired_at":    lock.AcquiredAt,
+				"renewed_at":     lock.RenewedAt,
+				"lease_id":       lock.LeaseId,
+				"labels":         encodeLabels(lock.Labels),
+				"payload":        lock.Payload,
+			},
+			"path = ?", lock.Key,
+		)
+	}
+
+	if err != nil {
+		logger.Error("failed-updating-lock", err)
+		return nil, false, err
+	}
+
+	return lock, newLock, nil
 }
 
 func (db *SQLDB) Release(ctx context.Context, logger lager.Logger, resource *models.Resource) error {
 	logger = logger.Session("release-lock", lagerDataFromLock(resource))
 
 	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
-		res, _, _, _, err := db.fetchLock(ctx, logger, tx, resource.Key)
+		current, err := db.fetchLock(ctx, logger, tx, resource.Key)
 		if err != nil {
 			sqlErr := db.helper.ConvertSQLError(err)
 			if sqlErr == helpers.ErrResourceNotFound {
@@ -111,7 +331,7 @@ func (db *SQLDB) Release(ctx context.Context, logger lager.Logger, resource *mod
 			return sqlErr
 		}
 
-		if res.Owner != resource.Owner {
+		if current.Owner != resource.Owner {
 			logger.Error("cannot-release-lock", models.ErrLockCollision)
 			return models.ErrLockCollision
 		}
@@ -129,12 +349,147 @@ func (db *SQLDB) Release(ctx context.Context, logger lager.Logger, resource *mod
 	return err
 }
 
+func (db *SQLDB) Update(ctx context.Context, logger lager.Logger, resource *models.Resource, expectedIndex int64) (*Lock, error) {
+	logger = logger.Session("update-lock", lagerDataFromLock(resource))
+	var lock *Lock
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		current, err := db.fetchLock(ctx, logger, tx, resource.Key)
+		if err != nil {
+			sqlErr := db.helper.ConvertSQLError(err)
+			if sqlErr == helpers.ErrResourceNotFound {
+				logger.Debug("lock-does-not-exist")
+				return models.ErrResourceNotFound
+			}
+			logger.Error("failed-to-fetch-lock", err)
+			return sqlErr
+		}
+
+		if current.Owner == "" {
+			logger.Debug("lock-does-not-exist")
+			return models.ErrResourceNotFound
+		}
+
+		if current.Owner != resource.Owner {
+			logger.Error("cannot-update-lock", models.ErrLockCollision, lager.Data{"fetched-owner": current.Owner})
+			return models.ErrLockCollision
+		}
+
+		if current.ModifiedIndex != expectedIndex {
+			logger.Debug("modified-index-mismatch", lager.Data{"expected-modified-index": expectedIndex, "fetched-modified-index": current.ModifiedIndex})
+			return models.ErrModifiedIndexMismatch
+		}
+
+		// labels and payload are only replaced when the request sets them, a
+		// value-only update keeps them
+		current.Value = resource.Value
+		if resource.Labels != nil {
+			current.Labels = resource.Labels
+		}
+		if resource.Payload != nil {
+			current.Payload = resource.Payload
+		}
+		current.ModifiedIndex++
+		current.RenewedAt = db.clock.Now().UnixNano()
+		lock = current
+
+		_, err = db.helper.Update(ctx, logger, tx, "locks",
+			helpers.SQLAttributes{
+				"value":          lock.Value,
+				"labels":         encodeLabels(lock.Labels),
+				"payload":        lock.Payload,
+				"modified_index": lock.ModifiedIndex,
+				"renewed_at":     lock.RenewedAt,
+			},
+			"path = ?", lock.Key,
+		)
+		if err != nil {
+			logger.Error("failed-updating-lock", err)
+			return err
+		}
+
+		return nil
+	})
+
+	return lock, db.helper.ConvertSQLError(err)
+}
+
+// Transfer hands the lock held by the owner of the resource to newOwner. The
+// row is kept, so that watchers see an update rather than a deletion followed
+// by a creation, and the new owner gets a new fencing token. A lock attached
+// to a lease is detached from it and keeps the TTL of the lease.
+func (db *SQLDB) Transfer(ctx context.Context, logger lager.Logger, resource *models.Resource, newOwner string) (*Lock, error) {
+	logger = logger.Session("transfer-lock", lagerDataFromLock(resource)).WithData(lager.Data{"new-owner": newOwner})
+	var lock *Lock
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		current, err := db.fetchLock(ctx, logger, tx, resource.Key)
+		if err != nil {
+			sqlErr := db.helper.ConvertSQLError(err)
+			if sqlErr == helpers.ErrResourceNotFound {
+				logger.Debug("lock-does-not-exist")
+				return models.ErrResourceNotFound
+			}
+			logger.Error("failed-to-fetch-lock", err)
+			return sqlErr
+		}
+
+		if current.Owner == "" {
+			logger.Debug("lock-does-not-exist")
+			return models.ErrResourceNotFound
+		}
+
+		if current.Owner != resource.Owner {
+			logger.Info("cannot-transfer-lock", lager.Data{"fetched-owner": current.Owner})
+			return models.ErrLockCollision
+		}
+
+		fencingToken, err := db.nextFencingToken(ctx, logger, tx)
+		if err != nil {
+			return err
+		}
+
+		now := db.clock.Now().UnixNano()
+		current.Owner = newOwner
+		current.ModifiedIndex++
+		current.FencingToken = fencingToken
+		current.AcquiredAt = now
+		current.RenewedAt = now
+		current.LeaseId = ""
+		lock = current
+
+		_, err = db.helper.Update(ctx, logger, tx, "locks",
+			helpers.SQLAttributes{
+				"owner":          lock.Owner,
+				"modified_index": lock.ModifiedIndex,
+				"fencing_token":  lock.FencingToken,
+				"acquired_at":    lock.AcquiredAt,
+				"renewed_at":     lock.RenewedAt,
+				"lease_id":       lock.LeaseId,
+			},
+			"path = ?", lock.Key,
+		)
+		if err != nil {
+			logger.Error("failed-updating-lock", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, db.helper.ConvertSQLError(err)
+	}
+
+	logger.Info("transferred-lock", lager.Data{"fencing-token": lock.FencingToken})
+	return lock, nil
+}
+
 func (db *SQLDB) Fetch(ctx context.Context, logger lager.Logger, key string) (*Lock, error) {
 	logger = logger.Session("fetch-lock", lager.Data{"key": key})
 	var lock *Lock
 
 	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
-		res, index, id, ttl, err := db.fetchLock(ctx, logger, tx, key)
+		fetchedLock, err := db.fetchLock(ctx, logger, tx, key)
 		if err != nil {
 			logger.Error("failed-to-fetch-lock", err)
 			sqlErr := db.helper.ConvertSQLError(err)
@@ -144,11 +499,11 @@ func (db *SQLDB) Fetch(ctx context.Context, logger lager.Logger, key string) (*L
 			return sqlErr
 		}
 
-		if res.Owner == "" {
+		if fetchedLock.Owner == "" {
 			return models.ErrResourceNotFound
 		}
 
-		lock = &Lock{Resource: res, ModifiedIndex: index, ModifiedId: id, TtlInSeconds: ttl}
+		lock = fetchedLock
 
 		return nil
 	})
@@ -156,6 +511,8 @@ func (db *SQLDB) Fetch(ctx context.Context, logger lager.Logger, key string) (*L
 	return lock, err
 }
 
+// FetchAll returns all the locks of lockType, or of all types when lockType is
+// empty, without their payloads.
 func (db *SQLDB) FetchAll(ctx context.Context, logger lager.Logger, lockType string) ([]*Lock, error) {
 	logger = logger.Session("fetch-all-locks", lager.Data{"type": lockType})
 	var locks []*Lock
@@ -170,7 +527,7 @@ func (db *SQLDB) FetchAll(ctx context.Context, logger lager.Logger, lockType str
 		}
 
 		rows, err := db.helper.All(ctx, logger, tx, "locks",
-			helpers.ColumnList{"path", "owner", "value", "type", "modified_index", "modified_id", "ttl"},
+			lockColumnsWithoutPayload,
 			helpers.NoLockRow, where, whereBindings...,
 		)
 		if err != nil {
@@ -179,45 +536,233 @@ func (db *SQLDB) FetchAll(ctx context.Context, logger lager.Logger, lockType str
 		}
 		defer rows.Close()
 
-		for rows.Next() {
-			var key, owner, value, lockType, id string
-			var index, ttl int64
+		locks = scanLocks(logger, rows, false)
+		return nil
+	})
 
-			err := rows.Scan(&key, &owner, &value, &lockType, &index, &id, &ttl)
-			if err != nil {
-				logger.Error("failed-to-scan-lock", err)
-				continue
-			}
+	return locks, db.helper.ConvertSQLError(err)
+}
 
-			if owner == "" {
+// FetchPage returns up to limit locks of the namespace ordered by key,
+// starting after the startAfter key. A limit of 0 returns all the remaining
+// locks. Empty lockType and keyPrefix do not filter the locks, and an empty
+// labelSelector matches every lock. The keys of the returned locks are
+// qualified with the namespace, while keyPrefix and startAfter are not. The
+// payloads of the locks are not fetched, so that pages of locks stay within
+// the size of a message; they are returned by Fetch.
+func (db *SQLDB) FetchPage(ctx context.Context, logger lager.Logger, namespace, lockType, keyPrefix, startAfter string, labelSelector map[string]string, limit int) ([]*Lock, error) {
+	logger = logger.Session("fetch-page", lager.Data{"namespace": namespace, "type": lockType, "key-prefix": keyPrefix, "start-after": startAfter, "label-selector": labelSelector, "limit": limit})
+	return db.fetchMatchingPages(ctx, logger, false, namespace, lockType, keyPrefix, startAfter, "", labelSelector, limit)
+}
+
+// FetchSharedPage returns up to limit shared holders of the namespace like
+// FetchPage, ordered by key and owner. A key can have more shared holders than
+// fit in a page, so the page starts after the holder with the startAfterKey
+// and startAfterOwner.
+func (db *SQLDB) FetchSharedPage(ctx context.Context, logger lager.Logger, namespace, lockType, keyPrefix, startAfterKey, startAfterOwner string, labelSelector map[string]string, limit int) ([]*Lock, error) {
+	logger = logger.Session("fetch-shared-page", lager.Data{"namespace": namespace, "type": lockType, "key-prefix": keyPrefix, "start-after-key": startAfterKey, "start-after-owner": startAfterOwner, "label-selector": labelSelector, "limit": limit})
+
+	locks, err := db.fetchMatchingPages(ctx, logger, true, namespace, lockType, keyPrefix, startAfterKey, startAfterOwner, labelSelector, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lock := range locks {
+		lock.Mode = models.SHARED
+	}
+
+	return locks, nil
+}
+
+func (db *SQLDB) fetchMatchingPages(ctx context.Context, logger lager.Logger, shared bool, namespace, lockType, keyPrefix, startAfter, startAfterOwner string, labelSelector map[string]string, limit int) ([]*Lock, error) {
+	keyPrefix = models.NamespacedKey(namespace, keyPrefix)
+	if startAfter != "" {
+		startAfter = models.NamespacedKey(namespace, startAfter)
+	}
+
+	if len(labelSelector) == 0 {
+		return db.fetchPage(ctx, logger, shared, namespace, lockType, keyPrefix, startAfter, startAfterOwner, limit)
+	}
+
+	// labels are stored encoded, so the selector is applied to the fetched
+	// rows, reading pages of limit rows until enough of them match
+	var locks []*Lock
+	for {
+		page, err := db.fetchPage(ctx, logger, shared, namespace, lockType, keyPrefix, startAfter, startAfterOwner, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lock := range page {
+			if !lock.MatchesLabels(labelSelector) {
 				continue
 			}
+			locks = append(locks, lock)
+			if limit > 0 && len(locks) == limit {
+				return locks, nil
+			}
+		}
+
+		if limit == 0 || len(page) < limit {
+			return locks, nil
+		}
+		startAfter = page[len(page)-1].Key
+		startAfterOwner = page[len(page)-1].Owner
+	}
+}
+
+// fetchPage reads a page of the locks table, or of the shared_locks table
+// when shared is set, in which case the page starts after the holder with the
+// startAfter key and startAfterOwner.
+func (db *SQLDB) fetchPage(ctx context.Context, logger lager.Logger, shared bool, namespace, lockType, keyPrefix, startAfter, startAfterOwner string, limit int) ([]*Lock, error) {
+	var locks []*Lock
 
-			locks = append(locks, &Lock{
-				Resource: &models.Resource{
-					Key:      key,
-					Owner:    owner,
-					Value:    value,
-					Type:     lockType,
-					TypeCode: models.GetTypeCode(lockType),
-				},
-				ModifiedIndex: index,
-				ModifiedId:    id,
-				TtlInSeconds:  ttl,
-			})
+	table, order := "locks", "path"
+	if shared {
+		table, order = "shared_locks", "path, owner"
+	}
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		wheres := []string{"owner <> ?"}
+		whereBindings := []interface{}{""}
+
+		if namespace == "" {
+			where, bindings := namespaceWhere(namespace)
+			wheres = append(wheres, where)
+			whereBindings = append(whereBindings, bindings...)
+		}
+
+		if lockType != "" {
+			wheres = append(wheres, "type = ?")
+			whereBindings = append(whereBindings, lockType)
+		}
+
+		if keyPrefix != "" {
+			wheres = append(wheres, "path LIKE ?")
+			whereBindings = append(whereBindings, escapeLike(keyPrefix)+"%")
+		}
+
+		if startAfter != "" && shared {
+			wheres = append(wheres, "(path > ? OR (path = ? AND owner > ?))")
+			whereBindings = append(whereBindings, startAfter, startAfter, startAfterOwner)
+		} else if startAfter != "" {
+			wheres = append(wheres, "path > ?")
+			whereBindings = append(whereBindings, startAfter)
+		}
+
+		query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s", strings.Join(lockColumnsWithoutPayload, ", "), table, strings.Join(wheres, " AND "), order)
+		if limit > 0 {
+			query += " LIMIT ?"
+			whereBindings = append(whereBindings, limit)
 		}
 
+		rows, err := tx.QueryContext(ctx, helpers.RebindForFlavor(query, db.flavor), whereBindings...)
+		if err != nil {
+			logger.Error("failed-to-fetch-locks", err)
+			return err
+		}
+		defer rows.Close()
+
+		locks = scanLocks(logger, rows, false)
 		return nil
 	})
 
 	return locks, db.helper.ConvertSQLError(err)
 }
 
-func (db *SQLDB) Count(ctx context.Context, logger lager.Logger, lockType string) (int, error) {
+var lockColumns = helpers.ColumnList{"path", "owner", "value", "type", "modified_index", "modified_id", "ttl", "fencing_token", "acquired_at", "renewed_at", "lease_id", "labels", "payload"}
+
+// lockColumnsWithoutPayload are the columns of locks listed in pages.
+var lockColumnsWithoutPayload = helpers.ColumnList{"path", "owner", "value", "type", "modified_index", "modified_id", "ttl", "fencing_token", "acquired_at", "renewed_at", "lease_id", "labels"}
+
+// scanLocks scans rows of the lockColumns, or of the lockColumnsWithoutPayload
+// when withPayload is false.
+func scanLocks(logger lager.Logger, rows *sql.Rows, withPayload bool) []*Lock {
+	var locks []*Lock
+
+	for rows.Next() {
+		var key, owner, value, lockType, id, leaseID, labels string
+		var index, ttl, fencingToken, acquiredAt, renewedAt int64
+		var payload []byte
+
+		dest := []interface{}{&key, &owner, &value, &lockType, &index, &id, &ttl, &fencingToken, &acquiredAt, &renewedAt, &leaseID, &labels}
+		if withPayload {
+			dest = append(dest, &payload)
+		}
+
+		err := rows.Scan(dest...)
+		if err != nil {
+			logger.Error("failed-to-scan-lock", err)
+			continue
+		}
+
+		if owner == "" {
+			continue
+		}
+
+		locks = append(locks, &Lock{
+			Resource: &models.Resource{
+				Key:      key,
+				Owner:    owner,
+				Value:    value,
+				Type:     lockType,
+				TypeCode: models.GetTypeCode(lockType),
+				LeaseId:  leaseID,
+				Labels:   decodeLabels(logger, labels),
+				Payload:  payload,
+			},
+			ModifiedIndex: index,
+			ModifiedId:    id,
+			TtlInSeconds:  ttl,
+			FencingToken:  fencingToken,
+			AcquiredAt:    acquiredAt,
+			RenewedAt:     renewedAt,
+		})
+	}
+
+	return locks
+}
+
+// escapeLike escapes the LIKE wildcards in s, using the default escape
+// character of both MySQL and Postgres.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// encodeLabels encodes the labels of a resource for the labels column. No
+// labels are stored as an empty string.
+func encodeLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	encoded, _ := json.Marshal(labels)
+	return string(encoded)
+}
+
+func decodeLabels(logger lager.Logger, encoded string) map[string]string {
+	if encoded == "" {
+		return nil
+	}
+
+	var labels map[string]string
+	err := json.Unmarshal([]byte(encoded), &labels)
+	if err != nil {
+		logger.Error("failed-to-decode-labels", err)
+		return nil
+	}
+	return labels
+}
+
+// Count returns the number of locks of the given type held in the namespace.
+func (db *SQLDB) Count(ctx context.Context, logger lager.Logger, namespace, lockType string) (int, error) {
 	whereBindings := make([]interface{}, 0)
 	wheres := "owner <> ?"
 	whereBindings = append(whereBindings, "")
 
+	where, bindings := namespaceWhere(namespace)
+	wheres += " AND " + where
+	whereBindings = append(whereBindings, bindings...)
+
 	if lockType != "" {
 		wheres += " AND type = ?"
 		whereBindings = append(whereBindings, lockType)
@@ -228,34 +773,85 @@ func (db *SQLDB) Count(ctx context.Context, logger lager.Logger, lockType string
 	return count, db.helper.ConvertSQLError(err)
 }
 
-func (db *SQLDB) fetchLock(ctx context.Context, logger lager.Logger, q helpers.Queryable, key string) (*models.Resource, int64, string, int64, error) {
+func (db *SQLDB) fetchLock(ctx context.Context, logger lager.Logger, q helpers.Queryable, key string) (*Lock, error) {
 	row := db.helper.One(ctx, logger, q, "locks",
-		helpers.ColumnList{"owner", "value", "type", "modified_index", "modified_id", "ttl"},
+		helpers.ColumnList{"owner", "value", "type", "modified_index", "modified_id", "ttl", "fencing_token", "acquired_at", "renewed_at", "lease_id", "labels", "payload"},
 		helpers.LockRow,
 		"path = ?", key,
 	)
 
-	var owner, value, lockType, id string
-	var index, ttl int64
-	err := row.Scan(&owner, &value, &lockType, &index, &id, &ttl)
+	var owner, value, lockType, id, leaseID, labels string
+	var index, ttl, fencingToken, acquiredAt, renewedAt int64
+	var payload []byte
+	err := row.Scan(&owner, &value, &lockType, &index, &id, &ttl, &fencingToken, &acquiredAt, &renewedAt, &leaseID, &labels, &payload)
 	if err != nil {
-		return nil, 0, "", 0, err
+		return nil, err
 	}
 
-	return &models.Resource{
-		Key:      key,
-		Owner:    owner,
-		Value:    value,
-		Type:     lockType,
-		TypeCode: models.GetTypeCode(lockType),
-	}, index, id, ttl, nil
+	return &Lock{
+		Resource: &models.Resource{
+			Key:      key,
+			Owner:    owner,
+			Value:    value,
+			Type:     lockType,
+			TypeCode: models.GetTypeCode(lockType),
+			LeaseId:  leaseID,
+			Labels:   decodeLabels(logger, labels),
+			Payload:  payload,
+		},
+		ModifiedIndex: index,
+		ModifiedId:    id,
+		TtlInSeconds:  ttl,
+		FencingToken:  fencingToken,
+		AcquiredAt:    acquiredAt,
+		RenewedAt:     renewedAt,
+	}, nil
+}
+
+// nextFencingToken increments the fencing token sequence and returns the new
+// value. The sequence is shared by all keys so that tokens keep increasing
+// when a released lock row is created again.
+func (db *SQLDB) nextFencingToken(ctx context.Context, logger lager.Logger, tx helpers.Tx) (int64, error) {
+	result, err := tx.ExecContext(ctx, helpers.RebindForFlavor("UPDATE locket_fencing_token SET token = token + 1 WHERE id = ?", db.flavor), 1)
+	if err != nil {
+		logger.Error("failed-incrementing-fencing-token", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Error("failed-incrementing-fencing-token", err)
+		return 0, err
+	}
+
+	if rowsAffected == 0 {
+		_, err = db.helper.Insert(ctx, logger, tx, "locket_fencing_token", helpers.SQLAttributes{"id": 1, "token": 1})
+		if err != nil {
+			logger.Error("failed-inserting-fencing-token", err)
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	var token int64
+	err = tx.QueryRowContext(ctx, helpers.RebindForFlavor("SELECT token FROM locket_fencing_token WHERE id = ?", db.flavor), 1).Scan(&token)
+	if err != nil {
+		logger.Error("failed-fetching-fencing-token", err)
+		return 0, err
+	}
+
+	return token, nil
 }
 
 func (db *SQLDB) FetchAndRelease(ctx context.Context, logger lager.Logger, lock *Lock) (bool, error) {
 	logger = logger.Session("fetch-and-release-lock", lagerDataFromLock(lock.Resource))
 
+	if lock.Mode == models.SHARED {
+		return db.fetchAndReleaseShared(ctx, logger, lock)
+	}
+
 	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
-		res, index, id, ttl, err := db.fetchLock(ctx, logger, tx, lock.Resource.Key)
+		fetchedLock, err := db.fetchLock(ctx, logger, tx, lock.Resource.Key)
 
 		if err != nil {
 			sqlErr := db.helper.ConvertSQLError(err)
@@ -269,8 +865,6 @@ func (db *SQLDB) FetchAndRelease(ctx context.Context, logger lager.Logger, lock
 
 		logger.Info("fetched-lock")
 
-		fetchedLock := &Lock{Resource: res, ModifiedIndex: index, ModifiedId: id, TtlInSeconds: ttl}
-
 		if fetchedLock.Resource.Owner != lock.Resource.Owner {
 			logger.Error("fetch-failed-owner-mismatch", models.ErrLockCollision, lager.Data{"fetched-owner": fetchedLock.Owner})
 			return models.ErrLockCollision
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
 	"code.cloudfoundry.org/locket/db"
@@ -104,37 +106,130 @@ var _ = Describe("Lock", func() {
 							Value:    "i can do anything",
 							TypeCode: models.LOCK,
 						}
-						lock, err := sqlDB.Lock(ctx, logger, typeCodeResource, 10)
+						lock, err := sqlDB.Lock(ctx, logger, typeCodeResource, 10, "")
 						Expect(err).NotTo(HaveOccurred())
 						Expect(lock).To(Equal(&db.Lock{
 							Resource:      expectedResource,
 							ModifiedIndex: 1,
 							ModifiedId:    "new-guid",
 							TtlInSeconds:  10,
+							FencingToken:  1,
+							AcquiredAt:    fakeClock.Now().UnixNano(),
+							RenewedAt:     fakeClock.Now().UnixNano(),
 						}))
 						Expect(validateLockInDB(rawDB, resource, 1, 10, "new-guid")).To(Succeed())
 					})
 				})
 
 				It("inserts the lock for the owner", func() {
-					lock, err := sqlDB.Lock(ctx, logger, resource, 10)
+					lock, err := sqlDB.Lock(ctx, logger, resource, 10, "")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(lock).To(Equal(&db.Lock{
 						Resource:      expectedResource,
 						ModifiedIndex: 1,
 						ModifiedId:    "new-guid",
 						TtlInSeconds:  10,
+						FencingToken:  1,
+						AcquiredAt:    fakeClock.Now().UnixNano(),
+						RenewedAt:     fakeClock.Now().UnixNano(),
 					}))
 					Expect(validateLockInDB(rawDB, resource, 1, 10, "new-guid")).To(Succeed())
 				})
 
+				Context("when the namespace of the key has a resource limit", func() {
+					It("does not hold more keys than the limit in the namespace", func() {
+						for _, key := range []string{"namespaces/quota/a", "namespaces/quota/b"} {
+							_, err := sqlDB.Lock(ctx, logger, &models.Resource{Key: key, Owner: "owner", TypeCode: models.LOCK}, 10, "")
+							Expect(err).NotTo(HaveOccurred())
+						}
+
+						_, err := sqlDB.Lock(ctx, logger, &models.Resource{Key: "namespaces/quota/c", Owner: "owner", TypeCode: models.LOCK}, 10, "")
+						Expect(err).To(Equal(models.ErrNamespaceQuotaExceeded))
+						Expect(validateLockNotInDB(rawDB, &models.Resource{Key: "namespaces/quota/c"})).To(Succeed())
+
+						// keys that are already held keep being renewed
+						_, err = sqlDB.Lock(ctx, logger, &models.Resource{Key: "namespaces/quota/a", Owner: "owner", TypeCode: models.LOCK}, 10, "")
+						Expect(err).NotTo(HaveOccurred())
+
+						// the default namespace is not limited
+						_, err = sqlDB.Lock(ctx, logger, &models.Resource{Key: "c", Owner: "owner", TypeCode: models.LOCK}, 10, "")
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("does not count keys without an owner", func() {
+						query := helpers.RebindForFlavor("INSERT INTO locks (path, owner, value) VALUES (?, ?, ?)", dbFlavor)
+						for _, key := range []string{"namespaces/quota/a", "namespaces/quota/b"} {
+							_, err := rawDB.Exec(query, key, "", "")
+							Expect(err).NotTo(HaveOccurred())
+						}
+
+						for _, key := range []string{"namespaces/quota/a", "namespaces/quota/c"} {
+							_, err := sqlDB.Lock(ctx, logger, &models.Resource{Key: key, Owner: "owner", TypeCode: models.LOCK}, 10, "")
+							Expect(err).NotTo(HaveOccurred())
+						}
+
+						// acquiring a key without an owner counts against the limit
+						_, err := sqlDB.Lock(ctx, logger, &models.Resource{Key: "namespaces/quota/b", Owner: "owner", TypeCode: models.LOCK}, 10, "")
+						Expect(err).To(Equal(models.ErrNamespaceQuotaExceeded))
+					})
+
+					It("does not hold more keys than the limit when new keys are acquired concurrently", func() {
+						rawDB.SetMaxOpenConns(10)
+
+						errs := make(chan error, 10)
+						for i := 0; i < 10; i++ {
+							go func(i int) {
+								defer GinkgoRecover()
+								_, err := sqlDB.Lock(ctx, logger, &models.Resource{Key: fmt.Sprintf("namespaces/quota/%d", i), Owner: "owner", TypeCode: models.LOCK}, 10, "")
+								errs <- err
+							}(i)
+						}
+
+						acquired := 0
+						for i := 0; i < 10; i++ {
+							err := <-errs
+							if err == nil {
+								acquired++
+								continue
+							}
+							Expect(err).To(Equal(models.ErrNamespaceQuotaExceeded))
+						}
+						Expect(acquired).To(Equal(2))
+
+						var count int
+						query := helpers.RebindForFlavor("SELECT COUNT(*) FROM locks WHERE path LIKE ?", dbFlavor)
+						Expect(rawDB.QueryRow(query, "namespaces/quota/%").Scan(&count)).To(Succeed())
+						Expect(count).To(Equal(2))
+					})
+				})
+
+				It("stores the labels of the resource", func() {
+					resource.Labels = map[string]string{"zone": "z1", "stack": "cflinuxfs4"}
+					_, err := sqlDB.Lock(ctx, logger, resource, 10, "")
+					Expect(err).NotTo(HaveOccurred())
+
+					lock, err := sqlDB.Fetch(ctx, logger, resource.Key)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lock.Labels).To(Equal(map[string]string{"zone": "z1", "stack": "cflinuxfs4"}))
+				})
+
+				It("stores the payload of the resource", func() {
+					resource.Payload = []byte(strings.Repeat("\x00\xff", 8192))
+					_, err := sqlDB.Lock(ctx, logger, resource, 10, "")
+					Expect(err).NotTo(HaveOccurred())
+
+					lock, err := sqlDB.Fetch(ctx, logger, resource.Key)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lock.Payload).To(Equal(resource.Payload))
+				})
+
 				Context("when generating a random guid fails", func() {
 					BeforeEach(func() {
 						fakeGUIDProvider.NextGUIDReturns("", errors.New("boom!"))
 					})
 
 					It("returns an error", func() {
-						_, err := sqlDB.Lock(ctx, logger, resource, 10)
+						_, err := sqlDB.Lock(ctx, logger, resource, 10, "")
 						Expect(err).To(HaveOccurred())
 					})
 				})
@@ -152,13 +247,16 @@ var _ = Describe("Lock", func() {
 				})
 
 				It("inserts the lock for the owner", func() {
-					lock, err := sqlDB.Lock(ctx, logger, resource, 10)
+					lock, err := sqlDB.Lock(ctx, logger, resource, 10, "")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(lock).To(Equal(&db.Lock{
 						Resource:      expectedResource,
 						ModifiedIndex: 301,
 						ModifiedId:    "new-guid",
 						TtlInSeconds:  10,
+						FencingToken:  1,
+						AcquiredAt:    fakeClock.Now().UnixNano(),
+						RenewedAt:     fakeClock.Now().UnixNano(),
 					}))
 					Expect(validateLockInDB(rawDB, resource, 301, 10, "new-guid")).To(Succeed())
 				})
@@ -167,7 +265,7 @@ var _ = Describe("Lock", func() {
 
 		Context("when the lock does exist", func() {
 			BeforeEach(func() {
-				_, err := sqlDB.Lock(ctx, logger, resource, 10)
+				_, err := sqlDB.Lock(ctx, logger, resource, 10, "")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(validateLockInDB(rawDB, resource, 1, 10, "new-guid")).To(Succeed())
 
@@ -182,27 +280,67 @@ var _ = Describe("Lock", func() {
 						Value: "i have never seen the princess bride and never will",
 					}
 
-					_, err := sqlDB.Lock(ctx, logger, newResource, 10)
+					_, err := sqlDB.Lock(ctx, logger, newResource, 10, "")
 					Expect(err).To(Equal(models.ErrLockCollision))
 					Expect(validateLockInDB(rawDB, resource, 1, 10, "new-guid")).To(Succeed())
 				})
 			})
 
 			Context("and the desired owner is the same", func() {
+				It("keeps the acquisition time and updates the renewal time", func() {
+					acquiredAt := fakeClock.Now().UnixNano()
+					fakeClock.Increment(5 * time.Second)
+
+					lock, err := sqlDB.Lock(ctx, logger, resource, 10, "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lock.AcquiredAt).To(Equal(acquiredAt))
+					Expect(lock.RenewedAt).To(Equal(fakeClock.Now().UnixNano()))
+
+					fetchedLock, err := sqlDB.Fetch(ctx, logger, resource.Key)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fetchedLock.AcquiredAt).To(Equal(acquiredAt))
+					Expect(fetchedLock.RenewedAt).To(Equal(fakeClock.Now().UnixNano()))
+				})
+
+				It("keeps the fencing token", func() {
+					lock, err := sqlDB.Lock(ctx, logger, resource, 10, "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lock.FencingToken).To(BeEquivalentTo(1))
+				})
+
 				It("increases the modified_index", func() {
-					lock, err := sqlDB.Lock(ctx, logger, resource, 10)
+					lock, err := sqlDB.Lock(ctx, logger, resource, 10, "")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(lock).To(Equal(&db.Lock{
 						Resource:      expectedResource,
 						ModifiedIndex: 2,
 						ModifiedId:    "new-guid",
 						TtlInSeconds:  10,
+						FencingToken:  1,
+						AcquiredAt:    fakeClock.Now().UnixNano(),
+						RenewedAt:     fakeClock.Now().UnixNano(),
 					}))
 					Expect(validateLockInDB(rawDB, resource, 2, 10, "new-guid")).To(Succeed())
 				})
 			})
 		})
 
+		Context("when the lock is released and acquired again", func() {
+			It("returns a greater fencing token", func() {
+				firstLock, err := sqlDB.Lock(ctx, logger, resource, 10, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = sqlDB.Release(ctx, logger, resource)
+				Expect(err).NotTo(HaveOccurred())
+
+				otherResource := &models.Resource{Key: resource.Key, Owner: "jim"}
+				secondLock, err := sqlDB.Lock(ctx, logger, otherResource, 10, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secondLock.ModifiedIndex).To(Equal(firstLock.ModifiedIndex))
+				Expect(secondLock.FencingToken).To(BeNumerically(">", firstLock.FencingToken))
+			})
+		})
+
 		Context("when the lock table disappear", func() {
 			BeforeEach(func() {
 				_, err := rawDB.Exec("DROP TABLE locks")
@@ -215,7 +353,7 @@ var _ = Describe("Lock", func() {
 			})
 
 			It("returns an unrecoverable error", func() {
-				_, err := sqlDB.Lock(ctx, logger, resource, 10)
+				_, err := sqlDB.Lock(ctx, logger, resource, 10, "")
 				Expect(err).To(Equal(helpers.ErrUnrecoverableError))
 			})
 		})
@@ -281,6 +419,343 @@ var _ = Describe("Lock", func() {
 		})
 	})
 
+	Context("LockBatch", func() {
+		var otherResource *models.Resource
+
+		BeforeEach(func() {
+			otherResource = &models.Resource{
+				Key:   "bark",
+				Owner: "iamthelizardking",
+				Value: "i can do anything",
+				Type:  "presence",
+			}
+		})
+
+		It("locks all the resources", func() {
+			locks, errs, err := sqlDB.LockBatch(ctx, logger, []*models.LockRequest{
+				{Resource: resource, TtlInSeconds: 10},
+				{Resource: otherResource, TtlInSeconds: 20},
+			}, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errs).To(Equal([]error{nil, nil}))
+			Expect(locks).To(HaveLen(2))
+			Expect(locks[0].Resource).To(Equal(expectedResource))
+			Expect(locks[1].Key).To(Equal(otherResource.Key))
+
+			Expect(validateLockInDB(rawDB, resource, 1, 10, "new-guid")).To(Succeed())
+			Expect(validateLockInDB(rawDB, otherResource, 1, 20, "new-guid")).To(Succeed())
+		})
+
+		Context("when one of the locks is owned by another owner", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.Lock(ctx, logger, &models.Resource{Key: resource.Key, Owner: "jim"}, 10, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a collision for that request and locks the others", func() {
+				locks, errs, err := sqlDB.LockBatch(ctx, logger, []*models.LockRequest{
+					{Resource: resource, TtlInSeconds: 10},
+					{Resource: otherResource, TtlInSeconds: 20},
+				}, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(errs[0]).To(Equal(models.ErrLockCollision))
+				Expect(locks[0]).To(BeNil())
+				Expect(errs[1]).NotTo(HaveOccurred())
+				Expect(validateLockInDB(rawDB, otherResource, 1, 20, "new-guid")).To(Succeed())
+			})
+		})
+
+		Context("when one of the requests is attached to a lease that does not exist", func() {
+			It("returns the error for that request and locks the others", func() {
+				leased := &models.Resource{Key: "leased", Owner: "iamthelizardking", LeaseId: "missing-lease"}
+				semaphore := &models.Resource{Key: "slots", Owner: "iamthelizardking", TypeCode: models.SEMAPHORE}
+
+				locks, errs, err := sqlDB.LockBatch(ctx, logger, []*models.LockRequest{
+					{Resource: resource, TtlInSeconds: 10},
+					{Resource: leased, TtlInSeconds: 10},
+					{Resource: semaphore, TtlInSeconds: 10, SemaphoreLimit: 2},
+					{Resource: otherResource, TtlInSeconds: 20},
+				}, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(errs).To(Equal([]error{nil, models.ErrLeaseNotFound, nil, nil}))
+				Expect(locks[1]).To(BeNil())
+				Expect(locks[2].Mode).To(Equal(models.SHARED))
+
+				Expect(validateLockInDB(rawDB, resource, 1, 10, "new-guid")).To(Succeed())
+				Expect(validateLockInDB(rawDB, otherResource, 1, 20, "new-guid")).To(Succeed())
+
+				_, err = sqlDB.Fetch(ctx, logger, leased.Key)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+
+		Context("when one of the requests has a different semaphore limit", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.LockShared(ctx, logger, &models.Resource{Key: "slots", Owner: "jim", TypeCode: models.SEMAPHORE}, 10, 3, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the error for that request and locks the others", func() {
+				semaphore := &models.Resource{Key: "slots", Owner: "iamthelizardking", TypeCode: models.SEMAPHORE}
+
+				_, errs, err := sqlDB.LockBatch(ctx, logger, []*models.LockRequest{
+					{Resource: semaphore, TtlInSeconds: 10, SemaphoreLimit: 2},
+					{Resource: otherResource, TtlInSeconds: 20},
+				}, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(errs).To(Equal([]error{models.ErrSemaphoreLimitMismatch, nil}))
+				Expect(validateLockInDB(rawDB, otherResource, 1, 20, "new-guid")).To(Succeed())
+
+				holders, err := sqlDB.FetchSharedHolders(ctx, logger, "slots")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holders).To(HaveLen(1))
+			})
+		})
+
+		Context("when the lock table disappear", func() {
+			BeforeEach(func() {
+				_, err := rawDB.Exec("DROP TABLE locks")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				err := sqlDB.CreateLockTable(ctx, logger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an unrecoverable error", func() {
+				_, _, err := sqlDB.LockBatch(ctx, logger, []*models.LockRequest{{Resource: resource, TtlInSeconds: 10}}, "")
+				Expect(err).To(Equal(helpers.ErrUnrecoverableError))
+			})
+		})
+	})
+
+	Context("LockMulti", func() {
+		var otherResource *models.Resource
+
+		BeforeEach(func() {
+			otherResource = &models.Resource{
+				Key:   "bark",
+				Owner: "iamthelizardking",
+				Value: "i can do anything",
+				Type:  "presence",
+			}
+		})
+
+		It("locks all the resources", func() {
+			locks, err := sqlDB.LockMulti(ctx, logger, []*models.LockRequest{
+				{Resource: resource, TtlInSeconds: 10},
+				{Resource: otherResource, TtlInSeconds: 20},
+			}, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(locks).To(HaveLen(2))
+			Expect(locks[0].Resource).To(Equal(expectedResource))
+			Expect(locks[1].Key).To(Equal(otherResource.Key))
+
+			Expect(validateLockInDB(rawDB, resource, 1, 10, "new-guid")).To(Succeed())
+			Expect(validateLockInDB(rawDB, otherResource, 1, 20, "new-guid")).To(Succeed())
+		})
+
+		Context("when one of the locks is owned by another owner", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.Lock(ctx, logger, &models.Resource{Key: resource.Key, Owner: "jim"}, 10, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a collision and locks none of them", func() {
+				_, err := sqlDB.LockMulti(ctx, logger, []*models.LockRequest{
+					{Resource: otherResource, TtlInSeconds: 20},
+					{Resource: resource, TtlInSeconds: 10},
+				}, "")
+				Expect(err).To(Equal(models.ErrLockCollision))
+				Expect(validateLockNotInDB(rawDB, otherResource)).To(Succeed())
+			})
+
+			It("does not consume fencing tokens", func() {
+				_, err := sqlDB.LockMulti(ctx, logger, []*models.LockRequest{
+					{Resource: otherResource, TtlInSeconds: 20},
+					{Resource: resource, TtlInSeconds: 10},
+				}, "")
+				Expect(err).To(Equal(models.ErrLockCollision))
+
+				lock, err := sqlDB.Lock(ctx, logger, otherResource, 20, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lock.FencingToken).To(BeEquivalentTo(2))
+			})
+		})
+	})
+
+	Context("Update", func() {
+		var lock *db.Lock
+
+		BeforeEach(func() {
+			var err error
+			lock, err = sqlDB.Lock(ctx, logger, resource, 10, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the value and increases the modified_index", func() {
+			updatedResource := &models.Resource{Key: resource.Key, Owner: resource.Owner, Value: "new value"}
+			updatedLock, err := sqlDB.Update(ctx, logger, updatedResource, lock.ModifiedIndex)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedLock.Value).To(Equal("new value"))
+			Expect(updatedLock.ModifiedIndex).To(Equal(lock.ModifiedIndex + 1))
+			Expect(updatedLock.ModifiedId).To(Equal(lock.ModifiedId))
+			Expect(updatedLock.FencingToken).To(Equal(lock.FencingToken))
+			Expect(updatedLock.AcquiredAt).To(Equal(lock.AcquiredAt))
+
+			expectedResource.Value = "new value"
+			Expect(validateLockInDB(rawDB, expectedResource, 2, 10, "new-guid")).To(Succeed())
+		})
+
+		It("replaces the labels", func() {
+			updatedResource := &models.Resource{Key: resource.Key, Owner: resource.Owner, Value: resource.Value, Labels: map[string]string{"zone": "z2"}}
+			_, err := sqlDB.Update(ctx, logger, updatedResource, lock.ModifiedIndex)
+			Expect(err).NotTo(HaveOccurred())
+
+			fetchedLock, err := sqlDB.Fetch(ctx, logger, resource.Key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetchedLock.Labels).To(Equal(map[string]string{"zone": "z2"}))
+		})
+
+		It("replaces the payload", func() {
+			updatedResource := &models.Resource{Key: resource.Key, Owner: resource.Owner, Value: resource.Value, Payload: []byte("manifest")}
+			_, err := sqlDB.Update(ctx, logger, updatedResource, lock.ModifiedIndex)
+			Expect(err).NotTo(HaveOccurred())
+
+			fetchedLock, err := sqlDB.Fetch(ctx, logger, resource.Key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetchedLock.Payload).To(Equal([]byte("manifest")))
+		})
+
+		Context("when the lock has labels and a payload", func() {
+			BeforeEach(func() {
+				labeled := &models.Resource{Key: resource.Key, Owner: resource.Owner, Value: resource.Value, Labels: map[string]string{"zone": "z1"}, Payload: []byte("manifest")}
+				var err error
+				lock, err = sqlDB.Update(ctx, logger, labeled, lock.ModifiedIndex)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps them when only the value is updated", func() {
+				updatedResource := &models.Resource{Key: resource.Key, Owner: resource.Owner, Value: "new value"}
+				updatedLock, err := sqlDB.Update(ctx, logger, updatedResource, lock.ModifiedIndex)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updatedLock.Labels).To(Equal(map[string]string{"zone": "z1"}))
+
+				fetchedLock, err := sqlDB.Fetch(ctx, logger, resource.Key)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchedLock.Value).To(Equal("new value"))
+				Expect(fetchedLock.Labels).To(Equal(map[string]string{"zone": "z1"}))
+				Expect(fetchedLock.Payload).To(Equal([]byte("manifest")))
+			})
+		})
+
+		Context("when the modified index does not match", func() {
+			It("returns a conflict error without updating the lock", func() {
+				updatedResource := &models.Resource{Key: resource.Key, Owner: resource.Owner, Value: "new value"}
+				_, err := sqlDB.Update(ctx, logger, updatedResource, lock.ModifiedIndex+1)
+				Expect(err).To(Equal(models.ErrModifiedIndexMismatch))
+				Expect(validateLockInDB(rawDB, resource, 1, 10, "new-guid")).To(Succeed())
+			})
+		})
+
+		Context("when the lock is owned by another owner", func() {
+			It("returns an error", func() {
+				otherResource := &models.Resource{Key: resource.Key, Owner: "jim", Value: "new value"}
+				_, err := sqlDB.Update(ctx, logger, otherResource, lock.ModifiedIndex)
+				Expect(err).To(Equal(models.ErrLockCollision))
+				Expect(validateLockInDB(rawDB, resource, 1, 10, "new-guid")).To(Succeed())
+			})
+		})
+
+		Context("when the lock does not exist", func() {
+			It("returns a resource not found error", func() {
+				_, err := sqlDB.Update(ctx, logger, &models.Resource{Key: "meow", Owner: "jim"}, 1)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+	})
+
+	Context("Transfer", func() {
+		var lock *db.Lock
+
+		BeforeEach(func() {
+			var err error
+			lock, err = sqlDB.Lock(ctx, logger, resource, 10, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("hands the lock to the new owner and increases the modified_index", func() {
+			fakeClock.Increment(time.Second)
+
+			transferredLock, err := sqlDB.Transfer(ctx, logger, resource, "successor")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transferredLock.Owner).To(Equal("successor"))
+			Expect(transferredLock.Value).To(Equal(resource.Value))
+			Expect(transferredLock.ModifiedIndex).To(Equal(lock.ModifiedIndex + 1))
+			Expect(transferredLock.ModifiedId).To(Equal(lock.ModifiedId))
+			Expect(transferredLock.FencingToken).To(BeNumerically(">", lock.FencingToken))
+			Expect(transferredLock.AcquiredAt).To(Equal(fakeClock.Now().UnixNano()))
+
+			expectedResource.Owner = "successor"
+			Expect(validateLockInDB(rawDB, expectedResource, 2, 10, "new-guid")).To(Succeed())
+		})
+
+		It("lets the new owner renew the lock", func() {
+			transferredLock, err := sqlDB.Transfer(ctx, logger, resource, "successor")
+			Expect(err).NotTo(HaveOccurred())
+
+			renewedLock, err := sqlDB.Lock(ctx, logger, &models.Resource{Key: resource.Key, Owner: "successor", Value: resource.Value, Type: resource.Type}, 10, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(renewedLock.FencingToken).To(Equal(transferredLock.FencingToken))
+
+			_, err = sqlDB.Lock(ctx, logger, resource, 10, "")
+			Expect(err).To(Equal(models.ErrLockCollision))
+		})
+
+		Context("when the lock is attached to a lease", func() {
+			BeforeEach(func() {
+				lease, err := sqlDB.GrantLease(ctx, logger, 30, "client")
+				Expect(err).NotTo(HaveOccurred())
+
+				resource.LeaseId = lease.ID
+				lock, err = sqlDB.Lock(ctx, logger, resource, 0, "client")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("detaches the lock from the lease", func() {
+				transferredLock, err := sqlDB.Transfer(ctx, logger, resource, "successor")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(transferredLock.LeaseId).To(BeEmpty())
+				Expect(transferredLock.TtlInSeconds).To(BeEquivalentTo(30))
+
+				released, err := sqlDB.RevokeLease(ctx, logger, resource.LeaseId, "client")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(released).To(BeEmpty())
+
+				fetchedLock, err := sqlDB.Fetch(ctx, logger, resource.Key)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchedLock.Owner).To(Equal("successor"))
+			})
+		})
+
+		Context("when the lock is owned by another owner", func() {
+			It("returns an error", func() {
+				otherResource := &models.Resource{Key: resource.Key, Owner: "jim"}
+				_, err := sqlDB.Transfer(ctx, logger, otherResource, "successor")
+				Expect(err).To(Equal(models.ErrLockCollision))
+				Expect(validateLockInDB(rawDB, resource, 1, 10, "new-guid")).To(Succeed())
+			})
+		})
+
+		Context("when the lock does not exist", func() {
+			It("returns a resource not found error", func() {
+				_, err := sqlDB.Transfer(ctx, logger, &models.Resource{Key: "meow", Owner: "jim"}, "successor")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+	})
+
 	Context("Fetch", func() {
 		var lock, expectedLock *models.Resource
 
@@ -513,6 +988,17 @@ var _ = Describe("Lock", func() {
 			Expect(locks).To(ConsistOf(dogLock, humanLock))
 		})
 
+		It("does not return the payloads of the locks", func() {
+			query := helpers.RebindForFlavor(`UPDATE locks SET payload = ? WHERE path = ?`, dbFlavor)
+			_, err := rawDB.Exec(query, []byte("manifest"), "test1")
+			Expect(err).NotTo(HaveOccurred())
+
+			locks, err := sqlDB.FetchAll(ctx, logger, "dog")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(locks).To(HaveLen(1))
+			Expect(locks[0].Payload).To(BeEmpty())
+		})
+
 		Context("when a type is specified", func() {
 			It("filters the locks returned by that type", func() {
 				locks, err := sqlDB.FetchAll(ctx, logger, "presence")
@@ -539,6 +1025,162 @@ var _ = Describe("Lock", func() {
 		})
 	})
 
+	Context("FetchPage", func() {
+		BeforeEach(func() {
+			query := helpers.RebindForFlavor(
+				`INSERT INTO locks (path, owner, value, type, modified_index, modified_id, ttl) VALUES (?, ?, ?, ?, ?, ?, ?);`,
+				dbFlavor,
+			)
+			for _, key := range []string{"cells/cell-2", "cells/cell-1", "cells_other", "cells/cell-3", "bbs"} {
+				result, err := rawDB.Exec(query, key, "owner", "", "presence", 1, "id", 20)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RowsAffected()).To(BeEquivalentTo(1))
+			}
+
+			result, err := rawDB.Exec(query, "cells/cell-0", "", "", "presence", 1, "", 20)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RowsAffected()).To(BeEquivalentTo(1))
+		})
+
+		keys := func(locks []*db.Lock) []string {
+			var keys []string
+			for _, lock := range locks {
+				keys = append(keys, lock.Key)
+			}
+			return keys
+		}
+
+		It("returns the locks with owners ordered by key", func() {
+			locks, err := sqlDB.FetchPage(ctx, logger, "", "", "", "", nil, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys(locks)).To(Equal([]string{"bbs", "cells/cell-1", "cells/cell-2", "cells/cell-3", "cells_other"}))
+		})
+
+		It("filters the locks by key prefix", func() {
+			locks, err := sqlDB.FetchPage(ctx, logger, "", "presence", "cells/", "", nil, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys(locks)).To(Equal([]string{"cells/cell-1", "cells/cell-2", "cells/cell-3"}))
+		})
+
+		It("does not treat LIKE wildcards in the prefix as wildcards", func() {
+			locks, err := sqlDB.FetchPage(ctx, logger, "", "", "cells_", "", nil, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys(locks)).To(Equal([]string{"cells_other"}))
+		})
+
+		It("returns up to limit locks after the given key", func() {
+			locks, err := sqlDB.FetchPage(ctx, logger, "", "", "cells/", "", nil, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys(locks)).To(Equal([]string{"cells/cell-1", "cells/cell-2"}))
+
+			locks, err = sqlDB.FetchPage(ctx, logger, "", "", "cells/", "cells/cell-2", nil, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys(locks)).To(Equal([]string{"cells/cell-3"}))
+		})
+
+		It("filters the locks by type", func() {
+			locks, err := sqlDB.FetchPage(ctx, logger, "", "lock", "", "", nil, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(locks).To(BeEmpty())
+		})
+
+		It("does not return the payloads of the locks", func() {
+			query := helpers.RebindForFlavor(`UPDATE locks SET payload = ? WHERE path = ?`, dbFlavor)
+			_, err := rawDB.Exec(query, []byte("manifest"), "bbs")
+			Expect(err).NotTo(HaveOccurred())
+
+			locks, err := sqlDB.FetchPage(ctx, logger, "", "", "bbs", "", nil, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(locks).To(HaveLen(1))
+			Expect(locks[0].Payload).To(BeEmpty())
+
+			lock, err := sqlDB.Fetch(ctx, logger, "bbs")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.Payload).To(Equal([]byte("manifest")))
+		})
+
+		Context("when there are locks in a namespace", func() {
+			BeforeEach(func() {
+				query := helpers.RebindForFlavor(
+					`INSERT INTO locks (path, owner, value, type, modified_index, modified_id, ttl) VALUES (?, ?, ?, ?, ?, ?, ?);`,
+					dbFlavor,
+				)
+				for _, key := range []string{"namespaces/diego/cells/cell-1", "namespaces/diego/cells/cell-2", "namespaces/routing/cells/cell-1"} {
+					_, err := rawDB.Exec(query, key, "owner", "", "presence", 1, "id", 20)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("does not return them with the locks of the default namespace", func() {
+				locks, err := sqlDB.FetchPage(ctx, logger, "", "", "", "", nil, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(locks)).To(Equal([]string{"bbs", "cells/cell-1", "cells/cell-2", "cells/cell-3", "cells_other"}))
+			})
+
+			It("returns the keys of the default namespace that start with the namespace prefix", func() {
+				legacyKey := models.NamespacedKey("", "namespaces/diego/cells/cell-1")
+				query := helpers.RebindForFlavor(
+					`INSERT INTO locks (path, owner, value, type, modified_index, modified_id, ttl) VALUES (?, ?, ?, ?, ?, ?, ?);`,
+					dbFlavor,
+				)
+				_, err := rawDB.Exec(query, legacyKey, "owner", "", "presence", 1, "id", 20)
+				Expect(err).NotTo(HaveOccurred())
+
+				locks, err := sqlDB.FetchPage(ctx, logger, "", "", "namespaces/", "", nil, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(locks)).To(Equal([]string{legacyKey}))
+
+				locks, err = sqlDB.FetchPage(ctx, logger, "diego", "", "", "", nil, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(locks)).NotTo(ContainElement(legacyKey))
+			})
+
+			It("returns the locks of the namespace with their qualified keys", func() {
+				locks, err := sqlDB.FetchPage(ctx, logger, "diego", "presence", "cells/", "", nil, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(locks)).To(Equal([]string{"namespaces/diego/cells/cell-1"}))
+
+				locks, err = sqlDB.FetchPage(ctx, logger, "diego", "presence", "cells/", "cells/cell-1", nil, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(locks)).To(Equal([]string{"namespaces/diego/cells/cell-2"}))
+			})
+		})
+
+		Context("when a label selector is given", func() {
+			BeforeEach(func() {
+				query := helpers.RebindForFlavor(`UPDATE locks SET labels = ? WHERE path = ?`, dbFlavor)
+				for key, labels := range map[string]string{
+					"cells/cell-1": `{"zone":"z1"}`,
+					"cells/cell-2": `{"zone":"z2"}`,
+					"cells/cell-3": `{"zone":"z1","stack":"cflinuxfs4"}`,
+				} {
+					_, err := rawDB.Exec(query, labels, key)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("returns the locks having every label of the selector", func() {
+				locks, err := sqlDB.FetchPage(ctx, logger, "", "", "", "", map[string]string{"zone": "z1"}, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(locks)).To(Equal([]string{"cells/cell-1", "cells/cell-3"}))
+
+				locks, err = sqlDB.FetchPage(ctx, logger, "", "", "", "", map[string]string{"zone": "z1", "stack": "cflinuxfs4"}, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(locks)).To(Equal([]string{"cells/cell-3"}))
+			})
+
+			It("returns up to limit matching locks", func() {
+				locks, err := sqlDB.FetchPage(ctx, logger, "", "", "", "", map[string]string{"zone": "z1"}, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(locks)).To(Equal([]string{"cells/cell-1"}))
+
+				locks, err = sqlDB.FetchPage(ctx, logger, "", "", "", "cells/cell-1", map[string]string{"zone": "z1"}, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(locks)).To(Equal([]string{"cells/cell-3"}))
+			})
+		})
+	})
+
 	Context("FetchAndRelease", func() {
 		var currentIndex, currentTTL int64
 		var oldLock *db.Lock
@@ -700,13 +1342,30 @@ var _ = Describe("Lock", func() {
 		})
 
 		It("retrieves a count of the locks", func() {
-			count, err := sqlDB.Count(ctx, logger, "")
+			count, err := sqlDB.Count(ctx, logger, "", "")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(2))
 		})
 
 		It("filters based on lock type", func() {
-			count, err := sqlDB.Count(ctx, logger, "dog")
+			count, err := sqlDB.Count(ctx, logger, "", "dog")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+
+		It("counts the locks of the namespace", func() {
+			query := helpers.RebindForFlavor(
+				`INSERT INTO locks (path, owner, value, type, modified_index, ttl) VALUES (?, ?, ?, ?, ?, ?);`,
+				dbFlavor,
+			)
+			_, err := rawDB.Exec(query, "namespaces/diego/test1", "jake", "thedog", "dog", 10, 20)
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := sqlDB.Count(ctx, logger, "diego", "dog")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+
+			count, err = sqlDB.Count(ctx, logger, "", "dog")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(count).To(Equal(1))
 		})
@@ -723,7 +1382,7 @@ var _ = Describe("Lock", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := sqlDB.Count(ctx, logger, "")
+				_, err := sqlDB.Count(ctx, logger, "", "")
 				Expect(err).To(Equal(helpers.ErrUnrecoverableError))
 			})
 		})
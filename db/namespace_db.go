@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/models"
+)
+
+// namespaceWhere returns the condition selecting the keys of the namespace.
+// Keys of a namespace are stored qualified with its name, see
+// models.NamespacedKey, so the keys of the default namespace are the ones
+// without the namespace prefix, or qualified with an empty name.
+func namespaceWhere(namespace string) (string, []interface{}) {
+	if namespace == "" {
+		return "(path NOT LIKE ? OR path LIKE ?)", []interface{}{
+			escapeLike(models.NamespaceKeyPrefix) + "%",
+			escapeLike(models.NamespacedKey("", models.NamespaceKeyPrefix)) + "%",
+		}
+	}
+	return "path LIKE ?", []interface{}{escapeLike(models.NamespacedKey(namespace, "")) + "%"}
+}
+
+// checkNamespaceQuota returns ErrNamespaceQuotaExceeded when the namespace
+// of the key already holds as many keys as it is allowed to. It is called
+// before a key becomes held, either by inserting its row or by acquiring an
+// ownerless row, and holds the row of the namespace until the transaction
+// ends so that concurrent acquisitions of new keys are counted one after the
+// other.
+func (db *SQLDB) checkNamespaceQuota(ctx context.Context, logger lager.Logger, tx helpers.Tx, key string) error {
+	name, _ := models.SplitNamespacedKey(key)
+	namespace, found := db.namespaces.Lookup(name)
+	if !found || namespace.MaxResources == 0 {
+		return nil
+	}
+
+	err := db.lockNamespace(ctx, logger, tx, name)
+	if err != nil {
+		return err
+	}
+
+	// ownerless rows only count while they have shared holders
+	where, bindings := namespaceWhere(name)
+	where += " AND (owner <> ? OR path IN (SELECT path FROM shared_locks))"
+	count, err := db.helper.Count(ctx, logger, tx, "locks", where, append(bindings, "")...)
+	if err != nil {
+		logger.Error("failed-to-count-namespace-resources", err, lager.Data{"namespace": name})
+		return err
+	}
+
+	if count >= namespace.MaxResources {
+		logger.Info("namespace-quota-exceeded", lager.Data{"namespace": name, "resources": count, "max-resources": namespace.MaxResources})
+		return models.ErrNamespaceQuotaExceeded
+	}
+
+	return nil
+}
+
+// lockNamespace locks the row of the namespace in the locket_namespaces
+// table, inserting it the first time the namespace is used.
+func (db *SQLDB) lockNamespace(ctx context.Context, logger lager.Logger, tx helpers.Tx, name string) error {
+	insert := "INSERT INTO locket_namespaces (name) VALUES (?) ON CONFLICT DO NOTHING"
+	if db.flavor == helpers.MySQL {
+		insert = "INSERT IGNORE INTO locket_namespaces (name) VALUES (?)"
+	}
+	_, err := tx.ExecContext(ctx, helpers.RebindForFlavor(insert, db.flavor), name)
+	if err != nil {
+		logger.Error("failed-to-insert-namespace", err, lager.Data{"namespace": name})
+		return err
+	}
+
+	var locked string
+	row := db.helper.One(ctx, logger, tx, "locket_namespaces", helpers.ColumnList{"name"}, helpers.LockRow, "name = ?", name)
+	err = row.Scan(&locked)
+	if err != nil {
+		logger.Error("failed-to-lock-namespace", err, lager.Data{"namespace": name})
+		return err
+	}
+
+	return nil
+}
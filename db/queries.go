@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
 	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/models"
 )
 
 func (db *SQLDB) CreateLockTable(ctx context.Context, logger lager.Logger) error {
@@ -17,16 +18,269 @@ func (db *SQLDB) CreateLockTable(ctx context.Context, logger lager.Logger) error
 			type VARCHAR(255) DEFAULT '',
 			modified_index BIGINT DEFAULT 0,
 			modified_id varchar(255) DEFAULT '',
-			ttl BIGINT DEFAULT 0
+			ttl BIGINT DEFAULT 0,
+			fencing_token BIGINT DEFAULT 0,
+			acquired_at BIGINT DEFAULT 0,
+			renewed_at BIGINT DEFAULT 0,
+			lease_id VARCHAR(255) DEFAULT '',
+			labels VARCHAR(4096) DEFAULT ''
 		);
 	`)
 	if err != nil {
 		return err
 	}
 
+	for _, column := range []struct{ name, definition string }{
+		{"fencing_token", "BIGINT DEFAULT 0"},
+		{"acquired_at", "BIGINT DEFAULT 0"},
+		{"renewed_at", "BIGINT DEFAULT 0"},
+		{"lease_id", "VARCHAR(255) DEFAULT ''"},
+		{"labels", "VARCHAR(4096) DEFAULT ''"},
+		{"payload", db.payloadColumnType()},
+		{"semaphore_limit", "INTEGER"},
+	} {
+		err = db.addColumnIfNotExists(ctx, logger, "locks", column.name, column.definition)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = db.createFencingTokenTable(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS shared_locks (
+			path VARCHAR(255),
+			owner VARCHAR(255),
+			value VARCHAR(4096),
+			type VARCHAR(255) DEFAULT '',
+			modified_index BIGINT DEFAULT 0,
+			modified_id varchar(255) DEFAULT '',
+			ttl BIGINT DEFAULT 0,
+			fencing_token BIGINT DEFAULT 0,
+			acquired_at BIGINT DEFAULT 0,
+			renewed_at BIGINT DEFAULT 0,
+			lease_id VARCHAR(255) DEFAULT '',
+			labels VARCHAR(4096) DEFAULT '',
+			PRIMARY KEY (path, owner)
+		);
+	`)
+	if err != nil {
+		return err
+	}
+
+	for _, column := range []struct{ name, definition string }{
+		{"lease_id", "VARCHAR(255) DEFAULT ''"},
+		{"labels", "VARCHAR(4096) DEFAULT ''"},
+		{"payload", db.payloadColumnType()},
+	} {
+		err = db.addColumnIfNotExists(ctx, logger, "shared_locks", column.name, column.definition)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS leases (
+			id VARCHAR(255) PRIMARY KEY,
+			ttl BIGINT DEFAULT 0,
+			modified_index BIGINT DEFAULT 0,
+			granted_at BIGINT DEFAULT 0,
+			renewed_at BIGINT DEFAULT 0,
+			owner VARCHAR(255) DEFAULT ''
+		);
+	`)
+	if err != nil {
+		return err
+	}
+
+	err = db.addColumnIfNotExists(ctx, logger, "leases", "owner", "VARCHAR(255) DEFAULT ''")
+	if err != nil {
+		return err
+	}
+
+	// the namespaces table is created along with the first keys of a
+	// namespace, so keys starting with the namespace prefix that were stored
+	// before it existed are keys of the default namespace
+	namespacesExist, err := db.tableExists(ctx, "locket_namespaces")
+	if err != nil {
+		return err
+	}
+
+	if !namespacesExist {
+		err = db.qualifyDefaultNamespaceKeys(ctx, logger)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS locket_namespaces (
+			name VARCHAR(255) PRIMARY KEY
+		);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS lock_audit_log (
+			id VARCHAR(255) PRIMARY KEY,
+			path VARCHAR(255),
+			owner VARCHAR(255),
+			type VARCHAR(255) DEFAULT '',
+			action VARCHAR(255),
+			actor VARCHAR(255),
+			reason VARCHAR(4096),
+			created_at BIGINT DEFAULT 0
+		);
+	`)
+	return err
+}
+
+// qualifyDefaultNamespaceKeys qualifies the keys stored by older versions of
+// locket that start with the namespace prefix with the default namespace, see
+// models.NamespacedKey. Keys that are already qualified are left alone, so
+// that locket instances starting at the same time can all run it.
+func (db *SQLDB) qualifyDefaultNamespaceKeys(ctx context.Context, logger lager.Logger) error {
+	logger = logger.Session("qualify-default-namespace-keys")
+
+	qualifiedPrefix := models.NamespacedKey("", models.NamespaceKeyPrefix)
+	for _, table := range []string{"locks", "shared_locks"} {
+		// the prefix qualifying a key with the default namespace is the
+		// namespace prefix followed by an empty namespace name
+		query := fmt.Sprintf("UPDATE %s SET path = CONCAT('%s/', path) WHERE path LIKE ? AND path NOT LIKE ?", table, models.NamespaceKeyPrefix)
+		result, err := db.ExecContext(ctx, helpers.RebindForFlavor(query, db.flavor), escapeLike(models.NamespaceKeyPrefix)+"%", escapeLike(qualifiedPrefix)+"%")
+		if err != nil {
+			logger.Error("failed-updating-keys", err, lager.Data{"table": table})
+			return err
+		}
+
+		updated, err := result.RowsAffected()
+		if err == nil && updated > 0 {
+			logger.Info("qualified-keys", lager.Data{"table": table, "keys": updated})
+		}
+	}
+
+	return nil
+}
+
+func (db *SQLDB) tableExists(ctx context.Context, table string) (bool, error) {
+	var query string
+	switch db.flavor {
+	case helpers.MySQL:
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?"
+	case helpers.Postgres:
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?"
+	default:
+		return false, fmt.Errorf("unsupported database flavor: %s", db.flavor)
+	}
+
+	var count int
+	err := db.QueryRowContext(ctx, helpers.RebindForFlavor(query, db.flavor), table).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// payloadColumnType returns the type of the payload columns, which hold
+// binary values larger than the value column. The column is only added once
+// the table exists, as its type depends on the database flavor.
+func (db *SQLDB) payloadColumnType() string {
+	if db.flavor == helpers.MySQL {
+		return "MEDIUMBLOB"
+	}
+	return "BYTEA"
+}
+
+func (db *SQLDB) createFencingTokenTable(ctx context.Context, logger lager.Logger) error {
+	logger = logger.Session("create-fencing-token-table")
+
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS locket_fencing_token (
+			id INT PRIMARY KEY,
+			token BIGINT NOT NULL
+		);
+	`)
+	if err != nil {
+		logger.Error("failed-creating-table", err)
+		return err
+	}
+
+	count, err := db.helper.Count(ctx, logger, db, "locket_fencing_token", "id = ?", 1)
+	if err != nil {
+		logger.Error("failed-counting-rows", err)
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	_, err = db.helper.Insert(ctx, logger, db, "locket_fencing_token", helpers.SQLAttributes{"id": 1, "token": 0})
+	// another locket instance may have inserted the row in the meantime
+	if err != nil && db.helper.ConvertSQLError(err) != helpers.ErrResourceExists {
+		logger.Error("failed-inserting-row", err)
+		return err
+	}
+
+	return nil
+}
+
+// addColumnIfNotExists adds a column to a table created by an older version
+// of locket. MySQL does not support ADD COLUMN IF NOT EXISTS, so the column
+// is looked up in information_schema first.
+func (db *SQLDB) addColumnIfNotExists(ctx context.Context, logger lager.Logger, table, column, definition string) error {
+	logger = logger.Session("add-column", lager.Data{"table": table, "column": column})
+
+	exists, err := db.columnExists(ctx, table, column)
+	if err != nil {
+		logger.Error("failed-checking-column", err)
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	logger.Info("adding-column")
+	_, err = db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	if err != nil {
+		// another locket instance may have added the column in the meantime
+		if exists, checkErr := db.columnExists(ctx, table, column); checkErr == nil && exists {
+			return nil
+		}
+		logger.Error("failed-adding-column", err)
+		return err
+	}
+
 	return nil
 }
 
+func (db *SQLDB) columnExists(ctx context.Context, table, column string) (bool, error) {
+	var query string
+	switch db.flavor {
+	case helpers.MySQL:
+		query = "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?"
+	case helpers.Postgres:
+		query = "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?"
+	default:
+		return false, fmt.Errorf("unsupported database flavor: %s", db.flavor)
+	}
+
+	var count int
+	err := db.QueryRowContext(ctx, helpers.RebindForFlavor(query, db.flavor), table, column).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 func (db *SQLDB) CreateHealthCheckTable(ctx context.Context, logger lager.Logger) error {
 	logger = logger.Session("create-health-check-table")
 	logger.Info("starting")
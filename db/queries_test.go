@@ -2,10 +2,118 @@ package db_test
 
 import (
 	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/locket/models"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
+var _ = Describe("CreateLockTable", func() {
+	Context("when the locks table was created by an older version", func() {
+		BeforeEach(func() {
+			_, err := rawDB.Exec("DROP TABLE locks")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = rawDB.Exec(`
+				CREATE TABLE locks (
+					path VARCHAR(255) PRIMARY KEY,
+					owner VARCHAR(255),
+					value VARCHAR(4096),
+					type VARCHAR(255) DEFAULT '',
+					modified_index BIGINT DEFAULT 0,
+					modified_id varchar(255) DEFAULT '',
+					ttl BIGINT DEFAULT 0
+				);
+			`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("adds the fencing_token column", func() {
+			err := sqlDB.CreateLockTable(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			var count int
+			scanner := rawDB.QueryRowContext(ctx, helpers.RebindForFlavor("SELECT COUNT(fencing_token) FROM locks", dbFlavor))
+			err = scanner.Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("adds the payload column", func() {
+			err := sqlDB.CreateLockTable(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			var count int
+			scanner := rawDB.QueryRowContext(ctx, helpers.RebindForFlavor("SELECT COUNT(payload) FROM locks", dbFlavor))
+			err = scanner.Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("adds the semaphore_limit column", func() {
+			err := sqlDB.CreateLockTable(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			var count int
+			scanner := rawDB.QueryRowContext(ctx, helpers.RebindForFlavor("SELECT COUNT(semaphore_limit) FROM locks", dbFlavor))
+			err = scanner.Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when the keys were stored by a version without namespaces", func() {
+		BeforeEach(func() {
+			_, err := rawDB.Exec("DROP TABLE locket_namespaces")
+			Expect(err).NotTo(HaveOccurred())
+
+			query := helpers.RebindForFlavor(`INSERT INTO locks (path, owner, value, type, modified_index, modified_id, ttl) VALUES (?, ?, ?, ?, ?, ?, ?)`, dbFlavor)
+			for _, key := range []string{"namespaces/diego/cell-1", "cells/cell-1"} {
+				_, err = rawDB.Exec(query, key, "owner", "", "lock", 1, "guid", 10)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			query = helpers.RebindForFlavor(`INSERT INTO shared_locks (path, owner, value, type, modified_index, modified_id, ttl) VALUES (?, ?, ?, ?, ?, ?, ?)`, dbFlavor)
+			_, err = rawDB.Exec(query, "namespaces/diego/maintenance", "reader", "", "lock", 1, "guid", 10)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps the keys starting with the namespace prefix in the default namespace", func() {
+			err := sqlDB.CreateLockTable(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			lock, err := sqlDB.Fetch(ctx, logger, models.NamespacedKey("", "namespaces/diego/cell-1"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.Owner).To(Equal("owner"))
+
+			_, err = sqlDB.Fetch(ctx, logger, "cells/cell-1")
+			Expect(err).NotTo(HaveOccurred())
+
+			holders, err := sqlDB.FetchSharedHolders(ctx, logger, models.NamespacedKey("", "namespaces/diego/maintenance"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(HaveLen(1))
+		})
+
+		It("qualifies the keys only once", func() {
+			err := sqlDB.CreateLockTable(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.Lock(ctx, logger, &models.Resource{Key: models.NamespacedKey("diego", "cell-2"), Owner: "owner", TypeCode: models.LOCK}, 10, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = sqlDB.CreateLockTable(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.Fetch(ctx, logger, models.NamespacedKey("diego", "cell-2"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("is idempotent and can be called multiple times", func() {
+		err := sqlDB.CreateLockTable(ctx, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = sqlDB.CreateLockTable(ctx, logger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
 var _ = Describe("CreateHealthCheckTable", func() {
 	It("creates the health check table successfully", func() {
 		err := sqlDB.CreateHealthCheckTable(ctx, logger)
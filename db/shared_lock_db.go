@@ -0,0 +1,428 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/models"
+)
+
+// Shared holders of a key are stored in the shared_locks table, one row per
+// owner. While a key has shared holders, its row in the locks table is kept
+// without an owner. Exclusive and shared requests both lock that row first,
+// which serializes them against each other. Semaphores are keys held in
+// shared mode by at most a limited number of owners. The limit is stored in
+// the semaphore_limit column of the row of the key by its first shared holder,
+// and the other holders have to request the same limit.
+
+// LockShared acquires or renews a shared holding of the key. A limit greater
+// than 0 caps the number of owners holding the key. A resource attached to a
+// lease is only locked if leaseOwner granted the lease.
+func (db *SQLDB) LockShared(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64, limit int, leaseOwner string) (*Lock, error) {
+	logger = logger.Session("lock-shared", lagerDataFromLock(resource))
+	var lock *Lock
+
+	var newLock bool
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		var err error
+		lock, newLock, err = db.lockShared(ctx, logger, tx, resource, ttl, limit, leaseOwner)
+		return err
+	})
+
+	if err == nil && newLock {
+		logger.Info("acquired-shared-lock")
+	}
+
+	return lock, db.helper.ConvertSQLError(err)
+}
+
+func (db *SQLDB) lockShared(ctx context.Context, logger lager.Logger, tx helpers.Tx, resource *models.Resource, ttl int64, limit int, leaseOwner string) (*Lock, bool, error) {
+	ttl, err := db.leaseTTL(ctx, logger, tx, resource, ttl, leaseOwner)
+	if err != nil {
+		return nil, false, err
+	}
+
+	current, err := db.fetchLock(ctx, logger, tx, resource.Key)
+	if err != nil {
+		sqlErr := db.helper.ConvertSQLError(err)
+		if sqlErr != helpers.ErrResourceNotFound {
+			logger.Error("failed-to-fetch-lock", err)
+			return nil, false, err
+		}
+
+		err = db.checkNamespaceQuota(ctx, logger, tx, resource.Key)
+		if err != nil {
+			return nil, false, err
+		}
+
+		_, err = db.helper.Insert(ctx, logger, tx, "locks",
+			helpers.SQLAttributes{
+				"path":            resource.Key,
+				"owner":           "",
+				"value":           "",
+				"semaphore_limit": limit,
+			},
+		)
+		if err != nil {
+			logger.Error("failed-to-insert-lock", err)
+			return nil, false, err
+		}
+	} else if current.Owner != "" {
+		logger.Debug("lock-already-exists")
+		return nil, false, models.ErrLockCollision
+	} else {
+		holders, err := db.countSharedHolders(ctx, logger, tx, resource.Key)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if holders == 0 {
+			err = db.checkNamespaceQuota(ctx, logger, tx, resource.Key)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		err = db.checkSemaphoreLimit(ctx, logger, tx, resource.Key, holders, limit)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	now := db.clock.Now().UnixNano()
+	lock := &Lock{
+		Resource:      models.GetResource(resource),
+		Mode:          models.SHARED,
+		ModifiedIndex: 1,
+		TtlInSeconds:  ttl,
+		AcquiredAt:    now,
+		RenewedAt:     now,
+	}
+
+	currentShared, err := db.fetchSharedLock(ctx, logger, tx, resource.Key, resource.Owner)
+	if err == nil {
+		lock.ModifiedIndex = currentShared.ModifiedIndex + 1
+		lock.ModifiedId = currentShared.ModifiedId
+		lock.FencingToken = currentShared.FencingToken
+		lock.AcquiredAt = currentShared.AcquiredAt
+
+		_, err = db.helper.Update(ctx, logger, tx, "shared_locks",
+			helpers.SQLAttributes{
+				"value":          lock.Value,
+				"type":           lock.Type,
+				"modified_index": lock.ModifiedIndex,
+				"ttl":            lock.TtlInSeconds,
+				"renewed_at":     lock.RenewedAt,
+				"lease_id":       lock.LeaseId,
+				"labels":         encodeLabels(lock.Labels),
+				"payload":        lock.Payload,
+			},
+			"path = ? AND owner = ?", lock.Key, lock.Owner,
+		)
+		if err != nil {
+			logger.Error("failed-updating-shared-lock", err)
+			return nil, false, err
+		}
+
+		return lock, false, nil
+	}
+
+	if db.helper.ConvertSQLError(err) != helpers.ErrResourceNotFound {
+		logger.Error("failed-to-fetch-shared-lock", err)
+		return nil, false, err
+	}
+
+	if limit > 0 {
+		count, err := db.countSharedHolders(ctx, logger, tx, resource.Key)
+		if err != nil {
+			return nil, false, err
+		}
+		if count >= limit {
+			logger.Debug("lock-held-by-too-many-owners", lager.Data{"shared-holders": count, "limit": limit})
+			return nil, false, models.ErrLockCollision
+		}
+	}
+
+	lock.ModifiedId, err = db.guidProvider.NextGUID()
+	if err != nil {
+		logger.Error("failed-to-generate-guid", err)
+		return nil, false, err
+	}
+
+	lock.FencingToken, err = db.nextFencingToken(ctx, logger, tx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = db.helper.Insert(ctx, logger, tx, "shared_locks",
+		helpers.SQLAttributes{
+			"path":           lock.Key,
+			"owner":          lock.Owner,
+			"value":          lock.Value,
+			"type":           lock.Type,
+			"modified_index": lock.ModifiedIndex,
+			"modified_id":    lock.ModifiedId,
+			"ttl":            lock.TtlInSeconds,
+			"fencing_token":  lock.FencingToken,
+			"acquired_at":    lock.AcquiredAt,
+			"renewed_at":     lock.RenewedAt,
+			"lease_id":       lock.LeaseId,
+			"labels":         encodeLabels(lock.Labels),
+			"payload":        lock.Payload,
+		},
+	)
+	if err != nil {
+		logger.Error("failed-inserting-shared-lock", err)
+		return nil, false, err
+	}
+
+	return lock, true, nil
+}
+
+// checkSemaphoreLimit returns ErrSemaphoreLimitMismatch when the key is held
+// in shared mode with a different limit than the requested one. A key without
+// shared holders takes the requested limit, as does a key whose limit was not
+// stored, e.g. held since before limits were stored.
+func (db *SQLDB) checkSemaphoreLimit(ctx context.Context, logger lager.Logger, tx helpers.Tx, key string, holders, limit int) error {
+	var storedLimit sql.NullInt64
+	if holders > 0 {
+		row := db.helper.One(ctx, logger, tx, "locks",
+			helpers.ColumnList{"semaphore_limit"},
+			helpers.NoLockRow,
+			"path = ?", key,
+		)
+		err := row.Scan(&storedLimit)
+		if err != nil {
+			logger.Error("failed-to-fetch-semaphore-limit", err)
+			return err
+		}
+	}
+
+	if storedLimit.Valid {
+		if storedLimit.Int64 != int64(limit) {
+			logger.Info("semaphore-limit-mismatch", lager.Data{"semaphore-limit": storedLimit.Int64, "requested-semaphore-limit": limit})
+			return models.ErrSemaphoreLimitMismatch
+		}
+		return nil
+	}
+
+	_, err := db.helper.Update(ctx, logger, tx, "locks",
+		helpers.SQLAttributes{"semaphore_limit": limit},
+		"path = ?", key,
+	)
+	if err != nil {
+		logger.Error("failed-updating-semaphore-limit", err)
+	}
+	return err
+}
+
+func (db *SQLDB) ReleaseShared(ctx context.Context, logger lager.Logger, resource *models.Resource) error {
+	logger = logger.Session("release-shared-lock", lagerDataFromLock(resource))
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		current, err := db.fetchSharedLockForRelease(ctx, logger, tx, resource.Key, resource.Owner)
+		if err != nil {
+			if err == models.ErrResourceNotFound {
+				logger.Debug("lock-does-not-exist")
+				return nil
+			}
+			return err
+		}
+
+		return db.deleteSharedLock(ctx, logger, tx, current)
+	})
+	return err
+}
+
+func (db *SQLDB) fetchAndReleaseShared(ctx context.Context, logger lager.Logger, lock *Lock) (bool, error) {
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		fetchedLock, err := db.fetchSharedLockForRelease(ctx, logger, tx, lock.Key, lock.Owner)
+		if err != nil {
+			if err == models.ErrResourceNotFound {
+				logger.Debug("lock-does-not-exist")
+			}
+			return err
+		}
+
+		logger.Info("fetched-shared-lock")
+
+		if fetchedLock.ModifiedId != lock.ModifiedId {
+			logger.Error("release-failed-id-mismatch", models.ErrLockCollision, lager.Data{"lock-modified-id": lock.ModifiedId, "fetched-modified-id": fetchedLock.ModifiedId})
+			return models.ErrLockCollision
+		}
+
+		if fetchedLock.ModifiedIndex != lock.ModifiedIndex {
+			logger.Error("release-failed-index-mismatch", models.ErrLockCollision, lager.Data{"lock-modified-index": lock.ModifiedIndex, "fetched-modified-index": fetchedLock.ModifiedIndex})
+			return models.ErrLockCollision
+		}
+
+		return db.deleteSharedLock(ctx, logger, tx, fetchedLock)
+	})
+
+	if err != nil {
+		if err == models.ErrResourceNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// fetchSharedLockForRelease locks the row of the key in the locks table before
+// the row of the shared holder, in the same order as LockShared does, so that
+// releases cannot deadlock with acquisitions.
+func (db *SQLDB) fetchSharedLockForRelease(ctx context.Context, logger lager.Logger, tx helpers.Tx, key, owner string) (*Lock, error) {
+	_, err := db.fetchLock(ctx, logger, tx, key)
+	if err != nil {
+		sqlErr := db.helper.ConvertSQLError(err)
+		if sqlErr == helpers.ErrResourceNotFound {
+			return nil, models.ErrResourceNotFound
+		}
+		logger.Error("failed-to-fetch-lock", err)
+		return nil, sqlErr
+	}
+
+	lock, err := db.fetchSharedLock(ctx, logger, tx, key, owner)
+	if err != nil {
+		sqlErr := db.helper.ConvertSQLError(err)
+		if sqlErr == helpers.ErrResourceNotFound {
+			return nil, models.ErrResourceNotFound
+		}
+		logger.Error("failed-to-fetch-shared-lock", err)
+		return nil, sqlErr
+	}
+
+	return lock, nil
+}
+
+// deleteSharedLock removes a shared holder, along with the ownerless row of
+// the key in the locks table once the last shared holder is gone.
+func (db *SQLDB) deleteSharedLock(ctx context.Context, logger lager.Logger, tx helpers.Tx, lock *Lock) error {
+	_, err := db.helper.Delete(ctx, logger, tx, "shared_locks",
+		"path = ? AND owner = ?", lock.Key, lock.Owner,
+	)
+	if err != nil {
+		logger.Error("failed-to-release-shared-lock", err)
+		return db.helper.ConvertSQLError(err)
+	}
+
+	count, err := db.countSharedHolders(ctx, logger, tx, lock.Key)
+	if err != nil {
+		return db.helper.ConvertSQLError(err)
+	}
+
+	if count == 0 {
+		_, err = db.helper.Delete(ctx, logger, tx, "locks",
+			"path = ? AND owner = ?", lock.Key, "",
+		)
+		if err != nil {
+			logger.Error("failed-to-release-lock", err)
+			return db.helper.ConvertSQLError(err)
+		}
+	}
+
+	logger.Info("released-shared-lock")
+	return nil
+}
+
+// FetchSharedHolders returns the shared holders of key, ordered by owner.
+func (db *SQLDB) FetchSharedHolders(ctx context.Context, logger lager.Logger, key string) ([]*Lock, error) {
+	logger = logger.Session("fetch-shared-holders", lager.Data{"key": key})
+	return db.fetchSharedLocks(ctx, logger, true, "path = ?", key)
+}
+
+// FetchAllSharedHolders returns the shared holders of all keys, without their
+// payloads.
+func (db *SQLDB) FetchAllSharedHolders(ctx context.Context, logger lager.Logger) ([]*Lock, error) {
+	logger = logger.Session("fetch-all-shared-holders")
+	return db.fetchSharedLocks(ctx, logger, false, "")
+}
+
+func (db *SQLDB) fetchSharedLocks(ctx context.Context, logger lager.Logger, withPayload bool, where string, whereBindings ...interface{}) ([]*Lock, error) {
+	var locks []*Lock
+
+	columns := lockColumnsWithoutPayload
+	if withPayload {
+		columns = lockColumns
+	}
+
+	err := db.helper.Transact(ctx, logger, db, func(logger lager.Logger, tx helpers.Tx) error {
+		rows, err := db.helper.All(ctx, logger, tx, "shared_locks",
+			columns,
+			helpers.NoLockRow, where, whereBindings...,
+		)
+		if err != nil {
+			logger.Error("failed-to-fetch-shared-locks", err)
+			return err
+		}
+		defer rows.Close()
+
+		locks = scanLocks(logger, rows, withPayload)
+		return nil
+	})
+	if err != nil {
+		return nil, db.helper.ConvertSQLError(err)
+	}
+
+	for _, lock := range locks {
+		lock.Mode = models.SHARED
+	}
+
+	sort.Slice(locks, func(i, j int) bool {
+		if locks[i].Key != locks[j].Key {
+			return locks[i].Key < locks[j].Key
+		}
+		return locks[i].Owner < locks[j].Owner
+	})
+
+	return locks, nil
+}
+
+func (db *SQLDB) fetchSharedLock(ctx context.Context, logger lager.Logger, q helpers.Queryable, key, owner string) (*Lock, error) {
+	row := db.helper.One(ctx, logger, q, "shared_locks",
+		helpers.ColumnList{"value", "type", "modified_index", "modified_id", "ttl", "fencing_token", "acquired_at", "renewed_at", "lease_id", "labels", "payload"},
+		helpers.LockRow,
+		"path = ? AND owner = ?", key, owner,
+	)
+
+	var value, lockType, id, leaseID, labels string
+	var index, ttl, fencingToken, acquiredAt, renewedAt int64
+	var payload []byte
+	err := row.Scan(&value, &lockType, &index, &id, &ttl, &fencingToken, &acquiredAt, &renewedAt, &leaseID, &labels, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Lock{
+		Resource: &models.Resource{
+			Key:      key,
+			Owner:    owner,
+			Value:    value,
+			Type:     lockType,
+			TypeCode: models.GetTypeCode(lockType),
+			LeaseId:  leaseID,
+			Labels:   decodeLabels(logger, labels),
+			Payload:  payload,
+		},
+		Mode:          models.SHARED,
+		ModifiedIndex: index,
+		ModifiedId:    id,
+		TtlInSeconds:  ttl,
+		FencingToken:  fencingToken,
+		AcquiredAt:    acquiredAt,
+		RenewedAt:     renewedAt,
+	}, nil
+}
+
+func (db *SQLDB) countSharedHolders(ctx context.Context, logger lager.Logger, q helpers.Queryable, key string) (int, error) {
+	count, err := db.helper.Count(ctx, logger, q, "shared_locks", "path = ?", key)
+	if err != nil {
+		logger.Error("failed-to-count-shared-holders", err)
+	}
+	return count, err
+}
@@ -0,0 +1,380 @@
+package db_test
+
+import (
+	"code.cloudfoundry.org/locket/db"
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shared Lock", func() {
+	var reader, otherReader, writer *models.Resource
+
+	BeforeEach(func() {
+		reader = &models.Resource{
+			Key:      "maintenance",
+			Owner:    "reader",
+			Value:    "reading",
+			TypeCode: models.LOCK,
+		}
+		otherReader = &models.Resource{
+			Key:      "maintenance",
+			Owner:    "other-reader",
+			Value:    "reading too",
+			TypeCode: models.LOCK,
+		}
+		writer = &models.Resource{
+			Key:      "maintenance",
+			Owner:    "writer",
+			Value:    "writing",
+			TypeCode: models.LOCK,
+		}
+
+		fakeGUIDProvider.NextGUIDReturns("new-guid", nil)
+	})
+
+	Context("LockShared", func() {
+		It("inserts a shared lock for the owner", func() {
+			lock, err := sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock).To(Equal(&db.Lock{
+				Resource:      models.GetResource(reader),
+				Mode:          models.SHARED,
+				ModifiedIndex: 1,
+				ModifiedId:    "new-guid",
+				TtlInSeconds:  10,
+				FencingToken:  1,
+				AcquiredAt:    fakeClock.Now().UnixNano(),
+				RenewedAt:     fakeClock.Now().UnixNano(),
+			}))
+		})
+
+		It("lets many owners hold the lock", func() {
+			_, err := sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sqlDB.LockShared(ctx, logger, otherReader, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			holders, err := sqlDB.FetchSharedHolders(ctx, logger, reader.Key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(HaveLen(2))
+			Expect(holders[0].Owner).To(Equal(otherReader.Owner))
+			Expect(holders[1].Owner).To(Equal(reader.Owner))
+		})
+
+		It("does not show the key as exclusively held", func() {
+			_, err := sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.Fetch(ctx, logger, reader.Key)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+
+			locks, err := sqlDB.FetchAll(ctx, logger, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(locks).To(BeEmpty())
+		})
+
+		Context("when the owner already holds the shared lock", func() {
+			var lock *db.Lock
+
+			BeforeEach(func() {
+				var err error
+				lock, err = sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("renews the shared lock", func() {
+				fakeGUIDProvider.NextGUIDReturns("another-guid", nil)
+
+				renewedLock, err := sqlDB.LockShared(ctx, logger, reader, 20, 0, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(renewedLock.ModifiedIndex).To(Equal(lock.ModifiedIndex + 1))
+				Expect(renewedLock.ModifiedId).To(Equal(lock.ModifiedId))
+				Expect(renewedLock.FencingToken).To(Equal(lock.FencingToken))
+				Expect(renewedLock.TtlInSeconds).To(BeEquivalentTo(20))
+			})
+		})
+
+		Context("when the key is held exclusively", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.Lock(ctx, logger, writer, 10, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a lock collision error", func() {
+				_, err := sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+				Expect(err).To(Equal(models.ErrLockCollision))
+			})
+
+			It("does not let the exclusive holder share the lock", func() {
+				_, err := sqlDB.LockShared(ctx, logger, writer, 10, 0, "")
+				Expect(err).To(Equal(models.ErrLockCollision))
+			})
+		})
+	})
+
+	Context("when the number of holders is limited", func() {
+		var semaphore, otherSemaphore *models.Resource
+
+		BeforeEach(func() {
+			semaphore = &models.Resource{Key: "uploads", Owner: "cell-1", TypeCode: models.SEMAPHORE}
+			otherSemaphore = &models.Resource{Key: "uploads", Owner: "cell-2", TypeCode: models.SEMAPHORE}
+
+			_, err := sqlDB.LockShared(ctx, logger, semaphore, 10, 1, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns a lock collision error once the limit is reached", func() {
+			_, err := sqlDB.LockShared(ctx, logger, otherSemaphore, 10, 1, "")
+			Expect(err).To(Equal(models.ErrLockCollision))
+		})
+
+		It("lets the holders renew their lock", func() {
+			lock, err := sqlDB.LockShared(ctx, logger, semaphore, 10, 1, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.ModifiedIndex).To(BeEquivalentTo(2))
+			Expect(lock.TypeCode).To(Equal(models.SEMAPHORE))
+		})
+
+		It("admits another owner once a holder releases the lock", func() {
+			Expect(sqlDB.ReleaseShared(ctx, logger, semaphore)).To(Succeed())
+
+			_, err := sqlDB.LockShared(ctx, logger, otherSemaphore, 10, 1, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects owners requesting a different limit", func() {
+			_, err := sqlDB.LockShared(ctx, logger, otherSemaphore, 10, 2, "")
+			Expect(err).To(Equal(models.ErrSemaphoreLimitMismatch))
+
+			_, err = sqlDB.LockShared(ctx, logger, otherSemaphore, 10, 0, "")
+			Expect(err).To(Equal(models.ErrSemaphoreLimitMismatch))
+		})
+
+		It("rejects holders renewing with a different limit", func() {
+			_, err := sqlDB.LockShared(ctx, logger, semaphore, 10, 2, "")
+			Expect(err).To(Equal(models.ErrSemaphoreLimitMismatch))
+		})
+
+		It("takes the limit of the next first holder once every holder released the lock", func() {
+			Expect(sqlDB.ReleaseShared(ctx, logger, semaphore)).To(Succeed())
+
+			_, err := sqlDB.LockShared(ctx, logger, otherSemaphore, 10, 2, "")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sqlDB.LockShared(ctx, logger, semaphore, 10, 2, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the limit of the holders was not stored", func() {
+			BeforeEach(func() {
+				_, err := rawDB.Exec(`UPDATE locks SET semaphore_limit = NULL`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("takes the limit of the next request", func() {
+				_, err := sqlDB.LockShared(ctx, logger, otherSemaphore, 10, 2, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = sqlDB.LockShared(ctx, logger, semaphore, 10, 1, "")
+				Expect(err).To(Equal(models.ErrSemaphoreLimitMismatch))
+			})
+		})
+	})
+
+	Context("Lock", func() {
+		Context("when the key is held in shared mode", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a lock collision error", func() {
+				_, err := sqlDB.Lock(ctx, logger, writer, 10, "")
+				Expect(err).To(Equal(models.ErrLockCollision))
+			})
+
+			It("does not let a shared holder upgrade the lock", func() {
+				_, err := sqlDB.Lock(ctx, logger, reader, 10, "")
+				Expect(err).To(Equal(models.ErrLockCollision))
+			})
+
+			Context("when the shared holders release the lock", func() {
+				BeforeEach(func() {
+					Expect(sqlDB.ReleaseShared(ctx, logger, reader)).To(Succeed())
+				})
+
+				It("acquires the lock", func() {
+					lock, err := sqlDB.Lock(ctx, logger, writer, 10, "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lock.Owner).To(Equal(writer.Owner))
+				})
+			})
+		})
+	})
+
+	Context("ReleaseShared", func() {
+		BeforeEach(func() {
+			_, err := sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sqlDB.LockShared(ctx, logger, otherReader, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("releases only the shared lock of the owner", func() {
+			Expect(sqlDB.ReleaseShared(ctx, logger, reader)).To(Succeed())
+
+			holders, err := sqlDB.FetchSharedHolders(ctx, logger, reader.Key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(HaveLen(1))
+			Expect(holders[0].Owner).To(Equal(otherReader.Owner))
+
+			_, err = sqlDB.Lock(ctx, logger, writer, 10, "")
+			Expect(err).To(Equal(models.ErrLockCollision))
+		})
+
+		It("removes the key once the last shared holder is gone", func() {
+			Expect(sqlDB.ReleaseShared(ctx, logger, reader)).To(Succeed())
+			Expect(sqlDB.ReleaseShared(ctx, logger, otherReader)).To(Succeed())
+
+			Expect(validateLockNotInDB(rawDB, reader)).To(Succeed())
+		})
+
+		Context("when the owner does not hold the shared lock", func() {
+			It("does not return an error", func() {
+				Expect(sqlDB.ReleaseShared(ctx, logger, writer)).To(Succeed())
+
+				holders, err := sqlDB.FetchSharedHolders(ctx, logger, reader.Key)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holders).To(HaveLen(2))
+			})
+		})
+	})
+
+	Context("FetchAndRelease", func() {
+		var lock *db.Lock
+
+		BeforeEach(func() {
+			var err error
+			lock, err = sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("releases the shared lock", func() {
+			expired, err := sqlDB.FetchAndRelease(ctx, logger, lock)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expired).To(BeTrue())
+
+			holders, err := sqlDB.FetchSharedHolders(ctx, logger, reader.Key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(BeEmpty())
+		})
+
+		Context("when the shared lock was renewed", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a lock collision error", func() {
+				_, err := sqlDB.FetchAndRelease(ctx, logger, lock)
+				Expect(err).To(Equal(models.ErrLockCollision))
+			})
+		})
+
+		Context("when the shared lock was released", func() {
+			BeforeEach(func() {
+				Expect(sqlDB.ReleaseShared(ctx, logger, reader)).To(Succeed())
+			})
+
+			It("does not expire it", func() {
+				expired, err := sqlDB.FetchAndRelease(ctx, logger, lock)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(expired).To(BeFalse())
+			})
+		})
+	})
+
+	Context("LockBatch", func() {
+		It("locks the requests in the requested mode", func() {
+			locks, errs, err := sqlDB.LockBatch(ctx, logger, []*models.LockRequest{
+				{Resource: reader, TtlInSeconds: 10, Mode: models.SHARED},
+				{Resource: otherReader, TtlInSeconds: 10, Mode: models.SHARED},
+				{Resource: writer, TtlInSeconds: 10},
+			}, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errs).To(Equal([]error{nil, nil, models.ErrLockCollision}))
+			Expect(locks[0].Mode).To(Equal(models.SHARED))
+			Expect(locks[1].Mode).To(Equal(models.SHARED))
+		})
+	})
+
+	Context("FetchAllSharedHolders", func() {
+		It("returns the shared holders of all keys", func() {
+			_, err := sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			otherKey := &models.Resource{Key: "backup", Owner: "reader", TypeCode: models.LOCK}
+			_, err = sqlDB.LockShared(ctx, logger, otherKey, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			holders, err := sqlDB.FetchAllSharedHolders(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(HaveLen(2))
+			Expect(holders[0].Key).To(Equal("backup"))
+			Expect(holders[0].Mode).To(Equal(models.SHARED))
+			Expect(holders[1].Key).To(Equal("maintenance"))
+		})
+
+		It("does not return the payloads of the holders", func() {
+			reader.Payload = []byte("manifest")
+			_, err := sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			holders, err := sqlDB.FetchAllSharedHolders(ctx, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(HaveLen(1))
+			Expect(holders[0].Payload).To(BeEmpty())
+
+			holders, err = sqlDB.FetchSharedHolders(ctx, logger, reader.Key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders[0].Payload).To(Equal([]byte("manifest")))
+		})
+	})
+
+	Context("FetchSharedPage", func() {
+		BeforeEach(func() {
+			for _, owner := range []string{"cell-1", "cell-2", "cell-3"} {
+				semaphore := &models.Resource{Key: "slots", Owner: owner, TypeCode: models.SEMAPHORE}
+				_, err := sqlDB.LockShared(ctx, logger, semaphore, 10, 3, "")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err := sqlDB.LockShared(ctx, logger, reader, 10, 0, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the holders of the type ordered by key and owner", func() {
+			holders, err := sqlDB.FetchSharedPage(ctx, logger, "", models.SemaphoreType, "", "", "", nil, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(HaveLen(3))
+			for i, owner := range []string{"cell-1", "cell-2", "cell-3"} {
+				Expect(holders[i].Key).To(Equal("slots"))
+				Expect(holders[i].Owner).To(Equal(owner))
+				Expect(holders[i].Mode).To(Equal(models.SHARED))
+				Expect(holders[i].TypeCode).To(Equal(models.SEMAPHORE))
+			}
+		})
+
+		It("pages through the holders of a key", func() {
+			holders, err := sqlDB.FetchSharedPage(ctx, logger, "", models.SemaphoreType, "", "", "", nil, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(HaveLen(2))
+			Expect(holders[1].Owner).To(Equal("cell-2"))
+
+			holders, err = sqlDB.FetchSharedPage(ctx, logger, "", models.SemaphoreType, "", "slots", "cell-2", nil, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(HaveLen(1))
+			Expect(holders[0].Owner).To(Equal("cell-3"))
+		})
+	})
+})
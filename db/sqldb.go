@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/diego-db-helpers/guidprovider"
 	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
 	"code.cloudfoundry.org/lager/v3"
@@ -11,19 +12,52 @@ import (
 
 //go:generate counterfeiter . LockDB
 type LockDB interface {
-	Lock(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64) (*Lock, error)
+	Lock(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64, leaseOwner string) (*Lock, error)
+	LockShared(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64, limit int, leaseOwner string) (*Lock, error)
+	LockBatch(ctx context.Context, logger lager.Logger, requests []*models.LockRequest, leaseOwner string) ([]*Lock, []error, error)
+	LockMulti(ctx context.Context, logger lager.Logger, requests []*models.LockRequest, leaseOwner string) ([]*Lock, error)
 	Release(ctx context.Context, logger lager.Logger, resource *models.Resource) error
+	ReleaseShared(ctx context.Context, logger lager.Logger, resource *models.Resource) error
+	Update(ctx context.Context, logger lager.Logger, resource *models.Resource, expectedIndex int64) (*Lock, error)
+	Transfer(ctx context.Context, logger lager.Logger, resource *models.Resource, newOwner string) (*Lock, error)
+	ForceRelease(ctx context.Context, logger lager.Logger, key, actor, reason string) ([]*Lock, error)
 	Fetch(ctx context.Context, logger lager.Logger, key string) (*Lock, error)
 	FetchAndRelease(ctx context.Context, logger lager.Logger, lock *Lock) (bool, error)
 	FetchAll(ctx context.Context, logger lager.Logger, lockType string) ([]*Lock, error)
-	Count(ctx context.Context, logger lager.Logger, lockType string) (int, error)
+	FetchPage(ctx context.Context, logger lager.Logger, namespace, lockType, keyPrefix, startAfter string, labelSelector map[string]string, limit int) ([]*Lock, error)
+	FetchSharedPage(ctx context.Context, logger lager.Logger, namespace, lockType, keyPrefix, startAfterKey, startAfterOwner string, labelSelector map[string]string, limit int) ([]*Lock, error)
+	FetchSharedHolders(ctx context.Context, logger lager.Logger, key string) ([]*Lock, error)
+	FetchAllSharedHolders(ctx context.Context, logger lager.Logger) ([]*Lock, error)
+	Count(ctx context.Context, logger lager.Logger, namespace, lockType string) (int, error)
+	GrantLease(ctx context.Context, logger lager.Logger, ttl int64, owner string) (*Lease, error)
+	KeepAliveLease(ctx context.Context, logger lager.Logger, id, owner string) (*Lease, error)
+	RevokeLease(ctx context.Context, logger lager.Logger, id, owner string) ([]*Lock, error)
+	ExpireLease(ctx context.Context, logger lager.Logger, lease *Lease) ([]*Lock, bool, error)
+	FetchAllLeases(ctx context.Context, logger lager.Logger) ([]*Lease, error)
+	FetchLeaseOwners(ctx context.Context, logger lager.Logger, ids []string) (map[string]string, error)
 }
 
 type Lock struct {
 	*models.Resource
+	Mode          models.LockMode
 	TtlInSeconds  int64
 	ModifiedIndex int64
 	ModifiedId    string
+	FencingToken  int64
+	AcquiredAt    int64
+	RenewedAt     int64
+}
+
+type Lease struct {
+	ID string
+	// Owner is the identity of the client that granted the lease, empty for
+	// clients without a certificate and for leases granted before owners were
+	// stored.
+	Owner         string
+	TtlInSeconds  int64
+	ModifiedIndex int64
+	GrantedAt     int64
+	RenewedAt     int64
 }
 
 type SQLDB struct {
@@ -31,18 +65,24 @@ type SQLDB struct {
 	flavor       string
 	helper       helpers.SQLHelper
 	guidProvider guidprovider.GUIDProvider
+	clock        clock.Clock
+	namespaces   models.Namespaces
 }
 
 func NewSQLDB(
 	db helpers.QueryableDB,
 	flavor string,
 	guidProvider guidprovider.GUIDProvider,
+	clock clock.Clock,
+	namespaces models.Namespaces,
 ) *SQLDB {
 	helper := helpers.NewSQLHelper(flavor)
 	return &SQLDB{
-		QueryableDB:  db,
+		QueryableDB:  newTracedDB(db, flavor),
 		flavor:       flavor,
 		helper:       helper,
 		guidProvider: guidProvider,
+		clock:        clock,
+		namespaces:   namespaces,
 	}
 }
@@ -5,15 +5,21 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/diego-db-helpers/guidprovider/guidproviderfakes"
 	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
 	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers/monitor"
 	"code.cloudfoundry.org/diego-db-helpers/testhelpers"
 	"code.cloudfoundry.org/lager/v3/lagertest"
 	sqldb "code.cloudfoundry.org/locket/db"
+	"code.cloudfoundry.org/locket/models"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"testing"
 )
@@ -24,10 +30,12 @@ var (
 	logger                               *lagertest.TestLogger
 	ctx                                  context.Context
 	fakeGUIDProvider                     *guidproviderfakes.FakeGUIDProvider
+	fakeClock                            *fakeclock.FakeClock
 	dbDriverName, dbBaseConnectionString string
 	dbFlavor                             string
 	sqlHelper                            helpers.SQLHelper
 	dbParams                             *helpers.ConnectParams
+	spanExporter                         *tracetest.InMemoryExporter
 )
 
 func TestSql(t *testing.T) {
@@ -41,6 +49,9 @@ var _ = BeforeSuite(func() {
 	logger = lagertest.NewTestLogger("sql-db")
 	ctx = context.Background()
 
+	spanExporter = tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter)))
+
 	if testhelpers.UsePostgres() {
 		dbDriverName = "postgres"
 		user, ok := os.LookupEnv("DB_USER")
@@ -94,8 +105,11 @@ var _ = BeforeSuite(func() {
 	Expect(rawDB.Ping()).NotTo(HaveOccurred())
 
 	fakeGUIDProvider = &guidproviderfakes.FakeGUIDProvider{}
+	fakeClock = fakeclock.NewFakeClock(time.Now())
 	db := helpers.NewMonitoredDB(rawDB, monitor.New())
-	sqlDB = sqldb.NewSQLDB(db, dbFlavor, fakeGUIDProvider)
+	sqlDB = sqldb.NewSQLDB(db, dbFlavor, fakeGUIDProvider, fakeClock, models.Namespaces{
+		"quota": {Name: "quota", MaxResources: 2},
+	})
 	err = sqlDB.CreateLockTable(ctx, logger)
 	Expect(err).NotTo(HaveOccurred())
 	err = sqlDB.CreateHealthCheckTable(ctx, logger)
@@ -113,6 +127,8 @@ var _ = BeforeEach(func() {
 	// to operate, otherwise a deadlock can be caused in bbs. For more
 	// information see https://www.pivotaltracker.com/story/show/136754083
 	rawDB.SetMaxOpenConns(1)
+
+	spanExporter.Reset()
 })
 
 var _ = AfterEach(func() {
@@ -141,4 +157,9 @@ func truncateTables(db *sql.DB) {
 var truncateTablesSQL = []string{
 	"TRUNCATE TABLE locks",
 	"TRUNCATE TABLE locket_health_check",
+	"TRUNCATE TABLE locket_fencing_token",
+	"TRUNCATE TABLE shared_locks",
+	"TRUNCATE TABLE leases",
+	"TRUNCATE TABLE lock_audit_log",
+	"TRUNCATE TABLE locket_namespaces",
 }
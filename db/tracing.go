@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/locket/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "code.cloudfoundry.org/locket/db"
+
+// tracedDB records a span for each query, and one for each transaction with
+// the spans of its queries as children.
+type tracedDB struct {
+	helpers.QueryableDB
+	system attribute.KeyValue
+}
+
+func newTracedDB(db helpers.QueryableDB, flavor string) *tracedDB {
+	system := semconv.DBSystemNameKey.String(flavor)
+	switch flavor {
+	case helpers.Postgres:
+		system = semconv.DBSystemNamePostgreSQL
+	case helpers.MySQL:
+		system = semconv.DBSystemNameMySQL
+	}
+	return &tracedDB{QueryableDB: db, system: system}
+}
+
+func (db *tracedDB) start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(db.system),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+func (db *tracedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return tracedExec(db, db.QueryableDB, ctx, query, args...)
+}
+
+func (db *tracedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return tracedPrepare(db, db.QueryableDB, ctx, query)
+}
+
+func (db *tracedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return tracedQuery(db, db.QueryableDB, ctx, query, args...)
+}
+
+func (db *tracedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) helpers.RowScanner {
+	return tracedQueryRow(db, db.QueryableDB, ctx, query, args...)
+}
+
+func (db *tracedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (helpers.Tx, error) {
+	_, span := db.start(ctx, "sql.transaction")
+
+	tx, err := db.QueryableDB.BeginTx(ctx, opts)
+	if err != nil {
+		tracing.End(span, err)
+		return tx, err
+	}
+
+	return &tracedTx{Tx: tx, db: db, span: span, once: &sync.Once{}}, nil
+}
+
+// tracedTx ends the span of the transaction on its commit or rollback,
+// whichever comes first.
+type tracedTx struct {
+	helpers.Tx
+	db   *tracedDB
+	span trace.Span
+	once *sync.Once
+}
+
+// withSpan returns the context of a query of the transaction, with the span
+// of the transaction as its parent.
+func (tx *tracedTx) withSpan(ctx context.Context) context.Context {
+	return trace.ContextWithSpan(ctx, tx.span)
+}
+
+func (tx *tracedTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return tracedExec(tx.db, tx.Tx, tx.withSpan(ctx), query, args...)
+}
+
+func (tx *tracedTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return tracedPrepare(tx.db, tx.Tx, tx.withSpan(ctx), query)
+}
+
+func (tx *tracedTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return tracedQuery(tx.db, tx.Tx, tx.withSpan(ctx), query, args...)
+}
+
+func (tx *tracedTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) helpers.RowScanner {
+	return tracedQueryRow(tx.db, tx.Tx, tx.withSpan(ctx), query, args...)
+}
+
+func (tx *tracedTx) Commit() error {
+	err := tx.Tx.Commit()
+	tx.end("commit", err)
+	return err
+}
+
+func (tx *tracedTx) Rollback() error {
+	err := tx.Tx.Rollback()
+	tx.end("rollback", err)
+	return err
+}
+
+func (tx *tracedTx) end(outcome string, err error) {
+	tx.once.Do(func() {
+		tx.span.SetAttributes(attribute.String("locket.transaction.outcome", outcome))
+		tracing.End(tx.span, err)
+	})
+}
+
+func tracedExec(db *tracedDB, q helpers.Queryable, ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := db.start(ctx, "sql.exec", semconv.DBQueryText(query))
+	result, err := q.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+	return result, err
+}
+
+func tracedPrepare(db *tracedDB, q helpers.Queryable, ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := db.start(ctx, "sql.prepare", semconv.DBQueryText(query))
+	stmt, err := q.PrepareContext(ctx, query)
+	tracing.End(span, err)
+	return stmt, err
+}
+
+func tracedQuery(db *tracedDB, q helpers.Queryable, ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := db.start(ctx, "sql.query", semconv.DBQueryText(query))
+	rows, err := q.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+	return rows, err
+}
+
+// tracedQueryRow ends the span of the query once its row is scanned, as the
+// error of the query is only returned then.
+func tracedQueryRow(db *tracedDB, q helpers.Queryable, ctx context.Context, query string, args ...interface{}) helpers.RowScanner {
+	ctx, span := db.start(ctx, "sql.query", semconv.DBQueryText(query))
+	return &tracedRow{RowScanner: q.QueryRowContext(ctx, query, args...), span: span}
+}
+
+type tracedRow struct {
+	helpers.RowScanner
+	span trace.Span
+}
+
+// Scan does not record missing rows as errors, they are how lookups of
+// missing locks end.
+func (r *tracedRow) Scan(dest ...interface{}) error {
+	err := r.RowScanner.Scan(dest...)
+	if err == sql.ErrNoRows {
+		r.span.End()
+	} else {
+		tracing.End(r.span, err)
+	}
+	return err
+}
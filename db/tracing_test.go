@@ -0,0 +1,139 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers/helpersfakes"
+	"code.cloudfoundry.org/locket/db"
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		requestCtx context.Context
+		request    trace.Span
+	)
+
+	BeforeEach(func() {
+		requestCtx, request = otel.Tracer("test").Start(context.Background(), "locketHandler.Lock")
+	})
+
+	It("records the queries and the transactions of the request", func() {
+		fakeGUIDProvider.NextGUIDReturns("new-guid", nil)
+		_, err := sqlDB.Lock(requestCtx, logger, &models.Resource{Key: "quack", Owner: "iamthelizardking", Type: "lock"}, 10, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		spans := spanExporter.GetSpans()
+		Expect(spans).NotTo(BeEmpty())
+
+		transaction := spans[len(spans)-1]
+		Expect(transaction.Name).To(Equal("sql.transaction"))
+		Expect(transaction.Parent.SpanID()).To(Equal(request.SpanContext().SpanID()))
+		Expect(transaction.Attributes).To(ContainElement(attribute.String("locket.transaction.outcome", "commit")))
+
+		for _, span := range spans[:len(spans)-1] {
+			Expect(span.Name).To(HavePrefix("sql."))
+			Expect(span.Parent.SpanID()).To(Equal(transaction.SpanContext.SpanID()))
+			Expect(span.Attributes).To(ContainElement(HaveField("Key", attribute.Key("db.query.text"))))
+		}
+	})
+
+	Context("with a fake database", func() {
+		var (
+			fakeDB *helpersfakes.FakeQueryableDB
+			fakeTx *helpersfakes.FakeTx
+			traced *db.SQLDB
+		)
+
+		BeforeEach(func() {
+			fakeDB = &helpersfakes.FakeQueryableDB{}
+			fakeTx = &helpersfakes.FakeTx{}
+			fakeDB.BeginTxReturns(fakeTx, nil)
+			traced = db.NewSQLDB(fakeDB, helpers.Postgres, fakeGUIDProvider, fakeClock, nil)
+		})
+
+		It("records each query with its statement in the span of the request", func() {
+			_, err := traced.ExecContext(requestCtx, "DELETE FROM locks WHERE path = $1", "quack")
+			Expect(err).NotTo(HaveOccurred())
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("sql.exec"))
+			Expect(spans[0].SpanKind).To(Equal(trace.SpanKindClient))
+			Expect(spans[0].Parent.SpanID()).To(Equal(request.SpanContext().SpanID()))
+			Expect(spans[0].Attributes).To(ContainElements(
+				attribute.String("db.system.name", "postgresql"),
+				attribute.String("db.query.text", "DELETE FROM locks WHERE path = $1"),
+			))
+
+			queryCtx, _, _ := fakeDB.ExecContextArgsForCall(0)
+			Expect(trace.SpanContextFromContext(queryCtx)).To(Equal(spans[0].SpanContext))
+		})
+
+		It("marks the span of failed queries as failed", func() {
+			fakeDB.QueryContextReturns(nil, errors.New("boom"))
+			_, err := traced.QueryContext(requestCtx, "SELECT 1")
+			Expect(err).To(MatchError("boom"))
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Status.Code).To(Equal(codes.Error))
+		})
+
+		It("ends the span of a row query once the row is scanned, without counting missing rows as failures", func() {
+			fakeRow := &helpersfakes.FakeRowScanner{}
+			fakeRow.ScanReturns(sql.ErrNoRows)
+			fakeDB.QueryRowContextReturns(fakeRow)
+
+			row := traced.QueryRowContext(requestCtx, "SELECT 1")
+			Expect(spanExporter.GetSpans()).To(BeEmpty())
+
+			Expect(row.Scan()).To(Equal(sql.ErrNoRows))
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Status.Code).To(Equal(codes.Unset))
+		})
+
+		It("records the queries of a transaction in its span, which ends once", func() {
+			tx, err := traced.BeginTx(requestCtx, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = tx.ExecContext(requestCtx, "UPDATE locks SET value = $1", "quack")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tx.Commit()).To(Succeed())
+			Expect(tx.Rollback()).To(Succeed())
+
+			Expect(fakeTx.ExecContextCallCount()).To(Equal(1))
+			Expect(fakeTx.CommitCallCount()).To(Equal(1))
+			Expect(fakeTx.RollbackCallCount()).To(Equal(1))
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].Name).To(Equal("sql.exec"))
+			Expect(spans[1].Name).To(Equal("sql.transaction"))
+			Expect(spans[0].Parent.SpanID()).To(Equal(spans[1].SpanContext.SpanID()))
+			Expect(spans[1].Parent.SpanID()).To(Equal(request.SpanContext().SpanID()))
+			Expect(spans[1].Attributes).To(ContainElement(attribute.String("locket.transaction.outcome", "commit")))
+		})
+
+		It("marks the span of transactions that fail to begin as failed", func() {
+			fakeDB.BeginTxReturns(nil, errors.New("boom"))
+			_, err := traced.BeginTx(requestCtx, nil)
+			Expect(err).To(MatchError("boom"))
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("sql.transaction"))
+			Expect(spans[0].Status.Code).To(Equal(codes.Error))
+		})
+	})
+})
@@ -0,0 +1,329 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrNoLeader           = errors.New("no leader elected")
+	ErrAlreadyCampaigning = errors.New("already campaigning")
+)
+
+// Election elects a single leader among the candidates campaigning for the
+// same key. The leader holds the lock on the key, with its value set to what
+// the other instances need to know about it, e.g. its address.
+type Election struct {
+	logger lager.Logger
+
+	client        models.LocketClient
+	key           string
+	candidate     string
+	ttlInSeconds  int64
+	clock         clock.Clock
+	retryInterval time.Duration
+
+	mutex       sync.Mutex
+	campaigning bool
+	leadership  *leadership
+}
+
+type leadership struct {
+	resource *models.Resource
+	resign   chan struct{}
+	done     chan struct{}
+}
+
+func NewElection(
+	logger lager.Logger,
+	client models.LocketClient,
+	key string,
+	candidate string,
+	ttlInSeconds int64,
+	clock clock.Clock,
+	retryInterval time.Duration,
+) *Election {
+	return &Election{
+		logger:        logger.Session("election", lager.Data{"key": key, "candidate": candidate}),
+		client:        client,
+		key:           key,
+		candidate:     candidate,
+		ttlInSeconds:  ttlInSeconds,
+		clock:         clock,
+		retryInterval: retryInterval,
+	}
+}
+
+// Campaign blocks until the candidate is elected or ctx is done. Once elected,
+// the leadership is renewed in the background until Resign is called, another
+// candidate takes the lock, or the renewals keep failing until the TTL runs
+// out, at which point the returned channel is closed.
+func (e *Election) Campaign(ctx context.Context, value string) (<-chan struct{}, error) {
+	logger := e.logger.Session("campaign")
+	logger.Info("started")
+	defer logger.Info("completed")
+
+	e.mutex.Lock()
+	if e.campaigning || e.leadership != nil {
+		e.mutex.Unlock()
+		return nil, ErrAlreadyCampaigning
+	}
+	e.campaigning = true
+	e.mutex.Unlock()
+
+	defer func() {
+		e.mutex.Lock()
+		e.campaigning = false
+		e.mutex.Unlock()
+	}()
+
+	resource := &models.Resource{
+		Key:      e.key,
+		Owner:    e.candidate,
+		Value:    value,
+		TypeCode: models.LOCK,
+	}
+
+	var renewedAt time.Time
+	for {
+		// wait on the server for the current leader to go away, rather than
+		// polling for it
+		renewedAt = e.clock.Now()
+		_, err := e.client.Lock(ctx, &models.LockRequest{
+			Resource:             resource,
+			TtlInSeconds:         e.ttlInSeconds,
+			WaitTimeoutInSeconds: e.ttlInSeconds,
+		})
+		if err == nil {
+			break
+		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if status.Code(err) != status.Code(models.ErrLockCollision) {
+			logger.Error("failed-to-acquire-leadership", err)
+		}
+
+		retry := e.clock.NewTimer(e.retryInterval)
+		select {
+		case <-ctx.Done():
+			retry.Stop()
+			return nil, ctx.Err()
+		case <-retry.C():
+		}
+	}
+
+	logger.Info("elected")
+
+	l := &leadership{
+		resource: resource,
+		resign:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	e.mutex.Lock()
+	e.leadership = l
+	e.mutex.Unlock()
+
+	go e.lead(l, renewedAt)
+
+	return l.done, nil
+}
+
+// lead renews the leadership every retry interval. The lock expires on the
+// server no earlier than the TTL after the last successful renewal was sent,
+// so failed renewals, e.g. while the database is unreachable, are retried
+// until then rather than failing over on the first one. A collision means
+// that another candidate holds the lock, which ends the leadership right away.
+func (e *Election) lead(l *leadership, renewedAt time.Time) {
+	logger := e.logger.Session("lead")
+	defer close(l.done)
+
+	ttl := time.Duration(e.ttlInSeconds) * time.Second
+	retry := e.clock.NewTimer(e.retryInterval)
+	defer retry.Stop()
+
+	for {
+		select {
+		case <-l.resign:
+			return
+
+		case <-retry.C():
+			attemptedAt := e.clock.Now()
+			ctx, cancel := context.WithTimeout(context.Background(), e.retryInterval)
+			_, err := e.client.Lock(ctx, &models.LockRequest{Resource: l.resource, TtlInSeconds: e.ttlInSeconds}, grpc.WaitForReady(true))
+			cancel()
+			if err == nil {
+				renewedAt = attemptedAt
+			} else if status.Code(err) == status.Code(models.ErrLockCollision) || e.clock.Since(renewedAt) >= ttl {
+				logger.Error("lost-leadership", err)
+
+				e.mutex.Lock()
+				if e.leadership == l {
+					e.leadership = nil
+				}
+				e.mutex.Unlock()
+				return
+			} else {
+				logger.Error("failed-to-renew-leadership", err, lager.Data{"since-renewal": e.clock.Since(renewedAt).String()})
+			}
+
+			retry.Reset(e.retryInterval)
+		}
+	}
+}
+
+// Resign gives up the leadership, if the candidate holds it, so that another
+// candidate can be elected right away.
+func (e *Election) Resign(ctx context.Context) error {
+	logger := e.logger.Session("resign")
+
+	e.mutex.Lock()
+	l := e.leadership
+	e.leadership = nil
+	e.mutex.Unlock()
+
+	if l == nil {
+		logger.Debug("not-leading")
+		return nil
+	}
+
+	close(l.resign)
+	<-l.done
+
+	_, err := e.client.Release(ctx, &models.ReleaseRequest{Resource: l.resource})
+	if err != nil {
+		logger.Error("failed-to-release-leadership", err)
+		return err
+	}
+
+	logger.Info("resigned")
+	return nil
+}
+
+// Leader returns the resource of the current leader, whose value is the one
+// it campaigned with, or ErrNoLeader if no candidate is elected.
+func (e *Election) Leader(ctx context.Context) (*models.Resource, error) {
+	resp, err := e.client.Fetch(ctx, &models.FetchRequest{Key: e.key})
+	if err != nil {
+		if status.Code(err) == status.Code(models.ErrResourceNotFound) {
+			return nil, ErrNoLeader
+		}
+		return nil, err
+	}
+
+	if resp.Resource == nil || resp.Resource.Owner == "" {
+		return nil, ErrNoLeader
+	}
+
+	return resp.Resource, nil
+}
+
+// Observe returns a channel receiving the current leader and then every
+// change of leader or of its value, until ctx is done. A nil resource means
+// that no candidate is elected.
+func (e *Election) Observe(ctx context.Context) <-chan *models.Resource {
+	leaders := make(chan *models.Resource)
+	go e.observe(ctx, leaders)
+	return leaders
+}
+
+func (e *Election) observe(ctx context.Context, leaders chan<- *models.Resource) {
+	logger := e.logger.Session("observe")
+	defer close(leaders)
+
+	var last *models.Resource
+	observed := false
+
+	publish := func(leader *models.Resource) bool {
+		if observed && sameLeader(last, leader) {
+			return true
+		}
+
+		select {
+		case leaders <- leader:
+			last = leader
+			observed = true
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		err := e.watchLeader(ctx, publish)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Error("failed-watching-leader", err)
+
+		retry := e.clock.NewTimer(e.retryInterval)
+		select {
+		case <-ctx.Done():
+			retry.Stop()
+			return
+		case <-retry.C():
+		}
+	}
+}
+
+func (e *Election) watchLeader(ctx context.Context, publish func(*models.Resource) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := e.client.Watch(ctx, &models.WatchRequest{Key: e.key})
+	if err != nil {
+		return err
+	}
+
+	// the server sends the header once the watch is established, so the
+	// leader fetched afterwards cannot miss a change
+	_, err = stream.Header()
+	if err != nil {
+		return err
+	}
+
+	leader, err := e.Leader(ctx)
+	if err != nil && err != ErrNoLeader {
+		return err
+	}
+
+	if !publish(leader) {
+		return nil
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		switch event.Type {
+		case models.CREATED, models.UPDATED:
+			leader = event.Resource
+		case models.DELETED, models.EXPIRED:
+			leader = nil
+		}
+
+		if !publish(leader) {
+			return nil
+		}
+	}
+}
+
+func sameLeader(a, b *models.Resource) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Owner == b.Owner && a.Value == b.Value
+}
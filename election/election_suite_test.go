@@ -0,0 +1,13 @@
+package election_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestElection(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Election Suite")
+}
@@ -0,0 +1,353 @@
+package election_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"code.cloudfoundry.org/locket/election"
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/models/modelsfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var _ = Describe("Election", func() {
+	var (
+		logger        *lagertest.TestLogger
+		fakeClient    *modelsfakes.FakeLocketClient
+		fakeClock     *fakeclock.FakeClock
+		retryInterval time.Duration
+
+		e *election.Election
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("election")
+		fakeClient = &modelsfakes.FakeLocketClient{}
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		retryInterval = time.Second
+
+		e = election.NewElection(logger, fakeClient, "leader", "instance-1", 15, fakeClock, retryInterval)
+	})
+
+	Context("Campaign", func() {
+		It("locks the key with the value of the candidate", func() {
+			done, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(done).NotTo(BeClosed())
+
+			Expect(fakeClient.LockCallCount()).To(Equal(1))
+			_, req, _ := fakeClient.LockArgsForCall(0)
+			Expect(req.Resource).To(Equal(&models.Resource{
+				Key:      "leader",
+				Owner:    "instance-1",
+				Value:    "10.0.0.1:8080",
+				TypeCode: models.LOCK,
+			}))
+			Expect(req.TtlInSeconds).To(BeEquivalentTo(15))
+			Expect(req.WaitTimeoutInSeconds).To(BeEquivalentTo(15))
+		})
+
+		It("renews the leadership every retry interval", func() {
+			_, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(fakeClock.WatcherCount).Should(Equal(1))
+			fakeClock.WaitForWatcherAndIncrement(retryInterval)
+			Eventually(fakeClient.LockCallCount).Should(Equal(2))
+
+			fakeClock.WaitForWatcherAndIncrement(retryInterval)
+			Eventually(fakeClient.LockCallCount).Should(Equal(3))
+		})
+
+		Context("when another candidate is the leader", func() {
+			BeforeEach(func() {
+				fakeClient.LockReturnsOnCall(0, nil, models.ErrLockCollision)
+			})
+
+			It("retries until it is elected", func() {
+				errCh := make(chan error, 1)
+				go func() {
+					_, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+					errCh <- err
+				}()
+
+				Eventually(fakeClient.LockCallCount).Should(Equal(1))
+				Consistently(errCh).ShouldNot(Receive())
+
+				fakeClock.WaitForWatcherAndIncrement(retryInterval)
+				Eventually(errCh).Should(Receive(BeNil()))
+				Expect(fakeClient.LockCallCount()).To(Equal(2))
+			})
+
+			It("gives up when the context is done", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				errCh := make(chan error, 1)
+				go func() {
+					_, err := e.Campaign(ctx, "10.0.0.1:8080")
+					errCh <- err
+				}()
+
+				Eventually(fakeClient.LockCallCount).Should(Equal(1))
+				cancel()
+				Eventually(errCh).Should(Receive(Equal(context.Canceled)))
+			})
+		})
+
+		Context("when it is already campaigning", func() {
+			It("returns an error", func() {
+				_, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = e.Campaign(context.Background(), "10.0.0.1:8080")
+				Expect(err).To(Equal(election.ErrAlreadyCampaigning))
+			})
+		})
+
+		Context("when renewing the leadership fails", func() {
+			It("closes the returned channel", func() {
+				done, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeClient.LockReturns(nil, models.ErrLockCollision)
+				fakeClock.WaitForWatcherAndIncrement(retryInterval)
+
+				Eventually(done).Should(BeClosed())
+				Expect(logger).To(gbytes.Say("lost-leadership"))
+			})
+
+			Context("when the renewal fails without a collision", func() {
+				It("keeps the leadership and retries", func() {
+					done, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+					Expect(err).NotTo(HaveOccurred())
+
+					fakeClient.LockReturnsOnCall(1, nil, errors.New("connection refused"))
+					fakeClock.WaitForWatcherAndIncrement(retryInterval)
+					Eventually(fakeClient.LockCallCount).Should(Equal(2))
+					Expect(logger).To(gbytes.Say("failed-to-renew-leadership"))
+
+					fakeClock.WaitForWatcherAndIncrement(retryInterval)
+					Eventually(fakeClient.LockCallCount).Should(Equal(3))
+					Consistently(done).ShouldNot(BeClosed())
+				})
+
+				It("closes the returned channel once the TTL runs out", func() {
+					done, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+					Expect(err).NotTo(HaveOccurred())
+
+					fakeClient.LockReturns(nil, errors.New("connection refused"))
+					for i := 2; i <= 15; i++ {
+						fakeClock.WaitForWatcherAndIncrement(retryInterval)
+						Eventually(fakeClient.LockCallCount).Should(Equal(i))
+					}
+					Consistently(done).ShouldNot(BeClosed())
+
+					fakeClock.WaitForWatcherAndIncrement(retryInterval)
+					Eventually(done).Should(BeClosed())
+					Expect(logger).To(gbytes.Say("lost-leadership"))
+				})
+			})
+
+			It("can campaign again", func() {
+				done, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeClient.LockReturnsOnCall(1, nil, models.ErrLockCollision)
+				fakeClock.WaitForWatcherAndIncrement(retryInterval)
+				Eventually(done).Should(BeClosed())
+
+				_, err = e.Campaign(context.Background(), "10.0.0.1:8080")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Context("Resign", func() {
+		It("releases the leadership", func() {
+			done, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(e.Resign(context.Background())).To(Succeed())
+			Expect(done).To(BeClosed())
+
+			Expect(fakeClient.ReleaseCallCount()).To(Equal(1))
+			_, req, _ := fakeClient.ReleaseArgsForCall(0)
+			Expect(req.Resource.Key).To(Equal("leader"))
+			Expect(req.Resource.Owner).To(Equal("instance-1"))
+		})
+
+		It("stops renewing the leadership", func() {
+			_, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.Resign(context.Background())).To(Succeed())
+
+			fakeClock.Increment(retryInterval)
+			Consistently(fakeClient.LockCallCount).Should(Equal(1))
+		})
+
+		Context("when the candidate is not the leader", func() {
+			It("does nothing", func() {
+				Expect(e.Resign(context.Background())).To(Succeed())
+				Expect(fakeClient.ReleaseCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when releasing fails", func() {
+			It("returns the error", func() {
+				_, err := e.Campaign(context.Background(), "10.0.0.1:8080")
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeClient.ReleaseReturns(nil, errors.New("boom"))
+				Expect(e.Resign(context.Background())).To(MatchError("boom"))
+			})
+		})
+	})
+
+	Context("Leader", func() {
+		It("returns the current leader", func() {
+			leader := &models.Resource{Key: "leader", Owner: "instance-2", Value: "10.0.0.2:8080"}
+			fakeClient.FetchReturns(&models.FetchResponse{Resource: leader}, nil)
+
+			resource, err := e.Leader(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resource).To(Equal(leader))
+
+			_, req, _ := fakeClient.FetchArgsForCall(0)
+			Expect(req.Key).To(Equal("leader"))
+		})
+
+		Context("when no candidate is elected", func() {
+			BeforeEach(func() {
+				fakeClient.FetchReturns(nil, models.ErrResourceNotFound)
+			})
+
+			It("returns ErrNoLeader", func() {
+				_, err := e.Leader(context.Background())
+				Expect(err).To(Equal(election.ErrNoLeader))
+			})
+		})
+	})
+
+	Context("Observe", func() {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+			stream *fakeWatchStream
+		)
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+			stream = newFakeWatchStream()
+			fakeClient.WatchStub = stream.watch
+			fakeClient.FetchReturns(&models.FetchResponse{
+				Resource: &models.Resource{Key: "leader", Owner: "instance-2", Value: "10.0.0.2:8080"},
+			}, nil)
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		It("sends the current leader and then every change of leader", func() {
+			leaders := e.Observe(ctx)
+
+			var leader *models.Resource
+			Eventually(leaders).Should(Receive(&leader))
+			Expect(leader.Owner).To(Equal("instance-2"))
+
+			_, req, _ := fakeClient.WatchArgsForCall(0)
+			Expect(req.Key).To(Equal("leader"))
+
+			stream.events <- &models.WatchEvent{Type: models.EXPIRED, Resource: leader}
+			Eventually(leaders).Should(Receive(BeNil()))
+
+			stream.events <- &models.WatchEvent{
+				Type:     models.CREATED,
+				Resource: &models.Resource{Key: "leader", Owner: "instance-3", Value: "10.0.0.3:8080"},
+			}
+			Eventually(leaders).Should(Receive(&leader))
+			Expect(leader.Owner).To(Equal("instance-3"))
+			Expect(leader.Value).To(Equal("10.0.0.3:8080"))
+		})
+
+		It("does not send renewals of the leadership", func() {
+			leaders := e.Observe(ctx)
+			Eventually(leaders).Should(Receive())
+
+			stream.events <- &models.WatchEvent{
+				Type:     models.UPDATED,
+				Resource: &models.Resource{Key: "leader", Owner: "instance-2", Value: "10.0.0.2:8080"},
+			}
+			Consistently(leaders).ShouldNot(Receive())
+		})
+
+		It("closes the channel when the context is done", func() {
+			leaders := e.Observe(ctx)
+			Eventually(leaders).Should(Receive())
+
+			cancel()
+			Eventually(leaders).Should(BeClosed())
+		})
+
+		Context("when the watch fails", func() {
+			It("watches again after the retry interval", func() {
+				leaders := e.Observe(ctx)
+				Eventually(leaders).Should(Receive())
+
+				secondStream := newFakeWatchStream()
+				fakeClient.WatchStub = secondStream.watch
+				fakeClient.FetchReturns(&models.FetchResponse{
+					Resource: &models.Resource{Key: "leader", Owner: "instance-3", Value: "10.0.0.3:8080"},
+				}, nil)
+
+				stream.errs <- errors.New("boom")
+				fakeClock.WaitForWatcherAndIncrement(retryInterval)
+
+				var leader *models.Resource
+				Eventually(leaders).Should(Receive(&leader))
+				Expect(leader.Owner).To(Equal("instance-3"))
+				Expect(fakeClient.WatchCallCount()).To(Equal(2))
+			})
+		})
+	})
+})
+
+type fakeWatchStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events chan *models.WatchEvent
+	errs   chan error
+}
+
+func newFakeWatchStream() *fakeWatchStream {
+	return &fakeWatchStream{
+		events: make(chan *models.WatchEvent),
+		errs:   make(chan error, 1),
+	}
+}
+
+func (s *fakeWatchStream) watch(ctx context.Context, req *models.WatchRequest, opts ...grpc.CallOption) (models.Locket_WatchClient, error) {
+	s.ctx = ctx
+	return s, nil
+}
+
+func (s *fakeWatchStream) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (s *fakeWatchStream) Recv() (*models.WatchEvent, error) {
+	select {
+	case event := <-s.events:
+		return event, nil
+	case err := <-s.errs:
+		return nil, err
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
@@ -0,0 +1 @@
+package election // import "code.cloudfoundry.org/locket/election"
@@ -3,6 +3,7 @@ package expiration
 import (
 	"context"
 	"os"
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -10,27 +11,31 @@ import (
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/locket"
 	"code.cloudfoundry.org/locket/db"
+	"code.cloudfoundry.org/locket/watch"
 )
 
 const (
-	locksExpiredCounter    = "LocksExpired"
-	presenceExpiredCounter = "PresenceExpired"
+	locksExpiredCounter      = "LocksExpired"
+	presenceExpiredCounter   = "PresenceExpired"
+	semaphoresExpiredCounter = "SemaphoresExpired"
 )
 
 type burglar struct {
 	logger        lager.Logger
 	lockDB        db.LockDB
 	lockPick      LockPick
+	hub           watch.Hub
 	clock         clock.Clock
 	checkInterval time.Duration
 	metronClient  loggingclient.IngressClient
 }
 
-func NewBurglar(logger lager.Logger, lockDB db.LockDB, lockPick LockPick, clock clock.Clock, checkInterval time.Duration, metronClient loggingclient.IngressClient) burglar {
+func NewBurglar(logger lager.Logger, lockDB db.LockDB, lockPick LockPick, hub watch.Hub, clock clock.Clock, checkInterval time.Duration, metronClient loggingclient.IngressClient) burglar {
 	return burglar{
 		logger:        logger,
 		lockDB:        lockDB,
 		lockPick:      lockPick,
+		hub:           hub,
 		clock:         clock,
 		checkInterval: checkInterval,
 		metronClient:  metronClient,
@@ -43,7 +48,7 @@ func (b burglar) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	logger.Info("started")
 	defer logger.Info("complete")
 
-	locks, err := b.lockDB.FetchAll(context.Background(), logger, "")
+	locks, err := b.fetchAll(logger)
 	if err != nil {
 		logger.Error("failed-fetching-locks", err)
 	}
@@ -52,6 +57,8 @@ func (b burglar) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 		b.lockPick.RegisterTTL(logger, lock)
 	}
 
+	b.registerLeases(logger)
+
 	check := b.clock.NewTicker(b.checkInterval)
 	expirationCheck := b.clock.NewTicker(locket.ExpirationMetricsInterval)
 
@@ -63,7 +70,7 @@ func (b burglar) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 			logger.Info("signalled", lager.Data{"signal": sig})
 			return nil
 		case <-check.C():
-			locks, err := b.lockDB.FetchAll(context.Background(), logger, "")
+			locks, err := b.fetchAll(logger)
 			if err != nil {
 				logger.Error("failed-fetching-locks", err)
 				continue
@@ -72,6 +79,8 @@ func (b burglar) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 			for _, lock := range locks {
 				b.lockPick.RegisterTTL(logger, lock)
 			}
+
+			b.registerLeases(logger)
 		case <-expirationCheck.C():
 			locksExpired, presencesExpired := b.lockPick.ExpirationCounts()
 			err := b.metronClient.SendMetric(locksExpiredCounter, int(locksExpired))
@@ -82,6 +91,75 @@ func (b burglar) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 			if err != nil {
 				logger.Debug("failed-to-send-presences-expired-metric", lager.Data{"error": err})
 			}
+			err = b.metronClient.SendMetric(semaphoresExpiredCounter, int(b.lockPick.SemaphoreExpirationCount()))
+			if err != nil {
+				logger.Debug("failed-to-send-semaphores-expired-metric", lager.Data{"error": err})
+			}
+
+			b.sendResourceTypeExpirationMetrics(logger)
+		}
+	}
+}
+
+// fetchAll returns the exclusive and shared holders of all keys, and syncs the
+// watch hub with the ones streamed to watchers.
+func (b burglar) fetchAll(logger lager.Logger) ([]*db.Lock, error) {
+	var locks []*db.Lock
+	err := b.hub.Sync(logger, func() ([]*db.Lock, error) {
+		exclusiveLocks, err := b.lockDB.FetchAll(context.Background(), logger, "")
+		if err != nil {
+			return nil, err
+		}
+
+		sharedLocks, err := b.lockDB.FetchAllSharedHolders(context.Background(), logger)
+		if err != nil {
+			return nil, err
+		}
+
+		locks = append(exclusiveLocks, sharedLocks...)
+
+		var streamed []*db.Lock
+		for _, lock := range locks {
+			if watch.Streamed(lock.Resource, lock.Mode) {
+				streamed = append(streamed, lock)
+			}
 		}
+		return streamed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return locks, nil
+}
+
+func (b burglar) sendResourceTypeExpirationMetrics(logger lager.Logger) {
+	counts := b.lockPick.ResourceTypeExpirationCounts()
+
+	metrics := make([]string, 0, len(counts))
+	for metric := range counts {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	for _, metric := range metrics {
+		err := b.metronClient.SendMetric(metric, int(counts[metric]))
+		if err != nil {
+			logger.Debug("failed-to-send-resource-type-expired-metric", lager.Data{"metric": metric, "error": err})
+		}
+	}
+}
+
+// registerLeases tracks the leases granted or kept alive through other locket
+// instances.
+func (b burglar) registerLeases(logger lager.Logger) {
+	leases, err := b.lockDB.FetchAllLeases(context.Background(), logger)
+	if err != nil {
+		logger.Error("failed-fetching-leases", err)
+		return
+	}
+
+	for _, lease := range leases {
+		b.lockPick.RegisterLease(logger, lease)
 	}
 }
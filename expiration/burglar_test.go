@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/locket/expiration"
 	"code.cloudfoundry.org/locket/expiration/expirationfakes"
 	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/watch"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
@@ -26,6 +27,7 @@ var _ = Describe("Burglar", func() {
 
 		fakeLockDB   *dbfakes.FakeLockDB
 		fakeLockPick *expirationfakes.FakeLockPick
+		hub          watch.Hub
 		fakeClock    *fakeclock.FakeClock
 		logger       *lagertest.TestLogger
 
@@ -37,6 +39,7 @@ var _ = Describe("Burglar", func() {
 	BeforeEach(func() {
 		fakeLockDB = &dbfakes.FakeLockDB{}
 		fakeLockPick = &expirationfakes.FakeLockPick{}
+		hub = watch.NewHub(watch.DefaultHistorySize, 0)
 		fakeClock = fakeclock.NewFakeClock(time.Now())
 		logger = lagertest.NewTestLogger("expiration")
 
@@ -71,7 +74,7 @@ var _ = Describe("Burglar", func() {
 	})
 
 	JustBeforeEach(func() {
-		runner = expiration.NewBurglar(logger, fakeLockDB, fakeLockPick, fakeClock, checkInterval, fakeMetronClient)
+		runner = expiration.NewBurglar(logger, fakeLockDB, fakeLockPick, hub, fakeClock, checkInterval, fakeMetronClient)
 		process = ifrit.Background(runner)
 	})
 
@@ -94,6 +97,109 @@ var _ = Describe("Burglar", func() {
 		Eventually(process.Ready()).Should(BeClosed())
 	})
 
+	Context("when there are shared holders", func() {
+		var sharedLock *db.Lock
+
+		BeforeEach(func() {
+			sharedLock = &db.Lock{
+				Resource: &models.Resource{
+					Key:      "maintenance",
+					Owner:    "reader",
+					Type:     "lock",
+					TypeCode: models.LOCK,
+				},
+				Mode:          models.SHARED,
+				TtlInSeconds:  15,
+				ModifiedIndex: 1,
+			}
+			fakeLockDB.FetchAllSharedHoldersReturns([]*db.Lock{sharedLock}, nil)
+		})
+
+		It("registers them with the lock pick", func() {
+			Eventually(fakeLockPick.RegisterTTLCallCount).Should(Equal(3))
+			_, lock := fakeLockPick.RegisterTTLArgsForCall(2)
+			Expect(lock).To(Equal(sharedLock))
+		})
+
+		It("does not publish them to the watch hub", func() {
+			sub, err := hub.Subscribe(watch.Filter{}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			defer sub.Close()
+
+			Eventually(process.Ready()).Should(BeClosed())
+			fakeClock.Increment(checkInterval)
+
+			Eventually(fakeLockDB.FetchAllSharedHoldersCallCount).Should(Equal(2))
+			Consistently(sub.Events()).ShouldNot(Receive())
+		})
+
+		Context("when they hold a semaphore", func() {
+			var holder *db.Lock
+
+			BeforeEach(func() {
+				holder = &db.Lock{
+					Resource: &models.Resource{
+						Key:      "uploads",
+						Owner:    "cell-1",
+						Type:     models.SemaphoreType,
+						TypeCode: models.SEMAPHORE,
+					},
+					Mode:          models.SHARED,
+					TtlInSeconds:  15,
+					ModifiedIndex: 1,
+				}
+				fakeLockDB.FetchAllSharedHoldersReturns([]*db.Lock{sharedLock, holder}, nil)
+			})
+
+			It("publishes the semaphore holders to the watch hub", func() {
+				sub, err := hub.Subscribe(watch.Filter{TypeCode: models.SEMAPHORE}, 0)
+				Expect(err).NotTo(HaveOccurred())
+				defer sub.Close()
+
+				Eventually(process.Ready()).Should(BeClosed())
+				fakeLockDB.FetchAllSharedHoldersReturns([]*db.Lock{sharedLock}, nil)
+				fakeClock.Increment(checkInterval)
+
+				var event *models.WatchEvent
+				Eventually(sub.Events()).Should(Receive(&event))
+				Expect(event.Type).To(Equal(models.DELETED))
+				Expect(event.Resource.Owner).To(Equal("cell-1"))
+			})
+		})
+	})
+
+	Context("when there are leases", func() {
+		var lease *db.Lease
+
+		BeforeEach(func() {
+			lease = &db.Lease{ID: "lease-guid", TtlInSeconds: 15, ModifiedIndex: 1}
+			fakeLockDB.FetchAllLeasesReturns([]*db.Lease{lease}, nil)
+		})
+
+		It("registers them with the lock pick on an interval", func() {
+			Eventually(fakeLockPick.RegisterLeaseCallCount).Should(Equal(1))
+			_, registeredLease := fakeLockPick.RegisterLeaseArgsForCall(0)
+			Expect(registeredLease).To(Equal(lease))
+
+			Eventually(process.Ready()).Should(BeClosed())
+			fakeClock.Increment(checkInterval)
+
+			Eventually(fakeLockPick.RegisterLeaseCallCount).Should(Equal(2))
+		})
+
+		Context("when fetching the leases fails", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchAllLeasesReturns(nil, errors.New("boom"))
+			})
+
+			It("logs the error and continues", func() {
+				Eventually(process.Ready()).Should(BeClosed())
+				Eventually(logger).Should(gbytes.Say("failed-fetching-leases"))
+				Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(2))
+			})
+		})
+	})
+
 	It("continues to fetch locks and register them on an interval", func() {
 		Eventually(fakeLockDB.FetchAllCallCount).Should(Equal(1))
 		_, _, lockType := fakeLockDB.FetchAllArgsForCall(0)
@@ -113,30 +219,72 @@ var _ = Describe("Burglar", func() {
 		Eventually(fakeLockPick.RegisterTTLCallCount).Should(Equal(initialRegisterTTLCallCount + 2))
 	})
 
-	It("periodically emits a counter metric showing the lock and presence haven't expired", func() {
+	It("reconciles the watch hub with the fetched locks", func() {
+		sub, err := hub.Subscribe(watch.Filter{}, 0)
+		Expect(err).NotTo(HaveOccurred())
+		defer sub.Close()
+
+		Eventually(process.Ready()).Should(BeClosed())
+		Consistently(sub.Events()).ShouldNot(Receive())
+
+		fakeLockDB.FetchAllReturns([]*db.Lock{expectedLock1}, nil)
+		fakeClock.Increment(checkInterval)
+
+		var event *models.WatchEvent
+		Eventually(sub.Events()).Should(Receive(&event))
+		Expect(event.Type).To(Equal(models.DELETED))
+		Expect(event.Resource.Key).To(Equal(expectedLock2.Key))
+	})
+
+	It("periodically emits a counter metric showing the lock, presence and semaphore haven't expired", func() {
 		counter := 0
 		fakeLockPick.ExpirationCountsStub = func() (uint32, uint32) {
 			counter++
 			return uint32(counter), uint32(counter)
 		}
+		fakeLockPick.SemaphoreExpirationCountStub = func() uint32 {
+			return uint32(counter)
+		}
 
 		for i := 0; i < 4; i++ {
 			fakeClock.WaitForNWatchersAndIncrement(60*time.Second, 2)
 
-			Eventually(fakeMetronClient.SendMetricCallCount).Should(BeEquivalentTo(2 * (i + 1)))
-			metric, value, _ := fakeMetronClient.SendMetricArgsForCall(i * 2)
+			Eventually(fakeMetronClient.SendMetricCallCount).Should(BeEquivalentTo(3 * (i + 1)))
+			metric, value, _ := fakeMetronClient.SendMetricArgsForCall(i * 3)
 			Expect(metric).To(BeEquivalentTo("LocksExpired"))
 			Expect(value).To(BeEquivalentTo(i + 1))
 
-			metric, value, _ = fakeMetronClient.SendMetricArgsForCall(i*2 + 1)
+			metric, value, _ = fakeMetronClient.SendMetricArgsForCall(i*3 + 1)
 			Expect(metric).To(BeEquivalentTo("PresenceExpired"))
 			Expect(value).To(BeEquivalentTo(i + 1))
 
+			metric, value, _ = fakeMetronClient.SendMetricArgsForCall(i*3 + 2)
+			Expect(metric).To(BeEquivalentTo("SemaphoresExpired"))
+			Expect(value).To(BeEquivalentTo(i + 1))
+
 			// make sure the other case statement is executed
 			Eventually(fakeLockDB.FetchAllCallCount).Should(Equal(i + 2))
 		}
 	})
 
+	It("periodically emits the expiration counters of the user-defined resource types", func() {
+		fakeLockPick.ResourceTypeExpirationCountsReturns(map[string]uint32{
+			"MigrationsExpired":         2,
+			"MaintenanceWindowsExpired": 3,
+		})
+
+		fakeClock.WaitForNWatchersAndIncrement(60*time.Second, 2)
+
+		Eventually(fakeMetronClient.SendMetricCallCount).Should(Equal(5))
+		metric, value, _ := fakeMetronClient.SendMetricArgsForCall(3)
+		Expect(metric).To(Equal("MaintenanceWindowsExpired"))
+		Expect(value).To(Equal(3))
+
+		metric, value, _ = fakeMetronClient.SendMetricArgsForCall(4)
+		Expect(metric).To(Equal("MigrationsExpired"))
+		Expect(value).To(Equal(2))
+	})
+
 	Context("when fetching the locks fails", func() {
 		BeforeEach(func() {
 			fakeLockDB.FetchAllReturns(nil, errors.New("we got the funk"))
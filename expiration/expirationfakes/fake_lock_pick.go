@@ -4,7 +4,7 @@ package expirationfakes
 import (
 	"sync"
 
-	"code.cloudfoundry.org/lager/v3"
+	lager "code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/locket/db"
 	"code.cloudfoundry.org/locket/expiration"
 )
@@ -22,12 +22,38 @@ type FakeLockPick struct {
 		result1 uint32
 		result2 uint32
 	}
+	RegisterLeaseStub        func(lager.Logger, *db.Lease)
+	registerLeaseMutex       sync.RWMutex
+	registerLeaseArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *db.Lease
+	}
 	RegisterTTLStub        func(lager.Logger, *db.Lock)
 	registerTTLMutex       sync.RWMutex
 	registerTTLArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 *db.Lock
 	}
+	ResourceTypeExpirationCountsStub        func() map[string]uint32
+	resourceTypeExpirationCountsMutex       sync.RWMutex
+	resourceTypeExpirationCountsArgsForCall []struct {
+	}
+	resourceTypeExpirationCountsReturns struct {
+		result1 map[string]uint32
+	}
+	resourceTypeExpirationCountsReturnsOnCall map[int]struct {
+		result1 map[string]uint32
+	}
+	SemaphoreExpirationCountStub        func() uint32
+	semaphoreExpirationCountMutex       sync.RWMutex
+	semaphoreExpirationCountArgsForCall []struct {
+	}
+	semaphoreExpirationCountReturns struct {
+		result1 uint32
+	}
+	semaphoreExpirationCountReturnsOnCall map[int]struct {
+		result1 uint32
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -88,6 +114,39 @@ func (fake *FakeLockPick) ExpirationCountsReturnsOnCall(i int, result1 uint32, r
 	}{result1, result2}
 }
 
+func (fake *FakeLockPick) RegisterLease(arg1 lager.Logger, arg2 *db.Lease) {
+	fake.registerLeaseMutex.Lock()
+	fake.registerLeaseArgsForCall = append(fake.registerLeaseArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *db.Lease
+	}{arg1, arg2})
+	stub := fake.RegisterLeaseStub
+	fake.recordInvocation("RegisterLease", []interface{}{arg1, arg2})
+	fake.registerLeaseMutex.Unlock()
+	if stub != nil {
+		fake.RegisterLeaseStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeLockPick) RegisterLeaseCallCount() int {
+	fake.registerLeaseMutex.RLock()
+	defer fake.registerLeaseMutex.RUnlock()
+	return len(fake.registerLeaseArgsForCall)
+}
+
+func (fake *FakeLockPick) RegisterLeaseCalls(stub func(lager.Logger, *db.Lease)) {
+	fake.registerLeaseMutex.Lock()
+	defer fake.registerLeaseMutex.Unlock()
+	fake.RegisterLeaseStub = stub
+}
+
+func (fake *FakeLockPick) RegisterLeaseArgsForCall(i int) (lager.Logger, *db.Lease) {
+	fake.registerLeaseMutex.RLock()
+	defer fake.registerLeaseMutex.RUnlock()
+	argsForCall := fake.registerLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeLockPick) RegisterTTL(arg1 lager.Logger, arg2 *db.Lock) {
 	fake.registerTTLMutex.Lock()
 	fake.registerTTLArgsForCall = append(fake.registerTTLArgsForCall, struct {
@@ -121,13 +180,115 @@ func (fake *FakeLockPick) RegisterTTLArgsForCall(i int) (lager.Logger, *db.Lock)
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeLockPick) ResourceTypeExpirationCounts() map[string]uint32 {
+	fake.resourceTypeExpirationCountsMutex.Lock()
+	ret, specificReturn := fake.resourceTypeExpirationCountsReturnsOnCall[len(fake.resourceTypeExpirationCountsArgsForCall)]
+	fake.resourceTypeExpirationCountsArgsForCall = append(fake.resourceTypeExpirationCountsArgsForCall, struct {
+	}{})
+	stub := fake.ResourceTypeExpirationCountsStub
+	fakeReturns := fake.resourceTypeExpirationCountsReturns
+	fake.recordInvocation("ResourceTypeExpirationCounts", []interface{}{})
+	fake.resourceTypeExpirationCountsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLockPick) ResourceTypeExpirationCountsCallCount() int {
+	fake.resourceTypeExpirationCountsMutex.RLock()
+	defer fake.resourceTypeExpirationCountsMutex.RUnlock()
+	return len(fake.resourceTypeExpirationCountsArgsForCall)
+}
+
+func (fake *FakeLockPick) ResourceTypeExpirationCountsCalls(stub func() map[string]uint32) {
+	fake.resourceTypeExpirationCountsMutex.Lock()
+	defer fake.resourceTypeExpirationCountsMutex.Unlock()
+	fake.ResourceTypeExpirationCountsStub = stub
+}
+
+func (fake *FakeLockPick) ResourceTypeExpirationCountsReturns(result1 map[string]uint32) {
+	fake.resourceTypeExpirationCountsMutex.Lock()
+	defer fake.resourceTypeExpirationCountsMutex.Unlock()
+	fake.ResourceTypeExpirationCountsStub = nil
+	fake.resourceTypeExpirationCountsReturns = struct {
+		result1 map[string]uint32
+	}{result1}
+}
+
+func (fake *FakeLockPick) ResourceTypeExpirationCountsReturnsOnCall(i int, result1 map[string]uint32) {
+	fake.resourceTypeExpirationCountsMutex.Lock()
+	defer fake.resourceTypeExpirationCountsMutex.Unlock()
+	fake.ResourceTypeExpirationCountsStub = nil
+	if fake.resourceTypeExpirationCountsReturnsOnCall == nil {
+		fake.resourceTypeExpirationCountsReturnsOnCall = make(map[int]struct {
+			result1 map[string]uint32
+		})
+	}
+	fake.resourceTypeExpirationCountsReturnsOnCall[i] = struct {
+		result1 map[string]uint32
+	}{result1}
+}
+
+func (fake *FakeLockPick) SemaphoreExpirationCount() uint32 {
+	fake.semaphoreExpirationCountMutex.Lock()
+	ret, specificReturn := fake.semaphoreExpirationCountReturnsOnCall[len(fake.semaphoreExpirationCountArgsForCall)]
+	fake.semaphoreExpirationCountArgsForCall = append(fake.semaphoreExpirationCountArgsForCall, struct {
+	}{})
+	stub := fake.SemaphoreExpirationCountStub
+	fakeReturns := fake.semaphoreExpirationCountReturns
+	fake.recordInvocation("SemaphoreExpirationCount", []interface{}{})
+	fake.semaphoreExpirationCountMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLockPick) SemaphoreExpirationCountCallCount() int {
+	fake.semaphoreExpirationCountMutex.RLock()
+	defer fake.semaphoreExpirationCountMutex.RUnlock()
+	return len(fake.semaphoreExpirationCountArgsForCall)
+}
+
+func (fake *FakeLockPick) SemaphoreExpirationCountCalls(stub func() uint32) {
+	fake.semaphoreExpirationCountMutex.Lock()
+	defer fake.semaphoreExpirationCountMutex.Unlock()
+	fake.SemaphoreExpirationCountStub = stub
+}
+
+func (fake *FakeLockPick) SemaphoreExpirationCountReturns(result1 uint32) {
+	fake.semaphoreExpirationCountMutex.Lock()
+	defer fake.semaphoreExpirationCountMutex.Unlock()
+	fake.SemaphoreExpirationCountStub = nil
+	fake.semaphoreExpirationCountReturns = struct {
+		result1 uint32
+	}{result1}
+}
+
+func (fake *FakeLockPick) SemaphoreExpirationCountReturnsOnCall(i int, result1 uint32) {
+	fake.semaphoreExpirationCountMutex.Lock()
+	defer fake.semaphoreExpirationCountMutex.Unlock()
+	fake.SemaphoreExpirationCountStub = nil
+	if fake.semaphoreExpirationCountReturnsOnCall == nil {
+		fake.semaphoreExpirationCountReturnsOnCall = make(map[int]struct {
+			result1 uint32
+		})
+	}
+	fake.semaphoreExpirationCountReturnsOnCall[i] = struct {
+		result1 uint32
+	}{result1}
+}
+
 func (fake *FakeLockPick) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.expirationCountsMutex.RLock()
-	defer fake.expirationCountsMutex.RUnlock()
-	fake.registerTTLMutex.RLock()
-	defer fake.registerTTLMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
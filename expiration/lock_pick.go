@@ -11,22 +11,31 @@ import (
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/locket/db"
 	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/watch"
 )
 
 //go:generate counterfeiter . LockPick
 type LockPick interface {
 	RegisterTTL(logger lager.Logger, lock *db.Lock)
+	RegisterLease(logger lager.Logger, lease *db.Lease)
 	ExpirationCounts() (uint32, uint32) // return lock and presence expirations, resp.
+	SemaphoreExpirationCount() uint32
+	ResourceTypeExpirationCounts() map[string]uint32
 }
 
 type lockPick struct {
-	lockDB                db.LockDB
-	clock                 clock.Clock
-	metronClient          loggingclient.IngressClient
-	lockTTLs              map[checkKey]chanAndIndex
-	lockMutex             *sync.Mutex
-	presencesExpiredCount *uint32
-	locksExpiredCount     *uint32
+	lockDB                 db.LockDB
+	hub                    watch.Hub
+	clock                  clock.Clock
+	metronClient           loggingclient.IngressClient
+	lockTTLs               map[checkKey]chanAndIndex
+	leaseTTLs              map[string]chanAndIndex
+	lockMutex              *sync.Mutex
+	presencesExpiredCount  *uint32
+	locksExpiredCount      *uint32
+	semaphoresExpiredCount *uint32
+	resourceTypes          models.ResourceTypes
+	typesExpiredCount      map[string]*uint32
 }
 
 type chanAndIndex struct {
@@ -39,15 +48,27 @@ type checkKey struct {
 	id  string
 }
 
-func NewLockPick(lockDB db.LockDB, clock clock.Clock, metronClient loggingclient.IngressClient) lockPick {
+func NewLockPick(lockDB db.LockDB, hub watch.Hub, clock clock.Clock, metronClient loggingclient.IngressClient, resourceTypes models.ResourceTypes) lockPick {
+	typesExpiredCount := make(map[string]*uint32)
+	for name, resourceType := range resourceTypes {
+		if resourceType.ExpiredMetric != "" {
+			typesExpiredCount[name] = new(uint32)
+		}
+	}
+
 	return lockPick{
-		lockDB:                lockDB,
-		clock:                 clock,
-		metronClient:          metronClient,
-		lockTTLs:              make(map[checkKey]chanAndIndex),
-		lockMutex:             &sync.Mutex{},
-		presencesExpiredCount: new(uint32),
-		locksExpiredCount:     new(uint32),
+		lockDB:                 lockDB,
+		hub:                    hub,
+		clock:                  clock,
+		metronClient:           metronClient,
+		lockTTLs:               make(map[checkKey]chanAndIndex),
+		leaseTTLs:              make(map[string]chanAndIndex),
+		lockMutex:              &sync.Mutex{},
+		presencesExpiredCount:  new(uint32),
+		locksExpiredCount:      new(uint32),
+		semaphoresExpiredCount: new(uint32),
+		resourceTypes:          resourceTypes,
+		typesExpiredCount:      typesExpiredCount,
 	}
 }
 
@@ -55,11 +76,51 @@ func (l lockPick) ExpirationCounts() (uint32, uint32) {
 	return atomic.LoadUint32(l.locksExpiredCount), atomic.LoadUint32(l.presencesExpiredCount)
 }
 
+// SemaphoreExpirationCount returns the expirations of the holders of
+// semaphores, which are not counted with the locks.
+func (l lockPick) SemaphoreExpirationCount() uint32 {
+	return atomic.LoadUint32(l.semaphoresExpiredCount)
+}
+
+// ResourceTypeExpirationCounts returns the expirations of the user-defined
+// resource types that have an expired metric, by metric name.
+func (l lockPick) ResourceTypeExpirationCounts() map[string]uint32 {
+	counts := make(map[string]uint32, len(l.typesExpiredCount))
+	for name, counter := range l.typesExpiredCount {
+		counts[l.resourceTypes[name].ExpiredMetric] = atomic.LoadUint32(counter)
+	}
+	return counts
+}
+
+// countExpiration counts the expiration of the lock under its type.
+// Resources of a user-defined type are only counted if the type has an
+// expired metric.
+func (l lockPick) countExpiration(lock *db.Lock) {
+	counter := l.locksExpiredCount
+	if lock.Type == models.PresenceType {
+		counter = l.presencesExpiredCount
+	} else if lock.Type == models.SemaphoreType {
+		counter = l.semaphoresExpiredCount
+	} else if _, userDefined := l.resourceTypes.Lookup(lock.Type); userDefined {
+		counter = l.typesExpiredCount[lock.Type]
+	}
+
+	if counter != nil {
+		atomic.AddUint32(counter, 1)
+	}
+}
+
 func (l lockPick) RegisterTTL(logger lager.Logger, lock *db.Lock) {
 	logger = logger.Session("register-ttl", lager.Data{"key": lock.Key, "modified-index": lock.ModifiedIndex, "type": lock.Type})
 	logger.Debug("starting")
 	logger.Debug("completed")
 
+	// locks attached to a lease expire with the lease
+	if lock.LeaseId != "" {
+		logger.Debug("attached-to-lease", lager.Data{"lease-id": lock.LeaseId})
+		return
+	}
+
 	newChanIndex := chanAndIndex{
 		channel: make(chan struct{}),
 		index:   lock.ModifiedIndex,
@@ -106,11 +167,74 @@ func (l lockPick) checkExpiration(logger lager.Logger, lock *db.Lock, closeChan
 
 		if expired {
 			logger.Info("lock-expired")
-			counter := l.locksExpiredCount
-			if lock.Type == models.PresenceType {
-				counter = l.presencesExpiredCount
+			if watch.Streamed(lock.Resource, lock.Mode) {
+				l.hub.Remove(logger, lock.Resource, models.EXPIRED)
+			}
+			l.countExpiration(lock)
+		}
+		return
+	}
+}
+
+// RegisterLease tracks a single deadline for all the locks attached to the
+// lease, and releases them together once the lease expires.
+func (l lockPick) RegisterLease(logger lager.Logger, lease *db.Lease) {
+	logger = logger.Session("register-lease", lager.Data{"lease-id": lease.ID, "modified-index": lease.ModifiedIndex})
+	logger.Debug("starting")
+	logger.Debug("completed")
+
+	newChanIndex := chanAndIndex{
+		channel: make(chan struct{}),
+		index:   lease.ModifiedIndex,
+	}
+	l.lockMutex.Lock()
+	defer l.lockMutex.Unlock()
+
+	channelIndex, ok := l.leaseTTLs[lease.ID]
+	if ok && channelIndex.index >= newChanIndex.index {
+		logger.Debug("found-expiration-goroutine-for-index", lager.Data{"index": channelIndex.index})
+		return
+	}
+
+	if ok && channelIndex.index < newChanIndex.index {
+		close(channelIndex.channel)
+	}
+
+	l.leaseTTLs[lease.ID] = newChanIndex
+	go l.checkLeaseExpiration(logger, lease, newChanIndex.channel)
+}
+
+func (l lockPick) checkLeaseExpiration(logger lager.Logger, lease *db.Lease, closeChan chan struct{}) {
+	leaseTimer := l.clock.NewTimer(time.Duration(lease.TtlInSeconds) * time.Second)
+
+	select {
+	case <-closeChan:
+		logger.Debug("cancelling-old-check-goroutine")
+		return
+	case <-leaseTimer.C():
+		defer func() {
+			l.lockMutex.Lock()
+			chanIndex := l.leaseTTLs[lease.ID]
+			if chanIndex.index == lease.ModifiedIndex {
+				delete(l.leaseTTLs, lease.ID)
+			}
+			l.lockMutex.Unlock()
+		}()
+
+		locks, expired, err := l.lockDB.ExpireLease(context.Background(), logger, lease)
+		if err != nil {
+			logger.Error("failed-compare-and-expire", err)
+			return
+		}
+
+		if expired {
+			logger.Info("lease-expired", lager.Data{"released-locks": len(locks)})
+			for _, lock := range locks {
+				if watch.Streamed(lock.Resource, lock.Mode) {
+					l.hub.Remove(logger, lock.Resource, models.EXPIRED)
+				}
+				l.countExpiration(lock)
 			}
-			atomic.AddUint32(counter, 1)
 		}
 		return
 	}
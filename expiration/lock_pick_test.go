@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/locket/db/dbfakes"
 	"code.cloudfoundry.org/locket/expiration"
 	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/watch/watchfakes"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -26,6 +27,7 @@ var _ = Describe("LockPick", func() {
 
 		logger           *lagertest.TestLogger
 		fakeLockDB       *dbfakes.FakeLockDB
+		fakeHub          *watchfakes.FakeHub
 		fakeClock        *fakeclock.FakeClock
 		fakeMetronClient *mfakes.FakeIngressClient
 
@@ -64,9 +66,16 @@ var _ = Describe("LockPick", func() {
 		fakeClock = fakeclock.NewFakeClock(time.Now())
 		logger = lagertest.NewTestLogger("lock-pick")
 		fakeLockDB = &dbfakes.FakeLockDB{}
+		fakeHub = &watchfakes.FakeHub{}
 		fakeMetronClient = new(mfakes.FakeIngressClient)
 
-		lockPick = expiration.NewLockPick(fakeLockDB, fakeClock, fakeMetronClient)
+		resourceTypes, err := models.NewResourceTypes([]models.ResourceType{
+			{Name: "maintenance-window", ExpiredMetric: "MaintenanceWindowsExpired"},
+			{Name: "migration"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		lockPick = expiration.NewLockPick(fakeLockDB, fakeHub, fakeClock, fakeMetronClient, resourceTypes)
 	})
 
 	Context("RegisterTTL", func() {
@@ -104,6 +113,104 @@ var _ = Describe("LockPick", func() {
 			}).Should(BeEquivalentTo(1))
 		})
 
+		Context("when the lock is of a user-defined type", func() {
+			It("increments the count for the expiration of the type", func() {
+				lock.Type = "maintenance-window"
+				lockPick.RegisterTTL(logger, lock)
+				fakeClock.WaitForNWatchersAndIncrement(ttl, 1)
+
+				Eventually(lockPick.ResourceTypeExpirationCounts).Should(Equal(map[string]uint32{"MaintenanceWindowsExpired": 1}))
+				locksExpired, _ := lockPick.ExpirationCounts()
+				Expect(locksExpired).To(BeZero())
+			})
+
+			It("does not count the expiration when the type has no expired metric", func() {
+				lock.Type = "migration"
+				lockPick.RegisterTTL(logger, lock)
+				fakeClock.WaitForNWatchersAndIncrement(ttl, 1)
+
+				Eventually(fakeLockDB.FetchAndReleaseCallCount).Should(Equal(1))
+				Consistently(func() uint32 {
+					locksExpired, _ := lockPick.ExpirationCounts()
+					return locksExpired
+				}).Should(BeZero())
+				Expect(lockPick.ResourceTypeExpirationCounts()).To(Equal(map[string]uint32{"MaintenanceWindowsExpired": 0}))
+			})
+		})
+
+		It("publishes an expired event to the watch hub", func() {
+			lockPick.RegisterTTL(logger, lock)
+			fakeClock.WaitForWatcherAndIncrement(ttl)
+
+			Eventually(fakeHub.RemoveCallCount).Should(Equal(1))
+			_, resource, eventType := fakeHub.RemoveArgsForCall(0)
+			Expect(resource).To(Equal(lock.Resource))
+			Expect(eventType).To(Equal(models.EXPIRED))
+		})
+
+		Context("when the lock is held in shared mode", func() {
+			BeforeEach(func() {
+				lock.Mode = models.SHARED
+			})
+
+			It("releases the shared holder without publishing an event", func() {
+				lockPick.RegisterTTL(logger, lock)
+				fakeClock.WaitForWatcherAndIncrement(ttl)
+
+				Eventually(fakeLockDB.FetchAndReleaseCallCount).Should(Equal(1))
+				_, _, oldLock := fakeLockDB.FetchAndReleaseArgsForCall(0)
+				Expect(oldLock.Mode).To(Equal(models.SHARED))
+
+				Eventually(func() uint32 {
+					locksExpired, _ := lockPick.ExpirationCounts()
+					return locksExpired
+				}).Should(BeEquivalentTo(1))
+				Expect(fakeHub.RemoveCallCount()).To(Equal(0))
+			})
+
+			Context("when the lock is a semaphore", func() {
+				BeforeEach(func() {
+					lock.Type = models.SemaphoreType
+					lock.TypeCode = models.SEMAPHORE
+				})
+
+				It("increments the count for semaphore expiration instead of lock expiration", func() {
+					lockPick.RegisterTTL(logger, lock)
+					fakeClock.WaitForWatcherAndIncrement(ttl)
+
+					Eventually(lockPick.SemaphoreExpirationCount).Should(BeEquivalentTo(1))
+					locksExpired, _ := lockPick.ExpirationCounts()
+					Expect(locksExpired).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the lock is attached to a lease", func() {
+			BeforeEach(func() {
+				lock.LeaseId = "lease-guid"
+			})
+
+			It("does not check that the lock expires", func() {
+				lockPick.RegisterTTL(logger, lock)
+
+				Consistently(fakeClock.WatcherCount).Should(Equal(0))
+			})
+		})
+
+		Context("when the lock was already released", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchAndReleaseReturns(false, nil)
+			})
+
+			It("does not publish an expired event", func() {
+				lockPick.RegisterTTL(logger, lock)
+				fakeClock.WaitForWatcherAndIncrement(ttl)
+
+				Eventually(fakeLockDB.FetchAndReleaseCallCount).Should(Equal(1))
+				Consistently(fakeHub.RemoveCallCount).Should(Equal(0))
+			})
+		})
+
 		It("logs the type of the lock", func() {
 			lockPick.RegisterTTL(logger, lock)
 			Eventually(logger.Buffer()).Should(gbytes.Say("\"type\":\"lock\""))
@@ -332,4 +439,109 @@ var _ = Describe("LockPick", func() {
 			})
 		})
 	})
+
+	Context("RegisterLease", func() {
+		var lease *db.Lease
+
+		BeforeEach(func() {
+			lease = &db.Lease{ID: "lease-guid", TtlInSeconds: 25, ModifiedIndex: 3}
+			lock.LeaseId = lease.ID
+			presence.LeaseId = lease.ID
+			fakeLockDB.ExpireLeaseReturns([]*db.Lock{lock, presence}, true, nil)
+		})
+
+		It("expires the lease after its ttl", func() {
+			lockPick.RegisterLease(logger, lease)
+
+			fakeClock.WaitForWatcherAndIncrement(ttl)
+
+			Eventually(fakeLockDB.ExpireLeaseCallCount).Should(Equal(1))
+			_, _, expiredLease := fakeLockDB.ExpireLeaseArgsForCall(0)
+			Expect(expiredLease).To(Equal(lease))
+		})
+
+		It("publishes an expired event for every released lock", func() {
+			lockPick.RegisterLease(logger, lease)
+			fakeClock.WaitForWatcherAndIncrement(ttl)
+
+			Eventually(fakeHub.RemoveCallCount).Should(Equal(2))
+			_, resource, eventType := fakeHub.RemoveArgsForCall(0)
+			Expect(resource).To(Equal(lock.Resource))
+			Expect(eventType).To(Equal(models.EXPIRED))
+			_, resource, eventType = fakeHub.RemoveArgsForCall(1)
+			Expect(resource).To(Equal(presence.Resource))
+			Expect(eventType).To(Equal(models.EXPIRED))
+		})
+
+		It("increments the expiration counts", func() {
+			lockPick.RegisterLease(logger, lease)
+			fakeClock.WaitForWatcherAndIncrement(ttl)
+
+			Eventually(func() []uint32 {
+				locksExpired, presencesExpired := lockPick.ExpirationCounts()
+				return []uint32{locksExpired, presencesExpired}
+			}).Should(Equal([]uint32{1, 1}))
+		})
+
+		Context("when the lease was kept alive", func() {
+			var keptAliveLease db.Lease
+
+			BeforeEach(func() {
+				lockPick.RegisterLease(logger, lease)
+				Eventually(fakeClock.WatcherCount).Should(Equal(1))
+
+				keptAliveLease = *lease
+				keptAliveLease.ModifiedIndex++
+			})
+
+			It("cancels the check of the previous index", func() {
+				lockPick.RegisterLease(logger, &keptAliveLease)
+
+				Eventually(fakeClock.WatcherCount).Should(Equal(2))
+				fakeClock.WaitForWatcherAndIncrement(ttl)
+
+				Eventually(logger).Should(gbytes.Say("cancelling-old-check"))
+
+				Eventually(fakeLockDB.ExpireLeaseCallCount).Should(Equal(1))
+				Consistently(fakeLockDB.ExpireLeaseCallCount).Should(Equal(1))
+				_, _, expiredLease := fakeLockDB.ExpireLeaseArgsForCall(0)
+				Expect(expiredLease).To(Equal(&keptAliveLease))
+			})
+
+			It("ignores registrations of the previous index", func() {
+				lockPick.RegisterLease(logger, &keptAliveLease)
+				lockPick.RegisterLease(logger, lease)
+
+				Eventually(fakeClock.WatcherCount).Should(Equal(2))
+				Consistently(fakeClock.WatcherCount).Should(Equal(2))
+			})
+		})
+
+		Context("when the lease was already released", func() {
+			BeforeEach(func() {
+				fakeLockDB.ExpireLeaseReturns(nil, false, nil)
+			})
+
+			It("does not publish an expired event", func() {
+				lockPick.RegisterLease(logger, lease)
+				fakeClock.WaitForWatcherAndIncrement(ttl)
+
+				Eventually(fakeLockDB.ExpireLeaseCallCount).Should(Equal(1))
+				Consistently(fakeHub.RemoveCallCount).Should(Equal(0))
+			})
+		})
+
+		Context("when expiring the lease fails", func() {
+			BeforeEach(func() {
+				fakeLockDB.ExpireLeaseReturns(nil, false, errors.New("boom"))
+			})
+
+			It("logs the error", func() {
+				lockPick.RegisterLease(logger, lease)
+				fakeClock.WaitForWatcherAndIncrement(ttl)
+
+				Eventually(logger.Buffer()).Should(gbytes.Say("failed-compare-and-expire"))
+			})
+		})
+	})
 })
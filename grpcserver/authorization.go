@@ -0,0 +1,291 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Operations are the names of the RPCs of the Locket service, as they are
+// given in authorization rules.
+var Operations = []string{
+	"Lock", "LockBatch", "LockMulti", "Release", "Update", "Transfer", "ForceRelease",
+	"Fetch", "FetchAll", "Watch", "GrantLease", "KeepAliveLease", "RevokeLease", "Session",
+}
+
+// AuthorizationRule grants the clients presenting a certificate with one of
+// the identities, either the common name or one of the DNS or URI subject
+// alternative names, the operations on the keys starting with one of the key
+// prefixes. No operations means all the operations but ForceRelease, which
+// has to be granted explicitly, and no key prefixes means all the keys.
+type AuthorizationRule struct {
+	Identities  []string `json:"identities"`
+	Operations  []string `json:"operations,omitempty"`
+	KeyPrefixes []string `json:"key_prefixes,omitempty"`
+}
+
+// Authorizer checks the requests of clients against authorization rules.
+// Requests not granted by any rule are denied.
+type Authorizer struct {
+	rules []AuthorizationRule
+}
+
+func NewAuthorizer(rules []AuthorizationRule) (*Authorizer, error) {
+	for i, rule := range rules {
+		if len(rule.Identities) == 0 {
+			return nil, fmt.Errorf("authorization rule %d has no identities", i)
+		}
+		for _, operation := range rule.Operations {
+			if !isOperation(operation) {
+				return nil, fmt.Errorf("authorization rule %d has unknown operation %q", i, operation)
+			}
+		}
+	}
+	return &Authorizer{rules: rules}, nil
+}
+
+// locketOperation returns the operation of a method of the Locket service.
+// Methods of other services, such as the health service, have none.
+func locketOperation(fullMethod string) (string, bool) {
+	prefix := "/" + LocketServiceName + "/"
+	if !strings.HasPrefix(fullMethod, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(fullMethod, prefix), true
+}
+
+func isOperation(name string) bool {
+	for _, operation := range Operations {
+		if operation == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize returns whether a client with the identities is allowed the
+// operation on all the keys. Keys of a namespace are qualified with it, see
+// models.NamespacedKey, so that a key prefix can grant a whole namespace.
+// Requests on key prefixes, such as FetchAll and Watch, are authorized as if
+// the prefix was a key.
+func (a *Authorizer) Authorize(identities []string, operation string, keys []string) bool {
+	var granted []AuthorizationRule
+	for _, rule := range a.rules {
+		if containsAny(rule.Identities, identities) && ruleGrants(rule, operation) {
+			granted = append(granted, rule)
+		}
+	}
+
+	if len(granted) == 0 {
+		return false
+	}
+
+	for _, key := range keys {
+		if !keyGranted(granted, key) {
+			return false
+		}
+	}
+	return true
+}
+
+func ruleGrants(rule AuthorizationRule, operation string) bool {
+	if len(rule.Operations) == 0 {
+		return operation != "ForceRelease"
+	}
+	return containsAny(rule.Operations, []string{operation})
+}
+
+func keyGranted(rules []AuthorizationRule, key string) bool {
+	for _, rule := range rules {
+		if len(rule.KeyPrefixes) == 0 {
+			return true
+		}
+		for _, prefix := range rule.KeyPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, value := range values {
+		for _, candidate := range candidates {
+			if value == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type authorizedKey struct{}
+
+// Authorized returns whether the unary request of ctx was granted by an
+// authorization rule. Requests of servers without authorization rules are
+// not, which handlers of privileged operations, such as ForceRelease, rely on
+// to deny them.
+func Authorized(ctx context.Context) bool {
+	authorized, _ := ctx.Value(authorizedKey{}).(bool)
+	return authorized
+}
+
+// UnaryServerInterceptor denies the unary requests that are not authorized.
+func (a *Authorizer) UnaryServerInterceptor(logger lager.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		operation, ok := locketOperation(info.FullMethod)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		err := a.authorize(logger, ctx, operation, req)
+		if err != nil {
+			return nil, err
+		}
+		return handler(context.WithValue(ctx, authorizedKey{}, true), req)
+	}
+}
+
+// StreamServerInterceptor denies the streams that are not authorized, and the
+// requests received on them that are not authorized, e.g. the locks and
+// releases of a session. A request that is denied ends the stream.
+func (a *Authorizer) StreamServerInterceptor(logger lager.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		operation, ok := locketOperation(info.FullMethod)
+		if !ok {
+			return handler(srv, stream)
+		}
+
+		err := a.authorize(logger, stream.Context(), operation, nil)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{ServerStream: stream, authorizer: a, logger: logger, operation: operation})
+	}
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	authorizer *Authorizer
+	logger     lager.Logger
+	operation  string
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+
+	operation := s.operation
+	if req, ok := m.(*models.SessionRequest); ok {
+		switch {
+		case req.Lock != nil:
+			operation = "Lock"
+		case req.Release != nil:
+			operation = "Release"
+		default:
+			// keep alives of the session were authorized with the session
+			return nil
+		}
+	}
+
+	return s.authorizer.authorize(s.logger, s.Context(), operation, m)
+}
+
+func (a *Authorizer) authorize(logger lager.Logger, ctx context.Context, operation string, req interface{}) error {
+	identities := ClientIdentities(ctx)
+	keys := requestKeys(req)
+	if a.Authorize(identities, operation, keys) {
+		return nil
+	}
+
+	logger.Error("unauthorized", models.ErrPermissionDenied, lager.Data{
+		"identities": identities,
+		"operation":  operation,
+		"keys":       keys,
+	})
+	return models.ErrPermissionDenied
+}
+
+// ClientIdentities returns the common name and the DNS and URI subject
+// alternative names of the certificate the client authenticated with.
+func ClientIdentities(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	var identities []string
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	identities = append(identities, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	return identities
+}
+
+// requestKeys returns the keys a request operates on, qualified with their
+// namespace.
+func requestKeys(req interface{}) []string {
+	resourceKey := func(resource *models.Resource) string {
+		return models.NamespacedKey(resource.GetNamespace(), resource.GetKey())
+	}
+
+	switch req := req.(type) {
+	case *models.LockRequest:
+		return []string{resourceKey(req.GetResource())}
+	case *models.LockBatchRequest:
+		var keys []string
+		for _, lockReq := range req.Requests {
+			keys = append(keys, resourceKey(lockReq.GetResource()))
+		}
+		return keys
+	case *models.LockMultiRequest:
+		var keys []string
+		for _, lockReq := range req.Requests {
+			keys = append(keys, resourceKey(lockReq.GetResource()))
+		}
+		return keys
+	case *models.ReleaseRequest:
+		return []string{resourceKey(req.GetResource())}
+	case *models.UpdateRequest:
+		return []string{resourceKey(req.GetResource())}
+	case *models.TransferRequest:
+		return []string{resourceKey(req.GetResource())}
+	case *models.ForceReleaseRequest:
+		return []string{models.NamespacedKey(req.Namespace, req.Key)}
+	case *models.FetchRequest:
+		return []string{models.NamespacedKey(req.Namespace, req.Key)}
+	case *models.FetchAllRequest:
+		return []string{models.NamespacedKey(req.Namespace, req.KeyPrefix)}
+	case *models.WatchRequest:
+		if req.Key != "" {
+			return []string{models.NamespacedKey(req.Namespace, req.Key)}
+		}
+		return []string{models.NamespacedKey(req.Namespace, req.KeyPrefix)}
+	case *models.SessionRequest:
+		if req.Lock != nil {
+			return []string{resourceKey(req.Lock.GetResource())}
+		}
+		if req.Release != nil {
+			return []string{resourceKey(req.Release.GetResource())}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package grpcserver_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"net/url"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"code.cloudfoundry.org/locket/grpcserver"
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+var _ = Describe("Authorizer", func() {
+	var (
+		logger     *lagertest.TestLogger
+		authorizer *grpcserver.Authorizer
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("authorizer")
+
+		var err error
+		authorizer, err = grpcserver.NewAuthorizer([]grpcserver.AuthorizationRule{
+			{Identities: []string{"bbs"}, KeyPrefixes: []string{"bbs", "namespaces/diego/"}},
+			{Identities: []string{"rep", "spiffe://cf/rep"}, Operations: []string{"Lock", "Release", "Session"}, KeyPrefixes: []string{"cells/"}},
+			{Identities: []string{"cfdot"}, Operations: []string{"Fetch", "FetchAll"}},
+			{Identities: []string{"locket-admin"}, Operations: []string{"ForceRelease"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("NewAuthorizer", func() {
+		It("rejects rules without identities", func() {
+			_, err := grpcserver.NewAuthorizer([]grpcserver.AuthorizationRule{{Operations: []string{"Lock"}}})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects unknown operations", func() {
+			_, err := grpcserver.NewAuthorizer([]grpcserver.AuthorizationRule{{Identities: []string{"bbs"}, Operations: []string{"Steal"}}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Authorize", func() {
+		It("allows the operations of the rules of the identity on their key prefixes", func() {
+			Expect(authorizer.Authorize([]string{"rep"}, "Lock", []string{"cells/cell-1"})).To(BeTrue())
+			Expect(authorizer.Authorize([]string{"rep"}, "Lock", []string{"bbs"})).To(BeFalse())
+			Expect(authorizer.Authorize([]string{"rep"}, "ForceRelease", []string{"cells/cell-1"})).To(BeFalse())
+		})
+
+		It("allows all the operations when the rule has none", func() {
+			Expect(authorizer.Authorize([]string{"bbs"}, "Transfer", []string{"bbs"})).To(BeTrue())
+		})
+
+		It("only allows ForceRelease to the rules listing it", func() {
+			Expect(authorizer.Authorize([]string{"bbs"}, "ForceRelease", []string{"bbs"})).To(BeFalse())
+			Expect(authorizer.Authorize([]string{"locket-admin"}, "ForceRelease", []string{"bbs"})).To(BeTrue())
+		})
+
+		It("allows all the keys when the rule has no key prefixes", func() {
+			Expect(authorizer.Authorize([]string{"cfdot"}, "FetchAll", []string{""})).To(BeTrue())
+			Expect(authorizer.Authorize([]string{"cfdot"}, "Lock", []string{"bbs"})).To(BeFalse())
+		})
+
+		It("requires all the keys to be allowed", func() {
+			Expect(authorizer.Authorize([]string{"bbs"}, "LockBatch", []string{"bbs", "namespaces/diego/lrp"})).To(BeTrue())
+			Expect(authorizer.Authorize([]string{"bbs"}, "LockBatch", []string{"bbs", "cells/cell-1"})).To(BeFalse())
+		})
+
+		It("denies identities without rules", func() {
+			Expect(authorizer.Authorize([]string{"routing-api"}, "Fetch", []string{"bbs"})).To(BeFalse())
+			Expect(authorizer.Authorize(nil, "GrantLease", nil)).To(BeFalse())
+		})
+	})
+
+	Describe("UnaryServerInterceptor", func() {
+		var (
+			ctx      context.Context
+			called   bool
+			handler  grpc.UnaryHandler
+			lockInfo *grpc.UnaryServerInfo
+		)
+
+		BeforeEach(func() {
+			ctx = contextWithClientCert(&x509.Certificate{
+				Subject: pkix.Name{CommonName: "cell-1"},
+				URIs:    []*url.URL{{Scheme: "spiffe", Host: "cf", Path: "/rep"}},
+			})
+			called = false
+			handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return &models.LockResponse{}, nil
+			}
+			lockInfo = &grpc.UnaryServerInfo{FullMethod: "/models.Locket/Lock"}
+		})
+
+		It("calls the handler when the request is authorized", func() {
+			req := &models.LockRequest{Resource: &models.Resource{Key: "cells/cell-1"}}
+			_, err := authorizer.UnaryServerInterceptor(logger)(ctx, req, lockInfo, handler)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(called).To(BeTrue())
+		})
+
+		It("marks the context of the authorized requests", func() {
+			Expect(grpcserver.Authorized(ctx)).To(BeFalse())
+
+			var authorized bool
+			handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+				authorized = grpcserver.Authorized(ctx)
+				return &models.LockResponse{}, nil
+			}
+			req := &models.LockRequest{Resource: &models.Resource{Key: "cells/cell-1"}}
+			_, err := authorizer.UnaryServerInterceptor(logger)(ctx, req, lockInfo, handler)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorized).To(BeTrue())
+		})
+
+		It("denies the request when it is not authorized", func() {
+			req := &models.LockRequest{Resource: &models.Resource{Key: "bbs"}}
+			_, err := authorizer.UnaryServerInterceptor(logger)(ctx, req, lockInfo, handler)
+			Expect(err).To(Equal(models.ErrPermissionDenied))
+			Expect(called).To(BeFalse())
+			Expect(logger).To(gbytes.Say("unauthorized"))
+		})
+
+		It("authorizes keys qualified with their namespace", func() {
+			req := &models.FetchRequest{Namespace: "diego", Key: "lrp"}
+			bbsCtx := contextWithClientCert(&x509.Certificate{DNSNames: []string{"bbs"}})
+			_, err := authorizer.UnaryServerInterceptor(logger)(bbsCtx, req, &grpc.UnaryServerInfo{FullMethod: "/models.Locket/Fetch"}, handler)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("denies clients that did not authenticate with a certificate", func() {
+			req := &models.LockRequest{Resource: &models.Resource{Key: "cells/cell-1"}}
+			_, err := authorizer.UnaryServerInterceptor(logger)(context.Background(), req, lockInfo, handler)
+			Expect(err).To(Equal(models.ErrPermissionDenied))
+		})
+	})
+
+	Describe("StreamServerInterceptor", func() {
+		var (
+			stream   *fakeServerStream
+			received []interface{}
+			handler  grpc.StreamHandler
+		)
+
+		BeforeEach(func() {
+			received = nil
+			handler = func(srv interface{}, stream grpc.ServerStream) error {
+				for {
+					req := &models.SessionRequest{}
+					err := stream.RecvMsg(req)
+					if err != nil {
+						return err
+					}
+					received = append(received, req)
+				}
+			}
+		})
+
+		It("authorizes each request received on the stream", func() {
+			stream = &fakeServerStream{
+				ctx: contextWithClientCert(&x509.Certificate{Subject: pkix.Name{CommonName: "rep"}}),
+				requests: []*models.SessionRequest{
+					{TtlInSeconds: 15},
+					{Lock: &models.LockRequest{Resource: &models.Resource{Key: "cells/cell-1"}}},
+					{},
+					{Lock: &models.LockRequest{Resource: &models.Resource{Key: "bbs"}}},
+				},
+			}
+
+			err := authorizer.StreamServerInterceptor(logger)(nil, stream, &grpc.StreamServerInfo{FullMethod: "/models.Locket/Session"}, handler)
+			Expect(err).To(Equal(models.ErrPermissionDenied))
+			Expect(received).To(HaveLen(3))
+		})
+
+		It("denies the stream when the operation is not authorized", func() {
+			stream = &fakeServerStream{
+				ctx:      contextWithClientCert(&x509.Certificate{Subject: pkix.Name{CommonName: "cfdot"}}),
+				requests: []*models.SessionRequest{{TtlInSeconds: 15}},
+			}
+
+			err := authorizer.StreamServerInterceptor(logger)(nil, stream, &grpc.StreamServerInfo{FullMethod: "/models.Locket/Session"}, handler)
+			Expect(err).To(Equal(models.ErrPermissionDenied))
+			Expect(received).To(BeEmpty())
+		})
+	})
+})
+
+func contextWithClientCert(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{cert}},
+			},
+		},
+	})
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*models.SessionRequest
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	if len(s.requests) == 0 {
+		return io.EOF
+	}
+	*m.(*models.SessionRequest) = *s.requests[0]
+	s.requests = s.requests[1:]
+	return nil
+}
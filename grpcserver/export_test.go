@@ -0,0 +1,8 @@
+package grpcserver
+
+// BucketCount returns the number of token buckets the limiter keeps.
+func (l *RateLimiter) BucketCount() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return len(l.buckets)
+}
@@ -0,0 +1,294 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/tracing"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Clients of the HTTP gateway that are slow to send the headers of a request,
+// or that keep a connection idle between requests, are disconnected so that
+// they cannot hold on to connections indefinitely.
+const (
+	GatewayReadHeaderTimeout = 10 * time.Second
+	GatewayIdleTimeout       = 2 * time.Minute
+)
+
+type httpGatewayRunner struct {
+	listenAddress string
+	handler       models.LocketServer
+	logger        lager.Logger
+	tlsConfig     *tls.Config
+	interceptors  []Interceptor
+}
+
+// NewHTTPGateway returns a runner serving Lock, Release, Fetch and FetchAll of
+// the handler over HTTP/JSON, for clients that cannot use gRPC. Clients
+// authenticate with the TLS config, and their requests go through the unary
+// interceptors as they do on the gRPC server.
+func NewHTTPGateway(logger lager.Logger, listenAddress string, tlsConfig *tls.Config, handler models.LocketServer, interceptors []Interceptor) httpGatewayRunner {
+	return httpGatewayRunner{
+		listenAddress: listenAddress,
+		handler:       handler,
+		logger:        logger,
+		tlsConfig:     tlsConfig,
+		interceptors:  interceptors,
+	}
+}
+
+func (g httpGatewayRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	logger := g.logger.Session("http-gateway")
+
+	logger.Info("started")
+	defer logger.Info("complete")
+
+	lis, err := tls.Listen("tcp", g.listenAddress, g.tlsConfig)
+	if err != nil {
+		logger.Error("failed-to-listen", err)
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           NewHTTPGatewayHandler(logger, g.handler, g.interceptors),
+		ReadHeaderTimeout: GatewayReadHeaderTimeout,
+		IdleTimeout:       GatewayIdleTimeout,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(lis)
+	}()
+
+	close(ready)
+
+	select {
+	case sig := <-signals:
+		logger.Info("signalled", lager.Data{"signal": sig})
+	case err = <-errCh:
+		logger.Error("failed-to-serve", err)
+	}
+
+	server.Shutdown(context.Background())
+	return err
+}
+
+// NewHTTPGatewayHandler returns the routes of the HTTP gateway:
+//
+//	POST   /v1/locks         Lock, with a LockRequest body
+//	DELETE /v1/locks         Release, with a ReleaseRequest body
+//	GET    /v1/locks         FetchAll, with the FetchAllRequest fields as query parameters
+//	GET    /v1/locks/{key}   Fetch, with an optional namespace query parameter
+//
+// Bodies are the JSON encoding of the messages, with the field names of the
+// proto file. Errors are answered with the HTTP status matching their gRPC
+// code and a body of the form {"code": "NotFound", "error": "resource-not-found"}.
+func NewHTTPGatewayHandler(logger lager.Logger, handler models.LocketServer, interceptors []Interceptor) http.Handler {
+	g := &httpGateway{
+		logger:       logger,
+		handler:      handler,
+		interceptors: unaryInterceptors(logger, interceptors),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/locks", g.lock)
+	mux.HandleFunc("DELETE /v1/locks", g.release)
+	mux.HandleFunc("GET /v1/locks", g.fetchAll)
+	mux.HandleFunc("GET /v1/locks/{key...}", g.fetch)
+	return mux
+}
+
+type httpGateway struct {
+	logger       lager.Logger
+	handler      models.LocketServer
+	interceptors []grpc.UnaryServerInterceptor
+}
+
+var errInvalidRequestBody = status.Errorf(codes.InvalidArgument, "invalid-request-body")
+var errInvalidQuery = status.Errorf(codes.InvalidArgument, "invalid-query")
+
+func (g *httpGateway) lock(w http.ResponseWriter, r *http.Request) {
+	req := &models.LockRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		g.writeError(w, "Lock", errInvalidRequestBody)
+		return
+	}
+
+	g.serve(w, r, "Lock", req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.handler.Lock(ctx, req.(*models.LockRequest))
+	})
+}
+
+func (g *httpGateway) release(w http.ResponseWriter, r *http.Request) {
+	req := &models.ReleaseRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		g.writeError(w, "Release", errInvalidRequestBody)
+		return
+	}
+
+	g.serve(w, r, "Release", req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.handler.Release(ctx, req.(*models.ReleaseRequest))
+	})
+}
+
+func (g *httpGateway) fetch(w http.ResponseWriter, r *http.Request) {
+	req := &models.FetchRequest{
+		Key:       r.PathValue("key"),
+		Namespace: r.URL.Query().Get("namespace"),
+	}
+
+	g.serve(w, r, "Fetch", req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.handler.Fetch(ctx, req.(*models.FetchRequest))
+	})
+}
+
+// fetchAll takes the label selector as label_selector query parameters of the
+// form key=value, which all have to match.
+func (g *httpGateway) fetchAll(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &models.FetchAllRequest{
+		Type:              query.Get("type"),
+		KeyPrefix:         query.Get("key_prefix"),
+		ContinuationToken: query.Get("continuation_token"),
+		Namespace:         query.Get("namespace"),
+	}
+
+	if typeCode := query.Get("type_code"); typeCode != "" {
+		code, ok := models.TypeCode_value[typeCode]
+		if !ok {
+			g.writeError(w, "FetchAll", errInvalidQuery)
+			return
+		}
+		req.TypeCode = models.TypeCode(code)
+	}
+
+	if pageSize := query.Get("page_size"); pageSize != "" {
+		size, err := strconv.ParseInt(pageSize, 10, 32)
+		if err != nil {
+			g.writeError(w, "FetchAll", errInvalidQuery)
+			return
+		}
+		req.PageSize = int32(size)
+	}
+
+	for _, selector := range query["label_selector"] {
+		key, value, ok := strings.Cut(selector, "=")
+		if !ok {
+			g.writeError(w, "FetchAll", errInvalidQuery)
+			return
+		}
+		if req.LabelSelector == nil {
+			req.LabelSelector = map[string]string{}
+		}
+		req.LabelSelector[key] = value
+	}
+
+	g.serve(w, r, "FetchAll", req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return g.handler.FetchAll(ctx, req.(*models.FetchAllRequest))
+	})
+}
+
+// serve calls the handler through the interceptors, as the gRPC server would
+// for the operation, and writes its response.
+func (g *httpGateway) serve(w http.ResponseWriter, r *http.Request, operation string, req interface{}, call grpc.UnaryHandler) {
+	info := &grpc.UnaryServerInfo{
+		Server:     g.handler,
+		FullMethod: "/" + LocketServiceName + "/" + operation,
+	}
+
+	handler := call
+	for i := len(g.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := g.interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+
+	// the trace context of the client comes in the headers of the request
+	ctx := tracing.Propagator.Extract(peerContext(r), propagation.HeaderCarrier(r.Header))
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		g.writeError(w, operation, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		g.logger.Error("failed-to-write-response", err, lager.Data{"operation": operation})
+	}
+}
+
+func (g *httpGateway) writeError(w http.ResponseWriter, operation string, err error) {
+	st := status.Convert(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	err = json.NewEncoder(w).Encode(map[string]string{
+		"code":  st.Code().String(),
+		"error": st.Message(),
+	})
+	if err != nil {
+		g.logger.Error("failed-to-write-response", err, lager.Data{"operation": operation})
+	}
+}
+
+// peerContext returns the context of the request with the client as the peer,
+// so that the client certificate is found as it is for gRPC requests.
+func peerContext(r *http.Request) context.Context {
+	p := &peer.Peer{}
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		p.Addr = addr
+	}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+	return peer.NewContext(r.Context(), p)
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,178 @@
+package grpcserver_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"code.cloudfoundry.org/locket/grpcserver"
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTPGateway", func() {
+	var (
+		logger       *lagertest.TestLogger
+		handler      *gatewayTestHandler
+		interceptors []grpcserver.Interceptor
+		gateway      http.Handler
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("http-gateway")
+		handler = &gatewayTestHandler{}
+		interceptors = nil
+	})
+
+	JustBeforeEach(func() {
+		gateway = grpcserver.NewHTTPGatewayHandler(logger, handler, interceptors)
+	})
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "cfdot"}}}},
+		}
+		recorder := httptest.NewRecorder()
+		gateway.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	It("locks with the request in the body", func() {
+		resp := serve("POST", "/v1/locks", `{"resource": {"key": "cell-1", "owner": "rep", "type_code": 2}, "ttl_in_seconds": 10}`)
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(resp.Body.String()).To(MatchJSON(`{"fencing_token": 3}`))
+
+		Expect(handler.lockRequest).To(Equal(&models.LockRequest{
+			Resource:     &models.Resource{Key: "cell-1", Owner: "rep", TypeCode: models.PRESENCE},
+			TtlInSeconds: 10,
+		}))
+	})
+
+	It("releases with the request in the body", func() {
+		resp := serve("DELETE", "/v1/locks", `{"resource": {"key": "cell-1", "owner": "rep"}}`)
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(handler.releaseRequest.Resource).To(Equal(&models.Resource{Key: "cell-1", Owner: "rep"}))
+	})
+
+	It("fetches the key of the path", func() {
+		resp := serve("GET", "/v1/locks/cells/cell-1?namespace=diego", "")
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(handler.fetchRequest).To(Equal(&models.FetchRequest{Key: "cells/cell-1", Namespace: "diego"}))
+
+		var fetchResp models.FetchResponse
+		Expect(json.Unmarshal(resp.Body.Bytes(), &fetchResp)).To(Succeed())
+		Expect(fetchResp.Resource.Key).To(Equal("cells/cell-1"))
+	})
+
+	It("fetches all with the query parameters", func() {
+		resp := serve("GET", "/v1/locks?type_code=PRESENCE&key_prefix=cells/&page_size=10&continuation_token=abc&namespace=diego&label_selector=zone%3Dz1&label_selector=stack%3Dcflinuxfs4", "")
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(handler.fetchAllRequest).To(Equal(&models.FetchAllRequest{
+			TypeCode:          models.PRESENCE,
+			KeyPrefix:         "cells/",
+			PageSize:          10,
+			ContinuationToken: "abc",
+			Namespace:         "diego",
+			LabelSelector:     map[string]string{"zone": "z1", "stack": "cflinuxfs4"},
+		}))
+	})
+
+	It("rejects invalid query parameters", func() {
+		resp := serve("GET", "/v1/locks?page_size=ten", "")
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		Expect(resp.Body.String()).To(MatchJSON(`{"code": "InvalidArgument", "error": "invalid-query"}`))
+		Expect(handler.fetchAllRequest).To(BeNil())
+	})
+
+	It("rejects invalid bodies", func() {
+		resp := serve("POST", "/v1/locks", `{"resource": `)
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		Expect(resp.Body.String()).To(MatchJSON(`{"code": "InvalidArgument", "error": "invalid-request-body"}`))
+		Expect(handler.lockRequest).To(BeNil())
+	})
+
+	It("answers errors with the http status of their code", func() {
+		resp := serve("GET", "/v1/locks/missing", "")
+		Expect(resp.Code).To(Equal(http.StatusNotFound))
+		Expect(resp.Body.String()).To(MatchJSON(`{"code": "NotFound", "error": "resource-not-found"}`))
+	})
+
+	Context("when there is a request tracer", func() {
+		BeforeEach(func() {
+			interceptors = []grpcserver.Interceptor{grpcserver.NewRequestTracer()}
+		})
+
+		It("continues the trace of the traceparent header", func() {
+			req := httptest.NewRequest("GET", "/v1/locks/cell-1", nil)
+			req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+			gateway.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("models.Locket/Fetch"))
+			Expect(spans[0].SpanContext.TraceID().String()).To(Equal("0af7651916cd43dd8448eb211c80319c"))
+			Expect(spans[0].Parent.SpanID().String()).To(Equal("b7ad6b7169203331"))
+		})
+	})
+
+	Context("when there is an authorizer", func() {
+		BeforeEach(func() {
+			authorizer, err := grpcserver.NewAuthorizer([]grpcserver.AuthorizationRule{
+				{Identities: []string{"cfdot"}, Operations: []string{"Fetch", "FetchAll"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			interceptors = []grpcserver.Interceptor{authorizer}
+		})
+
+		It("authorizes the client certificate of the request", func() {
+			resp := serve("GET", "/v1/locks/cell-1", "")
+			Expect(resp.Code).To(Equal(http.StatusOK))
+
+			resp = serve("POST", "/v1/locks", `{"resource": {"key": "cell-1", "owner": "cfdot"}}`)
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.String()).To(MatchJSON(`{"code": "PermissionDenied", "error": "permission-denied"}`))
+			Expect(handler.lockRequest).To(BeNil())
+		})
+	})
+})
+
+type gatewayTestHandler struct {
+	testHandler
+
+	lockRequest     *models.LockRequest
+	releaseRequest  *models.ReleaseRequest
+	fetchRequest    *models.FetchRequest
+	fetchAllRequest *models.FetchAllRequest
+}
+
+func (h *gatewayTestHandler) Lock(ctx context.Context, req *models.LockRequest) (*models.LockResponse, error) {
+	h.lockRequest = req
+	return &models.LockResponse{FencingToken: 3}, nil
+}
+
+func (h *gatewayTestHandler) Release(ctx context.Context, req *models.ReleaseRequest) (*models.ReleaseResponse, error) {
+	h.releaseRequest = req
+	return &models.ReleaseResponse{}, nil
+}
+
+func (h *gatewayTestHandler) Fetch(ctx context.Context, req *models.FetchRequest) (*models.FetchResponse, error) {
+	h.fetchRequest = req
+	if req.Key == "missing" {
+		return nil, models.ErrResourceNotFound
+	}
+	return &models.FetchResponse{Resource: &models.Resource{Key: req.Key, Owner: "rep"}}, nil
+}
+
+func (h *gatewayTestHandler) FetchAll(ctx context.Context, req *models.FetchAllRequest) (*models.FetchAllResponse, error) {
+	h.fetchAllRequest = req
+	return &models.FetchAllResponse{}, nil
+}
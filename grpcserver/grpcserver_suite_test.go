@@ -4,11 +4,16 @@ import (
 	"code.cloudfoundry.org/inigo/helpers/portauthority"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"testing"
 )
 
 var portAllocator portauthority.PortAllocator
+var spanExporter *tracetest.InMemoryExporter
+
 var _ = BeforeSuite(func() {
 	node := GinkgoParallelProcess()
 	startPort := 1050 * node
@@ -18,6 +23,13 @@ var _ = BeforeSuite(func() {
 	var err error
 	portAllocator, err = portauthority.New(startPort, endPort)
 	Expect(err).NotTo(HaveOccurred())
+
+	spanExporter = tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter)))
+})
+
+var _ = BeforeEach(func() {
+	spanExporter.Reset()
 })
 
 func TestGrpcserver(t *testing.T) {
@@ -0,0 +1,66 @@
+package grpcserver
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager/v3"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// LocketServiceName is the name of the Locket service, as health checks ask
+// for it. The empty name stands for the whole server.
+const LocketServiceName = "models.Locket"
+
+// HealthServer serves the standard grpc.health.v1.Health service. The server
+// is serving as long as none of the checks reporting to it, such as the
+// database health check, failed since it last succeeded.
+type HealthServer struct {
+	*health.Server
+	logger lager.Logger
+
+	lock    *sync.Mutex
+	failing map[string]error
+}
+
+func NewHealthServer(logger lager.Logger) *HealthServer {
+	server := &HealthServer{
+		Server:  health.NewServer(),
+		logger:  logger.Session("health-server"),
+		lock:    &sync.Mutex{},
+		failing: map[string]error{},
+	}
+	server.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+	return server
+}
+
+// ReportHealth records the result of the named check and updates the serving
+// status accordingly.
+func (h *HealthServer) ReportHealth(check string, err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	_, wasFailing := h.failing[check]
+	if err != nil {
+		if !wasFailing {
+			h.logger.Error("check-failed", err, lager.Data{"check": check})
+		}
+		h.failing[check] = err
+	} else {
+		if wasFailing {
+			h.logger.Info("check-recovered", lager.Data{"check": check})
+		}
+		delete(h.failing, check)
+	}
+
+	if len(h.failing) > 0 {
+		h.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	} else {
+		h.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+	}
+}
+
+func (h *HealthServer) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	h.Server.SetServingStatus("", status)
+	h.Server.SetServingStatus(LocketServiceName, status)
+}
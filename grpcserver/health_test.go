@@ -0,0 +1,57 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"code.cloudfoundry.org/locket/grpcserver"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var _ = Describe("HealthServer", func() {
+	var (
+		logger       *lagertest.TestLogger
+		healthServer *grpcserver.HealthServer
+	)
+
+	servingStatus := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		Expect(err).NotTo(HaveOccurred())
+		return resp.Status
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("health")
+		healthServer = grpcserver.NewHealthServer(logger)
+	})
+
+	It("is serving the server and the locket service", func() {
+		Expect(servingStatus("")).To(Equal(healthpb.HealthCheckResponse_SERVING))
+		Expect(servingStatus(grpcserver.LocketServiceName)).To(Equal(healthpb.HealthCheckResponse_SERVING))
+	})
+
+	It("is not serving while any check is failing", func() {
+		healthServer.ReportHealth("db-ping", errors.New("connection refused"))
+		healthServer.ReportHealth("db-health-check", errors.New("timed out"))
+		Expect(servingStatus("")).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+		Expect(servingStatus(grpcserver.LocketServiceName)).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+		Expect(logger).To(gbytes.Say("check-failed"))
+
+		healthServer.ReportHealth("db-ping", nil)
+		Expect(servingStatus("")).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+		Expect(logger).To(gbytes.Say("check-recovered"))
+
+		healthServer.ReportHealth("db-health-check", nil)
+		Expect(servingStatus("")).To(Equal(healthpb.HealthCheckResponse_SERVING))
+	})
+
+	It("is not serving once shut down", func() {
+		healthServer.Shutdown()
+		healthServer.ReportHealth("db-ping", nil)
+		Expect(servingStatus("")).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+	})
+})
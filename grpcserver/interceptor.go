@@ -0,0 +1,30 @@
+package grpcserver
+
+import (
+	"code.cloudfoundry.org/lager/v3"
+	"google.golang.org/grpc"
+)
+
+// Interceptor intercepts the requests of the gRPC server and of the HTTP
+// gateway, e.g. to monitor, limit or authorize them. Interceptors are called
+// in the order they are given, each one wrapping the next.
+type Interceptor interface {
+	UnaryServerInterceptor(logger lager.Logger) grpc.UnaryServerInterceptor
+	StreamServerInterceptor(logger lager.Logger) grpc.StreamServerInterceptor
+}
+
+func unaryInterceptors(logger lager.Logger, interceptors []Interceptor) []grpc.UnaryServerInterceptor {
+	var unary []grpc.UnaryServerInterceptor
+	for _, interceptor := range interceptors {
+		unary = append(unary, interceptor.UnaryServerInterceptor(logger))
+	}
+	return unary
+}
+
+func streamInterceptors(logger lager.Logger, interceptors []Interceptor) []grpc.StreamServerInterceptor {
+	var stream []grpc.StreamServerInterceptor
+	for _, interceptor := range interceptors {
+		stream = append(stream, interceptor.StreamServerInterceptor(logger))
+	}
+	return stream
+}
@@ -0,0 +1,248 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/models"
+	"google.golang.org/grpc"
+)
+
+// RateLimit limits the requests that each client with one of the identities
+// makes for each of the operations. No identities means all the clients, and
+// no operations means all the operations. Each client gets its own limits for
+// each operation, so one client going over them does not affect the others.
+type RateLimit struct {
+	Identities []string `json:"identities,omitempty"`
+	Operations []string `json:"operations,omitempty"`
+	// RequestsPerSecond is the rate at which requests are allowed on average,
+	// and Burst how many can be made at once. Zero means no rate limit.
+	RequestsPerSecond float64 `json:"requests_per_second,omitempty"`
+	Burst             int     `json:"burst,omitempty"`
+	// MaxInFlight limits the number of requests being handled at the same
+	// time, streams count for as long as they are open. Zero means no limit.
+	MaxInFlight int `json:"max_in_flight,omitempty"`
+}
+
+// RateLimiter applies the first rate limit matching a request, so specific
+// limits have to be listed before general ones. Requests without a matching
+// limit are not limited.
+type RateLimiter struct {
+	clock  clock.Clock
+	limits []RateLimit
+
+	lock      *sync.Mutex
+	buckets   map[limitKey]*tokenBucket
+	lastSweep time.Time
+	inFlight  map[limitKey]int
+}
+
+// bucketSweepInterval is how often the buckets that refilled are evicted, so
+// that the buckets of clients that went away do not pile up.
+const bucketSweepInterval = time.Minute
+
+type limitKey struct {
+	limit     int
+	client    string
+	operation string
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(clock clock.Clock, limits []RateLimit) (*RateLimiter, error) {
+	for i, limit := range limits {
+		for _, operation := range limit.Operations {
+			if !isOperation(operation) {
+				return nil, fmt.Errorf("rate limit %d has unknown operation %q", i, operation)
+			}
+		}
+		if limit.RequestsPerSecond < 0 || limit.Burst < 0 || limit.MaxInFlight < 0 {
+			return nil, fmt.Errorf("rate limit %d has a negative limit", i)
+		}
+	}
+
+	return &RateLimiter{
+		clock:     clock,
+		limits:    limits,
+		lock:      &sync.Mutex{},
+		buckets:   map[limitKey]*tokenBucket{},
+		lastSweep: clock.Now(),
+		inFlight:  map[limitKey]int{},
+	}, nil
+}
+
+// Acquire admits a request of the client with the identities, or returns
+// ErrRateLimitExceeded or ErrTooManyRequestsInFlight. The returned function
+// has to be called once the request is handled.
+func (l *RateLimiter) Acquire(identities []string, operation string) (func(), error) {
+	i, limit, found := l.match(identities, operation)
+	if !found {
+		return func() {}, nil
+	}
+
+	// clients are told apart by the first of their identities, the common
+	// name of their certificate when it has one
+	client := ""
+	if len(identities) > 0 {
+		client = identities[0]
+	}
+	key := limitKey{limit: i, client: client, operation: operation}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if limit.MaxInFlight > 0 && l.inFlight[key] >= limit.MaxInFlight {
+		return nil, models.ErrTooManyRequestsInFlight
+	}
+
+	if limit.RequestsPerSecond > 0 && !l.take(key, limit) {
+		return nil, models.ErrRateLimitExceeded
+	}
+
+	if limit.MaxInFlight == 0 {
+		return func() {}, nil
+	}
+
+	l.inFlight[key]++
+	released := false
+	return func() {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+
+		if released {
+			return
+		}
+		released = true
+
+		l.inFlight[key]--
+		if l.inFlight[key] == 0 {
+			delete(l.inFlight, key)
+		}
+	}, nil
+}
+
+func (l *RateLimiter) match(identities []string, operation string) (int, RateLimit, bool) {
+	for i, limit := range l.limits {
+		if len(limit.Identities) > 0 && !containsAny(limit.Identities, identities) {
+			continue
+		}
+		if len(limit.Operations) > 0 && !containsAny(limit.Operations, []string{operation}) {
+			continue
+		}
+		return i, limit, true
+	}
+	return 0, RateLimit{}, false
+}
+
+// take takes a token from the bucket of the key, which is refilled at the
+// rate of the limit up to its burst.
+func (l *RateLimiter) take(key limitKey, limit RateLimit) bool {
+	now := l.clock.Now()
+	if now.Sub(l.lastSweep) >= bucketSweepInterval {
+		l.sweep(now)
+	}
+
+	burst := limitBurst(limit)
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, last: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.refill(now, burst, limit.RequestsPerSecond)
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// sweep evicts the buckets that are full again. A full bucket is the same as
+// the new bucket a later request of its client would get.
+func (l *RateLimiter) sweep(now time.Time) {
+	l.lastSweep = now
+	for key, bucket := range l.buckets {
+		limit := l.limits[key.limit]
+		burst := limitBurst(limit)
+		bucket.refill(now, burst, limit.RequestsPerSecond)
+		if bucket.tokens >= burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time, burst, rate float64) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*rate)
+		b.last = now
+	}
+}
+
+// limitBurst returns the burst of the limit, at least one request or a
+// second worth of requests when it has none.
+func limitBurst(limit RateLimit) float64 {
+	if limit.Burst == 0 {
+		return math.Max(1, math.Ceil(limit.RequestsPerSecond))
+	}
+	return float64(limit.Burst)
+}
+
+// UnaryServerInterceptor rejects the unary requests over their limits.
+func (l *RateLimiter) UnaryServerInterceptor(logger lager.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		operation, ok := locketOperation(info.FullMethod)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		release, err := l.acquire(logger, ctx, operation)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects the streams over their limits. The requests
+// received on a stream, such as the keep alives of a session, are not limited.
+func (l *RateLimiter) StreamServerInterceptor(logger lager.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		operation, ok := locketOperation(info.FullMethod)
+		if !ok {
+			return handler(srv, stream)
+		}
+
+		release, err := l.acquire(logger, stream.Context(), operation)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return handler(srv, stream)
+	}
+}
+
+func (l *RateLimiter) acquire(logger lager.Logger, ctx context.Context, operation string) (func(), error) {
+	identities := ClientIdentities(ctx)
+	release, err := l.Acquire(identities, operation)
+	if err != nil {
+		logger.Info("request-limited", lager.Data{
+			"identities": identities,
+			"operation":  operation,
+			"error":      err.Error(),
+		})
+	}
+	return release, err
+}
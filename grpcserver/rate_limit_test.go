@@ -0,0 +1,193 @@
+package grpcserver_test
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"code.cloudfoundry.org/locket/grpcserver"
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"google.golang.org/grpc"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		fakeClock   *fakeclock.FakeClock
+		rateLimiter *grpcserver.RateLimiter
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		var err error
+		rateLimiter, err = grpcserver.NewRateLimiter(fakeClock, []grpcserver.RateLimit{
+			{Identities: []string{"bbs"}},
+			{Operations: []string{"Lock"}, RequestsPerSecond: 2, Burst: 2},
+			{Operations: []string{"Watch"}, MaxInFlight: 1},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("NewRateLimiter", func() {
+		It("rejects unknown operations", func() {
+			_, err := grpcserver.NewRateLimiter(fakeClock, []grpcserver.RateLimit{{Operations: []string{"Steal"}}})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects negative limits", func() {
+			_, err := grpcserver.NewRateLimiter(fakeClock, []grpcserver.RateLimit{{RequestsPerSecond: -1}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Acquire", func() {
+		It("allows a burst of requests and then the rate of the limit", func() {
+			for i := 0; i < 2; i++ {
+				_, err := rateLimiter.Acquire([]string{"rep"}, "Lock")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err := rateLimiter.Acquire([]string{"rep"}, "Lock")
+			Expect(err).To(Equal(models.ErrRateLimitExceeded))
+
+			fakeClock.Increment(500 * time.Millisecond)
+			_, err = rateLimiter.Acquire([]string{"rep"}, "Lock")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = rateLimiter.Acquire([]string{"rep"}, "Lock")
+			Expect(err).To(Equal(models.ErrRateLimitExceeded))
+		})
+
+		It("limits each client separately", func() {
+			for i := 0; i < 2; i++ {
+				_, err := rateLimiter.Acquire([]string{"rep"}, "Lock")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err := rateLimiter.Acquire([]string{"route-emitter"}, "Lock")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("evicts the buckets of the clients that stopped making requests", func() {
+			for _, client := range []string{"rep-1", "rep-2", "rep-3"} {
+				_, err := rateLimiter.Acquire([]string{client}, "Lock")
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(rateLimiter.BucketCount()).To(Equal(3))
+
+			fakeClock.Increment(time.Minute)
+			_, err := rateLimiter.Acquire([]string{"rep-4"}, "Lock")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rateLimiter.BucketCount()).To(Equal(1))
+		})
+
+		It("keeps the buckets that are still refilling", func() {
+			for i := 0; i < 2; i++ {
+				_, err := rateLimiter.Acquire([]string{"rep"}, "Lock")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			fakeClock.Increment(time.Minute - 500*time.Millisecond)
+			for i := 0; i < 2; i++ {
+				_, err := rateLimiter.Acquire([]string{"rep"}, "Lock")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			fakeClock.Increment(500 * time.Millisecond)
+			_, err := rateLimiter.Acquire([]string{"route-emitter"}, "Lock")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rateLimiter.BucketCount()).To(Equal(2))
+
+			_, err = rateLimiter.Acquire([]string{"rep"}, "Lock")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = rateLimiter.Acquire([]string{"rep"}, "Lock")
+			Expect(err).To(Equal(models.ErrRateLimitExceeded))
+		})
+
+		It("applies the first matching limit", func() {
+			for i := 0; i < 10; i++ {
+				_, err := rateLimiter.Acquire([]string{"bbs"}, "Lock")
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("does not limit requests without a matching limit", func() {
+			for i := 0; i < 10; i++ {
+				_, err := rateLimiter.Acquire([]string{"rep"}, "Fetch")
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("limits the requests in flight until they are released", func() {
+			release, err := rateLimiter.Acquire([]string{"cfdot"}, "Watch")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = rateLimiter.Acquire([]string{"cfdot"}, "Watch")
+			Expect(err).To(Equal(models.ErrTooManyRequestsInFlight))
+
+			release()
+			release()
+
+			release, err = rateLimiter.Acquire([]string{"cfdot"}, "Watch")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = rateLimiter.Acquire([]string{"cfdot"}, "Watch")
+			Expect(err).To(Equal(models.ErrTooManyRequestsInFlight))
+			release()
+		})
+	})
+
+	Describe("UnaryServerInterceptor", func() {
+		It("rejects the requests over the limit without calling the handler", func() {
+			logger := lagertest.NewTestLogger("rate-limiter")
+			ctx := contextWithClientCert(&x509.Certificate{Subject: pkix.Name{CommonName: "rep"}})
+			info := &grpc.UnaryServerInfo{FullMethod: "/models.Locket/Lock"}
+
+			calls := 0
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls++
+				return &models.LockResponse{}, nil
+			}
+
+			for i := 0; i < 2; i++ {
+				_, err := rateLimiter.UnaryServerInterceptor(logger)(ctx, &models.LockRequest{}, info, handler)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err := rateLimiter.UnaryServerInterceptor(logger)(ctx, &models.LockRequest{}, info, handler)
+			Expect(err).To(Equal(models.ErrRateLimitExceeded))
+			Expect(calls).To(Equal(2))
+			Expect(logger).To(gbytes.Say("request-limited"))
+		})
+	})
+
+	Describe("StreamServerInterceptor", func() {
+		It("counts streams in flight for as long as they are open", func() {
+			logger := lagertest.NewTestLogger("rate-limiter")
+			stream := &fakeServerStream{ctx: contextWithClientCert(&x509.Certificate{Subject: pkix.Name{CommonName: "cfdot"}})}
+			info := &grpc.StreamServerInfo{FullMethod: "/models.Locket/Watch"}
+
+			var nestedErr error
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				nestedErr = rateLimiter.StreamServerInterceptor(logger)(nil, stream, info, func(interface{}, grpc.ServerStream) error {
+					return nil
+				})
+				return nil
+			}
+
+			err := rateLimiter.StreamServerInterceptor(logger)(nil, stream, info, handler)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nestedErr).To(Equal(models.ErrTooManyRequestsInFlight))
+
+			err = rateLimiter.StreamServerInterceptor(logger)(nil, stream, info, func(interface{}, grpc.ServerStream) error {
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,180 @@
+package grpcserver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/lager/v3"
+	metrics_helpers "code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/locket/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestMonitor records the request metrics of every operation of the Locket
+// service, and logs the requests whose context is cancelled or times out with
+// their request ID. Requests failing with an unrecoverable database error
+// signal the exit channel, so that locket restarts.
+type RequestMonitor struct {
+	metrics metrics_helpers.RequestMetrics
+	exitCh  chan<- struct{}
+}
+
+func NewRequestMonitor(metrics metrics_helpers.RequestMetrics, exitCh chan<- struct{}) *RequestMonitor {
+	return &RequestMonitor{
+		metrics: metrics,
+		exitCh:  exitCh,
+	}
+}
+
+// RequestID returns the ID clients set as the uuid metadata of their
+// requests, or an empty string.
+func RequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if vals := md.Get("uuid"); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+func (m *RequestMonitor) UnaryServerInterceptor(logger lager.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		operation, ok := locketOperation(info.FullMethod)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		var resp interface{}
+		err := m.monitor(logger, ctx, operation, func() error {
+			var err error
+			resp, err = handler(ctx, req)
+			return err
+		}, func() (string, string) {
+			return requestResource(req)
+		})
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor monitors streams for as long as they are open. The
+// resource of a stream is the one of the first request received on it, such
+// as the key of a watch.
+func (m *RequestMonitor) StreamServerInterceptor(logger lager.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		operation, ok := locketOperation(info.FullMethod)
+		if !ok {
+			return handler(srv, stream)
+		}
+
+		monitored := &monitoredStream{ServerStream: stream, lock: &sync.Mutex{}}
+		return m.monitor(logger, stream.Context(), operation, func() error {
+			return handler(srv, monitored)
+		}, monitored.resource)
+	}
+}
+
+func (m *RequestMonitor) monitor(logger lager.Logger, ctx context.Context, operation string, f func() error, resource func() (string, string)) error {
+	m.metrics.IncrementRequestsStartedCounter(operation, 1)
+	m.metrics.IncrementRequestsInFlightCounter(operation, 1)
+	defer m.metrics.DecrementRequestsInFlightCounter(operation, 1)
+
+	start := time.Now()
+
+	err := f()
+
+	key, owner := resource()
+	logData := lager.Data{
+		"request-id":     RequestID(ctx),
+		"request-type":   operation,
+		"resource-key":   key,
+		"resource-owner": owner,
+	}
+	if ctx.Err() == context.Canceled {
+		logger.Info("context-cancelled", logData)
+		m.metrics.IncrementRequestsCancelledCounter(operation, 1)
+	} else if ctx.Err() == context.DeadlineExceeded {
+		logger.Info("context-deadline-exceeded", logData)
+	}
+
+	m.metrics.UpdateLatency(operation, time.Since(start))
+
+	// collisions and stale modified indexes are expected outcomes of
+	// contended requests, not failures
+	if err != nil && err != models.ErrLockCollision && err != models.ErrModifiedIndexMismatch {
+		m.metrics.IncrementRequestsFailedCounter(operation, 1)
+		m.exitIfUnrecoverable(logger, err)
+	} else {
+		m.metrics.IncrementRequestsSucceededCounter(operation, 1)
+	}
+	return err
+}
+
+func (m *RequestMonitor) exitIfUnrecoverable(logger lager.Logger, err error) {
+	if err != helpers.ErrUnrecoverableError {
+		return
+	}
+
+	logger.Error("unrecoverable-error", err)
+
+	select {
+	case m.exitCh <- struct{}{}:
+	default:
+	}
+}
+
+type monitoredStream struct {
+	grpc.ServerStream
+
+	lock     *sync.Mutex
+	received bool
+	key      string
+	owner    string
+}
+
+func (s *monitoredStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.received {
+		s.received = true
+		s.key, s.owner = requestResource(m)
+	}
+	return nil
+}
+
+func (s *monitoredStream) resource() (string, string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.key, s.owner
+}
+
+// requestResource returns the key and the owner of the resource a request is
+// for, as the client gave them.
+func requestResource(req interface{}) (string, string) {
+	switch req := req.(type) {
+	case *models.LockRequest:
+		return req.GetResource().GetKey(), req.GetResource().GetOwner()
+	case *models.ReleaseRequest:
+		return req.GetResource().GetKey(), req.GetResource().GetOwner()
+	case *models.UpdateRequest:
+		return req.GetResource().GetKey(), req.GetResource().GetOwner()
+	case *models.TransferRequest:
+		return req.GetResource().GetKey(), req.GetResource().GetOwner()
+	case *models.ForceReleaseRequest:
+		return req.Key, ""
+	case *models.FetchRequest:
+		return req.Key, ""
+	case *models.WatchRequest:
+		return req.Key, ""
+	}
+	return "", ""
+}
@@ -0,0 +1,199 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"code.cloudfoundry.org/locket/grpcserver"
+	"code.cloudfoundry.org/locket/metrics/helpers/helpersfakes"
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var _ = Describe("RequestMonitor", func() {
+	var (
+		logger             *lagertest.TestLogger
+		fakeRequestMetrics *helpersfakes.FakeRequestMetrics
+		exitCh             chan struct{}
+		monitor            *grpcserver.RequestMonitor
+		request            *models.LockRequest
+		info               *grpc.UnaryServerInfo
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("request-monitor")
+		fakeRequestMetrics = &helpersfakes.FakeRequestMetrics{}
+		exitCh = make(chan struct{}, 1)
+		monitor = grpcserver.NewRequestMonitor(fakeRequestMetrics, exitCh)
+		request = &models.LockRequest{Resource: &models.Resource{Key: "test", Owner: "myself"}}
+		info = &grpc.UnaryServerInfo{FullMethod: "/models.Locket/Lock"}
+	})
+
+	intercept := func(ctx context.Context, handlerErr error) error {
+		_, err := monitor.UnaryServerInterceptor(logger)(ctx, request, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &models.LockResponse{}, handlerErr
+		})
+		return err
+	}
+
+	Describe("UnaryServerInterceptor", func() {
+		It("records the metrics of successful requests", func() {
+			Expect(intercept(context.Background(), nil)).To(Succeed())
+
+			Expect(fakeRequestMetrics.IncrementRequestsStartedCounterCallCount()).To(Equal(1))
+			requestType, delta := fakeRequestMetrics.IncrementRequestsStartedCounterArgsForCall(0)
+			Expect(requestType).To(Equal("Lock"))
+			Expect(delta).To(Equal(1))
+
+			Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
+			Expect(fakeRequestMetrics.IncrementRequestsFailedCounterCallCount()).To(Equal(0))
+
+			Expect(fakeRequestMetrics.IncrementRequestsInFlightCounterCallCount()).To(Equal(1))
+			Expect(fakeRequestMetrics.DecrementRequestsInFlightCounterCallCount()).To(Equal(1))
+			requestType, delta = fakeRequestMetrics.DecrementRequestsInFlightCounterArgsForCall(0)
+			Expect(requestType).To(Equal("Lock"))
+			Expect(delta).To(Equal(1))
+
+			Expect(fakeRequestMetrics.UpdateLatencyCallCount()).To(Equal(1))
+			requestType, latency := fakeRequestMetrics.UpdateLatencyArgsForCall(0)
+			Expect(requestType).To(Equal("Lock"))
+			Expect(latency).To(BeNumerically(">=", 0))
+		})
+
+		It("records the metrics of failed requests", func() {
+			Expect(intercept(context.Background(), errors.New("boom"))).To(MatchError("boom"))
+
+			Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(0))
+			Expect(fakeRequestMetrics.IncrementRequestsFailedCounterCallCount()).To(Equal(1))
+			requestType, _ := fakeRequestMetrics.IncrementRequestsFailedCounterArgsForCall(0)
+			Expect(requestType).To(Equal("Lock"))
+			Consistently(exitCh).ShouldNot(Receive())
+		})
+
+		It("counts lock collisions and modified index mismatches as successes", func() {
+			Expect(intercept(context.Background(), models.ErrLockCollision)).To(Equal(models.ErrLockCollision))
+			Expect(intercept(context.Background(), models.ErrModifiedIndexMismatch)).To(Equal(models.ErrModifiedIndexMismatch))
+
+			Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(2))
+			Expect(fakeRequestMetrics.IncrementRequestsFailedCounterCallCount()).To(Equal(0))
+		})
+
+		It("does not monitor methods of other services", func() {
+			info.FullMethod = "/grpc.health.v1.Health/Check"
+			Expect(intercept(context.Background(), nil)).To(Succeed())
+			Expect(fakeRequestMetrics.IncrementRequestsStartedCounterCallCount()).To(Equal(0))
+		})
+
+		Context("when an unrecoverable error is returned", func() {
+			It("logs and writes to the exit channel", func() {
+				Expect(intercept(context.Background(), helpers.ErrUnrecoverableError)).To(HaveOccurred())
+				Expect(logger).To(gbytes.Say("unrecoverable-error"))
+				Expect(fakeRequestMetrics.IncrementRequestsFailedCounterCallCount()).To(Equal(1))
+				Expect(exitCh).To(Receive())
+			})
+		})
+
+		Context("when the context errors", func() {
+			var ctx context.Context
+
+			BeforeEach(func() {
+				ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("uuid", "some-request-id"))
+			})
+
+			Context("when the context was closed due to a client cancellation", func() {
+				BeforeEach(func() {
+					var cancel func()
+					ctx, cancel = context.WithCancel(ctx)
+					cancel()
+				})
+
+				It("logs the context cancelled error with request data", func() {
+					intercept(ctx, errors.New("Boom."))
+					Expect(logger).To(gbytes.Say("context-cancelled"))
+					Expect(logger).To(gbytes.Say("some-request-id"))
+					Expect(logger).To(gbytes.Say("Lock"))
+					Expect(logger).To(gbytes.Say("test"))
+					Expect(logger).To(gbytes.Say("myself"))
+				})
+
+				It("records an increase in the request cancelled metric", func() {
+					intercept(ctx, errors.New("Boom."))
+					Expect(fakeRequestMetrics.IncrementRequestsCancelledCounterCallCount()).To(Equal(1))
+					_, requestsCancelled := fakeRequestMetrics.IncrementRequestsCancelledCounterArgsForCall(0)
+					Expect(requestsCancelled).To(BeEquivalentTo(1))
+				})
+			})
+
+			Context("when the context was closed due to an exceeded deadline", func() {
+				BeforeEach(func() {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithDeadline(ctx, time.Unix(0, 0)) // nolint
+					defer cancel()
+				})
+
+				It("logs the context deadline exceeded error", func() {
+					intercept(ctx, errors.New("Boom."))
+					Expect(logger).To(gbytes.Say("context-deadline-exceeded"))
+					Expect(logger).To(gbytes.Say("some-request-id"))
+					Expect(fakeRequestMetrics.IncrementRequestsCancelledCounterCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the context has no uuid metadata", func() {
+				BeforeEach(func() {
+					var cancel func()
+					ctx, cancel = context.WithCancel(context.Background())
+					cancel()
+				})
+
+				It("does not panic and logs empty request-id", func() {
+					intercept(ctx, errors.New("Boom."))
+					Expect(logger).To(gbytes.Say("context-cancelled"))
+				})
+			})
+		})
+	})
+
+	Describe("StreamServerInterceptor", func() {
+		It("counts streams in flight for as long as they are open, with the key of their request", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			stream := &watchRequestStream{ServerStream: &fakeServerStream{ctx: ctx}}
+			streamInfo := &grpc.StreamServerInfo{FullMethod: "/models.Locket/Watch"}
+
+			err := monitor.StreamServerInterceptor(logger)(nil, stream, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+				req := &models.WatchRequest{}
+				Expect(stream.RecvMsg(req)).To(Succeed())
+
+				Expect(fakeRequestMetrics.IncrementRequestsInFlightCounterCallCount()).To(Equal(1))
+				requestType, _ := fakeRequestMetrics.IncrementRequestsInFlightCounterArgsForCall(0)
+				Expect(requestType).To(Equal("Watch"))
+				Expect(fakeRequestMetrics.DecrementRequestsInFlightCounterCallCount()).To(Equal(0))
+
+				cancel()
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeRequestMetrics.DecrementRequestsInFlightCounterCallCount()).To(Equal(1))
+			Expect(logger).To(gbytes.Say("context-cancelled"))
+			Expect(logger).To(gbytes.Say("cells/cell-1"))
+		})
+	})
+})
+
+// watchRequestStream receives a watch request for the cells/cell-1 key.
+type watchRequestStream struct {
+	grpc.ServerStream
+}
+
+func (s *watchRequestStream) RecvMsg(m interface{}) error {
+	m.(*models.WatchRequest).Key = "cells/cell-1"
+	return nil
+}
@@ -0,0 +1,103 @@
+package grpcserver
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const instrumentationName = "code.cloudfoundry.org/locket/grpcserver"
+
+// RequestTracer records a server span for every operation of the Locket
+// service, in the trace of the client when the request carries one. The spans
+// have the request ID and the resource of the request as attributes, so that
+// a request can be found in the logs of the client and of locket from its
+// trace, and the other way around. It has to be the first interceptor, for the
+// spans to cover the other ones.
+type RequestTracer struct{}
+
+func NewRequestTracer() *RequestTracer {
+	return &RequestTracer{}
+}
+
+func (t *RequestTracer) UnaryServerInterceptor(logger lager.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := locketOperation(info.FullMethod); !ok {
+			return handler(ctx, req)
+		}
+
+		ctx, span := t.start(ctx, info.FullMethod)
+		key, owner := requestResource(req)
+		span.SetAttributes(resourceAttributes(key, owner)...)
+
+		resp, err := handler(ctx, req)
+		t.end(span, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records a span for as long as the stream is open,
+// with the resource of the first request received on it.
+func (t *RequestTracer) StreamServerInterceptor(logger lager.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, ok := locketOperation(info.FullMethod); !ok {
+			return handler(srv, stream)
+		}
+
+		ctx, span := t.start(stream.Context(), info.FullMethod)
+		traced := &tracedStream{monitoredStream: &monitoredStream{ServerStream: stream, lock: &sync.Mutex{}}, ctx: ctx}
+
+		err := handler(srv, traced)
+		span.SetAttributes(resourceAttributes(traced.resource())...)
+		t.end(span, err)
+		return err
+	}
+}
+
+func (t *RequestTracer) start(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	name, attributes := tracing.RPCAttributes(fullMethod)
+	ctx, span := otel.Tracer(instrumentationName).Start(
+		tracing.Extract(ctx),
+		name,
+		attributes,
+		trace.WithSpanKind(trace.SpanKindServer),
+	)
+	if requestID := RequestID(ctx); requestID != "" {
+		span.SetAttributes(attribute.String("locket.request_id", requestID))
+	}
+	return ctx, span
+}
+
+func (t *RequestTracer) end(span trace.Span, err error) {
+	span.SetAttributes(semconv.RPCResponseStatusCode(status.Code(err).String()))
+	tracing.End(span, err)
+}
+
+func resourceAttributes(key, owner string) []attribute.KeyValue {
+	var attributes []attribute.KeyValue
+	if key != "" {
+		attributes = append(attributes, attribute.String("locket.resource.key", key))
+	}
+	if owner != "" {
+		attributes = append(attributes, attribute.String("locket.resource.owner", owner))
+	}
+	return attributes
+}
+
+// tracedStream is a stream whose context has the span of the stream.
+type tracedStream struct {
+	*monitoredStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,118 @@
+package grpcserver_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"code.cloudfoundry.org/locket/grpcserver"
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/tracing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var _ = Describe("RequestTracer", func() {
+	var (
+		logger *lagertest.TestLogger
+		tracer *grpcserver.RequestTracer
+		client trace.Span
+		ctx    context.Context
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("request-tracer")
+		tracer = grpcserver.NewRequestTracer()
+
+		// the trace context of a client request, as it comes to the server
+		var clientCtx context.Context
+		clientCtx, client = otel.Tracer("test").Start(context.Background(), "bbs-request")
+		md, _ := metadata.FromOutgoingContext(tracing.Inject(clientCtx))
+		md.Set("uuid", "some-request-id")
+		ctx = metadata.NewIncomingContext(context.Background(), md)
+	})
+
+	Describe("UnaryServerInterceptor", func() {
+		var (
+			request *models.LockRequest
+			info    *grpc.UnaryServerInfo
+		)
+
+		BeforeEach(func() {
+			request = &models.LockRequest{Resource: &models.Resource{Key: "test", Owner: "myself"}}
+			info = &grpc.UnaryServerInfo{FullMethod: "/models.Locket/Lock"}
+		})
+
+		intercept := func(handlerErr error) (trace.SpanContext, error) {
+			var handlerSpan trace.SpanContext
+			_, err := tracer.UnaryServerInterceptor(logger)(ctx, request, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerSpan = trace.SpanContextFromContext(ctx)
+				return &models.LockResponse{}, handlerErr
+			})
+			return handlerSpan, err
+		}
+
+		It("records a server span in the trace of the client", func() {
+			handlerSpan, err := intercept(nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("models.Locket/Lock"))
+			Expect(spans[0].SpanKind).To(Equal(trace.SpanKindServer))
+			Expect(spans[0].Parent.TraceID()).To(Equal(client.SpanContext().TraceID()))
+			Expect(spans[0].Parent.SpanID()).To(Equal(client.SpanContext().SpanID()))
+			Expect(spans[0].Parent.IsRemote()).To(BeTrue())
+			Expect(spans[0].Attributes).To(ContainElements(
+				attribute.String("locket.request_id", "some-request-id"),
+				attribute.String("locket.resource.key", "test"),
+				attribute.String("locket.resource.owner", "myself"),
+				attribute.String("rpc.response.status_code", "OK"),
+			))
+
+			Expect(handlerSpan).To(Equal(spans[0].SpanContext))
+		})
+
+		It("marks the span of failed requests as failed", func() {
+			_, err := intercept(models.ErrResourceNotFound)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Status.Code).To(Equal(codes.Error))
+			Expect(spans[0].Attributes).To(ContainElement(attribute.String("rpc.response.status_code", "NotFound")))
+		})
+
+		It("does not trace methods of other services", func() {
+			info.FullMethod = "/grpc.health.v1.Health/Check"
+			_, err := intercept(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spanExporter.GetSpans()).To(BeEmpty())
+		})
+	})
+
+	Describe("StreamServerInterceptor", func() {
+		It("records a span for as long as the stream is open, with the key of its request", func() {
+			stream := &watchRequestStream{ServerStream: &fakeServerStream{ctx: ctx}}
+			streamInfo := &grpc.StreamServerInfo{FullMethod: "/models.Locket/Watch"}
+
+			err := tracer.StreamServerInterceptor(logger)(nil, stream, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+				Expect(trace.SpanContextFromContext(stream.Context()).TraceID()).To(Equal(client.SpanContext().TraceID()))
+				Expect(stream.RecvMsg(&models.WatchRequest{})).To(Succeed())
+				Expect(spanExporter.GetSpans()).To(BeEmpty())
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("models.Locket/Watch"))
+			Expect(spans[0].Attributes).To(ContainElement(attribute.String("locket.resource.key", "cells/cell-1")))
+		})
+	})
+})
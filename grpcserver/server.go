@@ -4,11 +4,22 @@ import (
 	"crypto/tls"
 	"net"
 	"os"
+	"time"
 
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/locket/models"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+)
+
+// The server pings idle connections, so that the streams of clients that went
+// away without closing their connection, such as sessions, end within seconds
+// instead of when the TCP connection times out.
+const (
+	keepaliveTime    = 10 * time.Second
+	keepaliveTimeout = 5 * time.Second
 )
 
 type grpcServerRunner struct {
@@ -16,14 +27,28 @@ type grpcServerRunner struct {
 	handler       models.LocketServer
 	logger        lager.Logger
 	tlsConfig     *tls.Config
+	healthServer  *HealthServer
+	interceptors  []Interceptor
+
+	maxMessageSize int
 }
 
-func NewGRPCServer(logger lager.Logger, listenAddress string, tlsConfig *tls.Config, handler models.LocketServer) grpcServerRunner {
+// NewGRPCServer returns a runner serving the handler. Requests go through the
+// interceptors, such as the request monitor, the rate limiter and the
+// authorizer, in order. The health server, when not nil, is served as the
+// standard grpc.health.v1.Health service, which the interceptors let through,
+// and stops serving when the server is signalled. Messages received and sent
+// are limited to maxMessageSize bytes, see models.MaxMessageSize, or to the
+// defaults of gRPC when it is 0.
+func NewGRPCServer(logger lager.Logger, listenAddress string, tlsConfig *tls.Config, handler models.LocketServer, healthServer *HealthServer, interceptors []Interceptor, maxMessageSize int) grpcServerRunner {
 	return grpcServerRunner{
-		listenAddress: listenAddress,
-		handler:       handler,
-		logger:        logger,
-		tlsConfig:     tlsConfig,
+		listenAddress:  listenAddress,
+		handler:        handler,
+		logger:         logger,
+		tlsConfig:      tlsConfig,
+		healthServer:   healthServer,
+		interceptors:   interceptors,
+		maxMessageSize: maxMessageSize,
 	}
 }
 
@@ -39,8 +64,31 @@ func (s grpcServerRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 		return err
 	}
 
-	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(s.tlsConfig)))
+	opts := []grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(s.tlsConfig)),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    keepaliveTime,
+			Timeout: keepaliveTimeout,
+		}),
+	}
+
+	if s.maxMessageSize > 0 {
+		opts = append(opts,
+			grpc.MaxRecvMsgSize(s.maxMessageSize),
+			grpc.MaxSendMsgSize(s.maxMessageSize),
+		)
+	}
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryInterceptors(logger, s.interceptors)...),
+		grpc.ChainStreamInterceptor(streamInterceptors(logger, s.interceptors)...),
+	)
+
+	server := grpc.NewServer(opts...)
 	models.RegisterLocketServer(server, s.handler)
+	if s.healthServer != nil {
+		healthpb.RegisterHealthServer(server, s.healthServer)
+	}
 
 	errCh := make(chan error)
 	go func() {
@@ -58,6 +106,9 @@ func (s grpcServerRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 		break
 	}
 
+	if s.healthServer != nil {
+		s.healthServer.Shutdown()
+	}
 	server.GracefulStop()
 	return err
 }
@@ -48,7 +48,7 @@ var _ = Describe("GRPCServer", func() {
 		Expect(err).NotTo(HaveOccurred())
 		listenAddress = fmt.Sprintf("localhost:%d", port)
 
-		runner = grpcserver.NewGRPCServer(logger, listenAddress, tlsConfig, &testHandler{})
+		runner = grpcserver.NewGRPCServer(logger, listenAddress, tlsConfig, &testHandler{}, nil, nil, 0)
 	})
 
 	JustBeforeEach(func() {
@@ -87,7 +87,7 @@ var _ = Describe("GRPCServer", func() {
 		var alternateRunner ifrit.Runner
 
 		BeforeEach(func() {
-			alternateRunner = grpcserver.NewGRPCServer(logger, listenAddress, tlsConfig, &testHandler{})
+			alternateRunner = grpcserver.NewGRPCServer(logger, listenAddress, tlsConfig, &testHandler{}, nil, nil, 0)
 		})
 
 		It("exits with an error", func() {
@@ -104,6 +104,12 @@ type testHandler struct{}
 func (h *testHandler) Lock(ctx context.Context, req *models.LockRequest) (*models.LockResponse, error) {
 	return &models.LockResponse{}, nil
 }
+func (h *testHandler) LockBatch(ctx context.Context, req *models.LockBatchRequest) (*models.LockBatchResponse, error) {
+	return &models.LockBatchResponse{}, nil
+}
+func (h *testHandler) LockMulti(ctx context.Context, req *models.LockMultiRequest) (*models.LockMultiResponse, error) {
+	return &models.LockMultiResponse{}, nil
+}
 func (h *testHandler) Release(ctx context.Context, req *models.ReleaseRequest) (*models.ReleaseResponse, error) {
 	return &models.ReleaseResponse{}, nil
 }
@@ -113,3 +119,27 @@ func (h *testHandler) Fetch(ctx context.Context, req *models.FetchRequest) (*mod
 func (h *testHandler) FetchAll(ctx context.Context, req *models.FetchAllRequest) (*models.FetchAllResponse, error) {
 	return &models.FetchAllResponse{}, nil
 }
+func (h *testHandler) Update(ctx context.Context, req *models.UpdateRequest) (*models.UpdateResponse, error) {
+	return &models.UpdateResponse{}, nil
+}
+func (h *testHandler) Transfer(ctx context.Context, req *models.TransferRequest) (*models.TransferResponse, error) {
+	return &models.TransferResponse{}, nil
+}
+func (h *testHandler) ForceRelease(ctx context.Context, req *models.ForceReleaseRequest) (*models.ForceReleaseResponse, error) {
+	return &models.ForceReleaseResponse{}, nil
+}
+func (h *testHandler) Watch(req *models.WatchRequest, stream models.Locket_WatchServer) error {
+	return nil
+}
+func (h *testHandler) GrantLease(ctx context.Context, req *models.GrantLeaseRequest) (*models.GrantLeaseResponse, error) {
+	return &models.GrantLeaseResponse{}, nil
+}
+func (h *testHandler) KeepAliveLease(ctx context.Context, req *models.KeepAliveLeaseRequest) (*models.KeepAliveLeaseResponse, error) {
+	return &models.KeepAliveLeaseResponse{}, nil
+}
+func (h *testHandler) RevokeLease(ctx context.Context, req *models.RevokeLeaseRequest) (*models.RevokeLeaseResponse, error) {
+	return &models.RevokeLeaseResponse{}, nil
+}
+func (h *testHandler) Session(stream models.Locket_SessionServer) error {
+	return nil
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/grpcserver"
+	"code.cloudfoundry.org/locket/models"
+)
+
+// clientIdentity returns the identity of the client recorded in audit
+// records, the common name of its certificate or, when it has none, its first
+// subject alternative name.
+func clientIdentity(ctx context.Context) string {
+	identities := grpcserver.ClientIdentities(ctx)
+	if len(identities) == 0 {
+		return ""
+	}
+	return identities[0]
+}
+
+// hideForeignLeases clears the lease of the resources attached to a lease
+// granted by another client than the one of ctx. Knowing the id of a lease is
+// enough to keep alive or revoke all the locks attached to it. The owners of
+// the leases are fetched with dbCtx.
+func (h *locketHandler) hideForeignLeases(ctx, dbCtx context.Context, logger lager.Logger, resources []*models.Resource) error {
+	var ids []string
+	for _, resource := range resources {
+		if resource.LeaseId != "" {
+			ids = append(ids, resource.LeaseId)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	owners, err := h.db.FetchLeaseOwners(dbCtx, logger, ids)
+	if err != nil {
+		logger.Error("failed-to-fetch-lease-owners", err)
+		return err
+	}
+
+	identity := clientIdentity(ctx)
+	for _, resource := range resources {
+		owner, found := owners[resource.LeaseId]
+		if !found || owner != identity {
+			resource.LeaseId = ""
+		}
+	}
+
+	return nil
+}
+
+// hideForeignLease clears the lease of a watched resource like
+// hideForeignLeases. The lease is cleared as well when its owner cannot be
+// fetched, rather than failing the watch.
+func (h *locketHandler) hideForeignLease(ctx context.Context, logger lager.Logger, resource *models.Resource) {
+	if resource.GetLeaseId() == "" {
+		return
+	}
+
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	defer dbCancel()
+
+	err := h.hideForeignLeases(ctx, dbCtx, logger, []*models.Resource{resource})
+	if err != nil {
+		resource.LeaseId = ""
+	}
+}
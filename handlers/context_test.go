@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/diego-db-helpers/guidprovider"
 	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
 	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers/monitor"
@@ -15,8 +16,8 @@ import (
 	"code.cloudfoundry.org/locket/db"
 	"code.cloudfoundry.org/locket/expiration/expirationfakes"
 	"code.cloudfoundry.org/locket/handlers"
-	"code.cloudfoundry.org/locket/metrics/helpers/helpersfakes"
 	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/watch/watchfakes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
@@ -26,16 +27,15 @@ import (
 
 var _ = Describe("LocketHandler", func() {
 	var (
-		sqlProcess         ifrit.Process
-		sqlRunner          sqlrunner.SQLRunner
-		lockDB             *db.SQLDB
-		sqlConn            *sql.DB
-		fakeLockPick       *expirationfakes.FakeLockPick
-		logger             *lagertest.TestLogger
-		locketHandler      models.LocketServer
-		resource           *models.Resource
-		exitCh             chan struct{}
-		fakeRequestMetrics *helpersfakes.FakeRequestMetrics
+		sqlProcess    ifrit.Process
+		sqlRunner     sqlrunner.SQLRunner
+		lockDB        *db.SQLDB
+		sqlConn       *sql.DB
+		fakeLockPick  *expirationfakes.FakeLockPick
+		logger        *lagertest.TestLogger
+		locketHandler models.LocketServer
+		resource      *models.Resource
+		exitCh        chan struct{}
 	)
 
 	BeforeEach(func() {
@@ -62,12 +62,13 @@ var _ = Describe("LocketHandler", func() {
 			monitoredDB,
 			sqlRunner.DriverName(),
 			guidprovider.DefaultGuidProvider,
+			clock.NewClock(),
+			nil,
 		)
 		err = lockDB.CreateLockTable(context.Background(), logger)
 		Expect(err).NotTo(HaveOccurred())
 
 		fakeLockPick = &expirationfakes.FakeLockPick{}
-		fakeRequestMetrics = &helpersfakes.FakeRequestMetrics{}
 
 		exitCh = make(chan struct{}, 1)
 
@@ -82,9 +83,13 @@ var _ = Describe("LocketHandler", func() {
 			logger,
 			lockDB,
 			fakeLockPick,
-			fakeRequestMetrics,
+			&watchfakes.FakeHub{},
+			clock.NewClock(),
 			exitCh,
 			handlers.DefaultDBOperationTimeout,
+			nil,
+			handlers.DefaultMaxPayloadSize,
+			nil,
 		)
 	})
 
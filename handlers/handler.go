@@ -1,20 +1,28 @@
 package handlers
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
 	"time"
 
 	"context"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/locket/db"
 	"code.cloudfoundry.org/locket/expiration"
-	metrics_helpers "code.cloudfoundry.org/locket/metrics/helpers"
+	"code.cloudfoundry.org/locket/grpcserver"
 	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/tracing"
+	"code.cloudfoundry.org/locket/watch"
 	"google.golang.org/grpc/metadata"
 )
 
 const DefaultDBOperationTimeout = 10 * time.Second
+const DefaultMaxPayloadSize = 1024 * 1024
 
 type locketHandler struct {
 	logger lager.Logger
@@ -22,23 +30,36 @@ type locketHandler struct {
 	db                 db.LockDB
 	exitCh             chan<- struct{}
 	lockPick           expiration.LockPick
-	metrics            metrics_helpers.RequestMetrics
+	hub                watch.Hub
+	waiters            *lockWaiters
+	clock              clock.Clock
 	dbOperationTimeout time.Duration
+	resourceTypes      models.ResourceTypes
+	maxPayloadSize     int
+	namespaces         models.Namespaces
 }
 
-func NewLocketHandler(logger lager.Logger, db db.LockDB, lockPick expiration.LockPick, requestMetrics metrics_helpers.RequestMetrics, exitCh chan<- struct{}, dbOperationTimeout time.Duration) *locketHandler {
+func NewLocketHandler(logger lager.Logger, db db.LockDB, lockPick expiration.LockPick, hub watch.Hub, clock clock.Clock, exitCh chan<- struct{}, dbOperationTimeout time.Duration, resourceTypes models.ResourceTypes, maxPayloadSize int, namespaces models.Namespaces) *locketHandler {
 	return &locketHandler{
 		logger:             logger,
 		db:                 db,
 		lockPick:           lockPick,
+		hub:                hub,
+		waiters:            newLockWaiters(hub),
+		clock:              clock,
 		exitCh:             exitCh,
-		metrics:            requestMetrics,
 		dbOperationTimeout: dbOperationTimeout,
+		resourceTypes:      resourceTypes,
+		maxPayloadSize:     maxPayloadSize,
+		namespaces:         namespaces,
 	}
 }
 
-func (h *locketHandler) newDBContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), h.dbOperationTimeout)
+// newDBContext returns the context of a database operation of the request.
+// It keeps the span of the request, but it is not cancelled along with the
+// request, so that operations are not left half done by clients going away.
+func (h *locketHandler) newDBContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), h.dbOperationTimeout)
 }
 
 func (h *locketHandler) exitIfUnrecoverable(err error) {
@@ -54,143 +75,529 @@ func (h *locketHandler) exitIfUnrecoverable(err error) {
 	}
 }
 
-func (h *locketHandler) monitorRequest(requestType string, ctx context.Context, key string, owner string, f func() error) error {
-	h.metrics.IncrementRequestsStartedCounter(requestType, 1)
-	h.metrics.IncrementRequestsInFlightCounter(requestType, 1)
-	defer h.metrics.DecrementRequestsInFlightCounter(requestType, 1)
+// The exported methods implement models.LocketServer, each one in a span of
+// its own. Request metrics and logging are done by the interceptors of the
+// grpcserver package.
 
-	start := time.Now()
+func (h *locketHandler) Lock(ctx context.Context, req *models.LockRequest) (*models.LockResponse, error) {
+	ctx, span := startSpan(ctx, "Lock")
+	resp, err := h.lock(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (h *locketHandler) LockBatch(ctx context.Context, req *models.LockBatchRequest) (*models.LockBatchResponse, error) {
+	ctx, span := startSpan(ctx, "LockBatch")
+	resp, err := h.lockBatch(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (h *locketHandler) LockMulti(ctx context.Context, req *models.LockMultiRequest) (*models.LockMultiResponse, error) {
+	ctx, span := startSpan(ctx, "LockMulti")
+	resp, err := h.lockMulti(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (h *locketHandler) Release(ctx context.Context, req *models.ReleaseRequest) (*models.ReleaseResponse, error) {
+	ctx, span := startSpan(ctx, "Release")
+	resp, err := h.release(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (h *locketHandler) Update(ctx context.Context, req *models.UpdateRequest) (*models.UpdateResponse, error) {
+	ctx, span := startSpan(ctx, "Update")
+	resp, err := h.update(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (h *locketHandler) Transfer(ctx context.Context, req *models.TransferRequest) (*models.TransferResponse, error) {
+	ctx, span := startSpan(ctx, "Transfer")
+	resp, err := h.transfer(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
 
-	err := f()
+func (h *locketHandler) ForceRelease(ctx context.Context, req *models.ForceReleaseRequest) (*models.ForceReleaseResponse, error) {
+	ctx, span := startSpan(ctx, "ForceRelease")
+	resp, err := h.forceRelease(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
 
-	requestID := ""
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		if vals := md.Get("uuid"); len(vals) > 0 {
-			requestID = vals[0]
+func (h *locketHandler) Fetch(ctx context.Context, req *models.FetchRequest) (*models.FetchResponse, error) {
+	ctx, span := startSpan(ctx, "Fetch")
+	resp, err := h.fetch(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (h *locketHandler) FetchAll(ctx context.Context, req *models.FetchAllRequest) (*models.FetchAllResponse, error) {
+	ctx, span := startSpan(ctx, "FetchAll")
+	resp, err := h.fetchAll(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (h *locketHandler) GrantLease(ctx context.Context, req *models.GrantLeaseRequest) (*models.GrantLeaseResponse, error) {
+	ctx, span := startSpan(ctx, "GrantLease")
+	resp, err := h.grantLease(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (h *locketHandler) KeepAliveLease(ctx context.Context, req *models.KeepAliveLeaseRequest) (*models.KeepAliveLeaseResponse, error) {
+	ctx, span := startSpan(ctx, "KeepAliveLease")
+	resp, err := h.keepAliveLease(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (h *locketHandler) RevokeLease(ctx context.Context, req *models.RevokeLeaseRequest) (*models.RevokeLeaseResponse, error) {
+	ctx, span := startSpan(ctx, "RevokeLease")
+	resp, err := h.revokeLease(ctx, req)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (h *locketHandler) Session(stream models.Locket_SessionServer) error {
+	ctx, span := startSpan(stream.Context(), "Session")
+	err := h.session(&sessionStream{Locket_SessionServer: stream, ctx: ctx})
+	tracing.End(span, err)
+	return err
+}
+
+func (h *locketHandler) Watch(req *models.WatchRequest, stream models.Locket_WatchServer) error {
+	ctx, span := startSpan(stream.Context(), "Watch")
+	err := h.watch(req, &watchStream{Locket_WatchServer: stream, ctx: ctx})
+	tracing.End(span, err)
+	return err
+}
+
+func (h *locketHandler) lock(ctx context.Context, req *models.LockRequest) (*models.LockResponse, error) {
+	logger := h.logger.Session("lock")
+	logger.Debug("started")
+	defer logger.Debug("complete")
+
+	err := h.validateLock(logger, req)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped, err := h.scopeLockRequest(req)
+	if err != nil {
+		logger.Error("invalid-request", err, lager.Data{"namespace": req.Resource.GetNamespace(), "key": req.Resource.GetKey()})
+		return nil, err
+	}
+	req = scoped
+
+	if requestID := grpcserver.RequestID(ctx); requestID != "" {
+		logger = logger.WithData(lager.Data{"request-uuid": requestID})
+	}
+
+	lock, err := h.acquire(ctx, logger, req)
+	if err != nil {
+		if err != models.ErrLockCollision {
+			logger.Error("failed-locking-lock", err, lager.Data{
+				"key":   req.Resource.Key,
+				"owner": req.Resource.Owner,
+			})
 		}
+		return nil, err
 	}
 
-	logData := lager.Data{
-		"request-id":     requestID,
-		"request-type":   requestType,
-		"resource-key":   key,
-		"resource-owner": owner,
+	h.lockPick.RegisterTTL(logger, lock)
+	h.publish(logger, lock)
+
+	return &models.LockResponse{
+		FencingToken:  lock.FencingToken,
+		ModifiedIndex: lock.ModifiedIndex,
+	}, nil
+}
+
+func (h *locketHandler) validateLock(logger lager.Logger, req *models.LockRequest) error {
+	err := validate(req, h.resourceTypes)
+	if err != nil {
+		logger.Error("invalid-request", err, lager.Data{"typeCode": req.Resource.GetTypeCode()})
+
+		return err
 	}
-	if ctx.Err() == context.Canceled {
-		h.logger.Info("context-cancelled", logData)
-		h.metrics.IncrementRequestsCancelledCounter(requestType, 1)
-	} else if ctx.Err() == context.DeadlineExceeded {
-		h.logger.Info("context-deadline-exceeded", logData)
+
+	// resources attached to a lease use the ttl of the lease
+	if req.TtlInSeconds <= 0 && req.Resource.GetLeaseId() == "" {
+		logger.Error("failed-locking-lock", models.ErrInvalidTTL, lager.Data{
+			"key":   req.Resource.GetKey(),
+			"owner": req.Resource.GetOwner(),
+		})
+		return models.ErrInvalidTTL
 	}
 
-	h.metrics.UpdateLatency(requestType, time.Since(start))
+	// user-defined types bound the ttl their resources can be acquired with
+	resourceType, userDefined := h.resourceTypes.Lookup(models.GetResource(req.Resource).Type)
+	if userDefined && req.Resource.GetLeaseId() == "" && !resourceType.ValidTTL(req.TtlInSeconds) {
+		logger.Error("failed-locking-lock", models.ErrInvalidTTL, lager.Data{
+			"key":                req.Resource.GetKey(),
+			"owner":              req.Resource.GetOwner(),
+			"type":               resourceType.Name,
+			"ttl":                req.TtlInSeconds,
+			"min-ttl-in-seconds": resourceType.MinTTLInSeconds,
+			"max-ttl-in-seconds": resourceType.MaxTTLInSeconds,
+		})
+		return models.ErrInvalidTTL
+	}
 
-	if err != nil && err != models.ErrLockCollision {
-		h.metrics.IncrementRequestsFailedCounter(requestType, 1)
-		h.exitIfUnrecoverable(err)
-	} else {
-		h.metrics.IncrementRequestsSucceededCounter(requestType, 1)
+	if req.Resource.GetOwner() == "" {
+		logger.Error("failed-locking-lock", models.ErrInvalidOwner, lager.Data{
+			"key":   req.Resource.GetKey(),
+			"owner": req.Resource.GetOwner(),
+		})
+		return models.ErrInvalidOwner
 	}
-	return err
-}
 
-func (h *locketHandler) Lock(ctx context.Context, req *models.LockRequest) (*models.LockResponse, error) {
-	var (
-		response *models.LockResponse
-		err      error
-	)
+	if _, found := models.LockMode_name[int32(req.Mode)]; !found {
+		logger.Error("failed-locking-lock", models.ErrInvalidLockMode, lager.Data{
+			"key":   req.Resource.GetKey(),
+			"owner": req.Resource.GetOwner(),
+			"mode":  req.Mode,
+		})
+		return models.ErrInvalidLockMode
+	}
+
+	// only semaphores admit a limited number of owners
+	isSemaphore := models.GetResource(req.Resource).TypeCode == models.SEMAPHORE
+	if (isSemaphore && req.SemaphoreLimit <= 0) || (!isSemaphore && req.SemaphoreLimit != 0) {
+		logger.Error("failed-locking-lock", models.ErrInvalidSemaphoreLimit, lager.Data{
+			"key":             req.Resource.GetKey(),
+			"owner":           req.Resource.GetOwner(),
+			"semaphore-limit": req.SemaphoreLimit,
+		})
+		return models.ErrInvalidSemaphoreLimit
+	}
 
-	err = h.monitorRequest("Lock", ctx, req.Resource.Key, req.Resource.Owner, func() error {
-		response, err = h.lock(ctx, req)
+	err = h.validateContents(req.Resource)
+	if err != nil {
+		logger.Error("failed-locking-lock", err, lager.Data{
+			"key":          req.Resource.GetKey(),
+			"owner":        req.Resource.GetOwner(),
+			"value-size":   len(req.Resource.GetValue()),
+			"payload-size": len(req.Resource.GetPayload()),
+		})
 		return err
-	})
+	}
 
-	return response, err
+	return nil
 }
 
-func (h *locketHandler) Release(ctx context.Context, req *models.ReleaseRequest) (*models.ReleaseResponse, error) {
-	var (
-		response *models.ReleaseResponse
-		err      error
-	)
+// publish streams exclusive holders and semaphore holders to watchers, see
+// watch.Streamed.
+func (h *locketHandler) publish(logger lager.Logger, lock *db.Lock) {
+	if !watch.Streamed(lock.Resource, lock.Mode) {
+		return
+	}
+	h.hub.Upsert(logger, lock)
+}
 
-	err = h.monitorRequest("Release", ctx, req.Resource.Key, req.Resource.Owner, func() error {
-		response, err = h.release(req)
-		return err
-	})
+// acquire tries to lock the resource. When the request has a wait timeout and
+// the lock is held by a different owner, the request joins the wait queue of
+// the key and tries again every time it is woken up at the front of the queue.
+// Waiting requests that are not at the front of the queue only renew locks
+// they already hold, so that they cannot take a lock ahead of the waiters
+// before them. Requests without a wait timeout are not queued and take a free
+// lock as they always did.
+func (h *locketHandler) acquire(ctx context.Context, logger lager.Logger, req *models.LockRequest) (*db.Lock, error) {
+	if req.WaitTimeoutInSeconds <= 0 {
+		return h.tryLock(ctx, logger, req)
+	}
+
+	// join the queue before the first attempt, otherwise a release happening
+	// right after the collision would go unnoticed
+	waiter, err := h.waiters.enqueue(logger, req.Resource.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	acquired := false
+	defer func() {
+		// a shared holder lets the next waiter try as well, it may be
+		// waiting for a shared lock too
+		h.waiters.dequeue(req.Resource.Key, waiter, acquired && !req.IsShared())
+	}()
 
-	return response, err
+	timer := h.clock.NewTimer(time.Duration(req.WaitTimeoutInSeconds) * time.Second)
+	defer timer.Stop()
+
+	for {
+		lock, err := h.tryLockInTurn(ctx, logger, req, h.waiters.atFront(req.Resource.Key, waiter))
+		if err != models.ErrLockCollision {
+			acquired = err == nil
+			return lock, err
+		}
+
+		logger.Debug("waiting-for-lock", lager.Data{"key": req.Resource.Key, "owner": req.Resource.Owner})
+
+		select {
+		case <-waiter:
+		case <-timer.C():
+			logger.Debug("timed-out-waiting-for-lock", lager.Data{"key": req.Resource.Key, "owner": req.Resource.Owner})
+			return nil, models.ErrLockCollision
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
 }
 
-func (h *locketHandler) Fetch(ctx context.Context, req *models.FetchRequest) (*models.FetchResponse, error) {
-	var (
-		response *models.FetchResponse
-		err      error
-	)
+// tryLockInTurn tries to lock the resource when it is the turn of the request,
+// otherwise only when the owner of the request already holds the lock, e.g.
+// to renew it.
+func (h *locketHandler) tryLockInTurn(ctx context.Context, logger lager.Logger, req *models.LockRequest, inTurn bool) (*db.Lock, error) {
+	if !inTurn {
+		held, err := h.holds(ctx, logger, req)
+		if err != nil {
+			return nil, err
+		}
+		if !held {
+			return nil, models.ErrLockCollision
+		}
+	}
+	return h.tryLock(ctx, logger, req)
+}
 
-	err = h.monitorRequest("Fetch", ctx, req.Key, "", func() error {
-		response, err = h.fetch(req)
-		return err
-	})
+// holds returns whether the owner of the request holds the lock, in the mode
+// of the request.
+func (h *locketHandler) holds(ctx context.Context, logger lager.Logger, req *models.LockRequest) (bool, error) {
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	defer dbCancel()
 
-	return response, err
+	if req.IsShared() {
+		locks, err := h.db.FetchSharedHolders(dbCtx, logger, req.Resource.Key)
+		if err != nil {
+			return false, err
+		}
+		for _, lock := range locks {
+			if lock.Owner == req.Resource.Owner {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	lock, err := h.db.Fetch(dbCtx, logger, req.Resource.Key)
+	if err == models.ErrResourceNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return lock.Owner == req.Resource.Owner, nil
 }
 
-func (h *locketHandler) FetchAll(ctx context.Context, req *models.FetchAllRequest) (*models.FetchAllResponse, error) {
-	var (
-		response *models.FetchAllResponse
-		err      error
-	)
+func (h *locketHandler) tryLock(ctx context.Context, logger lager.Logger, req *models.LockRequest) (*db.Lock, error) {
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	defer dbCancel()
 
-	err = h.monitorRequest("FetchAll", ctx, "", "", func() error {
-		response, err = h.fetchAll(req)
-		return err
-	})
+	if req.IsShared() {
+		return h.db.LockShared(dbCtx, logger, req.Resource, req.TtlInSeconds, int(req.SemaphoreLimit), clientIdentity(ctx))
+	}
+	return h.db.Lock(dbCtx, logger, req.Resource, req.TtlInSeconds, clientIdentity(ctx))
+}
+
+func (h *locketHandler) lockBatch(ctx context.Context, req *models.LockBatchRequest) (*models.LockBatchResponse, error) {
+	logger := h.logger.Session("lock-batch", lager.Data{"count": len(req.Requests)})
+	logger.Debug("started")
+	defer logger.Debug("complete")
+
+	results := make([]*models.LockBatchResult, len(req.Requests))
 
-	return response, err
+	// invalid requests get their error right away, the others are locked in
+	// a single transaction
+	var valid []*models.LockRequest
+	var validIndexes []int
+	for i, lockReq := range req.Requests {
+		err := h.validateLock(logger, lockReq)
+		if err != nil {
+			results[i] = models.NewLockBatchErrorResult(err)
+			continue
+		}
+
+		scoped, err := h.scopeLockRequest(lockReq)
+		if err != nil {
+			logger.Error("invalid-request", err, lager.Data{"namespace": lockReq.Resource.GetNamespace(), "key": lockReq.Resource.GetKey()})
+			results[i] = models.NewLockBatchErrorResult(err)
+			continue
+		}
+
+		valid = append(valid, scoped)
+		validIndexes = append(validIndexes, i)
+	}
+
+	if len(valid) > 0 {
+		dbCtx, dbCancel := h.newDBContext(ctx)
+		defer dbCancel()
+
+		locks, errs, err := h.db.LockBatch(dbCtx, logger, valid, clientIdentity(ctx))
+		if err != nil {
+			logger.Error("failed-locking-batch", err)
+			return nil, err
+		}
+
+		for j, i := range validIndexes {
+			if errs[j] != nil {
+				results[i] = models.NewLockBatchErrorResult(errs[j])
+				continue
+			}
+
+			h.lockPick.RegisterTTL(logger, locks[j])
+			h.publish(logger, locks[j])
+
+			results[i] = &models.LockBatchResult{
+				Response: &models.LockResponse{
+					FencingToken:  locks[j].FencingToken,
+					ModifiedIndex: locks[j].ModifiedIndex,
+				},
+			}
+		}
+	}
+
+	return &models.LockBatchResponse{
+		Results: results,
+	}, nil
 }
 
-func (h *locketHandler) lock(ctx context.Context, req *models.LockRequest) (*models.LockResponse, error) {
-	logger := h.logger.Session("lock")
+func (h *locketHandler) lockMulti(ctx context.Context, req *models.LockMultiRequest) (*models.LockMultiResponse, error) {
+	logger := h.logger.Session("lock-multi", lager.Data{"count": len(req.Requests)})
 	logger.Debug("started")
 	defer logger.Debug("complete")
 
-	err := validate(req)
+	// a single invalid request fails all of them
+	scopedReqs := make([]*models.LockRequest, len(req.Requests))
+	for i, lockReq := range req.Requests {
+		err := h.validateLock(logger, lockReq)
+		if err != nil {
+			return nil, err
+		}
+
+		scopedReqs[i], err = h.scopeLockRequest(lockReq)
+		if err != nil {
+			logger.Error("invalid-request", err, lager.Data{"namespace": lockReq.Resource.GetNamespace(), "key": lockReq.Resource.GetKey()})
+			return nil, err
+		}
+	}
+
+	responses := make([]*models.LockResponse, len(req.Requests))
+	if len(req.Requests) == 0 {
+		return &models.LockMultiResponse{Responses: responses}, nil
+	}
+
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	defer dbCancel()
+
+	locks, err := h.db.LockMulti(dbCtx, logger, scopedReqs, clientIdentity(ctx))
 	if err != nil {
-		logger.Error("invalid-request", err, lager.Data{"typeCode": req.Resource.GetTypeCode()})
+		if err != models.ErrLockCollision {
+			logger.Error("failed-locking-multi", err)
+		}
+		return nil, err
+	}
+
+	for i, lock := range locks {
+		h.lockPick.RegisterTTL(logger, lock)
+		h.publish(logger, lock)
+
+		responses[i] = &models.LockResponse{
+			FencingToken:  lock.FencingToken,
+			ModifiedIndex: lock.ModifiedIndex,
+		}
+	}
+
+	return &models.LockMultiResponse{
+		Responses: responses,
+	}, nil
+}
 
+func (h *locketHandler) release(ctx context.Context, req *models.ReleaseRequest) (*models.ReleaseResponse, error) {
+	logger := h.logger.Session("release")
+	logger.Debug("started")
+	defer logger.Debug("complete")
+
+	if _, found := models.LockMode_name[int32(req.Mode)]; !found {
+		logger.Error("invalid-request", models.ErrInvalidLockMode, lager.Data{"mode": req.Mode})
+		return nil, models.ErrInvalidLockMode
+	}
+
+	resource, err := h.scopeResource(req.Resource)
+	if err != nil {
+		logger.Error("invalid-request", err, lager.Data{"namespace": req.Resource.GetNamespace(), "key": req.Resource.GetKey()})
 		return nil, err
 	}
 
-	if req.TtlInSeconds <= 0 {
-		logger.Error("failed-locking-lock", models.ErrInvalidTTL, lager.Data{
-			"key":   req.Resource.Key,
-			"owner": req.Resource.Owner,
-		})
-		return nil, models.ErrInvalidTTL
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	defer dbCancel()
+
+	if req.IsShared() {
+		err := h.db.ReleaseShared(dbCtx, logger, resource)
+		if err != nil {
+			return nil, err
+		}
+
+		// waiters are only woken up by the events of exclusive holders
+		h.waiters.notify(resource.Key)
+		if watch.Streamed(resource, models.SHARED) {
+			h.hub.Remove(logger, resource, models.DELETED)
+		}
+		return &models.ReleaseResponse{}, nil
 	}
 
-	if req.Resource.Owner == "" {
-		logger.Error("failed-locking-lock", models.ErrInvalidOwner, lager.Data{
-			"key":   req.Resource.Key,
-			"owner": req.Resource.Owner,
+	err = h.db.Release(dbCtx, logger, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	h.hub.Remove(logger, resource, models.DELETED)
+
+	return &models.ReleaseResponse{}, nil
+}
+
+func (h *locketHandler) update(ctx context.Context, req *models.UpdateRequest) (*models.UpdateResponse, error) {
+	logger := h.logger.Session("update")
+	logger.Debug("started")
+	defer logger.Debug("complete")
+
+	// a request without a resource has no owner either
+	if req.GetResource().GetOwner() == "" {
+		logger.Error("failed-updating-lock", models.ErrInvalidOwner, lager.Data{
+			"key":   req.GetResource().GetKey(),
+			"owner": req.GetResource().GetOwner(),
 		})
 		return nil, models.ErrInvalidOwner
 	}
 
-	md, _ := metadata.FromIncomingContext(ctx)
-	requestUUID := md["uuid"]
-	if len(requestUUID) > 0 {
-		logger = logger.WithData(lager.Data{"request-uuid": requestUUID[0]})
+	err := h.validateContents(req.Resource)
+	if err != nil {
+		logger.Error("failed-updating-lock", err, lager.Data{
+			"key":          req.Resource.Key,
+			"owner":        req.Resource.Owner,
+			"value-size":   len(req.Resource.Value),
+			"payload-size": len(req.Resource.Payload),
+		})
+		return nil, err
+	}
+
+	resource, err := h.scopeResource(req.Resource)
+	if err != nil {
+		logger.Error("invalid-request", err, lager.Data{"namespace": req.Resource.Namespace, "key": req.Resource.Key})
+		return nil, err
 	}
 
-	dbCtx, dbCancel := h.newDBContext()
+	dbCtx, dbCancel := h.newDBContext(ctx)
 	defer dbCancel()
 
-	lock, err := h.db.Lock(dbCtx, logger, req.Resource, req.TtlInSeconds)
+	lock, err := h.db.Update(dbCtx, logger, resource, req.ExpectedModifiedIndex)
 	if err != nil {
-		if err != models.ErrLockCollision {
-			logger.Error("failed-locking-lock", err, lager.Data{
+		if err != models.ErrModifiedIndexMismatch {
+			logger.Error("failed-updating-lock", err, lager.Data{
 				"key":   req.Resource.Key,
 				"owner": req.Resource.Owner,
 			})
@@ -198,82 +605,699 @@ func (h *locketHandler) lock(ctx context.Context, req *models.LockRequest) (*mod
 		return nil, err
 	}
 
+	// the modified index changed, so the expiration check registered for the
+	// previous index would no longer release the lock
 	h.lockPick.RegisterTTL(logger, lock)
+	h.hub.Upsert(logger, lock)
 
-	return &models.LockResponse{}, nil
+	return &models.UpdateResponse{
+		ModifiedIndex: lock.ModifiedIndex,
+	}, nil
 }
 
-func (h *locketHandler) release(req *models.ReleaseRequest) (*models.ReleaseResponse, error) {
-	logger := h.logger.Session("release")
+func (h *locketHandler) transfer(ctx context.Context, req *models.TransferRequest) (*models.TransferResponse, error) {
+	logger := h.logger.Session("transfer")
+	logger.Debug("started")
+	defer logger.Debug("complete")
+
+	// a request without a resource has no owner either, and transferring a
+	// lock to its owner would only bump its fencing token
+	owner := req.GetResource().GetOwner()
+	if owner == "" || req.NewOwner == "" || req.NewOwner == owner {
+		logger.Error("failed-transferring-lock", models.ErrInvalidOwner, lager.Data{
+			"key":       req.GetResource().GetKey(),
+			"owner":     owner,
+			"new-owner": req.NewOwner,
+		})
+		return nil, models.ErrInvalidOwner
+	}
+
+	resource, err := h.scopeResource(req.Resource)
+	if err != nil {
+		logger.Error("invalid-request", err, lager.Data{"namespace": req.Resource.Namespace, "key": req.Resource.Key})
+		return nil, err
+	}
+
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	defer dbCancel()
+
+	lock, err := h.db.Transfer(dbCtx, logger, resource, req.NewOwner)
+	if err != nil {
+		data := lager.Data{
+			"key":       req.Resource.Key,
+			"owner":     req.Resource.Owner,
+			"new-owner": req.NewOwner,
+		}
+		if err == models.ErrLockCollision || err == models.ErrModifiedIndexMismatch {
+			logger.Info("did-not-transfer-lock", data, lager.Data{"reason": err.Error()})
+		} else {
+			logger.Error("failed-transferring-lock", err, data)
+		}
+		return nil, err
+	}
+
+	h.lockPick.RegisterTTL(logger, lock)
+	h.hub.Upsert(logger, lock)
+
+	return &models.TransferResponse{
+		FencingToken:  lock.FencingToken,
+		ModifiedIndex: lock.ModifiedIndex,
+	}, nil
+}
+
+// forceRelease releases a lock regardless of its owner, e.g. a lock left
+// behind by an owner that is wedged but still renewing it. Only clients
+// granted the operation by an authorization rule are allowed to do so, which
+// servers without authorization rules never do.
+func (h *locketHandler) forceRelease(ctx context.Context, req *models.ForceReleaseRequest) (*models.ForceReleaseResponse, error) {
+	logger := h.logger.Session("force-release")
 	logger.Debug("started")
 	defer logger.Debug("complete")
 
-	dbCtx, dbCancel := h.newDBContext()
+	actor := clientIdentity(ctx)
+	if !grpcserver.Authorized(ctx) {
+		logger.Error("unauthorized", models.ErrPermissionDenied, lager.Data{"key": req.Key, "actor": actor})
+		return nil, models.ErrPermissionDenied
+	}
+
+	if req.Reason == "" {
+		logger.Error("invalid-request", models.ErrInvalidReason, lager.Data{"key": req.Key, "actor": actor})
+		return nil, models.ErrInvalidReason
+	}
+
+	key, err := h.scopeKey(req.Namespace, req.Key)
+	if err != nil {
+		logger.Error("invalid-request", err, lager.Data{"namespace": req.Namespace, "key": req.Key, "actor": actor})
+		return nil, err
+	}
+
+	dbCtx, dbCancel := h.newDBContext(ctx)
 	defer dbCancel()
 
-	err := h.db.Release(dbCtx, logger, req.Resource)
+	locks, err := h.db.ForceRelease(dbCtx, logger, key, actor, req.Reason)
 	if err != nil {
+		logger.Error("failed-force-releasing-lock", err, lager.Data{"key": req.Key, "actor": actor})
 		return nil, err
 	}
 
-	return &models.ReleaseResponse{}, nil
+	h.publishReleased(logger, locks)
+
+	released := make([]*models.Resource, 0, len(locks))
+	for _, lock := range locks {
+		released = append(released, unscopeResource(lock.Resource))
+	}
+
+	return &models.ForceReleaseResponse{Released: released}, nil
 }
 
-func (h *locketHandler) fetch(req *models.FetchRequest) (*models.FetchResponse, error) {
+func (h *locketHandler) fetch(ctx context.Context, req *models.FetchRequest) (*models.FetchResponse, error) {
 	logger := h.logger.Session("fetch")
 	logger.Debug("started")
 	defer logger.Debug("complete")
 
-	dbCtx, dbCancel := h.newDBContext()
+	key, err := h.scopeKey(req.Namespace, req.Key)
+	if err != nil {
+		logger.Error("invalid-request", err, lager.Data{"namespace": req.Namespace, "key": req.Key})
+		return nil, err
+	}
+
+	dbCtx, dbCancel := h.newDBContext(ctx)
 	defer dbCancel()
 
-	lock, err := h.db.Fetch(dbCtx, logger, req.Key)
+	lock, err := h.db.Fetch(dbCtx, logger, key)
+	if err == models.ErrResourceNotFound {
+		return h.fetchSharedHolders(ctx, dbCtx, logger, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resource := withLeaseTiming(lock)
+	err = h.hideForeignLeases(ctx, dbCtx, logger, []*models.Resource{resource})
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.FetchResponse{
-		Resource: lock.Resource,
+		Resource:      resource,
+		FencingToken:  lock.FencingToken,
+		ModifiedIndex: lock.ModifiedIndex,
 	}, nil
 }
 
-func (h *locketHandler) fetchAll(req *models.FetchAllRequest) (*models.FetchAllResponse, error) {
+// fetchSharedHolders returns the shared holders of a key that has no
+// exclusive holder.
+func (h *locketHandler) fetchSharedHolders(ctx, dbCtx context.Context, logger lager.Logger, key string) (*models.FetchResponse, error) {
+	locks, err := h.db.FetchSharedHolders(dbCtx, logger, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(locks) == 0 {
+		return nil, models.ErrResourceNotFound
+	}
+
+	var holders []*models.Resource
+	for _, lock := range locks {
+		holders = append(holders, withLeaseTiming(lock))
+	}
+
+	err = h.hideForeignLeases(ctx, dbCtx, logger, holders)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FetchResponse{
+		SharedHolders: holders,
+	}, nil
+}
+
+func (h *locketHandler) fetchAll(ctx context.Context, req *models.FetchAllRequest) (*models.FetchAllResponse, error) {
 	logger := h.logger.Session("fetch-all")
 	logger.Debug("started")
 	defer logger.Debug("complete")
 
-	err := validate(req)
+	err := validate(req, h.resourceTypes)
 	if err != nil {
 		logger.Error("invalid-request", err, lager.Data{"typeCode": req.GetTypeCode()})
 		return nil, err
 	}
 
-	dbCtx, dbCancel := h.newDBContext()
+	err = h.validateNamespace(req.Namespace)
+	if err != nil {
+		logger.Error("invalid-request", err, lager.Data{"namespace": req.Namespace})
+		return nil, err
+	}
+
+	if req.PageSize < 0 {
+		logger.Error("invalid-request", models.ErrInvalidPageSize, lager.Data{"page-size": req.PageSize})
+		return nil, models.ErrInvalidPageSize
+	}
+
+	startAfter, startAfterOwner, err := decodeContinuationToken(req.ContinuationToken)
+	if err != nil {
+		logger.Error("invalid-request", models.ErrInvalidContinuationToken, lager.Data{"continuation-token": req.ContinuationToken})
+		return nil, models.ErrInvalidContinuationToken
+	}
+
+	// fetch one more lock than requested to know whether there is a next page
+	limit := 0
+	if req.PageSize > 0 {
+		limit = int(req.PageSize) + 1
+	}
+
+	dbCtx, dbCancel := h.newDBContext(ctx)
 	defer dbCancel()
 
-	locks, err := h.db.FetchAll(dbCtx, logger, models.GetType(&models.Resource{TypeCode: req.TypeCode}))
+	// semaphores are only held in shared mode, so their holders are listed
+	lockType := models.GetType(&models.Resource{TypeCode: req.TypeCode, Type: req.Type})
+	var locks []*db.Lock
+	if lockType == models.SemaphoreType {
+		locks, err = h.db.FetchSharedPage(dbCtx, logger, req.Namespace, lockType, req.KeyPrefix, startAfter, startAfterOwner, req.LabelSelector, limit)
+	} else {
+		locks, err = h.db.FetchPage(dbCtx, logger, req.Namespace, lockType, req.KeyPrefix, startAfter, req.LabelSelector, limit)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	hasNextPage := req.PageSize > 0 && len(locks) > int(req.PageSize)
+	if hasNextPage {
+		locks = locks[:req.PageSize]
+	}
+
 	var responses []*models.Resource
 	for _, lock := range locks {
-		responses = append(responses, lock.Resource)
+		responses = append(responses, withLeaseTiming(lock))
+	}
+
+	err = h.hideForeignLeases(ctx, dbCtx, logger, responses)
+	if err != nil {
+		return nil, err
+	}
+
+	// the token holds the key within the namespace, as clients know it, and
+	// the owner of the last shared holder
+	var continuationToken string
+	if hasNextPage {
+		last := locks[len(locks)-1]
+		lastOwner := ""
+		if last.Mode == models.SHARED {
+			lastOwner = last.Owner
+		}
+		continuationToken = encodeContinuationToken(responses[len(responses)-1].Key, lastOwner)
 	}
 
 	return &models.FetchAllResponse{
-		Resources: responses,
+		Resources:         responses,
+		ContinuationToken: continuationToken,
 	}, nil
 }
 
-func validate(req interface{}) error {
+// withLeaseTiming fills in when the lock was acquired and last renewed, and
+// the earliest time at which it can expire. Locks written before these were
+// tracked have no renewal time and are returned without an expiration, as are
+// locks attached to a lease, which expire whenever the lease does. The key of
+// the returned resource is split from its namespace.
+func withLeaseTiming(lock *db.Lock) *models.Resource {
+	resource := unscopeResource(lock.Resource)
+	resource.AcquiredAt = lock.AcquiredAt
+	resource.RenewedAt = lock.RenewedAt
+	resource.TtlInSeconds = lock.TtlInSeconds
+	if lock.RenewedAt != 0 && lock.LeaseId == "" {
+		resource.ExpiresAt = lock.RenewedAt + int64(time.Duration(lock.TtlInSeconds)*time.Second)
+	}
+	return resource
+}
+
+func (h *locketHandler) grantLease(ctx context.Context, req *models.GrantLeaseRequest) (*models.GrantLeaseResponse, error) {
+	logger := h.logger.Session("grant-lease")
+	logger.Debug("started")
+	defer logger.Debug("complete")
+
+	if req.TtlInSeconds <= 0 {
+		logger.Error("failed-granting-lease", models.ErrInvalidTTL, lager.Data{"ttl": req.TtlInSeconds})
+		return nil, models.ErrInvalidTTL
+	}
+
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	defer dbCancel()
+
+	lease, err := h.db.GrantLease(dbCtx, logger, req.TtlInSeconds, clientIdentity(ctx))
+	if err != nil {
+		logger.Error("failed-granting-lease", err)
+		return nil, err
+	}
+
+	h.lockPick.RegisterLease(logger, lease)
+
+	return &models.GrantLeaseResponse{
+		LeaseId:      lease.ID,
+		TtlInSeconds: lease.TtlInSeconds,
+	}, nil
+}
+
+func (h *locketHandler) keepAliveLease(ctx context.Context, req *models.KeepAliveLeaseRequest) (*models.KeepAliveLeaseResponse, error) {
+	logger := h.logger.Session("keep-alive-lease", lager.Data{"lease-id": req.LeaseId})
+	logger.Debug("started")
+	defer logger.Debug("complete")
+
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	defer dbCancel()
+
+	lease, err := h.db.KeepAliveLease(dbCtx, logger, req.LeaseId, clientIdentity(ctx))
+	if err != nil {
+		if err != models.ErrLeaseNotFound {
+			logger.Error("failed-keeping-lease-alive", err)
+		}
+		return nil, err
+	}
+
+	// the modified index changed, so the expiration check registered for the
+	// previous index would no longer release the lease
+	h.lockPick.RegisterLease(logger, lease)
+
+	return &models.KeepAliveLeaseResponse{
+		TtlInSeconds: lease.TtlInSeconds,
+	}, nil
+}
+
+func (h *locketHandler) revokeLease(ctx context.Context, req *models.RevokeLeaseRequest) (*models.RevokeLeaseResponse, error) {
+	logger := h.logger.Session("revoke-lease", lager.Data{"lease-id": req.LeaseId})
+	logger.Debug("started")
+	defer logger.Debug("complete")
+
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	defer dbCancel()
+
+	locks, err := h.db.RevokeLease(dbCtx, logger, req.LeaseId, clientIdentity(ctx))
+	if err != nil {
+		if err != models.ErrLeaseNotFound {
+			logger.Error("failed-revoking-lease", err)
+		}
+		return nil, err
+	}
+
+	h.publishReleased(logger, locks)
+
+	return &models.RevokeLeaseResponse{}, nil
+}
+
+// publishReleased streams the release of the locks of a revoked lease to
+// watchers, and wakes up the waiters of its shared holders.
+func (h *locketHandler) publishReleased(logger lager.Logger, locks []*db.Lock) {
+	for _, lock := range locks {
+		if lock.Mode == models.SHARED {
+			h.waiters.notify(lock.Key)
+		}
+		if watch.Streamed(lock.Resource, lock.Mode) {
+			h.hub.Remove(logger, lock.Resource, models.DELETED)
+		}
+	}
+}
+
+// session holds the locks acquired through the stream on a lease granted when
+// the session starts. The lease is revoked as soon as the stream ends, so the
+// locks do not outlive the client by a whole ttl. The ttl of the lease only
+// matters when the server goes away along with the stream.
+func (h *locketHandler) session(stream models.Locket_SessionServer) error {
+	logger := h.logger.Session("session")
+	logger.Debug("started")
+	defer logger.Debug("complete")
+
+	ctx := stream.Context()
+
+	req, err := stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	if req.TtlInSeconds <= 0 {
+		logger.Error("failed-starting-session", models.ErrInvalidTTL, lager.Data{"ttl": req.TtlInSeconds})
+		return models.ErrInvalidTTL
+	}
+
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	lease, err := h.db.GrantLease(dbCtx, logger, req.TtlInSeconds, clientIdentity(ctx))
+	dbCancel()
+	if err != nil {
+		logger.Error("failed-starting-session", err)
+		return err
+	}
+	h.lockPick.RegisterLease(logger, lease)
+
+	logger = logger.WithData(lager.Data{"lease-id": lease.ID})
+	logger.Info("session-started")
+	defer h.endSession(ctx, logger, lease.ID)
+
+	err = stream.Send(&models.SessionResponse{
+		LeaseId:      lease.ID,
+		TtlInSeconds: lease.TtlInSeconds,
+	})
+	if err != nil {
+		logger.Error("failed-to-send-response", err)
+		return err
+	}
+
+	// keep-alives are handled as soon as they are received, so that a lock
+	// waiting for its turn cannot let the lease of the session expire. Locks
+	// and releases are handled one at a time, in the order they are received,
+	// and the responses are sent in the order of the requests.
+	sessionCtx, sessionCancel := context.WithCancel(ctx)
+	defer sessionCancel()
+
+	replies := make(chan chan sessionReply, maxPendingSessionRequests)
+	receiveErrCh := make(chan error, 1)
+	go func() {
+		receiveErrCh <- h.receiveSessionRequests(sessionCtx, stream, lease.ID, replies)
+	}()
+
+	for reply := range replies {
+		var r sessionReply
+		select {
+		case r = <-reply:
+		case <-sessionCtx.Done():
+			logger.Info("session-closed")
+			return nil
+		}
+
+		if r.err == models.ErrLeaseNotFound {
+			logger.Info("session-expired")
+			return r.err
+		}
+		if r.err != nil {
+			h.exitIfUnrecoverable(r.err)
+			r.resp = models.NewSessionErrorResponse(r.err)
+		}
+
+		err = stream.Send(r.resp)
+		if err != nil {
+			logger.Error("failed-to-send-response", err)
+			return err
+		}
+	}
+
+	err = <-receiveErrCh
+	if err == io.EOF || ctx.Err() != nil {
+		logger.Info("session-closed")
+		return nil
+	}
+	logger.Error("failed-to-receive-request", err)
+	return err
+}
+
+// maxPendingSessionRequests is the number of requests of a session that are
+// received ahead of their responses.
+const maxPendingSessionRequests = 64
+
+type sessionReply struct {
+	resp *models.SessionResponse
+	err  error
+}
+
+type sessionCall struct {
+	req   *models.SessionRequest
+	reply chan sessionReply
+}
+
+// receiveSessionRequests receives the requests of the session until the
+// stream ends, and queues a reply for each of them in replies, in order. Keep-
+// alives are handled right away, while locks and releases are handed to a
+// single worker. replies is closed once the stream ended and every queued
+// request was handled, and the error that ended the stream is returned.
+func (h *locketHandler) receiveSessionRequests(ctx context.Context, stream models.Locket_SessionServer, leaseID string, replies chan<- chan sessionReply) error {
+	calls := make(chan sessionCall, maxPendingSessionRequests)
+	workerDone := make(chan struct{})
+	defer func() {
+		close(calls)
+		<-workerDone
+		close(replies)
+	}()
+
+	go func() {
+		defer close(workerDone)
+		for call := range calls {
+			resp, err := h.sessionRequest(ctx, leaseID, call.req)
+			call.reply <- sessionReply{resp: resp, err: err}
+		}
+	}()
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		reply := make(chan sessionReply, 1)
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if req.Lock == nil && req.Release == nil {
+			resp, err := h.sessionRequest(ctx, leaseID, req)
+			reply <- sessionReply{resp: resp, err: err}
+			continue
+		}
+
+		select {
+		case calls <- sessionCall{req: req, reply: reply}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// sessionRequest locks or releases a resource on the lease of the session,
+// or keeps the lease alive when the request has neither.
+func (h *locketHandler) sessionRequest(ctx context.Context, leaseID string, req *models.SessionRequest) (*models.SessionResponse, error) {
+	switch {
+	case req.Lock != nil:
+		lockReq := *req.Lock
+		if lockReq.Resource != nil {
+			resource := *lockReq.Resource
+			resource.LeaseId = leaseID
+			lockReq.Resource = &resource
+		}
+
+		resp, err := h.lock(ctx, &lockReq)
+		if err != nil {
+			return nil, err
+		}
+		return &models.SessionResponse{Lock: resp}, nil
+
+	case req.Release != nil:
+		resp, err := h.release(ctx, req.Release)
+		if err != nil {
+			return nil, err
+		}
+		return &models.SessionResponse{Release: resp}, nil
+
+	default:
+		resp, err := h.keepAliveLease(ctx, &models.KeepAliveLeaseRequest{LeaseId: leaseID})
+		if err != nil {
+			return nil, err
+		}
+		return &models.SessionResponse{TtlInSeconds: resp.TtlInSeconds}, nil
+	}
+}
+
+// endSession revokes the lease of the session with a database context that is
+// not cancelled, the context of the stream is already done when the client
+// went away.
+func (h *locketHandler) endSession(ctx context.Context, logger lager.Logger, leaseID string) {
+	dbCtx, dbCancel := h.newDBContext(ctx)
+	defer dbCancel()
+
+	locks, err := h.db.RevokeLease(dbCtx, logger, leaseID, clientIdentity(ctx))
+	if err != nil {
+		if err != models.ErrLeaseNotFound {
+			logger.Error("failed-revoking-lease", err)
+		}
+		return
+	}
+
+	logger.Info("session-ended", lager.Data{"released-locks": len(locks)})
+	h.publishReleased(logger, locks)
+}
+
+// encodeContinuationToken encodes the key of the last resource of a page, and
+// the owner of the last shared holder after a dot, as a key can have more
+// shared holders than fit in a page.
+func encodeContinuationToken(lastKey, lastOwner string) string {
+	token := base64.RawURLEncoding.EncodeToString([]byte(lastKey))
+	if lastOwner != "" {
+		token += "." + base64.RawURLEncoding.EncodeToString([]byte(lastOwner))
+	}
+	return token
+}
+
+func decodeContinuationToken(token string) (string, string, error) {
+	encodedKey, encodedOwner, _ := strings.Cut(token, ".")
+
+	lastKey, err := base64.RawURLEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	lastOwner, err := base64.RawURLEncoding.DecodeString(encodedOwner)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(lastKey), string(lastOwner), nil
+}
+
+func (h *locketHandler) watch(req *models.WatchRequest, stream models.Locket_WatchServer) error {
+	logger := h.logger.Session("watch", lager.Data{
+		"key":            req.Key,
+		"key-prefix":     req.KeyPrefix,
+		"namespace":      req.Namespace,
+		"type-code":      req.TypeCode,
+		"type":           req.Type,
+		"start-revision": req.StartRevision,
+	})
+	logger.Debug("started")
+	defer logger.Debug("complete")
+
+	err := validate(req, h.resourceTypes)
+	if err != nil {
+		logger.Error("invalid-request", err)
+		return err
+	}
+
+	err = h.validateNamespace(req.Namespace)
+	if err != nil {
+		logger.Error("invalid-request", err)
+		return err
+	}
+
+	filter := watch.Filter{
+		Namespace: req.Namespace,
+		TypeCode:  req.TypeCode,
+		Type:      req.Type,
+	}
+	if req.Key != "" {
+		filter.Key, err = h.scopeKey(req.Namespace, req.Key)
+		if err != nil {
+			logger.Error("invalid-request", err)
+			return err
+		}
+	}
+	if req.KeyPrefix != "" {
+		filter.KeyPrefix, err = h.scopeKey(req.Namespace, req.KeyPrefix)
+		if err != nil {
+			logger.Error("invalid-request", err)
+			return err
+		}
+	}
+	sub, err := h.hub.Subscribe(filter, req.StartRevision)
+	if err != nil {
+		logger.Error("failed-to-subscribe", err)
+		return err
+	}
+	defer sub.Close()
+
+	// let the client know that the watch is established, so that it can list
+	// the current resources without missing any later change
+	err = stream.SendHeader(metadata.MD{})
+	if err != nil {
+		logger.Error("failed-to-send-header", err)
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			logger.Debug("watcher-disconnected")
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				err := sub.Err()
+				if err != nil {
+					logger.Error("subscription-closed", err)
+				}
+				return err
+			}
+
+			unscoped := unscopeEvent(event)
+			h.hideForeignLease(stream.Context(), logger, unscoped.Resource)
+
+			err := stream.Send(unscoped)
+			if err != nil {
+				logger.Error("failed-to-send-event", err, lager.Data{"revision": event.Revision})
+				return err
+			}
+		}
+	}
+}
+
+func validate(req interface{}, resourceTypes models.ResourceTypes) error {
 	var reqTypeCode models.TypeCode
+	var reqType string
 
 	switch incomingReq := req.(type) {
 	case *models.LockRequest:
 		reqTypeCode = incomingReq.Resource.GetTypeCode()
+		reqType = incomingReq.Resource.GetType()
 	case *models.FetchAllRequest:
 		reqTypeCode = incomingReq.GetTypeCode()
+		reqType = incomingReq.GetType()
+	case *models.WatchRequest:
+		if _, found := models.TypeCode_name[int32(incomingReq.GetTypeCode())]; !found {
+			return models.ErrInvalidType
+		}
+		// watching all the types is allowed
+		if incomingReq.GetTypeCode() == models.UNKNOWN && incomingReq.GetType() != "" {
+			if _, found := resourceTypes.Lookup(incomingReq.GetType()); !found {
+				return models.ErrInvalidType
+			}
+		}
+		return nil
 	default:
 		return nil
 	}
@@ -282,8 +1306,47 @@ func validate(req interface{}) error {
 		return models.ErrInvalidType
 	}
 
+	// resources of a user-defined type are identified by the name of the type
 	if reqTypeCode == models.UNKNOWN {
-		return models.ErrInvalidType
+		if _, found := resourceTypes.Lookup(reqType); !found {
+			return models.ErrInvalidType
+		}
+	}
+
+	return nil
+}
+
+// validateContents checks that the value, payload and labels of the resource
+// fit in their columns, so that oversized resources are rejected before they
+// reach the database.
+func (h *locketHandler) validateContents(resource *models.Resource) error {
+	if len(resource.GetValue()) > models.MaxValueLength {
+		return models.ErrValueTooLarge
+	}
+
+	if len(resource.GetPayload()) > h.maxPayloadSize {
+		return models.ErrPayloadTooLarge
+	}
+
+	return validateLabels(resource.GetLabels())
+}
+
+// validateLabels checks that the labels have non-empty keys and fit in the
+// labels column once encoded.
+func validateLabels(labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	for key := range labels {
+		if key == "" {
+			return models.ErrInvalidLabels
+		}
+	}
+
+	encoded, err := json.Marshal(labels)
+	if err != nil || len(encoded) > models.MaxLabelsLength {
+		return models.ErrInvalidLabels
 	}
 
 	return nil
@@ -2,39 +2,68 @@ package handlers_test
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
-	"code.cloudfoundry.org/diego-db-helpers/sqldb/helpers"
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/lager/v3/lagertest"
 	"code.cloudfoundry.org/locket/db"
 	"code.cloudfoundry.org/locket/db/dbfakes"
 	"code.cloudfoundry.org/locket/expiration/expirationfakes"
+	"code.cloudfoundry.org/locket/grpcserver"
 	"code.cloudfoundry.org/locket/handlers"
-	"code.cloudfoundry.org/locket/metrics/helpers/helpersfakes"
 	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/watch"
+	"code.cloudfoundry.org/locket/watch/watchfakes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 var _ = Describe("LocketHandler", func() {
 	var (
-		fakeLockDB         *dbfakes.FakeLockDB
-		fakeLockPick       *expirationfakes.FakeLockPick
-		logger             *lagertest.TestLogger
-		locketHandler      models.LocketServer
-		resource           *models.Resource
-		exitCh             chan struct{}
-		fakeRequestMetrics *helpersfakes.FakeRequestMetrics
+		fakeLockDB    *dbfakes.FakeLockDB
+		fakeLockPick  *expirationfakes.FakeLockPick
+		fakeHub       *watchfakes.FakeHub
+		fakeClock     *fakeclock.FakeClock
+		logger        *lagertest.TestLogger
+		locketHandler models.LocketServer
+		resource      *models.Resource
+		exitCh        chan struct{}
+		resourceTypes models.ResourceTypes
+		namespaces    models.Namespaces
 	)
 
 	BeforeEach(func() {
+		var err error
+		resourceTypes, err = models.NewResourceTypes([]models.ResourceType{
+			{Name: "maintenance-window", MinTTLInSeconds: 5, MaxTTLInSeconds: 60},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		namespaces, err = models.NewNamespaces([]models.Namespace{{Name: "diego"}})
+		Expect(err).NotTo(HaveOccurred())
+
 		fakeLockDB = &dbfakes.FakeLockDB{}
 		fakeLockPick = &expirationfakes.FakeLockPick{}
-		fakeRequestMetrics = &helpersfakes.FakeRequestMetrics{}
+		fakeHub = &watchfakes.FakeHub{}
+		fakeClock = fakeclock.NewFakeClock(time.Now())
 
 		logger = lagertest.NewTestLogger("locket-handler")
 		exitCh = make(chan struct{}, 1)
@@ -50,9 +79,13 @@ var _ = Describe("LocketHandler", func() {
 			logger,
 			fakeLockDB,
 			fakeLockPick,
-			fakeRequestMetrics,
+			fakeHub,
+			fakeClock,
 			exitCh,
 			handlers.DefaultDBOperationTimeout,
+			resourceTypes,
+			1024,
+			namespaces,
 		)
 	})
 
@@ -72,6 +105,7 @@ var _ = Describe("LocketHandler", func() {
 				Resource:      resource,
 				TtlInSeconds:  10,
 				ModifiedIndex: 2,
+				FencingToken:  7,
 			}
 
 			fakeLockDB.LockReturns(expectedLock, nil)
@@ -82,23 +116,23 @@ var _ = Describe("LocketHandler", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeLockDB.LockCallCount()).To(Equal(1))
 
-			_, _, actualResource, ttl := fakeLockDB.LockArgsForCall(0)
+			_, _, actualResource, ttl, _ := fakeLockDB.LockArgsForCall(0)
 			Expect(actualResource).To(Equal(resource))
 			Expect(ttl).To(BeEquivalentTo(10))
-
-			metricsRecordSuccess(fakeRequestMetrics)
-			metricsUseCorrectCallTags(fakeRequestMetrics, "Lock")
 		})
 
-		It("increments the in-flight counter and then decrements it when done", func() {
-			_, err := locketHandler.Lock(context.Background(), request)
+		It("only lets the lock be attached to the leases granted by the client", func() {
+			_, err := locketHandler.Lock(contextWithClientCommonName("cell"), request)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(fakeLockDB.LockCallCount()).To(Equal(1))
 
-			_, delta := fakeRequestMetrics.IncrementRequestsInFlightCounterArgsForCall(0)
-			Expect(delta).To(BeEquivalentTo(1))
-			_, delta = fakeRequestMetrics.DecrementRequestsInFlightCounterArgsForCall(0)
-			Expect(delta).To(BeEquivalentTo(1))
+			_, _, _, _, leaseOwner := fakeLockDB.LockArgsForCall(0)
+			Expect(leaseOwner).To(Equal("cell"))
+		})
+
+		It("returns the fencing token of the lock", func() {
+			resp, err := locketHandler.Lock(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.FencingToken).To(BeEquivalentTo(7))
 		})
 
 		It("registers the lock and ttl with the lock pick", func() {
@@ -108,9 +142,15 @@ var _ = Describe("LocketHandler", func() {
 			Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(1))
 			_, lock := fakeLockPick.RegisterTTLArgsForCall(0)
 			Expect(lock).To(Equal(expectedLock))
+		})
+
+		It("publishes the lock to the watch hub", func() {
+			_, err := locketHandler.Lock(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
 
-			metricsRecordSuccess(fakeRequestMetrics)
-			metricsUseCorrectCallTags(fakeRequestMetrics, "Lock")
+			Expect(fakeHub.UpsertCallCount()).To(Equal(1))
+			_, lock := fakeHub.UpsertArgsForCall(0)
+			Expect(lock).To(Equal(expectedLock))
 		})
 
 		Context("validate lock type", func() {
@@ -120,9 +160,6 @@ var _ = Describe("LocketHandler", func() {
 					request.Resource.TypeCode = models.LOCK
 					_, err := locketHandler.Lock(context.Background(), request)
 					Expect(err).NotTo(HaveOccurred())
-
-					metricsRecordSuccess(fakeRequestMetrics)
-					metricsUseCorrectCallTags(fakeRequestMetrics, "Lock")
 				})
 
 				It("should be invalid on an UNKNOWN type code and empty type", func() {
@@ -130,9 +167,6 @@ var _ = Describe("LocketHandler", func() {
 					request.Resource.TypeCode = models.UNKNOWN
 					_, err := locketHandler.Lock(context.Background(), request)
 					Expect(err).To(HaveOccurred())
-
-					metricsRecordFailure(fakeRequestMetrics)
-					metricsUseCorrectCallTags(fakeRequestMetrics, "Lock")
 				})
 
 				It("should be invalid on an non-existent type code", func() {
@@ -140,9 +174,6 @@ var _ = Describe("LocketHandler", func() {
 					request.Resource.TypeCode = 4
 					_, err := locketHandler.Lock(context.Background(), request)
 					Expect(err).To(HaveOccurred())
-
-					metricsRecordFailure(fakeRequestMetrics)
-					metricsUseCorrectCallTags(fakeRequestMetrics, "Lock")
 				})
 			})
 		})
@@ -161,9 +192,64 @@ var _ = Describe("LocketHandler", func() {
 				Expect(logger).To(gbytes.Say(models.ErrInvalidTTL.Error()))
 				Expect(logger).To(gbytes.Say("\"key\":"))
 				Expect(logger).To(gbytes.Say("\"owner\":"))
+			})
+		})
+
+		Context("when the resource is of a user-defined type", func() {
+			BeforeEach(func() {
+				request.Resource = &models.Resource{Key: "test", Owner: "myself", Type: "maintenance-window"}
+				request.TtlInSeconds = 30
+			})
+
+			It("locks the resource", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLockDB.LockCallCount()).To(Equal(1))
+				_, _, actualResource, _, _ := fakeLockDB.LockArgsForCall(0)
+				Expect(actualResource.Type).To(Equal("maintenance-window"))
+			})
+
+			Context("when the ttl is out of the bounds of the type", func() {
+				BeforeEach(func() {
+					request.TtlInSeconds = 61
+				})
+
+				It("returns a validation error", func() {
+					_, err := locketHandler.Lock(context.Background(), request)
+					Expect(err).To(Equal(models.ErrInvalidTTL))
+					Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the type is not registered", func() {
+				BeforeEach(func() {
+					request.Resource.Type = "migration"
+				})
+
+				It("returns an invalid type error", func() {
+					_, err := locketHandler.Lock(context.Background(), request)
+					Expect(err).To(Equal(models.ErrInvalidType))
+					Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the resource is attached to a lease", func() {
+			BeforeEach(func() {
+				resource.LeaseId = "lease-guid"
+				request = &models.LockRequest{
+					Resource: resource,
+				}
+			})
 
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "Lock")
+			It("does not require a TTL", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLockDB.LockCallCount()).To(Equal(1))
+				_, _, actualResource, _, _ := fakeLockDB.LockArgsForCall(0)
+				Expect(actualResource.LeaseId).To(Equal("lease-guid"))
 			})
 		})
 
@@ -179,9 +265,114 @@ var _ = Describe("LocketHandler", func() {
 				Expect(logger).To(gbytes.Say(models.ErrInvalidOwner.Error()))
 				Expect(logger).To(gbytes.Say("\"key\":"))
 				Expect(logger).To(gbytes.Say("\"owner\":"))
+			})
+		})
+
+		Context("when the resource has labels", func() {
+			BeforeEach(func() {
+				resource.Labels = map[string]string{"zone": "z1"}
+			})
+
+			It("locks the resource with its labels", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLockDB.LockCallCount()).To(Equal(1))
+				_, _, actualResource, _, _ := fakeLockDB.LockArgsForCall(0)
+				Expect(actualResource.Labels).To(Equal(map[string]string{"zone": "z1"}))
+			})
+
+			It("returns a validation error for an empty label key", func() {
+				resource.Labels[""] = "value"
+
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidLabels))
+				Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+			})
+
+			It("returns a validation error when the labels are too long", func() {
+				resource.Labels["long"] = strings.Repeat("a", models.MaxLabelsLength)
+
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidLabels))
+				Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the resource has a payload", func() {
+			It("locks the resource with its payload", func() {
+				resource.Payload = []byte(strings.Repeat("a", 1024))
+
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLockDB.LockCallCount()).To(Equal(1))
+				_, _, actualResource, _, _ := fakeLockDB.LockArgsForCall(0)
+				Expect(actualResource.Payload).To(HaveLen(1024))
+			})
+
+			It("returns a validation error when the payload is larger than the configured size", func() {
+				resource.Payload = []byte(strings.Repeat("a", 1025))
+
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).To(Equal(models.ErrPayloadTooLarge))
+				Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+				Expect(logger).To(gbytes.Say("\"payload-size\":1025"))
+			})
+		})
+
+		Context("when the value is too large", func() {
+			BeforeEach(func() {
+				resource.Value = strings.Repeat("a", models.MaxValueLength+1)
+			})
+
+			It("returns a validation error", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).To(Equal(models.ErrValueTooLarge))
+				Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the resource is in a namespace", func() {
+			BeforeEach(func() {
+				resource.Namespace = "diego"
+			})
+
+			It("locks the key qualified with the namespace", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, actualResource, _, _ := fakeLockDB.LockArgsForCall(0)
+				Expect(actualResource.Key).To(Equal("namespaces/diego/test"))
+				Expect(actualResource.Namespace).To(BeEmpty())
+				Expect(request.Resource.Key).To(Equal("test"))
+			})
+
+			Context("when the namespace is not registered", func() {
+				BeforeEach(func() {
+					resource.Namespace = "routing"
+				})
+
+				It("returns an invalid namespace error", func() {
+					_, err := locketHandler.Lock(context.Background(), request)
+					Expect(err).To(Equal(models.ErrInvalidNamespace))
+					Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when a key of the default namespace starts with the namespace prefix", func() {
+			BeforeEach(func() {
+				resource.Key = "namespaces/diego/test"
+			})
 
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "Lock")
+			It("locks the key qualified with the default namespace", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLockDB.LockCallCount()).To(Equal(1))
+				_, _, actualResource, _, _ := fakeLockDB.LockArgsForCall(0)
+				Expect(actualResource.Key).To(Equal("namespaces//namespaces/diego/test"))
 			})
 		})
 
@@ -200,9 +391,10 @@ var _ = Describe("LocketHandler", func() {
 
 			It("returns the error", func() {
 				Expect(err).To(HaveOccurred())
+			})
 
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "Lock")
+			It("does not publish to the watch hub", func() {
+				Expect(fakeHub.UpsertCallCount()).To(Equal(0))
 			})
 
 			It("logs the error with identifying information", func() {
@@ -221,538 +413,2106 @@ var _ = Describe("LocketHandler", func() {
 				})
 
 				It("counts the request in the metrics as a success", func() {
-					metricsRecordSuccess(fakeRequestMetrics)
 				})
 			})
 		})
 
-		Context("when an unrecoverable error is returned", func() {
+		Context("when the request is in shared mode", func() {
 			BeforeEach(func() {
-				fakeLockDB.LockReturns(nil, helpers.ErrUnrecoverableError)
+				request.Mode = models.SHARED
+				expectedLock.Mode = models.SHARED
+				fakeLockDB.LockSharedReturns(expectedLock, nil)
 			})
 
-			It("logs and writes to the exit channel", func() {
-				locketHandler.Lock(context.Background(), request)
-				Expect(logger).To(gbytes.Say("unrecoverable-error"))
+			It("reserves a shared lock in the database", func() {
+				resp, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.FencingToken).To(BeEquivalentTo(7))
+
+				Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+				Expect(fakeLockDB.LockSharedCallCount()).To(Equal(1))
+				_, _, actualResource, ttl, limit, _ := fakeLockDB.LockSharedArgsForCall(0)
+				Expect(actualResource).To(Equal(resource))
+				Expect(ttl).To(BeEquivalentTo(10))
+				Expect(limit).To(Equal(0))
+			})
 
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "Lock")
+			It("registers the shared lock with the lock pick", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
 
-				Expect(exitCh).To(Receive())
+				Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(1))
+				_, lock := fakeLockPick.RegisterTTLArgsForCall(0)
+				Expect(lock).To(Equal(expectedLock))
 			})
-		})
 
-		Context("when the context errors", func() {
-			var ctx context.Context
+			It("does not publish the shared lock to the watch hub", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeHub.UpsertCallCount()).To(Equal(0))
+			})
+		})
 
+		Context("when the resource is a semaphore", func() {
 			BeforeEach(func() {
-				ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("uuid", "some-request-id"))
+				resource.TypeCode = models.SEMAPHORE
+				request.SemaphoreLimit = 3
+				expectedLock.Mode = models.SHARED
+				fakeLockDB.LockSharedReturns(expectedLock, nil)
 			})
 
-			JustBeforeEach(func() {
-				fakeLockDB.LockReturns(nil, errors.New("Boom."))
-				locketHandler.Lock(ctx, request)
+			It("reserves a shared lock limited to the number of holders", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+				Expect(fakeLockDB.LockSharedCallCount()).To(Equal(1))
+				_, _, actualResource, _, limit, _ := fakeLockDB.LockSharedArgsForCall(0)
+				Expect(actualResource).To(Equal(resource))
+				Expect(limit).To(Equal(3))
+			})
+
+			It("publishes the holder to the watch hub", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHub.UpsertCallCount()).To(Equal(1))
+				_, publishedLock := fakeHub.UpsertArgsForCall(0)
+				Expect(publishedLock).To(Equal(expectedLock))
 			})
 
-			Context("when the context was closed due to a client cancellation", func() {
+			Context("when the semaphore limit is not set", func() {
 				BeforeEach(func() {
-					var cancel func()
-					ctx, cancel = context.WithCancel(ctx)
-					cancel()
+					request.SemaphoreLimit = 0
+				})
+
+				It("returns an invalid semaphore limit error", func() {
+					_, err := locketHandler.Lock(context.Background(), request)
+					Expect(err).To(Equal(models.ErrInvalidSemaphoreLimit))
+					Expect(fakeLockDB.LockSharedCallCount()).To(Equal(0))
 				})
+			})
+		})
+
+		Context("when a semaphore limit is set on a resource that is not a semaphore", func() {
+			BeforeEach(func() {
+				request.SemaphoreLimit = 3
+			})
+
+			It("returns an invalid semaphore limit error", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidSemaphoreLimit))
+				Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the lock mode is invalid", func() {
+			BeforeEach(func() {
+				request.Mode = models.LockMode(5)
+			})
+
+			It("returns an invalid lock mode error", func() {
+				_, err := locketHandler.Lock(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidLockMode))
+				Expect(fakeLockDB.LockCallCount()).To(Equal(0))
+				Expect(fakeLockDB.LockSharedCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the request has a wait timeout", func() {
+			var (
+				hub         watch.Hub
+				waitHandler models.LocketServer
+				heldLock    *db.Lock
+
+				lockMutex       *sync.Mutex
+				freeFor         string
+				attemptsByOwner map[string]int
+			)
+
+			lockAsync := func(owner string) <-chan error {
+				errCh := make(chan error, 1)
+				req := &models.LockRequest{
+					Resource:             &models.Resource{Key: resource.Key, Owner: owner, TypeCode: models.LOCK},
+					TtlInSeconds:         10,
+					WaitTimeoutInSeconds: 5,
+				}
+				go func(handler models.LocketServer, req *models.LockRequest, errCh chan<- error) {
+					_, err := handler.Lock(context.Background(), req)
+					errCh <- err
+				}(waitHandler, req, errCh)
+				return errCh
+			}
+
+			attempts := func(owner string) func() int {
+				return func() int {
+					lockMutex.Lock()
+					defer lockMutex.Unlock()
+					return attemptsByOwner[owner]
+				}
+			}
+
+			releaseTo := func(owner string) {
+				lockMutex.Lock()
+				freeFor = owner
+				lockMutex.Unlock()
+
+				_, err := waitHandler.Release(context.Background(), &models.ReleaseRequest{Resource: heldLock.Resource})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			BeforeEach(func() {
+				hub = watch.NewHub(watch.DefaultHistorySize, 0)
+				waitHandler = handlers.NewLocketHandler(
+					logger,
+					fakeLockDB,
+					fakeLockPick,
+					hub,
+					fakeClock,
+					exitCh,
+					handlers.DefaultDBOperationTimeout,
+					nil,
+					handlers.DefaultMaxPayloadSize,
+					namespaces,
+				)
+
+				heldLock = &db.Lock{
+					Resource:      &models.Resource{Key: resource.Key, Owner: "holder", TypeCode: models.LOCK},
+					TtlInSeconds:  10,
+					ModifiedIndex: 1,
+				}
+				hub.Upsert(logger, heldLock)
+				fakeLockDB.FetchReturns(heldLock, nil)
+
+				lockMutex = &sync.Mutex{}
+				freeFor = ""
+				attemptsByOwner = map[string]int{}
+				fakeLockDB.LockStub = func(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64, leaseOwner string) (*db.Lock, error) {
+					lockMutex.Lock()
+					defer lockMutex.Unlock()
+
+					attemptsByOwner[resource.Owner]++
+					if resource.Owner != freeFor {
+						return nil, models.ErrLockCollision
+					}
+					return &db.Lock{Resource: resource, TtlInSeconds: ttl, ModifiedIndex: 2}, nil
+				}
+			})
+
+			It("acquires the lock once the holder releases it", func() {
+				errCh := lockAsync("waiter")
+				Eventually(attempts("waiter")).Should(Equal(1))
+				Consistently(errCh).ShouldNot(Receive())
+
+				releaseTo("waiter")
+
+				Eventually(errCh).Should(Receive(BeNil()))
+				Expect(attempts("waiter")()).To(Equal(2))
+				Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(1))
+			})
+
+			It("acquires the lock of a namespace once the held lock expires", func() {
+				namespacedLock := &db.Lock{
+					Resource:      &models.Resource{Key: "namespaces/diego/" + resource.Key, Owner: "holder", TypeCode: models.LOCK},
+					TtlInSeconds:  10,
+					ModifiedIndex: 1,
+				}
+				hub.Upsert(logger, namespacedLock)
+
+				errCh := make(chan error, 1)
+				go func() {
+					defer GinkgoRecover()
+					_, err := waitHandler.Lock(context.Background(), &models.LockRequest{
+						Resource:             &models.Resource{Namespace: "diego", Key: resource.Key, Owner: "waiter", TypeCode: models.LOCK},
+						TtlInSeconds:         10,
+						WaitTimeoutInSeconds: 5,
+					})
+					errCh <- err
+				}()
+				Eventually(attempts("waiter")).Should(Equal(1))
+
+				lockMutex.Lock()
+				freeFor = "waiter"
+				lockMutex.Unlock()
+				hub.Remove(logger, namespacedLock.Resource, models.EXPIRED)
+
+				Eventually(errCh).Should(Receive(BeNil()))
+				Expect(attempts("waiter")()).To(Equal(2))
+			})
+
+			It("acquires the lock once the held lock expires", func() {
+				errCh := lockAsync("waiter")
+				Eventually(attempts("waiter")).Should(Equal(1))
+
+				lockMutex.Lock()
+				freeFor = "waiter"
+				lockMutex.Unlock()
+				hub.Remove(logger, heldLock.Resource, models.EXPIRED)
+
+				Eventually(errCh).Should(Receive(BeNil()))
+			})
+
+			It("wakes the waiters in the order they arrived", func() {
+				firstErrCh := lockAsync("first")
+				Eventually(attempts("first")).Should(Equal(1))
+				secondErrCh := lockAsync("second")
+				Eventually(fakeLockDB.FetchCallCount).Should(Equal(1))
+				Expect(attempts("second")()).To(Equal(0))
+
+				releaseTo("first")
+
+				Eventually(firstErrCh).Should(Receive(BeNil()))
+				Consistently(secondErrCh).ShouldNot(Receive())
+				Expect(attempts("second")()).To(Equal(0))
+
+				heldLock.Resource.Owner = "first"
+				releaseTo("second")
+
+				Eventually(secondErrCh).Should(Receive(BeNil()))
+				Expect(attempts("second")()).To(Equal(1))
+			})
+
+			It("does not let a contender arriving after a release take the lock ahead of the waiter", func() {
+				waiterErrCh := lockAsync("waiter")
+				Eventually(attempts("waiter")).Should(Equal(1))
 
-				It("logs the context cancelled error with request data", func() {
-					Expect(logger).To(gbytes.Say("context-cancelled"))
-					Expect(logger).To(gbytes.Say("some-request-id"))
-					Expect(logger).To(gbytes.Say("Lock"))
-					Expect(logger).To(gbytes.Say("test"))
-					Expect(logger).To(gbytes.Say("myself"))
+				// the lock was released, but the waiter was not woken up yet
+				lockMutex.Lock()
+				freeFor = "third"
+				lockMutex.Unlock()
+
+				thirdErrCh := lockAsync("third")
+				Eventually(fakeLockDB.FetchCallCount).Should(Equal(1))
+				Consistently(thirdErrCh).ShouldNot(Receive())
+				Expect(attempts("third")()).To(Equal(0))
+
+				releaseTo("waiter")
+
+				Eventually(waiterErrCh).Should(Receive(BeNil()))
+				Consistently(thirdErrCh).ShouldNot(Receive())
+				Expect(attempts("third")()).To(Equal(0))
+			})
+
+			It("lets a request without a wait timeout take a free lock while others are waiting", func() {
+				waiterErrCh := lockAsync("waiter")
+				Eventually(attempts("waiter")).Should(Equal(1))
+
+				lockMutex.Lock()
+				freeFor = "third"
+				lockMutex.Unlock()
+
+				_, err := waitHandler.Lock(context.Background(), &models.LockRequest{
+					Resource:     &models.Resource{Key: resource.Key, Owner: "third", TypeCode: models.LOCK},
+					TtlInSeconds: 10,
 				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(attempts("third")()).To(Equal(1))
+				Expect(fakeLockDB.FetchCallCount()).To(Equal(0))
+				Consistently(waiterErrCh).ShouldNot(Receive())
+			})
+
+			It("lets the holder renew the lock while others are waiting", func() {
+				lockAsync("waiter")
+				Eventually(attempts("waiter")).Should(Equal(1))
 
-				It("records an increase in the request cancelled metric", func() {
-					Expect(fakeRequestMetrics.IncrementRequestsCancelledCounterCallCount()).To(Equal(1))
-					_, requestsCancelled := fakeRequestMetrics.IncrementRequestsCancelledCounterArgsForCall(0)
-					Expect(requestsCancelled).To(BeEquivalentTo(1))
+				lockMutex.Lock()
+				freeFor = "holder"
+				lockMutex.Unlock()
+
+				_, err := waitHandler.Lock(context.Background(), &models.LockRequest{
+					Resource:     heldLock.Resource,
+					TtlInSeconds: 10,
 				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(attempts("holder")()).To(Equal(1))
 			})
 
-			Context("when the context was closed due to an exceeded deadline", func() {
+			It("hands the wake up over when the first waiter gives up", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				firstErrCh := make(chan error, 1)
+				go func(handler models.LocketServer, errCh chan<- error) {
+					_, err := handler.Lock(ctx, &models.LockRequest{
+						Resource:             &models.Resource{Key: resource.Key, Owner: "first", TypeCode: models.LOCK},
+						TtlInSeconds:         10,
+						WaitTimeoutInSeconds: 5,
+					})
+					errCh <- err
+				}(waitHandler, firstErrCh)
+				Eventually(attempts("first")).Should(Equal(1))
+				secondErrCh := lockAsync("second")
+				Eventually(fakeLockDB.FetchCallCount).Should(Equal(1))
+
+				lockMutex.Lock()
+				freeFor = "second"
+				lockMutex.Unlock()
+
+				cancel()
+				Eventually(firstErrCh).Should(Receive(Equal(context.Canceled)))
+				Eventually(secondErrCh).Should(Receive(BeNil()))
+			})
+
+			Context("when the key is held in shared mode", func() {
+				var sharedAttemptsByOwner map[string]int
+
+				lockSharedAsync := func(owner string) <-chan error {
+					errCh := make(chan error, 1)
+					req := &models.LockRequest{
+						Resource:             &models.Resource{Key: resource.Key, Owner: owner, TypeCode: models.LOCK},
+						TtlInSeconds:         10,
+						WaitTimeoutInSeconds: 5,
+						Mode:                 models.SHARED,
+					}
+					go func(handler models.LocketServer, req *models.LockRequest, errCh chan<- error) {
+						_, err := handler.Lock(context.Background(), req)
+						errCh <- err
+					}(waitHandler, req, errCh)
+					return errCh
+				}
+
+				sharedAttempts := func(owner string) func() int {
+					return func() int {
+						lockMutex.Lock()
+						defer lockMutex.Unlock()
+						return sharedAttemptsByOwner[owner]
+					}
+				}
+
 				BeforeEach(func() {
-					var cancel context.CancelFunc
-					ctx, cancel = context.WithDeadline(ctx, time.Unix(0, 0)) // nolint
-					defer cancel()
+					sharedAttemptsByOwner = map[string]int{}
+					fakeLockDB.LockSharedStub = func(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64, limit int, leaseOwner string) (*db.Lock, error) {
+						lockMutex.Lock()
+						defer lockMutex.Unlock()
+
+						sharedAttemptsByOwner[resource.Owner]++
+						if freeFor != "shared" {
+							return nil, models.ErrLockCollision
+						}
+						return &db.Lock{Resource: resource, Mode: models.SHARED, TtlInSeconds: ttl, ModifiedIndex: 1}, nil
+					}
 				})
 
-				It("logs the context deadline exceeded error", func() {
-					Expect(logger).To(gbytes.Say("context-deadline-exceeded"))
-					Expect(logger).To(gbytes.Say("some-request-id"))
-					Expect(logger).To(gbytes.Say("Lock"))
-					Expect(logger).To(gbytes.Say("test"))
-					Expect(logger).To(gbytes.Say("myself"))
+				It("wakes an exclusive waiter when a shared holder releases", func() {
+					errCh := lockAsync("waiter")
+					Eventually(attempts("waiter")).Should(Equal(1))
+
+					lockMutex.Lock()
+					freeFor = "waiter"
+					lockMutex.Unlock()
+
+					_, err := waitHandler.Release(context.Background(), &models.ReleaseRequest{
+						Resource: &models.Resource{Key: resource.Key, Owner: "reader"},
+						Mode:     models.SHARED,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(errCh).Should(Receive(BeNil()))
 				})
 
-				It("does not emit a requests cancelled metric", func() {
-					Expect(fakeRequestMetrics.IncrementRequestsCancelledCounterCallCount()).To(Equal(0))
+				It("lets every shared waiter acquire the lock once the exclusive holder releases", func() {
+					firstErrCh := lockSharedAsync("first")
+					Eventually(sharedAttempts("first")).Should(Equal(1))
+					secondErrCh := lockSharedAsync("second")
+					Eventually(fakeLockDB.FetchSharedHoldersCallCount).Should(Equal(1))
+
+					releaseTo("shared")
+
+					Eventually(firstErrCh).Should(Receive(BeNil()))
+					Eventually(secondErrCh).Should(Receive(BeNil()))
 				})
 			})
 
-			Context("when the context has no uuid metadata", func() {
+			It("returns a lock collision error when the wait times out", func() {
+				errCh := lockAsync("waiter")
+				Eventually(attempts("waiter")).Should(Equal(1))
+
+				fakeClock.WaitForWatcherAndIncrement(5 * time.Second)
+
+				Eventually(errCh).Should(Receive(Equal(models.ErrLockCollision)))
+				Expect(attempts("waiter")()).To(Equal(1))
+			})
+
+			It("does not wait when the lock is available", func() {
+				freeFor = "waiter"
+				errCh := lockAsync("waiter")
+
+				Eventually(errCh).Should(Receive(BeNil()))
+				Expect(attempts("waiter")()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("Release", func() {
+
+		It("releases the lock in the database", func() {
+			_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: resource})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLockDB.ReleaseCallCount()).Should(Equal(1))
+			_, _, actualResource := fakeLockDB.ReleaseArgsForCall(0)
+			Expect(actualResource).To(Equal(resource))
+		})
+
+		It("publishes a deleted event to the watch hub", func() {
+			_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: resource})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHub.RemoveCallCount()).To(Equal(1))
+			_, actualResource, eventType := fakeHub.RemoveArgsForCall(0)
+			Expect(actualResource).To(Equal(resource))
+			Expect(eventType).To(Equal(models.DELETED))
+		})
+
+		Context("when the request is in shared mode", func() {
+			It("releases the shared lock in the database", func() {
+				_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: resource, Mode: models.SHARED})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLockDB.ReleaseCallCount()).To(Equal(0))
+				Expect(fakeLockDB.ReleaseSharedCallCount()).To(Equal(1))
+				_, _, actualResource := fakeLockDB.ReleaseSharedArgsForCall(0)
+				Expect(actualResource).To(Equal(resource))
+
+				Expect(fakeHub.RemoveCallCount()).To(Equal(0))
+			})
+
+			Context("when releasing errors", func() {
 				BeforeEach(func() {
-					ctx = context.Background()
-					var cancel func()
-					ctx, cancel = context.WithCancel(ctx)
-					cancel()
+					fakeLockDB.ReleaseSharedReturns(errors.New("Boom."))
 				})
 
-				It("does not panic and logs empty request-id", func() {
-					Expect(logger).To(gbytes.Say("context-cancelled"))
+				It("returns the error", func() {
+					_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: resource, Mode: models.SHARED})
+					Expect(err).To(HaveOccurred())
 				})
 			})
 		})
-	})
 
-	Context("Release", func() {
-		Context("when the db request take too long", func() {
-			var (
-				blockDB chan struct{}
-			)
+		Context("when the resource is a semaphore", func() {
+			It("releases the shared lock of the owner", func() {
+				semaphore := &models.Resource{Key: "uploads", Owner: "cell-1", TypeCode: models.SEMAPHORE}
+				_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: semaphore})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLockDB.ReleaseCallCount()).To(Equal(0))
+				Expect(fakeLockDB.ReleaseSharedCallCount()).To(Equal(1))
+			})
+
+			It("publishes the release of the holder to the watch hub", func() {
+				semaphore := &models.Resource{Key: "uploads", Owner: "cell-1", TypeCode: models.SEMAPHORE}
+				_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: semaphore})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHub.RemoveCallCount()).To(Equal(1))
+				_, removed, eventType := fakeHub.RemoveArgsForCall(0)
+				Expect(removed).To(Equal(semaphore))
+				Expect(eventType).To(Equal(models.DELETED))
+			})
+		})
+
+		Context("when the lock mode is invalid", func() {
+			It("returns an invalid lock mode error", func() {
+				_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: resource, Mode: models.LockMode(5)})
+				Expect(err).To(Equal(models.ErrInvalidLockMode))
+				Expect(fakeLockDB.ReleaseCallCount()).To(Equal(0))
+				Expect(fakeLockDB.ReleaseSharedCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when releasing errors", func() {
+			BeforeEach(func() {
+				fakeLockDB.ReleaseReturns(errors.New("Boom."))
+			})
+
+			It("returns the error", func() {
+				_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: resource})
+				Expect(err).To(HaveOccurred())
+				Expect(fakeHub.RemoveCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("LockBatch", func() {
+		var (
+			request                     *models.LockBatchRequest
+			lockResource, otherResource *models.Resource
+			expectedLock                *db.Lock
+		)
+
+		BeforeEach(func() {
+			lockResource = &models.Resource{Key: "lock", Owner: "myself", TypeCode: models.LOCK}
+			otherResource = &models.Resource{Key: "other", Owner: "myself", TypeCode: models.PRESENCE}
+
+			request = &models.LockBatchRequest{
+				Requests: []*models.LockRequest{
+					{Resource: lockResource, TtlInSeconds: 10},
+					{Resource: &models.Resource{Key: "invalid", Owner: "myself", TypeCode: models.LOCK}},
+					{Resource: otherResource, TtlInSeconds: 10},
+				},
+			}
+
+			expectedLock = &db.Lock{Resource: lockResource, TtlInSeconds: 10, ModifiedIndex: 2, FencingToken: 3}
+			fakeLockDB.LockBatchReturns(
+				[]*db.Lock{expectedLock, nil},
+				[]error{nil, models.ErrLockCollision},
+				nil,
+			)
+		})
+
+		It("locks the valid requests in the database in a single call", func() {
+			_, err := locketHandler.LockBatch(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLockDB.LockBatchCallCount()).To(Equal(1))
+			_, _, requests, _ := fakeLockDB.LockBatchArgsForCall(0)
+			Expect(requests).To(Equal([]*models.LockRequest{request.Requests[0], request.Requests[2]}))
+		})
+
+		It("only lets the locks be attached to the leases granted by the client", func() {
+			_, err := locketHandler.LockBatch(contextWithClientCommonName("cell"), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _, leaseOwner := fakeLockDB.LockBatchArgsForCall(0)
+			Expect(leaseOwner).To(Equal("cell"))
+		})
+
+		It("returns a result per request in the order of the requests", func() {
+			resp, err := locketHandler.LockBatch(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Results).To(HaveLen(3))
+
+			Expect(resp.Results[0].Err()).NotTo(HaveOccurred())
+			Expect(resp.Results[0].Response).To(Equal(&models.LockResponse{FencingToken: 3, ModifiedIndex: 2}))
+
+			Expect(status.Code(resp.Results[1].Err())).To(Equal(status.Code(models.ErrInvalidTTL)))
+			Expect(status.Code(resp.Results[2].Err())).To(Equal(status.Code(models.ErrLockCollision)))
+		})
+
+		It("registers and publishes only the acquired locks", func() {
+			_, err := locketHandler.LockBatch(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(1))
+			_, lock := fakeLockPick.RegisterTTLArgsForCall(0)
+			Expect(lock).To(Equal(expectedLock))
+
+			Expect(fakeHub.UpsertCallCount()).To(Equal(1))
+			_, lock = fakeHub.UpsertArgsForCall(0)
+			Expect(lock).To(Equal(expectedLock))
+		})
+
+		Context("when all the requests are invalid", func() {
+			BeforeEach(func() {
+				request.Requests = request.Requests[1:2]
+			})
+
+			It("does not call the database", func() {
+				resp, err := locketHandler.LockBatch(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Results).To(HaveLen(1))
+				Expect(fakeLockDB.LockBatchCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the batch fails", func() {
+			BeforeEach(func() {
+				fakeLockDB.LockBatchReturns(nil, nil, errors.New("Boom."))
+			})
+
+			It("returns the error", func() {
+				_, err := locketHandler.LockBatch(context.Background(), request)
+				Expect(err).To(MatchError("Boom."))
+				Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("LockMulti", func() {
+		var (
+			request                     *models.LockMultiRequest
+			lockResource, otherResource *models.Resource
+			expectedLock, otherLock     *db.Lock
+		)
+
+		BeforeEach(func() {
+			lockResource = &models.Resource{Key: "lock", Owner: "myself", TypeCode: models.LOCK}
+			otherResource = &models.Resource{Key: "other", Owner: "myself", TypeCode: models.PRESENCE}
+
+			request = &models.LockMultiRequest{
+				Requests: []*models.LockRequest{
+					{Resource: lockResource, TtlInSeconds: 10},
+					{Resource: otherResource, TtlInSeconds: 10},
+				},
+			}
+
+			expectedLock = &db.Lock{Resource: lockResource, TtlInSeconds: 10, ModifiedIndex: 2, FencingToken: 3}
+			otherLock = &db.Lock{Resource: otherResource, TtlInSeconds: 10, ModifiedIndex: 1, FencingToken: 4}
+			fakeLockDB.LockMultiReturns([]*db.Lock{expectedLock, otherLock}, nil)
+		})
+
+		It("locks the requests in the database in a single call", func() {
+			resp, err := locketHandler.LockMulti(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Responses).To(Equal([]*models.LockResponse{
+				{FencingToken: 3, ModifiedIndex: 2},
+				{FencingToken: 4, ModifiedIndex: 1},
+			}))
+
+			Expect(fakeLockDB.LockMultiCallCount()).To(Equal(1))
+			_, _, requests, _ := fakeLockDB.LockMultiArgsForCall(0)
+			Expect(requests).To(Equal(request.Requests))
+		})
+
+		It("registers and publishes the locks", func() {
+			_, err := locketHandler.LockMulti(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(2))
+			Expect(fakeHub.UpsertCallCount()).To(Equal(2))
+		})
+
+		Context("when one of the requests is invalid", func() {
+			BeforeEach(func() {
+				request.Requests[1].TtlInSeconds = 0
+			})
+
+			It("returns the validation error without locking any of them", func() {
+				_, err := locketHandler.LockMulti(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidTTL))
+				Expect(fakeLockDB.LockMultiCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when one of the locks collides", func() {
+			BeforeEach(func() {
+				fakeLockDB.LockMultiReturns(nil, models.ErrLockCollision)
+			})
+
+			It("returns the collision", func() {
+				_, err := locketHandler.LockMulti(context.Background(), request)
+				Expect(err).To(Equal(models.ErrLockCollision))
+				Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(0))
+				Expect(fakeHub.UpsertCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("Update", func() {
+		var (
+			request     *models.UpdateRequest
+			updatedLock *db.Lock
+		)
+
+		BeforeEach(func() {
+			request = &models.UpdateRequest{
+				Resource:              resource,
+				ExpectedModifiedIndex: 4,
+			}
+
+			updatedLock = &db.Lock{
+				Resource:      resource,
+				TtlInSeconds:  10,
+				ModifiedIndex: 5,
+			}
+
+			fakeLockDB.UpdateReturns(updatedLock, nil)
+		})
+
+		It("updates the lock in the database", func() {
+			resp, err := locketHandler.Update(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.ModifiedIndex).To(BeEquivalentTo(5))
+
+			Expect(fakeLockDB.UpdateCallCount()).To(Equal(1))
+			_, _, actualResource, expectedIndex := fakeLockDB.UpdateArgsForCall(0)
+			Expect(actualResource).To(Equal(resource))
+			Expect(expectedIndex).To(BeEquivalentTo(4))
+		})
+
+		It("registers the new modified index with the lock pick", func() {
+			_, err := locketHandler.Update(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(1))
+			_, lock := fakeLockPick.RegisterTTLArgsForCall(0)
+			Expect(lock).To(Equal(updatedLock))
+		})
+
+		It("publishes the lock to the watch hub", func() {
+			_, err := locketHandler.Update(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHub.UpsertCallCount()).To(Equal(1))
+			_, lock := fakeHub.UpsertArgsForCall(0)
+			Expect(lock).To(Equal(updatedLock))
+		})
+
+		Context("when the request does not have an owner", func() {
+			BeforeEach(func() {
+				request.Resource = &models.Resource{Key: "test", Value: "test-value"}
+			})
+
+			It("returns a validation error", func() {
+				_, err := locketHandler.Update(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidOwner))
+				Expect(fakeLockDB.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the request does not have a resource", func() {
+			BeforeEach(func() {
+				request.Resource = nil
+			})
+
+			It("returns a validation error", func() {
+				_, err := locketHandler.Update(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidOwner))
+				Expect(fakeLockDB.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the payload is too large", func() {
+			BeforeEach(func() {
+				request.Resource.Payload = make([]byte, 1025)
+			})
+
+			It("returns a validation error", func() {
+				_, err := locketHandler.Update(context.Background(), request)
+				Expect(err).To(Equal(models.ErrPayloadTooLarge))
+				Expect(fakeLockDB.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the labels are invalid", func() {
+			BeforeEach(func() {
+				request.Resource.Labels = map[string]string{"": "value"}
+			})
+
+			It("returns a validation error", func() {
+				_, err := locketHandler.Update(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidLabels))
+				Expect(fakeLockDB.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the modified index does not match", func() {
+			BeforeEach(func() {
+				fakeLockDB.UpdateReturns(nil, models.ErrModifiedIndexMismatch)
+			})
+
+			It("returns the conflict error", func() {
+				_, err := locketHandler.Update(context.Background(), request)
+				Expect(err).To(Equal(models.ErrModifiedIndexMismatch))
+				Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(0))
+				Expect(fakeHub.UpsertCallCount()).To(Equal(0))
+			})
+
+			It("counts the request in the metrics as a success", func() {
+				_, err := locketHandler.Update(context.Background(), request)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when updating errors", func() {
+			BeforeEach(func() {
+				fakeLockDB.UpdateReturns(nil, errors.New("Boom."))
+			})
+
+			It("returns the error", func() {
+				_, err := locketHandler.Update(context.Background(), request)
+				Expect(err).To(MatchError("Boom."))
+			})
+		})
+	})
+
+	Context("Transfer", func() {
+		var (
+			request         *models.TransferRequest
+			transferredLock *db.Lock
+		)
+
+		BeforeEach(func() {
+			request = &models.TransferRequest{
+				Resource: resource,
+				NewOwner: "successor",
+			}
+
+			transferredLock = &db.Lock{
+				Resource:      &models.Resource{Key: resource.Key, Owner: "successor", Value: resource.Value, Type: resource.Type},
+				TtlInSeconds:  10,
+				ModifiedIndex: 5,
+				FencingToken:  7,
+			}
+
+			fakeLockDB.TransferReturns(transferredLock, nil)
+		})
+
+		It("transfers the lock in the database", func() {
+			resp, err := locketHandler.Transfer(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.ModifiedIndex).To(BeEquivalentTo(5))
+			Expect(resp.FencingToken).To(BeEquivalentTo(7))
+
+			Expect(fakeLockDB.TransferCallCount()).To(Equal(1))
+			_, _, actualResource, newOwner := fakeLockDB.TransferArgsForCall(0)
+			Expect(actualResource).To(Equal(resource))
+			Expect(newOwner).To(Equal("successor"))
+		})
+
+		It("registers the new modified index with the lock pick", func() {
+			_, err := locketHandler.Transfer(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(1))
+			_, lock := fakeLockPick.RegisterTTLArgsForCall(0)
+			Expect(lock).To(Equal(transferredLock))
+		})
+
+		It("publishes the lock to the watch hub", func() {
+			_, err := locketHandler.Transfer(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHub.UpsertCallCount()).To(Equal(1))
+			_, lock := fakeHub.UpsertArgsForCall(0)
+			Expect(lock).To(Equal(transferredLock))
+		})
+
+		Context("when the request does not have an owner", func() {
+			BeforeEach(func() {
+				request.Resource = &models.Resource{Key: "test"}
+			})
+
+			It("returns a validation error", func() {
+				_, err := locketHandler.Transfer(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidOwner))
+				Expect(fakeLockDB.TransferCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the request does not have a new owner", func() {
+			BeforeEach(func() {
+				request.NewOwner = ""
+			})
+
+			It("returns a validation error", func() {
+				_, err := locketHandler.Transfer(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidOwner))
+				Expect(fakeLockDB.TransferCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the request does not have a resource", func() {
+			BeforeEach(func() {
+				request.Resource = nil
+			})
+
+			It("returns a validation error", func() {
+				_, err := locketHandler.Transfer(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidOwner))
+				Expect(fakeLockDB.TransferCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the new owner is the owner", func() {
+			BeforeEach(func() {
+				request.NewOwner = resource.Owner
+			})
+
+			It("returns a validation error", func() {
+				_, err := locketHandler.Transfer(context.Background(), request)
+				Expect(err).To(Equal(models.ErrInvalidOwner))
+				Expect(fakeLockDB.TransferCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the lock is owned by another owner", func() {
+			BeforeEach(func() {
+				fakeLockDB.TransferReturns(nil, models.ErrLockCollision)
+			})
+
+			It("returns the collision error", func() {
+				_, err := locketHandler.Transfer(context.Background(), request)
+				Expect(err).To(Equal(models.ErrLockCollision))
+				Expect(fakeLockPick.RegisterTTLCallCount()).To(Equal(0))
+				Expect(fakeHub.UpsertCallCount()).To(Equal(0))
+			})
+
+			It("does not log the collision as an error", func() {
+				_, err := locketHandler.Transfer(context.Background(), request)
+				Expect(err).To(HaveOccurred())
+				Expect(logger).To(gbytes.Say("did-not-transfer-lock"))
+				for _, log := range logger.Logs() {
+					Expect(log.LogLevel).NotTo(Equal(lager.ERROR))
+				}
+			})
+		})
+
+		Context("when transferring errors", func() {
+			BeforeEach(func() {
+				fakeLockDB.TransferReturns(nil, errors.New("Boom."))
+			})
+
+			It("returns the error", func() {
+				_, err := locketHandler.Transfer(context.Background(), request)
+				Expect(err).To(MatchError("Boom."))
+			})
+		})
+	})
+
+	Context("ForceRelease", func() {
+		var (
+			request      *models.ForceReleaseRequest
+			ctx          context.Context
+			authorizer   *grpcserver.Authorizer
+			releasedLock *db.Lock
+		)
+
+		// ForceRelease is only allowed to clients granted it by the
+		// authorization rules, so the requests go through the authorizer
+		forceRelease := func(ctx context.Context, req *models.ForceReleaseRequest) (*models.ForceReleaseResponse, error) {
+			info := &grpc.UnaryServerInfo{FullMethod: "/" + grpcserver.LocketServiceName + "/ForceRelease"}
+			resp, err := authorizer.UnaryServerInterceptor(logger)(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return locketHandler.ForceRelease(ctx, req.(*models.ForceReleaseRequest))
+			})
+			if err != nil {
+				return nil, err
+			}
+			return resp.(*models.ForceReleaseResponse), nil
+		}
+
+		BeforeEach(func() {
+			request = &models.ForceReleaseRequest{
+				Key:    "test",
+				Reason: "owner is wedged",
+			}
+			ctx = contextWithClientCommonName("locket-admin")
+
+			var err error
+			authorizer, err = grpcserver.NewAuthorizer([]grpcserver.AuthorizationRule{
+				{Identities: []string{"locket-admin", "spiffe://cf/locket-admin"}, Operations: []string{"ForceRelease"}},
+				{Identities: []string{"bbs"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			releasedLock = &db.Lock{Resource: resource, ModifiedIndex: 3}
+			fakeLockDB.ForceReleaseReturns([]*db.Lock{releasedLock}, nil)
+		})
+
+		It("releases the lock in the database on behalf of the client", func() {
+			resp, err := forceRelease(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Released).To(Equal([]*models.Resource{resource}))
+
+			Expect(fakeLockDB.ForceReleaseCallCount()).To(Equal(1))
+			_, _, key, actor, reason := fakeLockDB.ForceReleaseArgsForCall(0)
+			Expect(key).To(Equal("test"))
+			Expect(actor).To(Equal("locket-admin"))
+			Expect(reason).To(Equal("owner is wedged"))
+		})
+
+		It("publishes the release to the watch hub", func() {
+			_, err := forceRelease(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHub.RemoveCallCount()).To(Equal(1))
+			_, removedResource, eventType := fakeHub.RemoveArgsForCall(0)
+			Expect(removedResource).To(Equal(resource))
+			Expect(eventType).To(Equal(models.DELETED))
+		})
+
+		Context("when the client is granted the operation through a subject alternative name", func() {
+			BeforeEach(func() {
+				ctx = contextWithClientCert(&x509.Certificate{
+					URIs: []*url.URL{{Scheme: "spiffe", Host: "cf", Path: "/locket-admin"}},
+				})
+			})
+
+			It("records the subject alternative name as the actor", func() {
+				_, err := forceRelease(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, _, actor, _ := fakeLockDB.ForceReleaseArgsForCall(0)
+				Expect(actor).To(Equal("spiffe://cf/locket-admin"))
+			})
+		})
+
+		Context("when the client is only granted the other operations", func() {
+			BeforeEach(func() {
+				ctx = contextWithClientCommonName("bbs")
+			})
+
+			It("returns a permission denied error", func() {
+				_, err := forceRelease(ctx, request)
+				Expect(err).To(Equal(models.ErrPermissionDenied))
+				Expect(fakeLockDB.ForceReleaseCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the client did not authenticate with a certificate", func() {
+			BeforeEach(func() {
+				ctx = context.Background()
+			})
+
+			It("returns a permission denied error", func() {
+				_, err := forceRelease(ctx, request)
+				Expect(err).To(Equal(models.ErrPermissionDenied))
+				Expect(fakeLockDB.ForceReleaseCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the server has no authorization rules", func() {
+			It("returns a permission denied error", func() {
+				_, err := locketHandler.ForceRelease(ctx, request)
+				Expect(err).To(Equal(models.ErrPermissionDenied))
+				Expect(fakeLockDB.ForceReleaseCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the request does not have a reason", func() {
+			BeforeEach(func() {
+				request.Reason = ""
+			})
+
+			It("returns a validation error", func() {
+				_, err := forceRelease(ctx, request)
+				Expect(err).To(Equal(models.ErrInvalidReason))
+				Expect(fakeLockDB.ForceReleaseCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when releasing errors", func() {
+			BeforeEach(func() {
+				fakeLockDB.ForceReleaseReturns(nil, models.ErrResourceNotFound)
+			})
+
+			It("returns the error", func() {
+				_, err := forceRelease(ctx, request)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+				Expect(fakeHub.RemoveCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("Fetch", func() {
+		BeforeEach(func() {
+			fakeLockDB.FetchReturns(&db.Lock{Resource: resource, FencingToken: 7}, nil)
+		})
+
+		It("fetches the lock in the database", func() {
+			fetchResp, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Key: "test-fetch"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetchResp.Resource).To(Equal(resource))
+			Expect(fetchResp.FencingToken).To(BeEquivalentTo(7))
+
+			Expect(fakeLockDB.FetchCallCount()).Should(Equal(1))
+			_, _, key := fakeLockDB.FetchArgsForCall(0)
+			Expect(key).To(Equal("test-fetch"))
+		})
+
+		It("returns the lease timing of the lock", func() {
+			acquiredAt := time.Unix(100, 0)
+			renewedAt := time.Unix(160, 0)
+			fakeLockDB.FetchReturns(&db.Lock{
+				Resource:     resource,
+				TtlInSeconds: 15,
+				AcquiredAt:   acquiredAt.UnixNano(),
+				RenewedAt:    renewedAt.UnixNano(),
+			}, nil)
+
+			fetchResp, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Key: "test-fetch"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetchResp.Resource.AcquiredAt).To(Equal(acquiredAt.UnixNano()))
+			Expect(fetchResp.Resource.RenewedAt).To(Equal(renewedAt.UnixNano()))
+			Expect(fetchResp.Resource.TtlInSeconds).To(BeEquivalentTo(15))
+			Expect(fetchResp.Resource.ExpiresAt).To(Equal(renewedAt.Add(15 * time.Second).UnixNano()))
+		})
+
+		Context("when the key is held in shared mode", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchReturns(nil, models.ErrResourceNotFound)
+				fakeLockDB.FetchSharedHoldersReturns([]*db.Lock{
+					{Resource: &models.Resource{Key: "test-fetch", Owner: "reader-1"}, Mode: models.SHARED},
+					{Resource: &models.Resource{Key: "test-fetch", Owner: "reader-2"}, Mode: models.SHARED},
+				}, nil)
+			})
+
+			It("returns the shared holders", func() {
+				fetchResp, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Key: "test-fetch"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchResp.Resource).To(BeNil())
+				Expect(fetchResp.SharedHolders).To(HaveLen(2))
+				Expect(fetchResp.SharedHolders[0].Owner).To(Equal("reader-1"))
+				Expect(fetchResp.SharedHolders[1].Owner).To(Equal("reader-2"))
+
+				Expect(fakeLockDB.FetchSharedHoldersCallCount()).To(Equal(1))
+				_, _, key := fakeLockDB.FetchSharedHoldersArgsForCall(0)
+				Expect(key).To(Equal("test-fetch"))
+			})
+
+			Context("when there are no shared holders", func() {
+				BeforeEach(func() {
+					fakeLockDB.FetchSharedHoldersReturns(nil, nil)
+				})
+
+				It("returns a resource not found error", func() {
+					_, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Key: "test-fetch"})
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+		})
+
+		Context("when the lock has no renewal time", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchReturns(&db.Lock{Resource: resource, TtlInSeconds: 15}, nil)
+			})
+
+			It("does not return an expiration time", func() {
+				fetchResp, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Key: "test-fetch"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchResp.Resource.TtlInSeconds).To(BeEquivalentTo(15))
+				Expect(fetchResp.Resource.ExpiresAt).To(BeZero())
+			})
+		})
+
+		Context("when the lock is attached to a lease", func() {
+			BeforeEach(func() {
+				resource.LeaseId = "lease-guid"
+				fakeLockDB.FetchReturns(&db.Lock{Resource: resource, TtlInSeconds: 15, RenewedAt: 1000}, nil)
+				fakeLockDB.FetchLeaseOwnersReturns(map[string]string{"lease-guid": "cell"}, nil)
+			})
+
+			It("does not return an expiration time", func() {
+				fetchResp, err := locketHandler.Fetch(contextWithClientCommonName("cell"), &models.FetchRequest{Key: "test-fetch"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchResp.Resource.LeaseId).To(Equal("lease-guid"))
+				Expect(fetchResp.Resource.ExpiresAt).To(BeZero())
+
+				Expect(fakeLockDB.FetchLeaseOwnersCallCount()).To(Equal(1))
+				_, _, ids := fakeLockDB.FetchLeaseOwnersArgsForCall(0)
+				Expect(ids).To(Equal([]string{"lease-guid"}))
+			})
+
+			Context("when the lease was granted by another client", func() {
+				It("does not return the lease", func() {
+					fetchResp, err := locketHandler.Fetch(contextWithClientCommonName("bbs"), &models.FetchRequest{Key: "test-fetch"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fetchResp.Resource.LeaseId).To(BeEmpty())
+					Expect(resource.LeaseId).To(Equal("lease-guid"))
+				})
+			})
+
+			Context("when the lease does not exist anymore", func() {
+				BeforeEach(func() {
+					fakeLockDB.FetchLeaseOwnersReturns(map[string]string{}, nil)
+				})
+
+				It("does not return the lease", func() {
+					fetchResp, err := locketHandler.Fetch(contextWithClientCommonName("cell"), &models.FetchRequest{Key: "test-fetch"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fetchResp.Resource.LeaseId).To(BeEmpty())
+				})
+			})
+
+			Context("when the owners of the leases cannot be fetched", func() {
+				BeforeEach(func() {
+					fakeLockDB.FetchLeaseOwnersReturns(nil, errors.New("boom"))
+				})
+
+				It("returns the error", func() {
+					_, err := locketHandler.Fetch(contextWithClientCommonName("cell"), &models.FetchRequest{Key: "test-fetch"})
+					Expect(err).To(MatchError("boom"))
+				})
+			})
+		})
+
+		Context("when a key of the default namespace starts with the namespace prefix", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchReturns(&db.Lock{Resource: &models.Resource{Key: "namespaces//namespaces/diego/test-fetch", Owner: "myself"}}, nil)
+			})
+
+			It("fetches the key qualified with the default namespace and returns it in the default namespace", func() {
+				fetchResp, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Key: "namespaces/diego/test-fetch"})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, key := fakeLockDB.FetchArgsForCall(0)
+				Expect(key).To(Equal("namespaces//namespaces/diego/test-fetch"))
+
+				Expect(fetchResp.Resource.Namespace).To(BeEmpty())
+				Expect(fetchResp.Resource.Key).To(Equal("namespaces/diego/test-fetch"))
+			})
+		})
+
+		Context("when the key is in a namespace", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchReturns(&db.Lock{Resource: &models.Resource{Key: "namespaces/diego/test-fetch", Owner: "myself"}}, nil)
+			})
+
+			It("fetches the key qualified with the namespace and returns it split from the namespace", func() {
+				fetchResp, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Namespace: "diego", Key: "test-fetch"})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, key := fakeLockDB.FetchArgsForCall(0)
+				Expect(key).To(Equal("namespaces/diego/test-fetch"))
+
+				Expect(fetchResp.Resource.Namespace).To(Equal("diego"))
+				Expect(fetchResp.Resource.Key).To(Equal("test-fetch"))
+			})
+
+			It("returns an invalid namespace error when the namespace is not registered", func() {
+				_, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Namespace: "routing", Key: "test-fetch"})
+				Expect(err).To(Equal(models.ErrInvalidNamespace))
+				Expect(fakeLockDB.FetchCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when fetching errors", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchReturns(nil, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				_, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Key: "test-fetch"})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("FetchAll", func() {
+		var expectedResources []*models.Resource
+		BeforeEach(func() {
+			expectedResources = []*models.Resource{
+				resource,
+				&models.Resource{Key: "cell", Owner: "cell-1", Value: "{}"},
+			}
+
+			var locks []*db.Lock
+			for _, r := range expectedResources {
+				locks = append(locks, &db.Lock{Resource: r})
+			}
+			fakeLockDB.FetchPageReturns(locks, nil)
+		})
+
+		Context("when the type is semaphore", func() {
+			var holders []*db.Lock
+
+			BeforeEach(func() {
+				holders = []*db.Lock{
+					{Resource: &models.Resource{Key: "uploads", Owner: "cell-1", TypeCode: models.SEMAPHORE, Type: models.SemaphoreType}, Mode: models.SHARED},
+					{Resource: &models.Resource{Key: "uploads", Owner: "cell-2", TypeCode: models.SEMAPHORE, Type: models.SemaphoreType}, Mode: models.SHARED},
+				}
+				fakeLockDB.FetchSharedPageReturns(holders, nil)
+			})
+
+			It("returns the holders of the semaphores", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.SEMAPHORE, KeyPrefix: "up"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fetchResp.Resources).To(HaveLen(2))
+				Expect(fetchResp.Resources[0].Owner).To(Equal("cell-1"))
+				Expect(fetchResp.Resources[1].Owner).To(Equal("cell-2"))
+
+				Expect(fakeLockDB.FetchPageCallCount()).To(Equal(0))
+				Expect(fakeLockDB.FetchSharedPageCallCount()).To(Equal(1))
+				_, _, namespace, lockType, keyPrefix, startAfterKey, startAfterOwner, _, limit := fakeLockDB.FetchSharedPageArgsForCall(0)
+				Expect(namespace).To(BeEmpty())
+				Expect(lockType).To(Equal(models.SemaphoreType))
+				Expect(keyPrefix).To(Equal("up"))
+				Expect(startAfterKey).To(BeEmpty())
+				Expect(startAfterOwner).To(BeEmpty())
+				Expect(limit).To(Equal(0))
+			})
+
+			It("continues the next page after the last holder", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.SEMAPHORE, PageSize: 1})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchResp.Resources).To(HaveLen(1))
+				Expect(fetchResp.ContinuationToken).NotTo(BeEmpty())
+
+				_, err = locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.SEMAPHORE, PageSize: 1, ContinuationToken: fetchResp.ContinuationToken})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, _, _, _, startAfterKey, startAfterOwner, _, limit := fakeLockDB.FetchSharedPageArgsForCall(1)
+				Expect(startAfterKey).To(Equal("uploads"))
+				Expect(startAfterOwner).To(Equal("cell-1"))
+				Expect(limit).To(Equal(2))
+			})
+		})
+
+		Context("when resources are attached to leases", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchPageReturns([]*db.Lock{
+					{Resource: &models.Resource{Key: "own", Owner: "cell-1", LeaseId: "own-lease"}},
+					{Resource: &models.Resource{Key: "foreign", Owner: "cell-2", LeaseId: "foreign-lease"}},
+					{Resource: &models.Resource{Key: "unleased", Owner: "cell-3"}},
+				}, nil)
+				fakeLockDB.FetchLeaseOwnersReturns(map[string]string{"own-lease": "cell", "foreign-lease": "other-cell"}, nil)
+			})
+
+			It("only returns the leases granted by the client", func() {
+				fetchResp, err := locketHandler.FetchAll(contextWithClientCommonName("cell"), &models.FetchAllRequest{TypeCode: models.LOCK})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fetchResp.Resources).To(HaveLen(3))
+				Expect(fetchResp.Resources[0].LeaseId).To(Equal("own-lease"))
+				Expect(fetchResp.Resources[1].LeaseId).To(BeEmpty())
+				Expect(fetchResp.Resources[2].LeaseId).To(BeEmpty())
+
+				Expect(fakeLockDB.FetchLeaseOwnersCallCount()).To(Equal(1))
+				_, _, ids := fakeLockDB.FetchLeaseOwnersArgsForCall(0)
+				Expect(ids).To(ConsistOf("own-lease", "foreign-lease"))
+			})
+		})
+
+		Context("validate lock type", func() {
+			Context("when type_code is set", func() {
+				It("should be valid on a valid type code and empty type", func() {
+					_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.LOCK})
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should be invalid on an UNKNOWN type code and empty type", func() {
+					_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{})
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when the type is valid", func() {
+			It("fetches all the presence locks in the database by type", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fetchResp.Resources).To(Equal(expectedResources))
+				Expect(fakeLockDB.FetchPageCallCount()).Should(Equal(1))
+				_, _, _, lockType, _, _, _, _ := fakeLockDB.FetchPageArgsForCall(0)
+				Expect(lockType).To(Equal("presence"))
+			})
+
+			It("fetches all the lock locks in the database by type", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.LOCK})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fetchResp.Resources).To(Equal(expectedResources))
+				Expect(fakeLockDB.FetchPageCallCount()).Should(Equal(1))
+				_, _, _, lockType, _, _, _, _ := fakeLockDB.FetchPageArgsForCall(0)
+				Expect(lockType).To(Equal("lock"))
+			})
+
+			It("fetches all the presence locks in the database by type code", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fetchResp.Resources).To(Equal(expectedResources))
+				Expect(fakeLockDB.FetchPageCallCount()).Should(Equal(1))
+				_, _, _, lockType, _, _, _, _ := fakeLockDB.FetchPageArgsForCall(0)
+				Expect(lockType).To(Equal("presence"))
+			})
+
+			It("fetches all the lock locks in the database by type code", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.LOCK})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fetchResp.Resources).To(Equal(expectedResources))
+				Expect(fakeLockDB.FetchPageCallCount()).Should(Equal(1))
+				_, _, _, lockType, _, _, _, _ := fakeLockDB.FetchPageArgsForCall(0)
+				Expect(lockType).To(Equal("lock"))
+			})
+		})
+
+		Context("when paginating", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchPageReturns([]*db.Lock{
+					{Resource: &models.Resource{Key: "cells/cell-1"}},
+					{Resource: &models.Resource{Key: "cells/cell-2"}},
+					{Resource: &models.Resource{Key: "cells/cell-3"}},
+				}, nil)
+			})
+
+			It("fetches one more lock than the page size and returns a continuation token", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{
+					TypeCode:  models.PRESENCE,
+					KeyPrefix: "cells/",
+					PageSize:  2,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, _, lockType, keyPrefix, startAfter, _, limit := fakeLockDB.FetchPageArgsForCall(0)
+				Expect(lockType).To(Equal("presence"))
+				Expect(keyPrefix).To(Equal("cells/"))
+				Expect(startAfter).To(BeEmpty())
+				Expect(limit).To(Equal(3))
+
+				Expect(fetchResp.Resources).To(HaveLen(2))
+				Expect(fetchResp.Resources[1].Key).To(Equal("cells/cell-2"))
+				Expect(fetchResp.ContinuationToken).NotTo(BeEmpty())
+			})
+
+			It("continues after the last key of the previous page", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE, PageSize: 2})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{
+					TypeCode:          models.PRESENCE,
+					PageSize:          2,
+					ContinuationToken: fetchResp.ContinuationToken,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, _, _, _, startAfter, _, _ := fakeLockDB.FetchPageArgsForCall(1)
+				Expect(startAfter).To(Equal("cells/cell-2"))
+			})
+
+			It("does not return a continuation token on the last page", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE, PageSize: 3})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchResp.Resources).To(HaveLen(3))
+				Expect(fetchResp.ContinuationToken).To(BeEmpty())
+			})
+
+			It("does not limit the locks when the page size is not set", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchResp.Resources).To(HaveLen(3))
+				Expect(fetchResp.ContinuationToken).To(BeEmpty())
+
+				_, _, _, _, _, _, _, limit := fakeLockDB.FetchPageArgsForCall(0)
+				Expect(limit).To(Equal(0))
+			})
+
+			It("returns an error for a negative page size", func() {
+				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE, PageSize: -1})
+				Expect(err).To(Equal(models.ErrInvalidPageSize))
+				Expect(fakeLockDB.FetchPageCallCount()).To(Equal(0))
+			})
+
+			It("returns an error for an invalid continuation token", func() {
+				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE, ContinuationToken: "not base64!"})
+				Expect(err).To(Equal(models.ErrInvalidContinuationToken))
+				Expect(fakeLockDB.FetchPageCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the request has a label selector", func() {
+			It("fetches the locks matching the selector", func() {
+				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{
+					TypeCode:      models.PRESENCE,
+					LabelSelector: map[string]string{"zone": "z1"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLockDB.FetchPageCallCount()).Should(Equal(1))
+				_, _, _, _, _, _, labelSelector, _ := fakeLockDB.FetchPageArgsForCall(0)
+				Expect(labelSelector).To(Equal(map[string]string{"zone": "z1"}))
+			})
+		})
+
+		Context("when the type is user-defined", func() {
+			It("fetches all the resources of the type", func() {
+				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{Type: "maintenance-window"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLockDB.FetchPageCallCount()).Should(Equal(1))
+				_, _, _, lockType, _, _, _, _ := fakeLockDB.FetchPageArgsForCall(0)
+				Expect(lockType).To(Equal("maintenance-window"))
+			})
+		})
+
+		Context("when the request is for a namespace", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchPageReturns([]*db.Lock{
+					{Resource: &models.Resource{Key: "namespaces/diego/cells/cell-1"}},
+					{Resource: &models.Resource{Key: "namespaces/diego/cells/cell-2"}},
+				}, nil)
+			})
+
+			It("fetches the resources of the namespace", func() {
+				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{
+					TypeCode:  models.PRESENCE,
+					Namespace: "diego",
+					PageSize:  1,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, namespace, _, _, _, _, _ := fakeLockDB.FetchPageArgsForCall(0)
+				Expect(namespace).To(Equal("diego"))
+
+				Expect(fetchResp.Resources).To(HaveLen(1))
+				Expect(fetchResp.Resources[0].Namespace).To(Equal("diego"))
+				Expect(fetchResp.Resources[0].Key).To(Equal("cells/cell-1"))
+
+				_, err = locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{
+					TypeCode:          models.PRESENCE,
+					Namespace:         "diego",
+					PageSize:          1,
+					ContinuationToken: fetchResp.ContinuationToken,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, _, _, _, startAfter, _, _ := fakeLockDB.FetchPageArgsForCall(1)
+				Expect(startAfter).To(Equal("cells/cell-1"))
+			})
+
+			It("returns an invalid namespace error when the namespace is not registered", func() {
+				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE, Namespace: "routing"})
+				Expect(err).To(Equal(models.ErrInvalidNamespace))
+				Expect(fakeLockDB.FetchPageCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the type is invalid", func() {
+			It("returns an invalid type error", func() {
+				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{Type: "dawg"})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when the type code is UNKNOWN", func() {
+			It("returns an invalid type error", func() {
+				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.UNKNOWN})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when fetching errors", func() {
+			BeforeEach(func() {
+				fakeLockDB.FetchPageReturns(nil, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("GrantLease", func() {
+		var lease *db.Lease
+
+		BeforeEach(func() {
+			lease = &db.Lease{ID: "lease-guid", TtlInSeconds: 10, ModifiedIndex: 1}
+			fakeLockDB.GrantLeaseReturns(lease, nil)
+		})
+
+		It("grants the lease in the database", func() {
+			resp, err := locketHandler.GrantLease(context.Background(), &models.GrantLeaseRequest{TtlInSeconds: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&models.GrantLeaseResponse{LeaseId: "lease-guid", TtlInSeconds: 10}))
+
+			Expect(fakeLockDB.GrantLeaseCallCount()).To(Equal(1))
+			_, _, ttl, _ := fakeLockDB.GrantLeaseArgsForCall(0)
+			Expect(ttl).To(BeEquivalentTo(10))
+		})
+
+		It("grants the lease to the identity of the client", func() {
+			_, err := locketHandler.GrantLease(contextWithClientCommonName("cell"), &models.GrantLeaseRequest{TtlInSeconds: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _, owner := fakeLockDB.GrantLeaseArgsForCall(0)
+			Expect(owner).To(Equal("cell"))
+		})
+
+		It("registers the lease with the lock pick", func() {
+			_, err := locketHandler.GrantLease(context.Background(), &models.GrantLeaseRequest{TtlInSeconds: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLockPick.RegisterLeaseCallCount()).To(Equal(1))
+			_, registeredLease := fakeLockPick.RegisterLeaseArgsForCall(0)
+			Expect(registeredLease).To(Equal(lease))
+		})
+
+		Context("when the request does not have a TTL", func() {
+			It("returns a validation error", func() {
+				_, err := locketHandler.GrantLease(context.Background(), &models.GrantLeaseRequest{})
+				Expect(err).To(Equal(models.ErrInvalidTTL))
+				Expect(fakeLockDB.GrantLeaseCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("KeepAliveLease", func() {
+		var lease *db.Lease
+
+		BeforeEach(func() {
+			lease = &db.Lease{ID: "lease-guid", TtlInSeconds: 10, ModifiedIndex: 2}
+			fakeLockDB.KeepAliveLeaseReturns(lease, nil)
+		})
+
+		It("keeps the lease alive in the database on behalf of the client", func() {
+			resp, err := locketHandler.KeepAliveLease(contextWithClientCommonName("cell"), &models.KeepAliveLeaseRequest{LeaseId: "lease-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.TtlInSeconds).To(BeEquivalentTo(10))
+
+			Expect(fakeLockDB.KeepAliveLeaseCallCount()).To(Equal(1))
+			_, _, id, owner := fakeLockDB.KeepAliveLeaseArgsForCall(0)
+			Expect(id).To(Equal("lease-guid"))
+			Expect(owner).To(Equal("cell"))
+		})
+
+		It("registers the new modified index with the lock pick", func() {
+			_, err := locketHandler.KeepAliveLease(context.Background(), &models.KeepAliveLeaseRequest{LeaseId: "lease-guid"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLockPick.RegisterLeaseCallCount()).To(Equal(1))
+			_, registeredLease := fakeLockPick.RegisterLeaseArgsForCall(0)
+			Expect(registeredLease).To(Equal(lease))
+		})
 
+		Context("when the lease does not exist", func() {
 			BeforeEach(func() {
-				blockDB = make(chan struct{})
-				fakeLockDB.ReleaseStub = func(ctx context.Context, logger lager.Logger, resource *models.Resource) error {
-					<-blockDB
-					return nil
-				}
+				fakeLockDB.KeepAliveLeaseReturns(nil, models.ErrLeaseNotFound)
 			})
 
-			AfterEach(func() {
-				select {
-				case <-blockDB:
-				default:
-					close(blockDB)
-				}
+			It("returns the error", func() {
+				_, err := locketHandler.KeepAliveLease(context.Background(), &models.KeepAliveLeaseRequest{LeaseId: "lease-guid"})
+				Expect(err).To(Equal(models.ErrLeaseNotFound))
+				Expect(fakeLockPick.RegisterLeaseCallCount()).To(Equal(0))
 			})
+		})
 
-			JustBeforeEach(func() {
-				go func() {
-					_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: resource})
-					Expect(err).NotTo(HaveOccurred())
-				}()
+		Context("when the lease was granted by another client", func() {
+			BeforeEach(func() {
+				fakeLockDB.KeepAliveLeaseReturns(nil, models.ErrPermissionDenied)
 			})
 
-			It("updates the in-flight counter", func() {
-				Eventually(fakeRequestMetrics.IncrementRequestsInFlightCounterCallCount).Should(Equal(1))
-				requestType, value := fakeRequestMetrics.IncrementRequestsInFlightCounterArgsForCall(0)
-				Expect(value).To(BeEquivalentTo(1))
-				Expect(requestType).To(Equal("Release"))
+			It("returns the error", func() {
+				_, err := locketHandler.KeepAliveLease(contextWithClientCommonName("bbs"), &models.KeepAliveLeaseRequest{LeaseId: "lease-guid"})
+				Expect(err).To(Equal(models.ErrPermissionDenied))
+				Expect(fakeLockPick.RegisterLeaseCallCount()).To(Equal(0))
 			})
+		})
+	})
 
-			It("does not decrement the counter", func() {
-				Consistently(fakeRequestMetrics.DecrementRequestsInFlightCounterCallCount).Should(BeZero())
-			})
+	Context("RevokeLease", func() {
+		var exclusiveLock, sharedLock *db.Lock
 
-			Context("when the db request is finished", func() {
-				BeforeEach(func() {
-					close(blockDB)
-				})
+		BeforeEach(func() {
+			exclusiveLock = &db.Lock{Resource: &models.Resource{Key: "exclusive", Owner: "myself", LeaseId: "lease-guid"}}
+			sharedLock = &db.Lock{Resource: &models.Resource{Key: "shared", Owner: "myself", LeaseId: "lease-guid"}, Mode: models.SHARED}
+			fakeLockDB.RevokeLeaseReturns([]*db.Lock{exclusiveLock, sharedLock}, nil)
+		})
 
-				It("decrements the counter", func() {
-					Eventually(fakeRequestMetrics.DecrementRequestsInFlightCounterCallCount).Should(Equal(1))
-					requestType, value := fakeRequestMetrics.DecrementRequestsInFlightCounterArgsForCall(0)
-					Expect(value).To(BeEquivalentTo(1))
-					Expect(requestType).To(Equal("Release"))
+		It("revokes the lease in the database on behalf of the client", func() {
+			_, err := locketHandler.RevokeLease(contextWithClientCommonName("cell"), &models.RevokeLeaseRequest{LeaseId: "lease-guid"})
+			Expect(err).NotTo(HaveOccurred())
 
-				})
-			})
+			Expect(fakeLockDB.RevokeLeaseCallCount()).To(Equal(1))
+			_, _, id, owner := fakeLockDB.RevokeLeaseArgsForCall(0)
+			Expect(id).To(Equal("lease-guid"))
+			Expect(owner).To(Equal("cell"))
 		})
 
-		It("releases the lock in the database", func() {
-			_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: resource})
+		It("publishes the release of the exclusive locks to the watch hub", func() {
+			_, err := locketHandler.RevokeLease(context.Background(), &models.RevokeLeaseRequest{LeaseId: "lease-guid"})
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeLockDB.ReleaseCallCount()).Should(Equal(1))
-			_, _, actualResource := fakeLockDB.ReleaseArgsForCall(0)
-			Expect(actualResource).To(Equal(resource))
-
-			metricsRecordSuccess(fakeRequestMetrics)
-			metricsUseCorrectCallTags(fakeRequestMetrics, "Release")
+			Expect(fakeHub.RemoveCallCount()).To(Equal(1))
+			_, removed, eventType := fakeHub.RemoveArgsForCall(0)
+			Expect(removed).To(Equal(exclusiveLock.Resource))
+			Expect(eventType).To(Equal(models.DELETED))
 		})
 
-		Context("when releasing errors", func() {
+		Context("when the lease does not exist", func() {
 			BeforeEach(func() {
-				fakeLockDB.ReleaseReturns(errors.New("Boom."))
+				fakeLockDB.RevokeLeaseReturns(nil, models.ErrLeaseNotFound)
 			})
 
 			It("returns the error", func() {
-				_, err := locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: resource})
-				Expect(err).To(HaveOccurred())
-
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "Release")
+				_, err := locketHandler.RevokeLease(context.Background(), &models.RevokeLeaseRequest{LeaseId: "lease-guid"})
+				Expect(err).To(Equal(models.ErrLeaseNotFound))
+				Expect(fakeHub.RemoveCallCount()).To(Equal(0))
 			})
 		})
 
-		Context("when an unrecoverable error is returned", func() {
+		Context("when the lease was granted by another client", func() {
 			BeforeEach(func() {
-				fakeLockDB.ReleaseReturns(helpers.ErrUnrecoverableError)
+				fakeLockDB.RevokeLeaseReturns(nil, models.ErrPermissionDenied)
+			})
+
+			It("returns the error", func() {
+				_, err := locketHandler.RevokeLease(contextWithClientCommonName("bbs"), &models.RevokeLeaseRequest{LeaseId: "lease-guid"})
+				Expect(err).To(Equal(models.ErrPermissionDenied))
+				Expect(fakeHub.RemoveCallCount()).To(Equal(0))
 			})
+		})
+	})
 
-			It("logs and writes to the exit channel", func() {
-				locketHandler.Release(context.Background(), &models.ReleaseRequest{Resource: resource})
-				Expect(logger).To(gbytes.Say("unrecoverable-error"))
+	Context("Session", func() {
+		var (
+			stream       *fakeSessionStream
+			ctx          context.Context
+			cancel       context.CancelFunc
+			sessionErrCh chan error
+			lease        *db.Lease
+		)
 
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "Release")
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(contextWithClientCommonName("cell"))
+			stream = &fakeSessionStream{ctx: ctx, requests: make(chan *models.SessionRequest, 4)}
+			stream.requests <- &models.SessionRequest{TtlInSeconds: 15}
 
-				Expect(exitCh).To(Receive())
-			})
+			lease = &db.Lease{ID: "lease-guid", TtlInSeconds: 15, ModifiedIndex: 1}
+			fakeLockDB.GrantLeaseReturns(lease, nil)
+			fakeLockDB.LockReturns(&db.Lock{Resource: resource, ModifiedIndex: 1, FencingToken: 3}, nil)
 		})
 
-		Context("when the context errors", func() {
-			var ctx context.Context
-			BeforeEach(func() {
-				ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("uuid", "some-request-id"))
-			})
+		JustBeforeEach(func() {
+			errCh := make(chan error, 1)
+			sessionErrCh = errCh
+			go func(handler models.LocketServer, stream *fakeSessionStream) {
+				errCh <- handler.Session(stream)
+			}(locketHandler, stream)
+		})
 
-			JustBeforeEach(func() {
-				fakeLockDB.ReleaseReturns(errors.New("Boom."))
-				locketHandler.Release(ctx, &models.ReleaseRequest{Resource: resource})
-			})
+		AfterEach(func() {
+			cancel()
+		})
 
-			Context("when the context was closed due to a client cancellation", func() {
-				BeforeEach(func() {
-					var cancel func()
-					ctx, cancel = context.WithCancel(ctx)
-					cancel()
-				})
+		It("grants a lease for the session", func() {
+			Eventually(stream.Sent).Should(HaveLen(1))
+			Expect(stream.Sent()[0]).To(Equal(&models.SessionResponse{LeaseId: "lease-guid", TtlInSeconds: 15}))
 
-				It("logs the context cancelled error", func() {
-					Expect(logger).To(gbytes.Say("context-cancelled"))
-					Expect(logger).To(gbytes.Say("some-request-id"))
-					Expect(logger).To(gbytes.Say("Release"))
-					Expect(logger).To(gbytes.Say("test"))
-					Expect(logger).To(gbytes.Say("myself"))
-				})
+			_, _, ttl, owner := fakeLockDB.GrantLeaseArgsForCall(0)
+			Expect(ttl).To(BeEquivalentTo(15))
+			Expect(owner).To(Equal("cell"))
 
-				It("records an increase in the request cancelled metric", func() {
-					Expect(fakeRequestMetrics.IncrementRequestsCancelledCounterCallCount()).To(Equal(1))
-					_, requestsCancelled := fakeRequestMetrics.IncrementRequestsCancelledCounterArgsForCall(0)
-					Expect(requestsCancelled).To(BeEquivalentTo(1))
-				})
-			})
+			Expect(fakeLockPick.RegisterLeaseCallCount()).To(Equal(1))
+		})
 
-			Context("when the context was closed due to an exceeded deadline", func() {
-				BeforeEach(func() {
-					var cancel context.CancelFunc
-					ctx, cancel = context.WithDeadline(ctx, time.Unix(0, 0)) // nolint
-					defer cancel()
-				})
+		It("acquires the locks requested in the session on its lease", func() {
+			stream.requests <- &models.SessionRequest{Lock: &models.LockRequest{Resource: resource}}
 
-				It("logs the context deadline exceeded error", func() {
-					Expect(logger).To(gbytes.Say("context-deadline-exceeded"))
-					Expect(logger).To(gbytes.Say("some-request-id"))
-					Expect(logger).To(gbytes.Say("Release"))
-					Expect(logger).To(gbytes.Say("test"))
-					Expect(logger).To(gbytes.Say("myself"))
-				})
+			Eventually(stream.Sent).Should(HaveLen(2))
+			Expect(stream.Sent()[1]).To(Equal(&models.SessionResponse{Lock: &models.LockResponse{ModifiedIndex: 1, FencingToken: 3}}))
 
-				It("does not emit a requests cancelled metric", func() {
-					Expect(fakeRequestMetrics.IncrementRequestsCancelledCounterCallCount()).To(Equal(0))
-				})
-			})
+			_, _, lockedResource, _, _ := fakeLockDB.LockArgsForCall(0)
+			Expect(lockedResource.LeaseId).To(Equal("lease-guid"))
+			Expect(resource.LeaseId).To(BeEmpty())
 		})
-	})
 
-	Context("Fetch", func() {
-		BeforeEach(func() {
-			fakeLockDB.FetchReturns(&db.Lock{Resource: resource}, nil)
+		It("releases the locks requested in the session", func() {
+			stream.requests <- &models.SessionRequest{Release: &models.ReleaseRequest{Resource: resource}}
+
+			Eventually(stream.Sent).Should(HaveLen(2))
+			Expect(stream.Sent()[1]).To(Equal(&models.SessionResponse{Release: &models.ReleaseResponse{}}))
+			Expect(fakeLockDB.ReleaseCallCount()).To(Equal(1))
 		})
 
-		It("fetches the lock in the database", func() {
-			fetchResp, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Key: "test-fetch"})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(fetchResp.Resource).To(Equal(resource))
+		It("keeps the lease alive on requests without a lock or a release", func() {
+			fakeLockDB.KeepAliveLeaseReturns(&db.Lease{ID: "lease-guid", TtlInSeconds: 15, ModifiedIndex: 2}, nil)
+			stream.requests <- &models.SessionRequest{}
 
-			Expect(fakeLockDB.FetchCallCount()).Should(Equal(1))
-			_, _, key := fakeLockDB.FetchArgsForCall(0)
-			Expect(key).To(Equal("test-fetch"))
+			Eventually(stream.Sent).Should(HaveLen(2))
+			Expect(stream.Sent()[1]).To(Equal(&models.SessionResponse{TtlInSeconds: 15}))
 
-			metricsRecordSuccess(fakeRequestMetrics)
-			metricsUseCorrectCallTags(fakeRequestMetrics, "Fetch")
+			_, _, id, owner := fakeLockDB.KeepAliveLeaseArgsForCall(0)
+			Expect(id).To(Equal("lease-guid"))
+			Expect(owner).To(Equal("cell"))
+			Expect(fakeLockPick.RegisterLeaseCallCount()).To(Equal(2))
 		})
 
-		Context("when fetching errors", func() {
-			BeforeEach(func() {
-				fakeLockDB.FetchReturns(nil, errors.New("boom"))
-			})
-
-			It("returns the error", func() {
-				_, err := locketHandler.Fetch(context.Background(), &models.FetchRequest{Key: "test-fetch"})
-				Expect(err).To(HaveOccurred())
+		It("returns the errors of the requests without ending the session", func() {
+			fakeLockDB.LockReturns(nil, models.ErrLockCollision)
+			stream.requests <- &models.SessionRequest{Lock: &models.LockRequest{Resource: resource}}
+			stream.requests <- &models.SessionRequest{Release: &models.ReleaseRequest{Resource: resource}}
 
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "Fetch")
-			})
+			Eventually(stream.Sent).Should(HaveLen(3))
+			Expect(stream.Sent()[1].Err()).To(MatchError(models.ErrLockCollision.Error()))
+			Expect(stream.Sent()[2].Err()).NotTo(HaveOccurred())
 		})
 
-		Context("when an unrecoverable error is returned", func() {
+		Context("when a lock waits for longer than the ttl of the session", func() {
 			BeforeEach(func() {
-				fakeLockDB.FetchReturns(nil, helpers.ErrUnrecoverableError)
+				fakeLockDB.LockReturns(nil, models.ErrLockCollision)
+				fakeLockDB.KeepAliveLeaseReturns(&db.Lease{ID: "lease-guid", TtlInSeconds: 15, ModifiedIndex: 2}, nil)
+
+				fakeSub := &watchfakes.FakeSubscription{}
+				fakeSub.EventsReturns(make(chan *models.WatchEvent))
+				fakeHub.SubscribeReturns(fakeSub, nil)
 			})
 
-			It("logs and writes to the exit channel", func() {
-				locketHandler.Fetch(context.Background(), &models.FetchRequest{Key: "test-fetch"})
-				Expect(logger).To(gbytes.Say("unrecoverable-error"))
+			It("keeps the lease alive while the lock waits and answers the requests in order", func() {
+				Eventually(stream.Sent).Should(HaveLen(1))
+				stream.requests <- &models.SessionRequest{Lock: &models.LockRequest{Resource: resource, WaitTimeoutInSeconds: 60}}
+				Eventually(fakeLockDB.LockCallCount).Should(Equal(1))
 
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "Fetch")
+				stream.requests <- &models.SessionRequest{}
+				Eventually(fakeLockDB.KeepAliveLeaseCallCount).Should(Equal(1))
+				Expect(fakeLockPick.RegisterLeaseCallCount()).To(Equal(2))
+				Consistently(stream.Sent).Should(HaveLen(1))
 
-				Expect(exitCh).To(Receive())
+				fakeClock.WaitForWatcherAndIncrement(60 * time.Second)
+
+				Eventually(stream.Sent).Should(HaveLen(3))
+				Expect(stream.Sent()[1].Err()).To(MatchError(models.ErrLockCollision.Error()))
+				Expect(stream.Sent()[2]).To(Equal(&models.SessionResponse{TtlInSeconds: 15}))
 			})
 		})
 
-		Context("when the context errors", func() {
-			var ctx context.Context
+		Context("when the client closes the session", func() {
 			BeforeEach(func() {
-				ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("uuid", "some-request-id"))
+				fakeLockDB.RevokeLeaseReturns([]*db.Lock{{Resource: resource}}, nil)
 			})
 
-			JustBeforeEach(func() {
-				fakeLockDB.FetchReturns(nil, errors.New("boom"))
-				locketHandler.Fetch(ctx, &models.FetchRequest{Key: "test-fetch"})
+			It("revokes the lease right away", func() {
+				Eventually(stream.Sent).Should(HaveLen(1))
+				close(stream.requests)
+
+				Eventually(sessionErrCh).Should(Receive(BeNil()))
+				Expect(fakeLockDB.RevokeLeaseCallCount()).To(Equal(1))
+				_, _, id, owner := fakeLockDB.RevokeLeaseArgsForCall(0)
+				Expect(id).To(Equal("lease-guid"))
+				Expect(owner).To(Equal("cell"))
+
+				Expect(fakeHub.RemoveCallCount()).To(Equal(1))
+				_, removed, eventType := fakeHub.RemoveArgsForCall(0)
+				Expect(removed).To(Equal(resource))
+				Expect(eventType).To(Equal(models.DELETED))
 			})
+		})
 
-			Context("when the context was closed due to a client cancellation", func() {
-				BeforeEach(func() {
-					var cancel func()
-					ctx, cancel = context.WithCancel(ctx)
-					cancel()
-				})
+		Context("when the stream breaks", func() {
+			It("revokes the lease right away", func() {
+				Eventually(stream.Sent).Should(HaveLen(1))
+				cancel()
 
-				It("logs the context cancelled error", func() {
-					Expect(logger).To(gbytes.Say("context-cancelled"))
-					Expect(logger).To(gbytes.Say("some-request-id"))
-					Expect(logger).To(gbytes.Say("Fetch"))
-					Expect(logger).To(gbytes.Say("test"))
-				})
+				Eventually(sessionErrCh).Should(Receive(BeNil()))
+				Expect(fakeLockDB.RevokeLeaseCallCount()).To(Equal(1))
+			})
+		})
 
-				It("records an increase in the request cancelled metric", func() {
-					Expect(fakeRequestMetrics.IncrementRequestsCancelledCounterCallCount()).To(Equal(1))
-					_, requestsCancelled := fakeRequestMetrics.IncrementRequestsCancelledCounterArgsForCall(0)
-					Expect(requestsCancelled).To(BeEquivalentTo(1))
-				})
+		Context("when the lease of the session expired", func() {
+			BeforeEach(func() {
+				fakeLockDB.KeepAliveLeaseReturns(nil, models.ErrLeaseNotFound)
+				stream.requests <- &models.SessionRequest{}
 			})
 
-			Context("when the context was closed due to an exceeded deadline", func() {
-				BeforeEach(func() {
-					var cancel context.CancelFunc
-					ctx, cancel = context.WithDeadline(ctx, time.Unix(0, 0)) // nolint
-					defer cancel()
-				})
+			It("ends the session", func() {
+				Eventually(sessionErrCh).Should(Receive(Equal(models.ErrLeaseNotFound)))
+			})
+		})
 
-				It("logs the context deadline exceeded error", func() {
-					Expect(logger).To(gbytes.Say("context-deadline-exceeded"))
-					Expect(logger).To(gbytes.Say("some-request-id"))
-					Expect(logger).To(gbytes.Say("Fetch"))
-					Expect(logger).To(gbytes.Say("test"))
-				})
+		Context("when the session does not start with a ttl", func() {
+			BeforeEach(func() {
+				stream.requests = make(chan *models.SessionRequest, 1)
+				stream.requests <- &models.SessionRequest{}
+			})
 
-				It("does not emit a requests cancelled metric", func() {
-					Expect(fakeRequestMetrics.IncrementRequestsCancelledCounterCallCount()).To(Equal(0))
-				})
+			It("returns a validation error", func() {
+				Eventually(sessionErrCh).Should(Receive(Equal(models.ErrInvalidTTL)))
+				Expect(fakeLockDB.GrantLeaseCallCount()).To(Equal(0))
 			})
 		})
 	})
 
-	Context("FetchAll", func() {
-		var expectedResources []*models.Resource
+	Context("Watch", func() {
+		var (
+			request         *models.WatchRequest
+			stream          *fakeWatchStream
+			fakeSub         *watchfakes.FakeSubscription
+			events          chan *models.WatchEvent
+			ctx             context.Context
+			cancel          context.CancelFunc
+			watchErrCh      chan error
+			expectedEvent   *models.WatchEvent
+			anotherResource *models.Resource
+		)
+
 		BeforeEach(func() {
-			expectedResources = []*models.Resource{
-				resource,
-				&models.Resource{Key: "cell", Owner: "cell-1", Value: "{}"},
-			}
+			request = &models.WatchRequest{KeyPrefix: "te", TypeCode: models.LOCK, StartRevision: 5}
 
-			var locks []*db.Lock
-			for _, r := range expectedResources {
-				locks = append(locks, &db.Lock{Resource: r})
-			}
-			fakeLockDB.FetchAllReturns(locks, nil)
-		})
+			events = make(chan *models.WatchEvent, 2)
+			fakeSub = &watchfakes.FakeSubscription{}
+			fakeSub.EventsReturns(events)
+			fakeHub.SubscribeReturns(fakeSub, nil)
 
-		Context("validate lock type", func() {
-			Context("when type_code is set", func() {
-				It("should be valid on a valid type code and empty type", func() {
-					_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.LOCK})
-					Expect(err).NotTo(HaveOccurred())
+			ctx, cancel = context.WithCancel(context.Background())
+			stream = &fakeWatchStream{ctx: ctx}
 
-					metricsRecordSuccess(fakeRequestMetrics)
-					metricsUseCorrectCallTags(fakeRequestMetrics, "FetchAll")
+			anotherResource = &models.Resource{Key: "test-2", Owner: "someone", TypeCode: models.LOCK}
+			expectedEvent = &models.WatchEvent{Type: models.CREATED, Resource: resource, Revision: 5}
+			events <- expectedEvent
+			events <- &models.WatchEvent{Type: models.EXPIRED, Resource: anotherResource, Revision: 6}
+		})
 
-					_, err = locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE})
-					Expect(err).NotTo(HaveOccurred())
-				})
+		JustBeforeEach(func() {
+			errCh := make(chan error, 1)
+			watchErrCh = errCh
+			go func(handler models.LocketServer, request *models.WatchRequest, stream *fakeWatchStream) {
+				errCh <- handler.Watch(request, stream)
+			}(locketHandler, request, stream)
+		})
 
-				It("should be invalid on an UNKNOWN type code and empty type", func() {
-					_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{})
-					Expect(err).To(HaveOccurred())
+		AfterEach(func() {
+			cancel()
+		})
 
-					metricsRecordFailure(fakeRequestMetrics)
-					metricsUseCorrectCallTags(fakeRequestMetrics, "FetchAll")
-				})
-			})
+		It("subscribes to the watch hub with the requested filter and revision", func() {
+			Eventually(fakeHub.SubscribeCallCount).Should(Equal(1))
+			filter, startRevision := fakeHub.SubscribeArgsForCall(0)
+			Expect(filter).To(Equal(watch.Filter{KeyPrefix: "te", TypeCode: models.LOCK}))
+			Expect(startRevision).To(BeEquivalentTo(5))
 		})
 
-		Context("when the type is valid", func() {
-			It("fetches all the presence locks in the database by type", func() {
-				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE})
-				Expect(err).NotTo(HaveOccurred())
+		It("streams events to the watcher in order", func() {
+			Eventually(stream.Sent).Should(HaveLen(2))
+			Expect(stream.Sent()[0]).To(Equal(expectedEvent))
+			Expect(stream.Sent()[1].Type).To(Equal(models.EXPIRED))
+			Expect(stream.Sent()[1].Resource).To(Equal(anotherResource))
+		})
 
-				metricsRecordSuccess(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "FetchAll")
+		Context("when the watcher disconnects", func() {
+			It("closes the subscription and records a successful request", func() {
+				Eventually(stream.Sent).Should(HaveLen(2))
+				cancel()
 
-				Expect(fetchResp.Resources).To(Equal(expectedResources))
-				Expect(fakeLockDB.FetchAllCallCount()).Should(Equal(1))
-				_, _, lockType := fakeLockDB.FetchAllArgsForCall(0)
-				Expect(lockType).To(Equal("presence"))
+				Eventually(watchErrCh).Should(Receive(BeNil()))
+				Expect(fakeSub.CloseCallCount()).To(Equal(1))
 			})
+		})
 
-			It("fetches all the lock locks in the database by type", func() {
-				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.LOCK})
-				Expect(err).NotTo(HaveOccurred())
-
-				metricsRecordSuccess(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "FetchAll")
-
-				Expect(fetchResp.Resources).To(Equal(expectedResources))
-				Expect(fakeLockDB.FetchAllCallCount()).Should(Equal(1))
-				_, _, lockType := fakeLockDB.FetchAllArgsForCall(0)
-				Expect(lockType).To(Equal("lock"))
+		Context("when the resources are attached to leases", func() {
+			BeforeEach(func() {
+				cancel()
+				ctx, cancel = context.WithCancel(contextWithClientCommonName("cell"))
+				stream.ctx = ctx
+
+				events = make(chan *models.WatchEvent, 2)
+				fakeSub.EventsReturns(events)
+				events <- &models.WatchEvent{Type: models.CREATED, Resource: &models.Resource{Key: "own", Owner: "cell-1", LeaseId: "own-lease"}, Revision: 5}
+				events <- &models.WatchEvent{Type: models.CREATED, Resource: &models.Resource{Key: "foreign", Owner: "cell-2", LeaseId: "foreign-lease"}, Revision: 6}
+
+				fakeLockDB.FetchLeaseOwnersStub = func(_ context.Context, _ lager.Logger, ids []string) (map[string]string, error) {
+					if ids[0] == "own-lease" {
+						return map[string]string{"own-lease": "cell"}, nil
+					}
+					return nil, errors.New("boom")
+				}
 			})
 
-			It("fetches all the presence locks in the database by type code", func() {
-				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE})
-				Expect(err).NotTo(HaveOccurred())
+			It("only streams the leases granted by the client", func() {
+				Eventually(stream.Sent).Should(HaveLen(2))
+				Expect(stream.Sent()[0].Resource.LeaseId).To(Equal("own-lease"))
+				Expect(stream.Sent()[1].Resource.LeaseId).To(BeEmpty())
+				Expect(fakeLockDB.FetchLeaseOwnersCallCount()).To(Equal(2))
+			})
+		})
 
-				metricsRecordSuccess(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "FetchAll")
+		Context("when the request is for a namespace", func() {
+			BeforeEach(func() {
+				request = &models.WatchRequest{Namespace: "diego", KeyPrefix: "te", TypeCode: models.LOCK}
+
+				events = make(chan *models.WatchEvent, 1)
+				fakeSub.EventsReturns(events)
+				events <- &models.WatchEvent{
+					Type:     models.CREATED,
+					Resource: &models.Resource{Key: "namespaces/diego/test", Owner: "myself", TypeCode: models.LOCK},
+					Revision: 5,
+				}
+			})
 
-				Expect(fetchResp.Resources).To(Equal(expectedResources))
-				Expect(fakeLockDB.FetchAllCallCount()).Should(Equal(1))
-				_, _, lockType := fakeLockDB.FetchAllArgsForCall(0)
-				Expect(lockType).To(Equal("presence"))
+			It("subscribes to the resources of the namespace", func() {
+				Eventually(fakeHub.SubscribeCallCount).Should(Equal(1))
+				filter, _ := fakeHub.SubscribeArgsForCall(0)
+				Expect(filter).To(Equal(watch.Filter{Namespace: "diego", KeyPrefix: "namespaces/diego/te", TypeCode: models.LOCK}))
 			})
 
-			It("fetches all the lock locks in the database by type code", func() {
-				fetchResp, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.LOCK})
-				Expect(err).NotTo(HaveOccurred())
+			It("streams the resources split from the namespace", func() {
+				Eventually(stream.Sent).Should(HaveLen(1))
+				Expect(stream.Sent()[0].Resource.Namespace).To(Equal("diego"))
+				Expect(stream.Sent()[0].Resource.Key).To(Equal("test"))
+			})
+		})
 
-				metricsRecordSuccess(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "FetchAll")
+		Context("when the namespace is not registered", func() {
+			BeforeEach(func() {
+				request = &models.WatchRequest{Namespace: "routing"}
+			})
 
-				Expect(fetchResp.Resources).To(Equal(expectedResources))
-				Expect(fakeLockDB.FetchAllCallCount()).Should(Equal(1))
-				_, _, lockType := fakeLockDB.FetchAllArgsForCall(0)
-				Expect(lockType).To(Equal("lock"))
+			It("returns an invalid namespace error", func() {
+				Eventually(watchErrCh).Should(Receive(Equal(models.ErrInvalidNamespace)))
+				Expect(fakeHub.SubscribeCallCount()).To(Equal(0))
 			})
 		})
 
-		Context("when the type is invalid", func() {
-			It("returns an invalid type error", func() {
-				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{Type: "dawg"})
-				Expect(err).To(HaveOccurred())
+		Context("when the subscription is closed by the hub", func() {
+			BeforeEach(func() {
+				close(events)
+				fakeSub.ErrReturns(models.ErrWatcherTooSlow)
+			})
 
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "FetchAll")
+			It("returns the subscription error", func() {
+				Eventually(watchErrCh).Should(Receive(Equal(models.ErrWatcherTooSlow)))
 			})
 		})
 
-		Context("when the type code is UNKNOWN", func() {
-			It("returns an invalid type error", func() {
-				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.UNKNOWN})
-				Expect(err).To(HaveOccurred())
+		Context("when sending an event fails", func() {
+			BeforeEach(func() {
+				stream.sendErr = errors.New("boom")
+			})
 
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "FetchAll")
+			It("returns the error and closes the subscription", func() {
+				Eventually(watchErrCh).Should(Receive(MatchError("boom")))
+				Expect(fakeSub.CloseCallCount()).To(Equal(1))
 			})
 		})
 
-		Context("when fetching errors", func() {
+		Context("when subscribing fails", func() {
 			BeforeEach(func() {
-				fakeLockDB.FetchAllReturns(nil, errors.New("boom"))
+				fakeHub.SubscribeReturns(nil, models.ErrRevisionCompacted)
 			})
 
 			It("returns the error", func() {
-				_, err := locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{})
-				Expect(err).To(HaveOccurred())
-
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "FetchAll")
+				Eventually(watchErrCh).Should(Receive(Equal(models.ErrRevisionCompacted)))
 			})
 		})
 
-		Context("when an unrecoverable error is returned", func() {
+		Context("when the type code is invalid", func() {
 			BeforeEach(func() {
-				fakeLockDB.FetchAllReturns(nil, helpers.ErrUnrecoverableError)
+				request.TypeCode = 42
 			})
 
-			It("logs and writes to the exit channel", func() {
-				locketHandler.FetchAll(context.Background(), &models.FetchAllRequest{TypeCode: models.PRESENCE})
-				Expect(logger).To(gbytes.Say("unrecoverable-error"))
-
-				metricsRecordFailure(fakeRequestMetrics)
-				metricsUseCorrectCallTags(fakeRequestMetrics, "FetchAll")
-
-				Expect(exitCh).To(Receive())
+			It("returns an invalid type error without subscribing", func() {
+				Eventually(watchErrCh).Should(Receive(Equal(models.ErrInvalidType)))
+				Expect(fakeHub.SubscribeCallCount()).To(Equal(0))
 			})
 		})
 
-		Context("when the context errors", func() {
-			var ctx context.Context
+		Context("when no type code is given", func() {
 			BeforeEach(func() {
-				ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("uuid", "some-request-id"))
+				request.TypeCode = models.UNKNOWN
 			})
 
-			JustBeforeEach(func() {
-				fakeLockDB.FetchAllReturns(nil, errors.New("boom"))
-				locketHandler.FetchAll(ctx, &models.FetchAllRequest{})
+			It("watches all types", func() {
+				Eventually(fakeHub.SubscribeCallCount).Should(Equal(1))
+				filter, _ := fakeHub.SubscribeArgsForCall(0)
+				Expect(filter.TypeCode).To(Equal(models.UNKNOWN))
 			})
+		})
 
-			Context("when the context was closed due to a client cancellation", func() {
-				BeforeEach(func() {
-					var cancel func()
-					ctx, cancel = context.WithCancel(ctx)
-					cancel()
-				})
-
-				It("logs the context cancelled error", func() {
-					Expect(logger).To(gbytes.Say("context-cancelled"))
-					Expect(logger).To(gbytes.Say("some-request-id"))
-					Expect(logger).To(gbytes.Say("FetchAll"))
-				})
-
-				It("records an increase in the request cancelled metric", func() {
-					Expect(fakeRequestMetrics.IncrementRequestsCancelledCounterCallCount()).To(Equal(1))
-					_, requestsCancelled := fakeRequestMetrics.IncrementRequestsCancelledCounterArgsForCall(0)
-					Expect(requestsCancelled).To(BeEquivalentTo(1))
-				})
+		Context("when a user-defined type is given", func() {
+			BeforeEach(func() {
+				request.TypeCode = models.UNKNOWN
+				request.Type = "maintenance-window"
 			})
 
-			Context("when the context was closed due to an exceeded deadline", func() {
-				BeforeEach(func() {
-					var cancel context.CancelFunc
-					ctx, cancel = context.WithDeadline(ctx, time.Unix(0, 0)) // nolint
-					defer cancel()
-				})
+			It("watches the resources of the type", func() {
+				Eventually(fakeHub.SubscribeCallCount).Should(Equal(1))
+				filter, _ := fakeHub.SubscribeArgsForCall(0)
+				Expect(filter.Type).To(Equal("maintenance-window"))
+			})
+		})
 
-				It("logs the context deadline exceeded error", func() {
-					Expect(logger).To(gbytes.Say("context-deadline-exceeded"))
-					Expect(logger).To(gbytes.Say("some-request-id"))
-					Expect(logger).To(gbytes.Say("FetchAll"))
-				})
+		Context("when an unregistered type is given", func() {
+			BeforeEach(func() {
+				request.TypeCode = models.UNKNOWN
+				request.Type = "migration"
+			})
 
-				It("does not emit a requests cancelled metric", func() {
-					Expect(fakeRequestMetrics.IncrementRequestsCancelledCounterCallCount()).To(Equal(0))
-				})
+			It("returns an invalid type error without subscribing", func() {
+				Eventually(watchErrCh).Should(Receive(Equal(models.ErrInvalidType)))
+				Expect(fakeHub.SubscribeCallCount()).To(Equal(0))
 			})
 		})
 	})
@@ -799,7 +2559,7 @@ var _ = Describe("LocketHandler", func() {
 		}
 
 		It("Lock: does not cancel the DB operation when the gRPC context is cancelled", func() {
-			fakeLockDB.LockStub = func(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64) (*db.Lock, error) {
+			fakeLockDB.LockStub = func(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64, leaseOwner string) (*db.Lock, error) {
 				<-blockDB
 				return &db.Lock{Resource: resource, TtlInSeconds: ttl, ModifiedIndex: 1}, nil
 			}
@@ -809,7 +2569,7 @@ var _ = Describe("LocketHandler", func() {
 					_, err := locketHandler.Lock(ctx, &models.LockRequest{Resource: resource, TtlInSeconds: 10})
 					Expect(err).NotTo(HaveOccurred())
 				},
-				func() context.Context { ctx, _, _, _ := fakeLockDB.LockArgsForCall(0); return ctx },
+				func() context.Context { ctx, _, _, _, _ := fakeLockDB.LockArgsForCall(0); return ctx },
 			)
 		})
 
@@ -844,17 +2604,17 @@ var _ = Describe("LocketHandler", func() {
 		})
 
 		It("FetchAll: does not cancel the DB operation when the gRPC context is cancelled", func() {
-			fakeLockDB.FetchAllStub = func(ctx context.Context, logger lager.Logger, lockType string) ([]*db.Lock, error) {
+			fakeLockDB.FetchPageStub = func(ctx context.Context, logger lager.Logger, namespace, lockType, keyPrefix, startAfter string, labelSelector map[string]string, limit int) ([]*db.Lock, error) {
 				<-blockDB
 				return []*db.Lock{{Resource: resource}}, nil
 			}
 			verifyDBContextIsolation(
-				func() { Eventually(fakeLockDB.FetchAllCallCount).Should(Equal(1)) },
+				func() { Eventually(fakeLockDB.FetchPageCallCount).Should(Equal(1)) },
 				func(ctx context.Context) {
 					_, err := locketHandler.FetchAll(ctx, &models.FetchAllRequest{TypeCode: models.LOCK})
 					Expect(err).NotTo(HaveOccurred())
 				},
-				func() context.Context { ctx, _, _ := fakeLockDB.FetchAllArgsForCall(0); return ctx },
+				func() context.Context { ctx, _, _, _, _, _, _, _ := fakeLockDB.FetchPageArgsForCall(0); return ctx },
 			)
 		})
 
@@ -864,12 +2624,16 @@ var _ = Describe("LocketHandler", func() {
 				logger,
 				fakeLockDB,
 				fakeLockPick,
-				fakeRequestMetrics,
+				fakeHub,
+				fakeClock,
 				exitCh,
 				shortTimeout,
+				nil,
+				handlers.DefaultMaxPayloadSize,
+				nil,
 			)
 
-			fakeLockDB.LockStub = func(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64) (*db.Lock, error) {
+			fakeLockDB.LockStub = func(ctx context.Context, logger lager.Logger, resource *models.Resource, ttl int64, leaseOwner string) (*db.Lock, error) {
 				deadline, ok := ctx.Deadline()
 				Expect(ok).To(BeTrue())
 				Expect(time.Until(deadline)).To(BeNumerically("<=", shortTimeout))
@@ -883,85 +2647,165 @@ var _ = Describe("LocketHandler", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
-})
 
-func metricsRecordSuccess(fakeRequestMetrics *helpersfakes.FakeRequestMetrics) {
-	Expect(fakeRequestMetrics.IncrementRequestsStartedCounterCallCount()).To(Equal(1))
-	_, started := fakeRequestMetrics.IncrementRequestsStartedCounterArgsForCall(0)
-	Expect(started).To(BeEquivalentTo(1))
+	Context("Tracing", func() {
+		var (
+			ctx    context.Context
+			parent trace.Span
+		)
+
+		BeforeEach(func() {
+			ctx, parent = otel.Tracer("test").Start(context.Background(), "models.Locket/Lock")
+			fakeLockDB.LockReturns(&db.Lock{Resource: resource, TtlInSeconds: 10}, nil)
+		})
+
+		It("records a span for the method in the trace of the request", func() {
+			_, err := locketHandler.Lock(ctx, &models.LockRequest{Resource: resource, TtlInSeconds: 10})
+			Expect(err).NotTo(HaveOccurred())
 
-	Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(1))
-	_, succeeded := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
-	Expect(succeeded).To(BeEquivalentTo(1))
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("locketHandler.Lock"))
+			Expect(spans[0].Parent.SpanID()).To(Equal(parent.SpanContext().SpanID()))
+			Expect(spans[0].Status.Code).To(Equal(codes.Unset))
+		})
 
-	Expect(fakeRequestMetrics.IncrementRequestsFailedCounterCallCount()).To(Equal(0))
+		It("passes the span of the method to the database", func() {
+			_, err := locketHandler.Lock(ctx, &models.LockRequest{Resource: resource, TtlInSeconds: 10})
+			Expect(err).NotTo(HaveOccurred())
 
-	Expect(fakeRequestMetrics.IncrementRequestsInFlightCounterCallCount()).To(Equal(1))
-	_, incInFlight := fakeRequestMetrics.IncrementRequestsInFlightCounterArgsForCall(0)
-	Expect(incInFlight).To(BeEquivalentTo(1))
+			dbCtx, _, _, _, _ := fakeLockDB.LockArgsForCall(0)
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(trace.SpanContextFromContext(dbCtx)).To(Equal(spans[0].SpanContext))
+		})
 
-	Expect(fakeRequestMetrics.DecrementRequestsInFlightCounterCallCount()).To(Equal(1))
-	_, decInFlight := fakeRequestMetrics.IncrementRequestsInFlightCounterArgsForCall(0)
-	Expect(decInFlight).To(BeEquivalentTo(1))
+		It("does not mark lock collisions as failures", func() {
+			fakeLockDB.LockReturns(nil, models.ErrLockCollision)
+			_, err := locketHandler.Lock(ctx, &models.LockRequest{Resource: resource, TtlInSeconds: 10})
+			Expect(err).To(Equal(models.ErrLockCollision))
 
-	Expect(fakeRequestMetrics.UpdateLatencyCallCount()).To(Equal(1))
-	_, latency := fakeRequestMetrics.UpdateLatencyArgsForCall(0)
-	Expect(latency).To(BeNumerically(">=", 0))
-}
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Status.Code).To(Equal(codes.Unset))
+		})
+
+		It("marks the span of failed methods as failed", func() {
+			fakeLockDB.FetchReturns(nil, models.ErrResourceNotFound)
+			_, err := locketHandler.Fetch(ctx, &models.FetchRequest{Key: "test"})
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("locketHandler.Fetch"))
+			Expect(spans[0].Status.Code).To(Equal(codes.Error))
+		})
 
-func metricsRecordFailure(fakeRequestMetrics *helpersfakes.FakeRequestMetrics) {
-	Expect(fakeRequestMetrics.IncrementRequestsStartedCounterCallCount()).To(Equal(1))
-	_, started := fakeRequestMetrics.IncrementRequestsStartedCounterArgsForCall(0)
-	Expect(started).To(BeEquivalentTo(1))
+		It("records a span for as long as a session is open", func() {
+			fakeLockDB.GrantLeaseReturns(&db.Lease{ID: "lease-1", TtlInSeconds: 15}, nil)
+			requests := make(chan *models.SessionRequest, 1)
+			requests <- &models.SessionRequest{TtlInSeconds: 15}
+			close(requests)
 
-	Expect(fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount()).To(Equal(0))
+			err := locketHandler.Session(&fakeSessionStream{ctx: ctx, requests: requests})
+			Expect(err).NotTo(HaveOccurred())
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("locketHandler.Session"))
 
-	Expect(fakeRequestMetrics.IncrementRequestsFailedCounterCallCount()).To(Equal(1))
-	_, failed := fakeRequestMetrics.IncrementRequestsFailedCounterArgsForCall(0)
-	Expect(failed).To(BeEquivalentTo(1))
+			dbCtx, _, _, _ := fakeLockDB.GrantLeaseArgsForCall(0)
+			Expect(trace.SpanContextFromContext(dbCtx)).To(Equal(spans[0].SpanContext))
+			revokeCtx, _, _, _ := fakeLockDB.RevokeLeaseArgsForCall(0)
+			Expect(trace.SpanContextFromContext(revokeCtx)).To(Equal(spans[0].SpanContext))
+		})
+	})
+})
 
-	Expect(fakeRequestMetrics.IncrementRequestsInFlightCounterCallCount()).To(Equal(1))
-	_, incInFlight := fakeRequestMetrics.IncrementRequestsInFlightCounterArgsForCall(0)
-	Expect(incInFlight).To(BeEquivalentTo(1))
+type fakeWatchStream struct {
+	grpc.ServerStream
 
-	Expect(fakeRequestMetrics.DecrementRequestsInFlightCounterCallCount()).To(Equal(1))
-	_, decInFlight := fakeRequestMetrics.IncrementRequestsInFlightCounterArgsForCall(0)
-	Expect(decInFlight).To(BeEquivalentTo(1))
+	ctx     context.Context
+	sendErr error
 
-	Expect(fakeRequestMetrics.UpdateLatencyCallCount()).To(Equal(1))
-	_, latency := fakeRequestMetrics.UpdateLatencyArgsForCall(0)
-	Expect(latency).To(BeNumerically(">=", 0))
+	lock sync.Mutex
+	sent []*models.WatchEvent
 }
 
-func metricsUseCorrectCallTags(fakeRequestMetrics *helpersfakes.FakeRequestMetrics, expectedRequestType string) {
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
 
-	if fakeRequestMetrics.IncrementRequestsStartedCounterCallCount() > 0 {
-		requestType, _ := fakeRequestMetrics.IncrementRequestsStartedCounterArgsForCall(0)
-		Expect(requestType).To(Equal(expectedRequestType))
-	}
+func (s *fakeWatchStream) SendHeader(metadata.MD) error {
+	return nil
+}
 
-	if fakeRequestMetrics.IncrementRequestsSucceededCounterCallCount() > 0 {
-		requestType, _ := fakeRequestMetrics.IncrementRequestsSucceededCounterArgsForCall(0)
-		Expect(requestType).To(Equal(expectedRequestType))
+func (s *fakeWatchStream) Send(event *models.WatchEvent) error {
+	if s.sendErr != nil {
+		return s.sendErr
 	}
 
-	if fakeRequestMetrics.IncrementRequestsFailedCounterCallCount() > 0 {
-		requestType, _ := fakeRequestMetrics.IncrementRequestsFailedCounterArgsForCall(0)
-		Expect(requestType).To(Equal(expectedRequestType))
-	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sent = append(s.sent, event)
+	return nil
+}
 
-	if fakeRequestMetrics.IncrementRequestsInFlightCounterCallCount() > 0 {
-		requestType, _ := fakeRequestMetrics.IncrementRequestsInFlightCounterArgsForCall(0)
-		Expect(requestType).To(Equal(expectedRequestType))
-	}
+func (s *fakeWatchStream) Sent() []*models.WatchEvent {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]*models.WatchEvent{}, s.sent...)
+}
 
-	if fakeRequestMetrics.DecrementRequestsInFlightCounterCallCount() > 0 {
-		requestType, _ := fakeRequestMetrics.DecrementRequestsInFlightCounterArgsForCall(0)
-		Expect(requestType).To(Equal(expectedRequestType))
-	}
+type fakeSessionStream struct {
+	grpc.ServerStream
+
+	ctx      context.Context
+	requests chan *models.SessionRequest
+
+	lock sync.Mutex
+	sent []*models.SessionResponse
+}
 
-	if fakeRequestMetrics.UpdateLatencyCallCount() > 0 {
-		requestType, _ := fakeRequestMetrics.UpdateLatencyArgsForCall(0)
-		Expect(requestType).To(Equal(expectedRequestType))
+func (s *fakeSessionStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeSessionStream) Recv() (*models.SessionRequest, error) {
+	select {
+	case req, ok := <-s.requests:
+		if !ok {
+			return nil, io.EOF
+		}
+		return req, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
 	}
 }
+
+func (s *fakeSessionStream) Send(resp *models.SessionResponse) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func (s *fakeSessionStream) Sent() []*models.SessionResponse {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]*models.SessionResponse{}, s.sent...)
+}
+
+func contextWithClientCommonName(commonName string) context.Context {
+	return contextWithClientCert(&x509.Certificate{Subject: pkix.Name{CommonName: commonName}})
+}
+
+func contextWithClientCert(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{cert}},
+			},
+		},
+	})
+}
@@ -3,10 +3,24 @@ package handlers_test
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"testing"
 )
 
+var spanExporter *tracetest.InMemoryExporter
+
+var _ = BeforeSuite(func() {
+	spanExporter = tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter)))
+})
+
+var _ = BeforeEach(func() {
+	spanExporter.Reset()
+})
+
 func TestHandlers(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Handlers Suite")
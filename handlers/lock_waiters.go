@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/watch"
+)
+
+// lockWaiters keeps a FIFO queue of blocked Lock requests per key. The front
+// of a queue is woken whenever the watch hub reports that the key was released
+// or expired, which covers releases and expirations handled by this instance
+// as well as the ones the burglar picks up from other instances.
+type lockWaiters struct {
+	lock   *sync.Mutex
+	hub    watch.Hub
+	queues map[string]*waitQueue
+}
+
+type waitQueue struct {
+	waiters []chan struct{}
+	sub     watch.Subscription
+}
+
+func newLockWaiters(hub watch.Hub) *lockWaiters {
+	return &lockWaiters{
+		lock:   &sync.Mutex{},
+		hub:    hub,
+		queues: make(map[string]*waitQueue),
+	}
+}
+
+// enqueue adds a waiter to the back of the queue for key. The returned channel
+// receives a value whenever the waiter is at the front of the queue and the
+// key may have become available.
+func (w *lockWaiters) enqueue(logger lager.Logger, key string) (chan struct{}, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	q, ok := w.queues[key]
+	if !ok {
+		// keys are qualified with their namespace
+		namespace, _ := models.SplitNamespacedKey(key)
+		sub, err := w.hub.Subscribe(watch.Filter{Namespace: namespace, Key: key}, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		q = &waitQueue{sub: sub}
+		w.queues[key] = q
+		go w.wakeOnRelease(logger.Session("wake-on-release", lager.Data{"key": key}), key, q)
+	}
+
+	waiter := make(chan struct{}, 1)
+	q.waiters = append(q.waiters, waiter)
+	return waiter, nil
+}
+
+// dequeue removes the waiter from the queue for key. A waiter leaving the
+// front of the queue without acquiring the lock may have swallowed a wake up,
+// so it is handed over to the next waiter.
+func (w *lockWaiters) dequeue(key string, waiter chan struct{}, acquired bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	q, ok := w.queues[key]
+	if !ok {
+		return
+	}
+
+	wasFront := false
+	for i, ch := range q.waiters {
+		if ch == waiter {
+			wasFront = i == 0
+			q.waiters = append(q.waiters[:i], q.waiters[i+1:]...)
+			break
+		}
+	}
+
+	if len(q.waiters) == 0 {
+		delete(w.queues, key)
+		q.sub.Close()
+		return
+	}
+
+	if wasFront && !acquired {
+		wake(q.waiters[0])
+	}
+}
+
+// atFront returns whether the waiter is at the front of the queue for key.
+// A waiter whose queue went away with its subscription is left to try on its
+// own.
+func (w *lockWaiters) atFront(key string, waiter chan struct{}) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	q, ok := w.queues[key]
+	if !ok {
+		return true
+	}
+	for i, ch := range q.waiters {
+		if ch == waiter {
+			return i == 0
+		}
+	}
+	return true
+}
+
+// notify wakes the front of the queue for key, for releases that the watch hub
+// does not report.
+func (w *lockWaiters) notify(key string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	q, ok := w.queues[key]
+	if ok && len(q.waiters) > 0 {
+		wake(q.waiters[0])
+	}
+}
+
+func (w *lockWaiters) wakeOnRelease(logger lager.Logger, key string, q *waitQueue) {
+	for event := range q.sub.Events() {
+		if event.Type != models.DELETED && event.Type != models.EXPIRED {
+			continue
+		}
+
+		w.lock.Lock()
+		if len(q.waiters) > 0 {
+			wake(q.waiters[0])
+		}
+		w.lock.Unlock()
+	}
+
+	err := q.sub.Err()
+	if err == nil {
+		return
+	}
+	logger.Error("subscription-closed", err)
+
+	// the remaining waiters will only be woken by their timeouts, make sure
+	// later requests start over with a new subscription
+	w.lock.Lock()
+	if w.queues[key] == q {
+		delete(w.queues, key)
+	}
+	w.lock.Unlock()
+}
+
+func wake(waiter chan struct{}) {
+	select {
+	case waiter <- struct{}{}:
+	default:
+	}
+}
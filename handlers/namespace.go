@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"code.cloudfoundry.org/locket/models"
+)
+
+// Resources are stored and streamed internally with their key qualified by
+// their namespace. Requests are scoped to their namespace before reaching the
+// database or the watch hub, and the resources returned to clients are split
+// back into namespace and key.
+
+func (h *locketHandler) validateNamespace(namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	if _, found := h.namespaces.Lookup(namespace); !found {
+		return models.ErrInvalidNamespace
+	}
+	return nil
+}
+
+// scopeKey qualifies the key with its namespace.
+func (h *locketHandler) scopeKey(namespace, key string) (string, error) {
+	err := h.validateNamespace(namespace)
+	if err != nil {
+		return "", err
+	}
+
+	return models.NamespacedKey(namespace, key), nil
+}
+
+// scopeResource returns a copy of the resource with its key qualified by its
+// namespace.
+func (h *locketHandler) scopeResource(resource *models.Resource) (*models.Resource, error) {
+	if resource == nil {
+		return nil, nil
+	}
+
+	key, err := h.scopeKey(resource.Namespace, resource.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := *resource
+	scoped.Key = key
+	scoped.Namespace = ""
+	return &scoped, nil
+}
+
+func (h *locketHandler) scopeLockRequest(req *models.LockRequest) (*models.LockRequest, error) {
+	resource, err := h.scopeResource(req.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := *req
+	scoped.Resource = resource
+	return &scoped, nil
+}
+
+// unscopeResource returns a copy of the resource with its namespace split
+// from its key.
+func unscopeResource(resource *models.Resource) *models.Resource {
+	if resource == nil {
+		return nil
+	}
+
+	unscoped := *resource
+	unscoped.Namespace, unscoped.Key = models.SplitNamespacedKey(resource.Key)
+	return &unscoped
+}
+
+// unscopeEvent returns a copy of the event with its resource unscoped. Events
+// are shared by all the subscribers of the hub, so they are not modified.
+func unscopeEvent(event *models.WatchEvent) *models.WatchEvent {
+	unscoped := *event
+	unscoped.Resource = unscopeResource(event.Resource)
+	return &unscoped
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/locket/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "code.cloudfoundry.org/locket/handlers"
+
+func startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, "locketHandler."+method)
+}
+
+// sessionStream and watchStream are the streams of the handler methods, with
+// the span of the method in their context.
+type sessionStream struct {
+	models.Locket_SessionServer
+	ctx context.Context
+}
+
+func (s *sessionStream) Context() context.Context {
+	return s.ctx
+}
+
+type watchStream struct {
+	models.Locket_WatchServer
+	ctx context.Context
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/tracing"
 	"code.cloudfoundry.org/tlsconfig"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
@@ -65,6 +66,13 @@ func newClientInternal(logger lager.Logger, config ClientLocketConfig, skipCertV
 			Time:    time.Duration(config.LocketClientKeepAliveTime) * time.Second,
 			Timeout: time.Duration(config.LocketClientKeepAliveTimeout) * time.Second,
 		}),
+		// requests carry the trace context of the caller, and are recorded
+		// as spans of the tracer provider of the application, if it has one
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(tracing.StreamClientInterceptor()),
+		// the server decides how large payloads can be, accept the largest
+		// responses it can be configured to send
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(models.MaxMessageSize(models.MaxPayloadLength))),
 	)
 	if err != nil {
 		return nil, err
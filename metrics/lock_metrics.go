@@ -3,10 +3,12 @@ package metrics
 import (
 	"context"
 	"os"
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/clock"
 	loggingclient "code.cloudfoundry.org/diego-logging-client"
+	loggregator "code.cloudfoundry.org/go-loggregator/v9"
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/locket/db"
 	"code.cloudfoundry.org/locket/models"
@@ -24,15 +26,40 @@ type lockMetricsNotifier struct {
 	metricsInterval time.Duration
 	lockDB          db.LockDB
 	metronClient    loggingclient.IngressClient
+	resourceTypes   []models.ResourceType
+	namespaces      []string
 }
 
-func NewLockMetricsNotifier(logger lager.Logger, ticker clock.Clock, metronClient loggingclient.IngressClient, metricsInterval time.Duration, lockDB db.LockDB) ifrit.Runner {
+func NewLockMetricsNotifier(logger lager.Logger, ticker clock.Clock, metronClient loggingclient.IngressClient, metricsInterval time.Duration, lockDB db.LockDB, resourceTypes models.ResourceTypes, namespaces models.Namespaces) ifrit.Runner {
+	// only the user-defined types with an active metric are counted, in a
+	// stable order
+	var countedTypes []models.ResourceType
+	for _, resourceType := range resourceTypes {
+		if resourceType.ActiveMetric != "" {
+			countedTypes = append(countedTypes, resourceType)
+		}
+	}
+	sort.Slice(countedTypes, func(i, j int) bool {
+		return countedTypes[i].Name < countedTypes[j].Name
+	})
+
+	// the default namespace is counted first, without a namespace tag
+	countedNamespaces := []string{""}
+	var names []string
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	countedNamespaces = append(countedNamespaces, names...)
+
 	return &lockMetricsNotifier{
 		logger:          logger,
 		ticker:          ticker,
 		metricsInterval: metricsInterval,
 		lockDB:          lockDB,
 		metronClient:    metronClient,
+		resourceTypes:   countedTypes,
+		namespaces:      countedNamespaces,
 	}
 }
 
@@ -50,27 +77,54 @@ func (notifier *lockMetricsNotifier) Run(signals <-chan os.Signal, ready chan<-
 		case <-tick.C():
 			logger.Debug("emitting-metrics")
 
-			locks, err := notifier.lockDB.Count(context.Background(), logger, models.LockType)
-			if err != nil {
-				logger.Error("failed-to-retrieve-lock-count", err)
-			} else {
-				err = notifier.metronClient.SendMetric(activeLocksMetric, locks)
-				if err != nil {
-					logger.Error("failed-sending-lock-count", err)
-				}
-			}
-
-			presences, err := notifier.lockDB.Count(context.Background(), logger, models.PresenceType)
-			if err != nil {
-				logger.Error("failed-to-retrieve-presence-count", err)
-			} else {
-				err = notifier.metronClient.SendMetric(activePresencesMetric, presences)
-				if err != nil {
-					logger.Error("failed-sending-presences-count", err)
-				}
+			for _, namespace := range notifier.namespaces {
+				notifier.emitCounts(logger, namespace)
 			}
 
 			logger.Debug("emitted-metrics")
 		}
 	}
 }
+
+// emitCounts emits the number of resources held in the namespace. The
+// metrics of a registered namespace are tagged with its name.
+func (notifier *lockMetricsNotifier) emitCounts(logger lager.Logger, namespace string) {
+	var opts []loggregator.EmitGaugeOption
+	if namespace != "" {
+		logger = logger.WithData(lager.Data{"namespace": namespace})
+		opts = append(opts, loggregator.WithEnvelopeTag("namespace", namespace))
+	}
+
+	locks, err := notifier.lockDB.Count(context.Background(), logger, namespace, models.LockType)
+	if err != nil {
+		logger.Error("failed-to-retrieve-lock-count", err)
+	} else {
+		err = notifier.metronClient.SendMetric(activeLocksMetric, locks, opts...)
+		if err != nil {
+			logger.Error("failed-sending-lock-count", err)
+		}
+	}
+
+	presences, err := notifier.lockDB.Count(context.Background(), logger, namespace, models.PresenceType)
+	if err != nil {
+		logger.Error("failed-to-retrieve-presence-count", err)
+	} else {
+		err = notifier.metronClient.SendMetric(activePresencesMetric, presences, opts...)
+		if err != nil {
+			logger.Error("failed-sending-presences-count", err)
+		}
+	}
+
+	for _, resourceType := range notifier.resourceTypes {
+		count, err := notifier.lockDB.Count(context.Background(), logger, namespace, resourceType.Name)
+		if err != nil {
+			logger.Error("failed-to-retrieve-resource-type-count", err, lager.Data{"type": resourceType.Name})
+			continue
+		}
+
+		err = notifier.metronClient.SendMetric(resourceType.ActiveMetric, count, opts...)
+		if err != nil {
+			logger.Error("failed-sending-resource-type-count", err, lager.Data{"type": resourceType.Name})
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/clock/fakeclock"
 	mfakes "code.cloudfoundry.org/diego-logging-client/testhelpers"
 	loggregator "code.cloudfoundry.org/go-loggregator/v9"
+	"code.cloudfoundry.org/go-loggregator/v9/rpc/loggregator_v2"
 	"code.cloudfoundry.org/lager/v3"
 	"code.cloudfoundry.org/lager/v3/lagertest"
 	"code.cloudfoundry.org/locket/db/dbfakes"
@@ -21,8 +22,9 @@ import (
 
 var _ = Describe("LockMetrics", func() {
 	type FakeGauge struct {
-		Name  string
-		Value int
+		Name      string
+		Value     int
+		Namespace string
 	}
 
 	var (
@@ -33,6 +35,8 @@ var _ = Describe("LockMetrics", func() {
 		fakeClock        *fakeclock.FakeClock
 		metricsInterval  time.Duration
 		lockDB           *dbfakes.FakeLockDB
+		resourceTypes    models.ResourceTypes
+		namespaces       models.Namespaces
 		metricsChan      chan FakeGauge
 	)
 
@@ -43,8 +47,10 @@ var _ = Describe("LockMetrics", func() {
 		metricsInterval = 10 * time.Second
 
 		lockDB = &dbfakes.FakeLockDB{}
+		resourceTypes = nil
+		namespaces = nil
 
-		lockDB.CountStub = func(c context.Context, l lager.Logger, lockType string) (int, error) {
+		lockDB.CountStub = func(c context.Context, l lager.Logger, namespace, lockType string) (int, error) {
 			switch {
 			case lockType == models.LockType:
 				return 3, nil
@@ -61,7 +67,12 @@ var _ = Describe("LockMetrics", func() {
 		fakeMetronClient.SendMetricStub = func(name string, value int, opts ...loggregator.EmitGaugeOption) error {
 			defer GinkgoRecover()
 
-			Eventually(ch).Should(BeSent(FakeGauge{name, value}))
+			envelope := &loggregator_v2.Envelope{Tags: map[string]string{}}
+			for _, opt := range opts {
+				opt(envelope)
+			}
+
+			Eventually(ch).Should(BeSent(FakeGauge{name, value, envelope.Tags["namespace"]}))
 			return nil
 		}
 	})
@@ -73,6 +84,8 @@ var _ = Describe("LockMetrics", func() {
 			fakeMetronClient,
 			metricsInterval,
 			lockDB,
+			resourceTypes,
+			namespaces,
 		)
 		process = ifrit.Background(runner)
 		Eventually(process.Ready()).Should(BeClosed())
@@ -89,15 +102,71 @@ var _ = Describe("LockMetrics", func() {
 		})
 
 		It("emits a metric for the number of active locks", func() {
-			Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActiveLocks", 3})))
+			Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActiveLocks", 3, ""})))
 			fakeClock.Increment(metricsInterval)
-			Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActiveLocks", 3})))
+			Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActiveLocks", 3, ""})))
 		})
 
 		It("emits a metric for the number of active presences", func() {
-			Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActivePresences", 2})))
+			Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActivePresences", 2, ""})))
 			fakeClock.Increment(metricsInterval)
-			Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActivePresences", 2})))
+			Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActivePresences", 2, ""})))
+		})
+
+		Context("when there are user-defined resource types", func() {
+			BeforeEach(func() {
+				var err error
+				resourceTypes, err = models.NewResourceTypes([]models.ResourceType{
+					{Name: "maintenance-window", ActiveMetric: "ActiveMaintenanceWindows"},
+					{Name: "migration"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				lockDB.CountStub = func(c context.Context, l lager.Logger, namespace, lockType string) (int, error) {
+					if lockType == "maintenance-window" {
+						return 4, nil
+					}
+					return 1, nil
+				}
+			})
+
+			It("emits a metric for the number of active resources of the types with an active metric", func() {
+				Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActiveMaintenanceWindows", 4, ""})))
+				Eventually(lockDB.CountCallCount).Should(Equal(3))
+				_, _, _, lockType := lockDB.CountArgsForCall(2)
+				Expect(lockType).To(Equal("maintenance-window"))
+			})
+		})
+
+		Context("when there are namespaces", func() {
+			BeforeEach(func() {
+				var err error
+				namespaces, err = models.NewNamespaces([]models.Namespace{{Name: "routing"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				lockDB.CountStub = func(c context.Context, l lager.Logger, namespace, lockType string) (int, error) {
+					if namespace == "routing" {
+						return 7, nil
+					}
+					return 1, nil
+				}
+			})
+
+			It("counts the resources of the default namespace separately", func() {
+				Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActiveLocks", 1, ""})))
+				Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActivePresences", 1, ""})))
+			})
+
+			It("emits the metrics of each namespace tagged with its name", func() {
+				Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActiveLocks", 7, "routing"})))
+				Eventually(metricsChan).Should(Receive(Equal(FakeGauge{"ActivePresences", 7, "routing"})))
+
+				Expect(lockDB.CountCallCount()).To(Equal(4))
+				_, _, namespace, _ := lockDB.CountArgsForCall(0)
+				Expect(namespace).To(BeEmpty())
+				_, _, namespace, _ = lockDB.CountArgsForCall(2)
+				Expect(namespace).To(Equal("routing"))
+			})
 		})
 	})
 
@@ -1,7 +1,12 @@
 package models
 
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
 func GetResource(resource *Resource) *Resource {
-	r := &Resource{Key: resource.Key, Owner: resource.Owner, Value: resource.Value}
+	r := &Resource{Key: resource.Key, Owner: resource.Owner, Value: resource.Value, LeaseId: resource.LeaseId, Labels: resource.Labels, Payload: resource.Payload, Namespace: resource.Namespace}
 	if resource.TypeCode == UNKNOWN {
 		r.TypeCode = GetTypeCode(resource.Type)
 		r.Type = resource.Type
@@ -18,6 +23,8 @@ func GetTypeCode(lockType string) TypeCode {
 		return LOCK
 	case PresenceType:
 		return PRESENCE
+	case SemaphoreType:
+		return SEMAPHORE
 	default:
 		return UNKNOWN
 	}
@@ -29,7 +36,65 @@ func GetType(resource *Resource) string {
 		return LockType
 	case PRESENCE:
 		return PresenceType
+	case SEMAPHORE:
+		return SemaphoreType
 	default:
 		return resource.Type
 	}
 }
+
+// IsShared reports whether the request is for a shared holding of the key,
+// either explicitly or because the resource is a semaphore.
+func (r *LockRequest) IsShared() bool {
+	return r.GetMode() == SHARED || isSemaphore(r.GetResource())
+}
+
+// IsShared reports whether the request releases a shared holding of the key,
+// either explicitly or because the resource is a semaphore.
+func (r *ReleaseRequest) IsShared() bool {
+	return r.GetMode() == SHARED || isSemaphore(r.GetResource())
+}
+
+func isSemaphore(resource *Resource) bool {
+	return resource.GetTypeCode() == SEMAPHORE || GetTypeCode(resource.GetType()) == SEMAPHORE
+}
+
+func NewLockBatchErrorResult(err error) *LockBatchResult {
+	st := status.Convert(err)
+	return &LockBatchResult{ErrorCode: int32(st.Code()), Error: st.Message()}
+}
+
+// Err returns the error of a single request in a LockBatch as a grpc status
+// error, or nil if the request succeeded.
+func (r *LockBatchResult) Err() error {
+	if codes.Code(r.ErrorCode) == codes.OK {
+		return nil
+	}
+	return status.Error(codes.Code(r.ErrorCode), r.Error)
+}
+
+func NewSessionErrorResponse(err error) *SessionResponse {
+	st := status.Convert(err)
+	return &SessionResponse{ErrorCode: int32(st.Code()), Error: st.Message()}
+}
+
+// Err returns the error of a single request in a Session as a grpc status
+// error, or nil if the request succeeded.
+func (r *SessionResponse) Err() error {
+	if codes.Code(r.ErrorCode) == codes.OK {
+		return nil
+	}
+	return status.Error(codes.Code(r.ErrorCode), r.Error)
+}
+
+// MatchesLabels reports whether the resource has every label of the selector
+// with the same value. An empty selector matches every resource.
+func (r *Resource) MatchesLabels(selector map[string]string) bool {
+	for key, value := range selector {
+		labelValue, ok := r.GetLabels()[key]
+		if !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
@@ -1,9 +1,13 @@
 package models_test
 
 import (
+	"errors"
+
 	"code.cloudfoundry.org/locket/models"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var _ = Describe("helpers", func() {
@@ -11,6 +15,7 @@ var _ = Describe("helpers", func() {
 		It("matches the correct type to the type code", func() {
 			Expect(models.GetType(&models.Resource{TypeCode: models.PRESENCE})).To(Equal("presence"))
 			Expect(models.GetType(&models.Resource{TypeCode: models.LOCK})).To(Equal("lock"))
+			Expect(models.GetType(&models.Resource{TypeCode: models.SEMAPHORE})).To(Equal("semaphore"))
 			Expect(models.GetType(&models.Resource{Type: "sandwich", TypeCode: models.UNKNOWN})).To(Equal("sandwich"))
 		})
 	})
@@ -19,6 +24,7 @@ var _ = Describe("helpers", func() {
 		It("matches the correct type code to the type", func() {
 			Expect(models.GetTypeCode("presence")).To(Equal(models.PRESENCE))
 			Expect(models.GetTypeCode("lock")).To(Equal(models.LOCK))
+			Expect(models.GetTypeCode("semaphore")).To(Equal(models.SEMAPHORE))
 			Expect(models.GetTypeCode("sandwich")).To(Equal(models.UNKNOWN))
 		})
 	})
@@ -71,5 +77,77 @@ var _ = Describe("helpers", func() {
 			Expect(models.GetResource(resource2).TypeCode).To(Equal(models.PRESENCE))
 			Expect(models.GetResource(resource2).Type).To(Equal("presence"))
 		})
+
+		It("keeps the labels and the payload", func() {
+			resource := &models.Resource{Key: "sandwich", Labels: map[string]string{"zone": "z1"}, Payload: []byte{0, 1, 2}}
+			Expect(models.GetResource(resource).Labels).To(Equal(map[string]string{"zone": "z1"}))
+			Expect(models.GetResource(resource).Payload).To(Equal([]byte{0, 1, 2}))
+		})
+	})
+
+	Describe("MatchesLabels", func() {
+		var resource *models.Resource
+
+		BeforeEach(func() {
+			resource = &models.Resource{Labels: map[string]string{"zone": "z1", "stack": "cflinuxfs4"}}
+		})
+
+		It("matches when every label of the selector has the same value", func() {
+			Expect(resource.MatchesLabels(map[string]string{"zone": "z1"})).To(BeTrue())
+			Expect(resource.MatchesLabels(map[string]string{"zone": "z1", "stack": "cflinuxfs4"})).To(BeTrue())
+		})
+
+		It("does not match a different value or a missing label", func() {
+			Expect(resource.MatchesLabels(map[string]string{"zone": "z2"})).To(BeFalse())
+			Expect(resource.MatchesLabels(map[string]string{"zone": "z1", "version": "1"})).To(BeFalse())
+			Expect(resource.MatchesLabels(map[string]string{"version": ""})).To(BeFalse())
+		})
+
+		It("matches every resource with an empty selector", func() {
+			Expect(resource.MatchesLabels(nil)).To(BeTrue())
+			Expect((&models.Resource{}).MatchesLabels(nil)).To(BeTrue())
+		})
+	})
+
+	Describe("IsShared", func() {
+		It("is true for shared requests and semaphores", func() {
+			Expect((&models.LockRequest{Resource: &models.Resource{TypeCode: models.LOCK}}).IsShared()).To(BeFalse())
+			Expect((&models.LockRequest{Resource: &models.Resource{TypeCode: models.LOCK}, Mode: models.SHARED}).IsShared()).To(BeTrue())
+			Expect((&models.LockRequest{Resource: &models.Resource{TypeCode: models.SEMAPHORE}}).IsShared()).To(BeTrue())
+			Expect((&models.ReleaseRequest{Resource: &models.Resource{Type: "semaphore"}}).IsShared()).To(BeTrue())
+			Expect((&models.ReleaseRequest{}).IsShared()).To(BeFalse())
+		})
+	})
+
+	Describe("LockBatchResult", func() {
+		It("round trips the error of a failed request", func() {
+			result := models.NewLockBatchErrorResult(models.ErrLockCollision)
+			Expect(status.Code(result.Err())).To(Equal(codes.AlreadyExists))
+			Expect(result.Err()).To(MatchError(models.ErrLockCollision.Error()))
+		})
+
+		It("converts errors that are not grpc errors", func() {
+			result := models.NewLockBatchErrorResult(errors.New("boom"))
+			Expect(status.Code(result.Err())).To(Equal(codes.Unknown))
+			Expect(status.Convert(result.Err()).Message()).To(Equal("boom"))
+		})
+
+		It("returns no error for a successful request", func() {
+			result := &models.LockBatchResult{Response: &models.LockResponse{}}
+			Expect(result.Err()).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("SessionResponse", func() {
+		It("round trips the error of a failed request", func() {
+			resp := models.NewSessionErrorResponse(models.ErrLockCollision)
+			Expect(status.Code(resp.Err())).To(Equal(codes.AlreadyExists))
+			Expect(resp.Err()).To(MatchError(models.ErrLockCollision.Error()))
+		})
+
+		It("returns no error for a successful request", func() {
+			resp := &models.SessionResponse{Lock: &models.LockResponse{}}
+			Expect(resp.Err()).NotTo(HaveOccurred())
+		})
 	})
 })
@@ -4,9 +4,11 @@
 package models
 
 import (
+	bytes "bytes"
 	context "context"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -32,33 +34,95 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type TypeCode int32
 
 const (
-	UNKNOWN  TypeCode = 0
-	LOCK     TypeCode = 1
-	PRESENCE TypeCode = 2
+	UNKNOWN   TypeCode = 0
+	LOCK      TypeCode = 1
+	PRESENCE  TypeCode = 2
+	SEMAPHORE TypeCode = 3
 )
 
 var TypeCode_name = map[int32]string{
 	0: "UNKNOWN",
 	1: "LOCK",
 	2: "PRESENCE",
+	3: "SEMAPHORE",
 }
 
 var TypeCode_value = map[string]int32{
-	"UNKNOWN":  0,
-	"LOCK":     1,
-	"PRESENCE": 2,
+	"UNKNOWN":   0,
+	"LOCK":      1,
+	"PRESENCE":  2,
+	"SEMAPHORE": 3,
 }
 
 func (TypeCode) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_5f2d92f834ce8fa9, []int{0}
 }
 
+type EventType int32
+
+const (
+	UNKNOWN_EVENT EventType = 0
+	CREATED       EventType = 1
+	UPDATED       EventType = 2
+	DELETED       EventType = 3
+	EXPIRED       EventType = 4
+)
+
+var EventType_name = map[int32]string{
+	0: "UNKNOWN_EVENT",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "DELETED",
+	4: "EXPIRED",
+}
+
+var EventType_value = map[string]int32{
+	"UNKNOWN_EVENT": 0,
+	"CREATED":       1,
+	"UPDATED":       2,
+	"DELETED":       3,
+	"EXPIRED":       4,
+}
+
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{1}
+}
+
+type LockMode int32
+
+const (
+	EXCLUSIVE LockMode = 0
+	SHARED    LockMode = 1
+)
+
+var LockMode_name = map[int32]string{
+	0: "EXCLUSIVE",
+	1: "SHARED",
+}
+
+var LockMode_value = map[string]int32{
+	"EXCLUSIVE": 0,
+	"SHARED":    1,
+}
+
+func (LockMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{2}
+}
+
 type Resource struct {
-	Key      string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Owner    string   `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
-	Value    string   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
-	Type     string   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // Deprecated: Do not use.
-	TypeCode TypeCode `protobuf:"varint,5,opt,name=type_code,json=typeCode,proto3,enum=models.TypeCode" json:"type_code,omitempty"`
+	Key          string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Owner        string            `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Value        string            `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Type         string            `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // Deprecated: Do not use.
+	TypeCode     TypeCode          `protobuf:"varint,5,opt,name=type_code,json=typeCode,proto3,enum=models.TypeCode" json:"type_code,omitempty"`
+	AcquiredAt   int64             `protobuf:"varint,6,opt,name=acquired_at,json=acquiredAt,proto3" json:"acquired_at,omitempty"`
+	RenewedAt    int64             `protobuf:"varint,7,opt,name=renewed_at,json=renewedAt,proto3" json:"renewed_at,omitempty"`
+	TtlInSeconds int64             `protobuf:"varint,8,opt,name=ttl_in_seconds,json=ttlInSeconds,proto3" json:"ttl_in_seconds,omitempty"`
+	ExpiresAt    int64             `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LeaseId      string            `protobuf:"bytes,10,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	Labels       map[string]string `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Payload      []byte            `protobuf:"bytes,12,opt,name=payload,proto3" json:"payload,omitempty"`
+	Namespace    string            `protobuf:"bytes,13,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
 
 func (m *Resource) Reset()      { *m = Resource{} }
@@ -129,9 +193,68 @@ func (m *Resource) GetTypeCode() TypeCode {
 	return UNKNOWN
 }
 
+func (m *Resource) GetAcquiredAt() int64 {
+	if m != nil {
+		return m.AcquiredAt
+	}
+	return 0
+}
+
+func (m *Resource) GetRenewedAt() int64 {
+	if m != nil {
+		return m.RenewedAt
+	}
+	return 0
+}
+
+func (m *Resource) GetTtlInSeconds() int64 {
+	if m != nil {
+		return m.TtlInSeconds
+	}
+	return 0
+}
+
+func (m *Resource) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *Resource) GetLeaseId() string {
+	if m != nil {
+		return m.LeaseId
+	}
+	return ""
+}
+
+func (m *Resource) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Resource) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *Resource) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
 type LockRequest struct {
-	Resource     *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
-	TtlInSeconds int64     `protobuf:"varint,2,opt,name=ttl_in_seconds,json=ttlInSeconds,proto3" json:"ttl_in_seconds,omitempty"`
+	Resource             *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	TtlInSeconds         int64     `protobuf:"varint,2,opt,name=ttl_in_seconds,json=ttlInSeconds,proto3" json:"ttl_in_seconds,omitempty"`
+	WaitTimeoutInSeconds int64     `protobuf:"varint,3,opt,name=wait_timeout_in_seconds,json=waitTimeoutInSeconds,proto3" json:"wait_timeout_in_seconds,omitempty"`
+	Mode                 LockMode  `protobuf:"varint,4,opt,name=mode,proto3,enum=models.LockMode" json:"mode,omitempty"`
+	SemaphoreLimit       int32     `protobuf:"varint,5,opt,name=semaphore_limit,json=semaphoreLimit,proto3" json:"semaphore_limit,omitempty"`
 }
 
 func (m *LockRequest) Reset()      { *m = LockRequest{} }
@@ -180,7 +303,30 @@ func (m *LockRequest) GetTtlInSeconds() int64 {
 	return 0
 }
 
+func (m *LockRequest) GetWaitTimeoutInSeconds() int64 {
+	if m != nil {
+		return m.WaitTimeoutInSeconds
+	}
+	return 0
+}
+
+func (m *LockRequest) GetMode() LockMode {
+	if m != nil {
+		return m.Mode
+	}
+	return EXCLUSIVE
+}
+
+func (m *LockRequest) GetSemaphoreLimit() int32 {
+	if m != nil {
+		return m.SemaphoreLimit
+	}
+	return 0
+}
+
 type LockResponse struct {
+	FencingToken  int64 `protobuf:"varint,1,opt,name=fencing_token,json=fencingToken,proto3" json:"fencing_token,omitempty"`
+	ModifiedIndex int64 `protobuf:"varint,2,opt,name=modified_index,json=modifiedIndex,proto3" json:"modified_index,omitempty"`
 }
 
 func (m *LockResponse) Reset()      { *m = LockResponse{} }
@@ -215,21 +361,35 @@ func (m *LockResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_LockResponse proto.InternalMessageInfo
 
-type ReleaseRequest struct {
-	Resource *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+func (m *LockResponse) GetFencingToken() int64 {
+	if m != nil {
+		return m.FencingToken
+	}
+	return 0
 }
 
-func (m *ReleaseRequest) Reset()      { *m = ReleaseRequest{} }
-func (*ReleaseRequest) ProtoMessage() {}
-func (*ReleaseRequest) Descriptor() ([]byte, []int) {
+func (m *LockResponse) GetModifiedIndex() int64 {
+	if m != nil {
+		return m.ModifiedIndex
+	}
+	return 0
+}
+
+type LockBatchRequest struct {
+	Requests []*LockRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (m *LockBatchRequest) Reset()      { *m = LockBatchRequest{} }
+func (*LockBatchRequest) ProtoMessage() {}
+func (*LockBatchRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5f2d92f834ce8fa9, []int{3}
 }
-func (m *ReleaseRequest) XXX_Unmarshal(b []byte) error {
+func (m *LockBatchRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LockBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ReleaseRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LockBatchRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -239,39 +399,42 @@ func (m *ReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *ReleaseRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReleaseRequest.Merge(m, src)
+func (m *LockBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockBatchRequest.Merge(m, src)
 }
-func (m *ReleaseRequest) XXX_Size() int {
+func (m *LockBatchRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ReleaseRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReleaseRequest.DiscardUnknown(m)
+func (m *LockBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockBatchRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReleaseRequest proto.InternalMessageInfo
+var xxx_messageInfo_LockBatchRequest proto.InternalMessageInfo
 
-func (m *ReleaseRequest) GetResource() *Resource {
+func (m *LockBatchRequest) GetRequests() []*LockRequest {
 	if m != nil {
-		return m.Resource
+		return m.Requests
 	}
 	return nil
 }
 
-type ReleaseResponse struct {
+type LockBatchResult struct {
+	Response  *LockResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	ErrorCode int32         `protobuf:"varint,2,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Error     string        `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (m *ReleaseResponse) Reset()      { *m = ReleaseResponse{} }
-func (*ReleaseResponse) ProtoMessage() {}
-func (*ReleaseResponse) Descriptor() ([]byte, []int) {
+func (m *LockBatchResult) Reset()      { *m = LockBatchResult{} }
+func (*LockBatchResult) ProtoMessage() {}
+func (*LockBatchResult) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5f2d92f834ce8fa9, []int{4}
 }
-func (m *ReleaseResponse) XXX_Unmarshal(b []byte) error {
+func (m *LockBatchResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LockBatchResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ReleaseResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LockBatchResult.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -281,33 +444,54 @@ func (m *ReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *ReleaseResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReleaseResponse.Merge(m, src)
+func (m *LockBatchResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockBatchResult.Merge(m, src)
 }
-func (m *ReleaseResponse) XXX_Size() int {
+func (m *LockBatchResult) XXX_Size() int {
 	return m.Size()
 }
-func (m *ReleaseResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReleaseResponse.DiscardUnknown(m)
+func (m *LockBatchResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockBatchResult.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReleaseResponse proto.InternalMessageInfo
+var xxx_messageInfo_LockBatchResult proto.InternalMessageInfo
 
-type FetchRequest struct {
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+func (m *LockBatchResult) GetResponse() *LockResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
 }
 
-func (m *FetchRequest) Reset()      { *m = FetchRequest{} }
-func (*FetchRequest) ProtoMessage() {}
-func (*FetchRequest) Descriptor() ([]byte, []int) {
+func (m *LockBatchResult) GetErrorCode() int32 {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return 0
+}
+
+func (m *LockBatchResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type LockBatchResponse struct {
+	Results []*LockBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (m *LockBatchResponse) Reset()      { *m = LockBatchResponse{} }
+func (*LockBatchResponse) ProtoMessage() {}
+func (*LockBatchResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5f2d92f834ce8fa9, []int{5}
 }
-func (m *FetchRequest) XXX_Unmarshal(b []byte) error {
+func (m *LockBatchResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FetchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LockBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FetchRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LockBatchResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -317,40 +501,40 @@ func (m *FetchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *FetchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FetchRequest.Merge(m, src)
+func (m *LockBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockBatchResponse.Merge(m, src)
 }
-func (m *FetchRequest) XXX_Size() int {
+func (m *LockBatchResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *FetchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_FetchRequest.DiscardUnknown(m)
+func (m *LockBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockBatchResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FetchRequest proto.InternalMessageInfo
+var xxx_messageInfo_LockBatchResponse proto.InternalMessageInfo
 
-func (m *FetchRequest) GetKey() string {
+func (m *LockBatchResponse) GetResults() []*LockBatchResult {
 	if m != nil {
-		return m.Key
+		return m.Results
 	}
-	return ""
+	return nil
 }
 
-type FetchResponse struct {
-	Resource *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+type LockMultiRequest struct {
+	Requests []*LockRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
 }
 
-func (m *FetchResponse) Reset()      { *m = FetchResponse{} }
-func (*FetchResponse) ProtoMessage() {}
-func (*FetchResponse) Descriptor() ([]byte, []int) {
+func (m *LockMultiRequest) Reset()      { *m = LockMultiRequest{} }
+func (*LockMultiRequest) ProtoMessage() {}
+func (*LockMultiRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5f2d92f834ce8fa9, []int{6}
 }
-func (m *FetchResponse) XXX_Unmarshal(b []byte) error {
+func (m *LockMultiRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FetchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LockMultiRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FetchResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LockMultiRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -360,41 +544,40 @@ func (m *FetchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *FetchResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FetchResponse.Merge(m, src)
+func (m *LockMultiRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockMultiRequest.Merge(m, src)
 }
-func (m *FetchResponse) XXX_Size() int {
+func (m *LockMultiRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *FetchResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_FetchResponse.DiscardUnknown(m)
+func (m *LockMultiRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockMultiRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FetchResponse proto.InternalMessageInfo
+var xxx_messageInfo_LockMultiRequest proto.InternalMessageInfo
 
-func (m *FetchResponse) GetResource() *Resource {
+func (m *LockMultiRequest) GetRequests() []*LockRequest {
 	if m != nil {
-		return m.Resource
+		return m.Requests
 	}
 	return nil
 }
 
-type FetchAllRequest struct {
-	Type     string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // Deprecated: Do not use.
-	TypeCode TypeCode `protobuf:"varint,2,opt,name=type_code,json=typeCode,proto3,enum=models.TypeCode" json:"type_code,omitempty"`
+type LockMultiResponse struct {
+	Responses []*LockResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
 }
 
-func (m *FetchAllRequest) Reset()      { *m = FetchAllRequest{} }
-func (*FetchAllRequest) ProtoMessage() {}
-func (*FetchAllRequest) Descriptor() ([]byte, []int) {
+func (m *LockMultiResponse) Reset()      { *m = LockMultiResponse{} }
+func (*LockMultiResponse) ProtoMessage() {}
+func (*LockMultiResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5f2d92f834ce8fa9, []int{7}
 }
-func (m *FetchAllRequest) XXX_Unmarshal(b []byte) error {
+func (m *LockMultiResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FetchAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LockMultiResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FetchAllRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LockMultiResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -404,48 +587,41 @@ func (m *FetchAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *FetchAllRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FetchAllRequest.Merge(m, src)
+func (m *LockMultiResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockMultiResponse.Merge(m, src)
 }
-func (m *FetchAllRequest) XXX_Size() int {
+func (m *LockMultiResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *FetchAllRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_FetchAllRequest.DiscardUnknown(m)
+func (m *LockMultiResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockMultiResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FetchAllRequest proto.InternalMessageInfo
-
-// Deprecated: Do not use.
-func (m *FetchAllRequest) GetType() string {
-	if m != nil {
-		return m.Type
-	}
-	return ""
-}
+var xxx_messageInfo_LockMultiResponse proto.InternalMessageInfo
 
-func (m *FetchAllRequest) GetTypeCode() TypeCode {
+func (m *LockMultiResponse) GetResponses() []*LockResponse {
 	if m != nil {
-		return m.TypeCode
+		return m.Responses
 	}
-	return UNKNOWN
+	return nil
 }
 
-type FetchAllResponse struct {
-	Resources []*Resource `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+type ReleaseRequest struct {
+	Resource *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Mode     LockMode  `protobuf:"varint,2,opt,name=mode,proto3,enum=models.LockMode" json:"mode,omitempty"`
 }
 
-func (m *FetchAllResponse) Reset()      { *m = FetchAllResponse{} }
-func (*FetchAllResponse) ProtoMessage() {}
-func (*FetchAllResponse) Descriptor() ([]byte, []int) {
+func (m *ReleaseRequest) Reset()      { *m = ReleaseRequest{} }
+func (*ReleaseRequest) ProtoMessage() {}
+func (*ReleaseRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5f2d92f834ce8fa9, []int{8}
 }
-func (m *FetchAllResponse) XXX_Unmarshal(b []byte) error {
+func (m *ReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *FetchAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_FetchAllResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ReleaseRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -455,1185 +631,8524 @@ func (m *FetchAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *FetchAllResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FetchAllResponse.Merge(m, src)
+func (m *ReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseRequest.Merge(m, src)
 }
-func (m *FetchAllResponse) XXX_Size() int {
+func (m *ReleaseRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *FetchAllResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_FetchAllResponse.DiscardUnknown(m)
+func (m *ReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FetchAllResponse proto.InternalMessageInfo
+var xxx_messageInfo_ReleaseRequest proto.InternalMessageInfo
 
-func (m *FetchAllResponse) GetResources() []*Resource {
+func (m *ReleaseRequest) GetResource() *Resource {
 	if m != nil {
-		return m.Resources
+		return m.Resource
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("models.TypeCode", TypeCode_name, TypeCode_value)
-	proto.RegisterType((*Resource)(nil), "models.Resource")
-	proto.RegisterType((*LockRequest)(nil), "models.LockRequest")
-	proto.RegisterType((*LockResponse)(nil), "models.LockResponse")
-	proto.RegisterType((*ReleaseRequest)(nil), "models.ReleaseRequest")
-	proto.RegisterType((*ReleaseResponse)(nil), "models.ReleaseResponse")
-	proto.RegisterType((*FetchRequest)(nil), "models.FetchRequest")
-	proto.RegisterType((*FetchResponse)(nil), "models.FetchResponse")
-	proto.RegisterType((*FetchAllRequest)(nil), "models.FetchAllRequest")
-	proto.RegisterType((*FetchAllResponse)(nil), "models.FetchAllResponse")
+func (m *ReleaseRequest) GetMode() LockMode {
+	if m != nil {
+		return m.Mode
+	}
+	return EXCLUSIVE
 }
 
-func init() { proto.RegisterFile("locket.proto", fileDescriptor_5f2d92f834ce8fa9) }
-
-var fileDescriptor_5f2d92f834ce8fa9 = []byte{
-	// 490 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xf6, 0xe6, 0xaf, 0xce, 0xc4, 0xa4, 0x66, 0x29, 0xad, 0x95, 0xc3, 0x2a, 0xb2, 0x38, 0x54,
-	0x08, 0x82, 0xd4, 0x22, 0x4e, 0xfc, 0x88, 0x44, 0x41, 0x42, 0x8d, 0x52, 0xe4, 0x82, 0xe0, 0x16,
-	0x05, 0x67, 0x24, 0x50, 0x8c, 0x37, 0x64, 0x37, 0xa0, 0xdc, 0x78, 0x03, 0x78, 0x0c, 0x1e, 0x85,
-	0x63, 0x8e, 0x3d, 0x12, 0xe7, 0xc2, 0xb1, 0x07, 0x1e, 0x00, 0x79, 0xed, 0xb5, 0x13, 0x82, 0x40,
-	0xf4, 0xe4, 0x99, 0xcf, 0xf3, 0xcd, 0xf7, 0x79, 0x66, 0x0c, 0x56, 0xc0, 0xfd, 0x31, 0xca, 0xd6,
-	0x64, 0xca, 0x25, 0xa7, 0x95, 0x77, 0x7c, 0x84, 0x81, 0x70, 0x3f, 0x13, 0x30, 0x3d, 0x14, 0x7c,
-	0x36, 0xf5, 0x91, 0xda, 0x50, 0x1c, 0xe3, 0xdc, 0x21, 0x4d, 0x72, 0x58, 0xf5, 0xe2, 0x90, 0xee,
-	0x41, 0x99, 0x7f, 0x0c, 0x71, 0xea, 0x14, 0x14, 0x96, 0x24, 0x31, 0xfa, 0x61, 0x18, 0xcc, 0xd0,
-	0x29, 0x26, 0xa8, 0x4a, 0xe8, 0x3e, 0x94, 0xe4, 0x7c, 0x82, 0x4e, 0x29, 0x06, 0xdb, 0x05, 0x87,
-	0x78, 0x2a, 0xa7, 0xb7, 0xa1, 0x1a, 0x3f, 0x07, 0x3e, 0x1f, 0xa1, 0x53, 0x6e, 0x92, 0xc3, 0xfa,
-	0x91, 0xdd, 0x4a, 0xe4, 0x5b, 0xcf, 0xe7, 0x13, 0xec, 0xf0, 0x11, 0x7a, 0xa6, 0x4c, 0x23, 0x77,
-	0x08, 0xb5, 0x1e, 0xf7, 0xc7, 0x1e, 0xbe, 0x9f, 0xa1, 0x90, 0xf4, 0x16, 0x98, 0xd3, 0xd4, 0x9f,
-	0x32, 0x56, 0xcb, 0xc9, 0xda, 0xb7, 0x97, 0x55, 0xd0, 0x1b, 0x50, 0x97, 0x32, 0x18, 0xbc, 0x0d,
-	0x07, 0x02, 0x7d, 0x1e, 0x8e, 0x84, 0x32, 0x5e, 0xf4, 0x2c, 0x29, 0x83, 0xa7, 0xe1, 0x59, 0x82,
-	0xb9, 0x75, 0xb0, 0x12, 0x09, 0x31, 0xe1, 0xa1, 0x40, 0xf7, 0x21, 0xd4, 0x3d, 0x0c, 0x70, 0x28,
-	0xf0, 0x52, 0xaa, 0xee, 0x55, 0xd8, 0xcd, 0xf8, 0x69, 0xcb, 0x26, 0x58, 0x4f, 0x50, 0xfa, 0x6f,
-	0x74, 0xc3, 0xad, 0xd1, 0xba, 0x0f, 0xe0, 0x4a, 0x5a, 0x91, 0x50, 0xfe, 0x53, 0xf3, 0x15, 0xec,
-	0x2a, 0xfa, 0xe3, 0x20, 0xd0, 0x1a, 0x7a, 0x01, 0xe4, 0x6f, 0x0b, 0x28, 0xfc, 0x73, 0x01, 0x6d,
-	0xb0, 0xf3, 0xce, 0xa9, 0xb7, 0x16, 0x54, 0xb5, 0xb2, 0x70, 0x48, 0xb3, 0xf8, 0x47, 0x73, 0x79,
-	0xc9, 0xcd, 0x3b, 0x60, 0xea, 0xce, 0xb4, 0x06, 0x3b, 0x2f, 0xfa, 0x27, 0xfd, 0xd3, 0x97, 0x7d,
-	0xdb, 0xa0, 0x26, 0x94, 0x7a, 0xa7, 0x9d, 0x13, 0x9b, 0x50, 0x0b, 0xcc, 0x67, 0x5e, 0xf7, 0xac,
-	0xdb, 0xef, 0x74, 0xed, 0xc2, 0xd1, 0x4f, 0x02, 0x95, 0x9e, 0x3a, 0x50, 0x7a, 0x0c, 0xa5, 0x38,
-	0xa2, 0xd7, 0xb4, 0xc0, 0xda, 0x39, 0x34, 0xf6, 0x36, 0xc1, 0x74, 0xda, 0x06, 0xbd, 0x07, 0x65,
-	0x65, 0x9a, 0x66, 0x05, 0xeb, 0xe3, 0x6f, 0x5c, 0xff, 0x0d, 0xcd, 0x78, 0xf7, 0x61, 0x27, 0x5d,
-	0x1d, 0xdd, 0xcf, 0x3f, 0x68, 0xfd, 0x16, 0x1a, 0x07, 0x5b, 0x78, 0xc6, 0x7e, 0x04, 0xa6, 0x1e,
-	0x15, 0x3d, 0xd8, 0x90, 0xc8, 0xd7, 0xd2, 0x70, 0xb6, 0x5f, 0xe8, 0x06, 0xed, 0xbb, 0x8b, 0x25,
-	0x33, 0xce, 0x97, 0xcc, 0xb8, 0x58, 0x32, 0xf2, 0x29, 0x62, 0xe4, 0x6b, 0xc4, 0xc8, 0xb7, 0x88,
-	0x91, 0x45, 0xc4, 0xc8, 0xf7, 0x88, 0x91, 0x1f, 0x11, 0x33, 0x2e, 0x22, 0x46, 0xbe, 0xac, 0x98,
-	0xb1, 0x58, 0x31, 0xe3, 0x7c, 0xc5, 0x8c, 0xd7, 0x15, 0xf5, 0x0f, 0x1f, 0xff, 0x0a, 0x00, 0x00,
-	0xff, 0xff, 0x40, 0xe5, 0x0d, 0xd7, 0xd3, 0x03, 0x00, 0x00,
+type ReleaseResponse struct {
 }
 
-func (x TypeCode) String() string {
-	s, ok := TypeCode_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+func (m *ReleaseResponse) Reset()      { *m = ReleaseResponse{} }
+func (*ReleaseResponse) ProtoMessage() {}
+func (*ReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{9}
 }
-func (this *Resource) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*Resource)
-	if !ok {
-		that2, ok := that.(Resource)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *ReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReleaseResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Key != that1.Key {
-		return false
-	}
-	if this.Owner != that1.Owner {
-		return false
-	}
-	if this.Value != that1.Value {
-		return false
-	}
-	if this.Type != that1.Type {
-		return false
+}
+func (m *ReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseResponse.Merge(m, src)
+}
+func (m *ReleaseResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseResponse proto.InternalMessageInfo
+
+type FetchRequest struct {
+	Key       string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+func (m *FetchRequest) Reset()      { *m = FetchRequest{} }
+func (*FetchRequest) ProtoMessage() {}
+func (*FetchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{10}
+}
+func (m *FetchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FetchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FetchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if this.TypeCode != that1.TypeCode {
-		return false
+}
+func (m *FetchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchRequest.Merge(m, src)
+}
+func (m *FetchRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FetchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchRequest proto.InternalMessageInfo
+
+func (m *FetchRequest) GetKey() string {
+	if m != nil {
+		return m.Key
 	}
-	return true
+	return ""
 }
-func (this *LockRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *FetchRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
 	}
+	return ""
+}
 
-	that1, ok := that.(*LockRequest)
-	if !ok {
-		that2, ok := that.(LockRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type FetchResponse struct {
+	Resource      *Resource   `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	FencingToken  int64       `protobuf:"varint,2,opt,name=fencing_token,json=fencingToken,proto3" json:"fencing_token,omitempty"`
+	ModifiedIndex int64       `protobuf:"varint,3,opt,name=modified_index,json=modifiedIndex,proto3" json:"modified_index,omitempty"`
+	SharedHolders []*Resource `protobuf:"bytes,4,rep,name=shared_holders,json=sharedHolders,proto3" json:"shared_holders,omitempty"`
+}
+
+func (m *FetchResponse) Reset()      { *m = FetchResponse{} }
+func (*FetchResponse) ProtoMessage() {}
+func (*FetchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{11}
+}
+func (m *FetchResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FetchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FetchResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *FetchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchResponse.Merge(m, src)
+}
+func (m *FetchResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *FetchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchResponse proto.InternalMessageInfo
+
+func (m *FetchResponse) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
 	}
-	if !this.Resource.Equal(that1.Resource) {
-		return false
+	return nil
+}
+
+func (m *FetchResponse) GetFencingToken() int64 {
+	if m != nil {
+		return m.FencingToken
 	}
-	if this.TtlInSeconds != that1.TtlInSeconds {
-		return false
+	return 0
+}
+
+func (m *FetchResponse) GetModifiedIndex() int64 {
+	if m != nil {
+		return m.ModifiedIndex
 	}
-	return true
+	return 0
 }
-func (this *LockResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *FetchResponse) GetSharedHolders() []*Resource {
+	if m != nil {
+		return m.SharedHolders
 	}
+	return nil
+}
 
-	that1, ok := that.(*LockResponse)
-	if !ok {
-		that2, ok := that.(LockResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type FetchAllRequest struct {
+	Type              string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // Deprecated: Do not use.
+	TypeCode          TypeCode          `protobuf:"varint,2,opt,name=type_code,json=typeCode,proto3,enum=models.TypeCode" json:"type_code,omitempty"`
+	KeyPrefix         string            `protobuf:"bytes,3,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	PageSize          int32             `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	ContinuationToken string            `protobuf:"bytes,5,opt,name=continuation_token,json=continuationToken,proto3" json:"continuation_token,omitempty"`
+	LabelSelector     map[string]string `protobuf:"bytes,6,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Namespace         string            `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+func (m *FetchAllRequest) Reset()      { *m = FetchAllRequest{} }
+func (*FetchAllRequest) ProtoMessage() {}
+func (*FetchAllRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{12}
+}
+func (m *FetchAllRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FetchAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FetchAllRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *FetchAllRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchAllRequest.Merge(m, src)
+}
+func (m *FetchAllRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FetchAllRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchAllRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchAllRequest proto.InternalMessageInfo
+
+// Deprecated: Do not use.
+func (m *FetchAllRequest) GetType() string {
+	if m != nil {
+		return m.Type
 	}
-	return true
+	return ""
 }
-func (this *ReleaseRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *FetchAllRequest) GetTypeCode() TypeCode {
+	if m != nil {
+		return m.TypeCode
 	}
+	return UNKNOWN
+}
 
-	that1, ok := that.(*ReleaseRequest)
-	if !ok {
-		that2, ok := that.(ReleaseRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *FetchAllRequest) GetKeyPrefix() string {
+	if m != nil {
+		return m.KeyPrefix
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return ""
+}
+
+func (m *FetchAllRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
 	}
-	if !this.Resource.Equal(that1.Resource) {
-		return false
+	return 0
+}
+
+func (m *FetchAllRequest) GetContinuationToken() string {
+	if m != nil {
+		return m.ContinuationToken
 	}
-	return true
+	return ""
 }
-func (this *ReleaseResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *FetchAllRequest) GetLabelSelector() map[string]string {
+	if m != nil {
+		return m.LabelSelector
 	}
+	return nil
+}
 
-	that1, ok := that.(*ReleaseResponse)
-	if !ok {
-		that2, ok := that.(ReleaseResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *FetchAllRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	return true
+	return ""
 }
-func (this *FetchRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*FetchRequest)
-	if !ok {
-		that2, ok := that.(FetchRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type FetchAllResponse struct {
+	Resources         []*Resource `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	ContinuationToken string      `protobuf:"bytes,2,opt,name=continuation_token,json=continuationToken,proto3" json:"continuation_token,omitempty"`
+}
+
+func (m *FetchAllResponse) Reset()      { *m = FetchAllResponse{} }
+func (*FetchAllResponse) ProtoMessage() {}
+func (*FetchAllResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{13}
+}
+func (m *FetchAllResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FetchAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FetchAllResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Key != that1.Key {
-		return false
+}
+func (m *FetchAllResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchAllResponse.Merge(m, src)
+}
+func (m *FetchAllResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *FetchAllResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchAllResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchAllResponse proto.InternalMessageInfo
+
+func (m *FetchAllResponse) GetResources() []*Resource {
+	if m != nil {
+		return m.Resources
 	}
-	return true
+	return nil
 }
-func (this *FetchResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *FetchAllResponse) GetContinuationToken() string {
+	if m != nil {
+		return m.ContinuationToken
 	}
+	return ""
+}
 
-	that1, ok := that.(*FetchResponse)
-	if !ok {
-		that2, ok := that.(FetchResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type UpdateRequest struct {
+	Resource              *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	ExpectedModifiedIndex int64     `protobuf:"varint,2,opt,name=expected_modified_index,json=expectedModifiedIndex,proto3" json:"expected_modified_index,omitempty"`
+}
+
+func (m *UpdateRequest) Reset()      { *m = UpdateRequest{} }
+func (*UpdateRequest) ProtoMessage() {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{14}
+}
+func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Resource.Equal(that1.Resource) {
-		return false
+}
+func (m *UpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRequest.Merge(m, src)
+}
+func (m *UpdateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+
+func (m *UpdateRequest) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
 	}
-	return true
+	return nil
 }
-func (this *FetchAllRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *UpdateRequest) GetExpectedModifiedIndex() int64 {
+	if m != nil {
+		return m.ExpectedModifiedIndex
 	}
+	return 0
+}
 
-	that1, ok := that.(*FetchAllRequest)
-	if !ok {
-		that2, ok := that.(FetchAllRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type UpdateResponse struct {
+	ModifiedIndex int64 `protobuf:"varint,1,opt,name=modified_index,json=modifiedIndex,proto3" json:"modified_index,omitempty"`
+}
+
+func (m *UpdateResponse) Reset()      { *m = UpdateResponse{} }
+func (*UpdateResponse) ProtoMessage() {}
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{15}
+}
+func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Type != that1.Type {
-		return false
-	}
-	if this.TypeCode != that1.TypeCode {
-		return false
-	}
-	return true
 }
-func (this *FetchAllResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *UpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateResponse.Merge(m, src)
+}
+func (m *UpdateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateResponse proto.InternalMessageInfo
+
+func (m *UpdateResponse) GetModifiedIndex() int64 {
+	if m != nil {
+		return m.ModifiedIndex
 	}
+	return 0
+}
 
-	that1, ok := that.(*FetchAllResponse)
-	if !ok {
-		that2, ok := that.(FetchAllResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type TransferRequest struct {
+	Resource *Resource `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	NewOwner string    `protobuf:"bytes,2,opt,name=new_owner,json=newOwner,proto3" json:"new_owner,omitempty"`
+}
+
+func (m *TransferRequest) Reset()      { *m = TransferRequest{} }
+func (*TransferRequest) ProtoMessage() {}
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{16}
+}
+func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransferRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *TransferRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferRequest.Merge(m, src)
+}
+func (m *TransferRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransferRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferRequest proto.InternalMessageInfo
+
+func (m *TransferRequest) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
 	}
-	if len(this.Resources) != len(that1.Resources) {
-		return false
+	return nil
+}
+
+func (m *TransferRequest) GetNewOwner() string {
+	if m != nil {
+		return m.NewOwner
 	}
-	for i := range this.Resources {
-		if !this.Resources[i].Equal(that1.Resources[i]) {
-			return false
+	return ""
+}
+
+type TransferResponse struct {
+	FencingToken  int64 `protobuf:"varint,1,opt,name=fencing_token,json=fencingToken,proto3" json:"fencing_token,omitempty"`
+	ModifiedIndex int64 `protobuf:"varint,2,opt,name=modified_index,json=modifiedIndex,proto3" json:"modified_index,omitempty"`
+}
+
+func (m *TransferResponse) Reset()      { *m = TransferResponse{} }
+func (*TransferResponse) ProtoMessage() {}
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{17}
+}
+func (m *TransferResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransferResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *Resource) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 9)
-	s = append(s, "&models.Resource{")
-	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
-	s = append(s, "Owner: "+fmt.Sprintf("%#v", this.Owner)+",\n")
-	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	s = append(s, "TypeCode: "+fmt.Sprintf("%#v", this.TypeCode)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+func (m *TransferResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferResponse.Merge(m, src)
 }
-func (this *LockRequest) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&models.LockRequest{")
-	if this.Resource != nil {
-		s = append(s, "Resource: "+fmt.Sprintf("%#v", this.Resource)+",\n")
+func (m *TransferResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransferResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferResponse proto.InternalMessageInfo
+
+func (m *TransferResponse) GetFencingToken() int64 {
+	if m != nil {
+		return m.FencingToken
 	}
-	s = append(s, "TtlInSeconds: "+fmt.Sprintf("%#v", this.TtlInSeconds)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return 0
 }
-func (this *LockResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *TransferResponse) GetModifiedIndex() int64 {
+	if m != nil {
+		return m.ModifiedIndex
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&models.LockResponse{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return 0
 }
-func (this *ReleaseRequest) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&models.ReleaseRequest{")
-	if this.Resource != nil {
-		s = append(s, "Resource: "+fmt.Sprintf("%#v", this.Resource)+",\n")
-	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+type ForceReleaseRequest struct {
+	Key       string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Reason    string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
-func (this *ReleaseResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 4)
-	s = append(s, "&models.ReleaseResponse{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+func (m *ForceReleaseRequest) Reset()      { *m = ForceReleaseRequest{} }
+func (*ForceReleaseRequest) ProtoMessage() {}
+func (*ForceReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{18}
 }
-func (this *FetchRequest) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&models.FetchRequest{")
-	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+func (m *ForceReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (this *FetchResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&models.FetchResponse{")
-	if this.Resource != nil {
-		s = append(s, "Resource: "+fmt.Sprintf("%#v", this.Resource)+",\n")
+func (m *ForceReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ForceReleaseRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
 }
-func (this *FetchAllRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (m *ForceReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceReleaseRequest.Merge(m, src)
+}
+func (m *ForceReleaseRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ForceReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForceReleaseRequest proto.InternalMessageInfo
+
+func (m *ForceReleaseRequest) GetKey() string {
+	if m != nil {
+		return m.Key
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&models.FetchAllRequest{")
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	s = append(s, "TypeCode: "+fmt.Sprintf("%#v", this.TypeCode)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func (this *FetchAllResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *ForceReleaseRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&models.FetchAllResponse{")
-	if this.Resources != nil {
-		s = append(s, "Resources: "+fmt.Sprintf("%#v", this.Resources)+",\n")
+	return ""
+}
+
+func (m *ForceReleaseRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func valueToGoStringLocket(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+type ForceReleaseResponse struct {
+	Released []*Resource `protobuf:"bytes,1,rep,name=released,proto3" json:"released,omitempty"`
+}
+
+func (m *ForceReleaseResponse) Reset()      { *m = ForceReleaseResponse{} }
+func (*ForceReleaseResponse) ProtoMessage() {}
+func (*ForceReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{19}
+}
+func (m *ForceReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ForceReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ForceReleaseResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *ForceReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForceReleaseResponse.Merge(m, src)
+}
+func (m *ForceReleaseResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ForceReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForceReleaseResponse.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_ForceReleaseResponse proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *ForceReleaseResponse) GetReleased() []*Resource {
+	if m != nil {
+		return m.Released
+	}
+	return nil
+}
 
-// LocketClient is the client API for Locket service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type LocketClient interface {
-	Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error)
-	Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error)
-	Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error)
-	FetchAll(ctx context.Context, in *FetchAllRequest, opts ...grpc.CallOption) (*FetchAllResponse, error)
+type WatchRequest struct {
+	Key           string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	KeyPrefix     string   `protobuf:"bytes,2,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	TypeCode      TypeCode `protobuf:"varint,3,opt,name=type_code,json=typeCode,proto3,enum=models.TypeCode" json:"type_code,omitempty"`
+	StartRevision int64    `protobuf:"varint,4,opt,name=start_revision,json=startRevision,proto3" json:"start_revision,omitempty"`
+	Type          string   `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Namespace     string   `protobuf:"bytes,6,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
 
-type locketClient struct {
-	cc *grpc.ClientConn
+func (m *WatchRequest) Reset()      { *m = WatchRequest{} }
+func (*WatchRequest) ProtoMessage() {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{20}
+}
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
 }
 
-func NewLocketClient(cc *grpc.ClientConn) LocketClient {
-	return &locketClient{cc}
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
 }
 
-func (c *locketClient) Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error) {
-	out := new(LockResponse)
-	err := c.cc.Invoke(ctx, "/models.Locket/Lock", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *WatchRequest) GetKeyPrefix() string {
+	if m != nil {
+		return m.KeyPrefix
 	}
-	return out, nil
+	return ""
 }
 
-func (c *locketClient) Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error) {
-	out := new(FetchResponse)
-	err := c.cc.Invoke(ctx, "/models.Locket/Fetch", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *WatchRequest) GetTypeCode() TypeCode {
+	if m != nil {
+		return m.TypeCode
 	}
-	return out, nil
+	return UNKNOWN
 }
 
-func (c *locketClient) Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error) {
-	out := new(ReleaseResponse)
-	err := c.cc.Invoke(ctx, "/models.Locket/Release", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *WatchRequest) GetStartRevision() int64 {
+	if m != nil {
+		return m.StartRevision
 	}
-	return out, nil
+	return 0
 }
 
-func (c *locketClient) FetchAll(ctx context.Context, in *FetchAllRequest, opts ...grpc.CallOption) (*FetchAllResponse, error) {
-	out := new(FetchAllResponse)
-	err := c.cc.Invoke(ctx, "/models.Locket/FetchAll", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *WatchRequest) GetType() string {
+	if m != nil {
+		return m.Type
 	}
-	return out, nil
+	return ""
 }
 
-// LocketServer is the server API for Locket service.
-type LocketServer interface {
-	Lock(context.Context, *LockRequest) (*LockResponse, error)
-	Fetch(context.Context, *FetchRequest) (*FetchResponse, error)
-	Release(context.Context, *ReleaseRequest) (*ReleaseResponse, error)
-	FetchAll(context.Context, *FetchAllRequest) (*FetchAllResponse, error)
+func (m *WatchRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
 }
 
-// UnimplementedLocketServer can be embedded to have forward compatible implementations.
-type UnimplementedLocketServer struct {
+type WatchEvent struct {
+	Type     EventType `protobuf:"varint,1,opt,name=type,proto3,enum=models.EventType" json:"type,omitempty"`
+	Resource *Resource `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	Revision int64     `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
 }
 
-func (*UnimplementedLocketServer) Lock(ctx context.Context, req *LockRequest) (*LockResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
+func (m *WatchEvent) Reset()      { *m = WatchEvent{} }
+func (*WatchEvent) ProtoMessage() {}
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{21}
 }
-func (*UnimplementedLocketServer) Fetch(ctx context.Context, req *FetchRequest) (*FetchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Fetch not implemented")
+func (m *WatchEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedLocketServer) Release(ctx context.Context, req *ReleaseRequest) (*ReleaseResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+func (m *WatchEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedLocketServer) FetchAll(ctx context.Context, req *FetchAllRequest) (*FetchAllResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FetchAll not implemented")
+func (m *WatchEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEvent.Merge(m, src)
 }
-
-func RegisterLocketServer(s *grpc.Server, srv LocketServer) {
-	s.RegisterService(&_Locket_serviceDesc, srv)
+func (m *WatchEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEvent.DiscardUnknown(m)
 }
 
-func _Locket_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LockRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LocketServer).Lock(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/models.Locket/Lock",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LocketServer).Lock(ctx, req.(*LockRequest))
+var xxx_messageInfo_WatchEvent proto.InternalMessageInfo
+
+func (m *WatchEvent) GetType() EventType {
+	if m != nil {
+		return m.Type
 	}
-	return interceptor(ctx, in, info, handler)
+	return UNKNOWN_EVENT
 }
 
-func _Locket_Fetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FetchRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LocketServer).Fetch(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/models.Locket/Fetch",
+func (m *WatchEvent) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LocketServer).Fetch(ctx, req.(*FetchRequest))
+	return nil
+}
+
+func (m *WatchEvent) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Locket_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReleaseRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LocketServer).Release(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/models.Locket/Release",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LocketServer).Release(ctx, req.(*ReleaseRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type GrantLeaseRequest struct {
+	TtlInSeconds int64 `protobuf:"varint,1,opt,name=ttl_in_seconds,json=ttlInSeconds,proto3" json:"ttl_in_seconds,omitempty"`
 }
 
-func _Locket_FetchAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FetchAllRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LocketServer).FetchAll(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/models.Locket/FetchAll",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LocketServer).FetchAll(ctx, req.(*FetchAllRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *GrantLeaseRequest) Reset()      { *m = GrantLeaseRequest{} }
+func (*GrantLeaseRequest) ProtoMessage() {}
+func (*GrantLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{22}
 }
-
-var _Locket_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "models.Locket",
-	HandlerType: (*LocketServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Lock",
-			Handler:    _Locket_Lock_Handler,
-		},
-		{
-			MethodName: "Fetch",
-			Handler:    _Locket_Fetch_Handler,
-		},
-		{
-			MethodName: "Release",
-			Handler:    _Locket_Release_Handler,
-		},
-		{
-			MethodName: "FetchAll",
-			Handler:    _Locket_FetchAll_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "locket.proto",
+func (m *GrantLeaseRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *Resource) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GrantLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GrantLeaseRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *Resource) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *GrantLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GrantLeaseRequest.Merge(m, src)
 }
-
-func (m *Resource) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.TypeCode != 0 {
-		i = encodeVarintLocket(dAtA, i, uint64(m.TypeCode))
-		i--
-		dAtA[i] = 0x28
-	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintLocket(dAtA, i, uint64(len(m.Type)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintLocket(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Owner) > 0 {
-		i -= len(m.Owner)
-		copy(dAtA[i:], m.Owner)
-		i = encodeVarintLocket(dAtA, i, uint64(len(m.Owner)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintLocket(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *GrantLeaseRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GrantLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GrantLeaseRequest.DiscardUnknown(m)
 }
 
-func (m *LockRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GrantLeaseRequest proto.InternalMessageInfo
+
+func (m *GrantLeaseRequest) GetTtlInSeconds() int64 {
+	if m != nil {
+		return m.TtlInSeconds
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *LockRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type GrantLeaseResponse struct {
+	LeaseId      string `protobuf:"bytes,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	TtlInSeconds int64  `protobuf:"varint,2,opt,name=ttl_in_seconds,json=ttlInSeconds,proto3" json:"ttl_in_seconds,omitempty"`
 }
 
-func (m *LockRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.TtlInSeconds != 0 {
-		i = encodeVarintLocket(dAtA, i, uint64(m.TtlInSeconds))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Resource != nil {
-		{
-			size, err := m.Resource.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintLocket(dAtA, i, uint64(size))
+func (m *GrantLeaseResponse) Reset()      { *m = GrantLeaseResponse{} }
+func (*GrantLeaseResponse) ProtoMessage() {}
+func (*GrantLeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{23}
+}
+func (m *GrantLeaseResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GrantLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GrantLeaseResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *LockResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *GrantLeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GrantLeaseResponse.Merge(m, src)
 }
-
-func (m *LockResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *GrantLeaseResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GrantLeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GrantLeaseResponse.DiscardUnknown(m)
 }
 
-func (m *LockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+var xxx_messageInfo_GrantLeaseResponse proto.InternalMessageInfo
+
+func (m *GrantLeaseResponse) GetLeaseId() string {
+	if m != nil {
+		return m.LeaseId
+	}
+	return ""
 }
 
-func (m *ReleaseRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GrantLeaseResponse) GetTtlInSeconds() int64 {
+	if m != nil {
+		return m.TtlInSeconds
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *ReleaseRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type KeepAliveLeaseRequest struct {
+	LeaseId string `protobuf:"bytes,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
 }
 
-func (m *ReleaseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Resource != nil {
-		{
-			size, err := m.Resource.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintLocket(dAtA, i, uint64(size))
+func (m *KeepAliveLeaseRequest) Reset()      { *m = KeepAliveLeaseRequest{} }
+func (*KeepAliveLeaseRequest) ProtoMessage() {}
+func (*KeepAliveLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{24}
+}
+func (m *KeepAliveLeaseRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeepAliveLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeepAliveLeaseRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *KeepAliveLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeepAliveLeaseRequest.Merge(m, src)
+}
+func (m *KeepAliveLeaseRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeepAliveLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeepAliveLeaseRequest.DiscardUnknown(m)
 }
 
-func (m *ReleaseResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_KeepAliveLeaseRequest proto.InternalMessageInfo
+
+func (m *KeepAliveLeaseRequest) GetLeaseId() string {
+	if m != nil {
+		return m.LeaseId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ReleaseResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type KeepAliveLeaseResponse struct {
+	TtlInSeconds int64 `protobuf:"varint,1,opt,name=ttl_in_seconds,json=ttlInSeconds,proto3" json:"ttl_in_seconds,omitempty"`
 }
 
-func (m *ReleaseResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (m *KeepAliveLeaseResponse) Reset()      { *m = KeepAliveLeaseResponse{} }
+func (*KeepAliveLeaseResponse) ProtoMessage() {}
+func (*KeepAliveLeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{25}
 }
-
-func (m *FetchRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *KeepAliveLeaseResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeepAliveLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeepAliveLeaseResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *KeepAliveLeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeepAliveLeaseResponse.Merge(m, src)
+}
+func (m *KeepAliveLeaseResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeepAliveLeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeepAliveLeaseResponse.DiscardUnknown(m)
 }
 
-func (m *FetchRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_KeepAliveLeaseResponse proto.InternalMessageInfo
+
+func (m *KeepAliveLeaseResponse) GetTtlInSeconds() int64 {
+	if m != nil {
+		return m.TtlInSeconds
+	}
+	return 0
 }
 
-func (m *FetchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintLocket(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0xa
+type RevokeLeaseRequest struct {
+	LeaseId string `protobuf:"bytes,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+}
+
+func (m *RevokeLeaseRequest) Reset()      { *m = RevokeLeaseRequest{} }
+func (*RevokeLeaseRequest) ProtoMessage() {}
+func (*RevokeLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{26}
+}
+func (m *RevokeLeaseRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevokeLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevokeLeaseRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *RevokeLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeLeaseRequest.Merge(m, src)
+}
+func (m *RevokeLeaseRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevokeLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeLeaseRequest.DiscardUnknown(m)
 }
 
-func (m *FetchResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RevokeLeaseRequest proto.InternalMessageInfo
+
+func (m *RevokeLeaseRequest) GetLeaseId() string {
+	if m != nil {
+		return m.LeaseId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *FetchResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RevokeLeaseResponse struct {
 }
 
-func (m *FetchResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Resource != nil {
-		{
-			size, err := m.Resource.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintLocket(dAtA, i, uint64(size))
+func (m *RevokeLeaseResponse) Reset()      { *m = RevokeLeaseResponse{} }
+func (*RevokeLeaseResponse) ProtoMessage() {}
+func (*RevokeLeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{27}
+}
+func (m *RevokeLeaseResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevokeLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevokeLeaseResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *RevokeLeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeLeaseResponse.Merge(m, src)
+}
+func (m *RevokeLeaseResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevokeLeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeLeaseResponse.DiscardUnknown(m)
 }
 
-func (m *FetchAllRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+var xxx_messageInfo_RevokeLeaseResponse proto.InternalMessageInfo
+
+type SessionRequest struct {
+	TtlInSeconds int64           `protobuf:"varint,1,opt,name=ttl_in_seconds,json=ttlInSeconds,proto3" json:"ttl_in_seconds,omitempty"`
+	Lock         *LockRequest    `protobuf:"bytes,2,opt,name=lock,proto3" json:"lock,omitempty"`
+	Release      *ReleaseRequest `protobuf:"bytes,3,opt,name=release,proto3" json:"release,omitempty"`
 }
 
-func (m *FetchAllRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SessionRequest) Reset()      { *m = SessionRequest{} }
+func (*SessionRequest) ProtoMessage() {}
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{28}
+}
+func (m *SessionRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionRequest.Merge(m, src)
+}
+func (m *SessionRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionRequest.DiscardUnknown(m)
 }
 
-func (m *FetchAllRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.TypeCode != 0 {
-		i = encodeVarintLocket(dAtA, i, uint64(m.TypeCode))
-		i--
-		dAtA[i] = 0x10
+var xxx_messageInfo_SessionRequest proto.InternalMessageInfo
+
+func (m *SessionRequest) GetTtlInSeconds() int64 {
+	if m != nil {
+		return m.TtlInSeconds
 	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintLocket(dAtA, i, uint64(len(m.Type)))
-		i--
-		dAtA[i] = 0xa
+	return 0
+}
+
+func (m *SessionRequest) GetLock() *LockRequest {
+	if m != nil {
+		return m.Lock
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *FetchAllResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SessionRequest) GetRelease() *ReleaseRequest {
+	if m != nil {
+		return m.Release
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *FetchAllResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SessionResponse struct {
+	LeaseId      string           `protobuf:"bytes,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	TtlInSeconds int64            `protobuf:"varint,2,opt,name=ttl_in_seconds,json=ttlInSeconds,proto3" json:"ttl_in_seconds,omitempty"`
+	Lock         *LockResponse    `protobuf:"bytes,3,opt,name=lock,proto3" json:"lock,omitempty"`
+	Release      *ReleaseResponse `protobuf:"bytes,4,opt,name=release,proto3" json:"release,omitempty"`
+	ErrorCode    int32            `protobuf:"varint,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Error        string           `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (m *FetchAllResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Resources) > 0 {
-		for iNdEx := len(m.Resources) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Resources[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintLocket(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *SessionResponse) Reset()      { *m = SessionResponse{} }
+func (*SessionResponse) ProtoMessage() {}
+func (*SessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5f2d92f834ce8fa9, []int{29}
+}
+func (m *SessionResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *SessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionResponse.Merge(m, src)
+}
+func (m *SessionResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionResponse.DiscardUnknown(m)
 }
 
-func encodeVarintLocket(dAtA []byte, offset int, v uint64) int {
-	offset -= sovLocket(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_SessionResponse proto.InternalMessageInfo
+
+func (m *SessionResponse) GetLeaseId() string {
+	if m != nil {
+		return m.LeaseId
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return ""
 }
-func (m *Resource) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovLocket(uint64(l))
-	}
-	l = len(m.Owner)
-	if l > 0 {
-		n += 1 + l + sovLocket(uint64(l))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovLocket(uint64(l))
-	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovLocket(uint64(l))
-	}
-	if m.TypeCode != 0 {
-		n += 1 + sovLocket(uint64(m.TypeCode))
+
+func (m *SessionResponse) GetTtlInSeconds() int64 {
+	if m != nil {
+		return m.TtlInSeconds
 	}
-	return n
+	return 0
 }
 
-func (m *LockRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Resource != nil {
-		l = m.Resource.Size()
-		n += 1 + l + sovLocket(uint64(l))
-	}
-	if m.TtlInSeconds != 0 {
-		n += 1 + sovLocket(uint64(m.TtlInSeconds))
+func (m *SessionResponse) GetLock() *LockResponse {
+	if m != nil {
+		return m.Lock
 	}
-	return n
+	return nil
 }
 
-func (m *LockResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SessionResponse) GetRelease() *ReleaseResponse {
+	if m != nil {
+		return m.Release
 	}
-	var l int
-	_ = l
-	return n
+	return nil
 }
 
-func (m *ReleaseRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Resource != nil {
-		l = m.Resource.Size()
-		n += 1 + l + sovLocket(uint64(l))
+func (m *SessionResponse) GetErrorCode() int32 {
+	if m != nil {
+		return m.ErrorCode
 	}
-	return n
+	return 0
 }
 
-func (m *ReleaseResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SessionResponse) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	var l int
-	_ = l
-	return n
+	return ""
 }
 
-func (m *FetchRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovLocket(uint64(l))
-	}
-	return n
+func init() {
+	proto.RegisterEnum("models.TypeCode", TypeCode_name, TypeCode_value)
+	proto.RegisterEnum("models.EventType", EventType_name, EventType_value)
+	proto.RegisterEnum("models.LockMode", LockMode_name, LockMode_value)
+	proto.RegisterType((*Resource)(nil), "models.Resource")
+	proto.RegisterMapType((map[string]string)(nil), "models.Resource.LabelsEntry")
+	proto.RegisterType((*LockRequest)(nil), "models.LockRequest")
+	proto.RegisterType((*LockResponse)(nil), "models.LockResponse")
+	proto.RegisterType((*LockBatchRequest)(nil), "models.LockBatchRequest")
+	proto.RegisterType((*LockBatchResult)(nil), "models.LockBatchResult")
+	proto.RegisterType((*LockBatchResponse)(nil), "models.LockBatchResponse")
+	proto.RegisterType((*LockMultiRequest)(nil), "models.LockMultiRequest")
+	proto.RegisterType((*LockMultiResponse)(nil), "models.LockMultiResponse")
+	proto.RegisterType((*ReleaseRequest)(nil), "models.ReleaseRequest")
+	proto.RegisterType((*ReleaseResponse)(nil), "models.ReleaseResponse")
+	proto.RegisterType((*FetchRequest)(nil), "models.FetchRequest")
+	proto.RegisterType((*FetchResponse)(nil), "models.FetchResponse")
+	proto.RegisterType((*FetchAllRequest)(nil), "models.FetchAllRequest")
+	proto.RegisterMapType((map[string]string)(nil), "models.FetchAllRequest.LabelSelectorEntry")
+	proto.RegisterType((*FetchAllResponse)(nil), "models.FetchAllResponse")
+	proto.RegisterType((*UpdateRequest)(nil), "models.UpdateRequest")
+	proto.RegisterType((*UpdateResponse)(nil), "models.UpdateResponse")
+	proto.RegisterType((*TransferRequest)(nil), "models.TransferRequest")
+	proto.RegisterType((*TransferResponse)(nil), "models.TransferResponse")
+	proto.RegisterType((*ForceReleaseRequest)(nil), "models.ForceReleaseRequest")
+	proto.RegisterType((*ForceReleaseResponse)(nil), "models.ForceReleaseResponse")
+	proto.RegisterType((*WatchRequest)(nil), "models.WatchRequest")
+	proto.RegisterType((*WatchEvent)(nil), "models.WatchEvent")
+	proto.RegisterType((*GrantLeaseRequest)(nil), "models.GrantLeaseRequest")
+	proto.RegisterType((*GrantLeaseResponse)(nil), "models.GrantLeaseResponse")
+	proto.RegisterType((*KeepAliveLeaseRequest)(nil), "models.KeepAliveLeaseRequest")
+	proto.RegisterType((*KeepAliveLeaseResponse)(nil), "models.KeepAliveLeaseResponse")
+	proto.RegisterType((*RevokeLeaseRequest)(nil), "models.RevokeLeaseRequest")
+	proto.RegisterType((*RevokeLeaseResponse)(nil), "models.RevokeLeaseResponse")
+	proto.RegisterType((*SessionRequest)(nil), "models.SessionRequest")
+	proto.RegisterType((*SessionResponse)(nil), "models.SessionResponse")
 }
 
-func (m *FetchResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Resource != nil {
-		l = m.Resource.Size()
-		n += 1 + l + sovLocket(uint64(l))
-	}
-	return n
+func init() { proto.RegisterFile("locket.proto", fileDescriptor_5f2d92f834ce8fa9) }
+
+var fileDescriptor_5f2d92f834ce8fa9 = []byte{
+	// 1658 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x49, 0x6f, 0xdb, 0xc6,
+	0x17, 0x17, 0xb5, 0xeb, 0x69, 0xb1, 0x3c, 0xf1, 0xc2, 0xd0, 0x89, 0xfe, 0x06, 0xff, 0x31, 0x62,
+	0x18, 0xad, 0xe3, 0x28, 0x5b, 0x53, 0x14, 0x69, 0x64, 0x9b, 0x89, 0x0d, 0xcb, 0x4b, 0x28, 0x3b,
+	0x09, 0x8a, 0xb6, 0x04, 0x23, 0x8e, 0x63, 0xc2, 0x34, 0xa9, 0x90, 0x23, 0x2f, 0x01, 0x0a, 0xf4,
+	0x23, 0xb4, 0x5f, 0xa1, 0xa7, 0x7e, 0x8e, 0x5e, 0xda, 0x63, 0x8e, 0x39, 0x36, 0x4e, 0x81, 0xf6,
+	0x98, 0x53, 0xcf, 0xc5, 0x0c, 0x17, 0x91, 0x12, 0x95, 0x38, 0x46, 0x7b, 0xb2, 0xe6, 0xbd, 0xc7,
+	0xdf, 0xbc, 0xf5, 0xc7, 0x47, 0x43, 0xc9, 0xb0, 0xda, 0xfb, 0x98, 0xcc, 0x77, 0x6c, 0x8b, 0x58,
+	0x28, 0x7b, 0x60, 0x69, 0xd8, 0x70, 0xc4, 0xd7, 0x29, 0xc8, 0xcb, 0xd8, 0xb1, 0xba, 0x76, 0x1b,
+	0xa3, 0x2a, 0xa4, 0xf6, 0xf1, 0x09, 0xcf, 0x4d, 0x73, 0xb3, 0x05, 0x99, 0xfe, 0x44, 0x63, 0x90,
+	0xb1, 0x8e, 0x4c, 0x6c, 0xf3, 0x49, 0x26, 0x73, 0x0f, 0x54, 0x7a, 0xa8, 0x1a, 0x5d, 0xcc, 0xa7,
+	0x5c, 0x29, 0x3b, 0xa0, 0x09, 0x48, 0x93, 0x93, 0x0e, 0xe6, 0xd3, 0x54, 0xb8, 0x98, 0xe4, 0x39,
+	0x99, 0x9d, 0xd1, 0xa7, 0x50, 0xa0, 0x7f, 0x95, 0xb6, 0xa5, 0x61, 0x3e, 0x33, 0xcd, 0xcd, 0x56,
+	0xea, 0xd5, 0x79, 0xf7, 0xfa, 0xf9, 0xed, 0x93, 0x0e, 0x5e, 0xb2, 0x34, 0x2c, 0xe7, 0x89, 0xf7,
+	0x0b, 0xfd, 0x0f, 0x8a, 0x6a, 0xfb, 0x45, 0x57, 0xb7, 0xb1, 0xa6, 0xa8, 0x84, 0xcf, 0x4e, 0x73,
+	0xb3, 0x29, 0x19, 0x7c, 0x51, 0x83, 0xa0, 0xcb, 0x00, 0x36, 0x36, 0xf1, 0x91, 0xab, 0xcf, 0x31,
+	0x7d, 0xc1, 0x93, 0x34, 0x08, 0xba, 0x02, 0x15, 0x42, 0x0c, 0x45, 0x37, 0x15, 0x07, 0xb7, 0x2d,
+	0x53, 0x73, 0xf8, 0x3c, 0x33, 0x29, 0x11, 0x62, 0xac, 0x9a, 0x2d, 0x57, 0x46, 0x41, 0xf0, 0x71,
+	0x47, 0xb7, 0xb1, 0x43, 0x41, 0x0a, 0x2e, 0x88, 0x27, 0x69, 0x10, 0x74, 0x11, 0xf2, 0x06, 0x56,
+	0x1d, 0xac, 0xe8, 0x1a, 0x0f, 0x2c, 0xc8, 0x1c, 0x3b, 0xaf, 0x6a, 0xe8, 0x26, 0x64, 0x0d, 0xf5,
+	0x19, 0x36, 0x1c, 0xbe, 0x38, 0x9d, 0x9a, 0x2d, 0xd6, 0x2f, 0xf9, 0xb1, 0xf8, 0x69, 0x9c, 0x6f,
+	0x32, 0xb5, 0x64, 0x12, 0xfb, 0x44, 0xf6, 0x6c, 0x11, 0x0f, 0xb9, 0x8e, 0x7a, 0x62, 0x58, 0xaa,
+	0xc6, 0x97, 0xa6, 0xb9, 0xd9, 0x92, 0xec, 0x1f, 0xd1, 0x25, 0x28, 0x98, 0xea, 0x01, 0x76, 0x3a,
+	0x6a, 0x1b, 0xf3, 0x65, 0x76, 0x57, 0x4f, 0x20, 0xdc, 0x85, 0x62, 0x08, 0x2e, 0xbe, 0x42, 0x6e,
+	0x2d, 0x92, 0xa1, 0x5a, 0x7c, 0x9e, 0xfc, 0x8c, 0x13, 0xff, 0xe4, 0xa0, 0xd8, 0xb4, 0xda, 0xfb,
+	0x32, 0x7e, 0xd1, 0xc5, 0x0e, 0x41, 0x9f, 0x40, 0xde, 0xf6, 0x5c, 0x64, 0x00, 0xc5, 0x7a, 0xb5,
+	0xdf, 0x75, 0x39, 0xb0, 0x88, 0x49, 0x63, 0x32, 0x26, 0x8d, 0xb7, 0x60, 0xf2, 0x48, 0xd5, 0x89,
+	0x42, 0xf4, 0x03, 0x6c, 0x75, 0x49, 0xd8, 0x3c, 0xc5, 0xcc, 0xc7, 0xa8, 0x7a, 0xdb, 0xd5, 0xf6,
+	0x1e, 0xbb, 0x02, 0x69, 0x7a, 0x33, 0x9f, 0x8e, 0x76, 0x03, 0xf5, 0x76, 0x9d, 0x76, 0x03, 0xd3,
+	0xa2, 0xab, 0x30, 0xe2, 0xe0, 0x03, 0xb5, 0xb3, 0x67, 0xd9, 0x58, 0x31, 0xf4, 0x03, 0x9d, 0xb0,
+	0xf6, 0xc9, 0xc8, 0x95, 0x40, 0xdc, 0xa4, 0x52, 0xf1, 0x2b, 0x28, 0xb9, 0x81, 0x3a, 0x1d, 0xcb,
+	0x74, 0x30, 0xfa, 0x3f, 0x94, 0x77, 0xb1, 0xd9, 0xd6, 0xcd, 0xe7, 0x0a, 0xb1, 0xf6, 0xb1, 0xc9,
+	0xc2, 0x4d, 0xc9, 0x25, 0x4f, 0xb8, 0x4d, 0x65, 0x68, 0x06, 0x2a, 0x07, 0x96, 0xa6, 0xef, 0xea,
+	0x58, 0x53, 0x74, 0x53, 0xc3, 0xc7, 0x5e, 0x80, 0x65, 0x5f, 0xba, 0x4a, 0x85, 0xe2, 0x12, 0x54,
+	0x29, 0xf6, 0xa2, 0x4a, 0xda, 0x7b, 0x7e, 0x26, 0xaf, 0xd1, 0x4c, 0xb2, 0x9f, 0x0e, 0xcf, 0xb1,
+	0x26, 0xb8, 0x10, 0x0e, 0xc1, 0x33, 0x93, 0x03, 0x23, 0xf1, 0x18, 0x46, 0x42, 0x20, 0x4e, 0xd7,
+	0x20, 0x68, 0x81, 0x55, 0x83, 0xf9, 0xeb, 0x55, 0x63, 0x2c, 0x8a, 0xe1, 0xea, 0xe4, 0xc0, 0x8a,
+	0xb5, 0xac, 0x6d, 0x5b, 0xb6, 0x3b, 0x48, 0x49, 0x96, 0x89, 0x02, 0x93, 0xb0, 0xb9, 0x19, 0x83,
+	0x0c, 0x3b, 0xf8, 0x43, 0xc9, 0x0e, 0xe2, 0x03, 0x18, 0x0d, 0xdf, 0xec, 0x22, 0x5d, 0x87, 0x9c,
+	0xcd, 0xbc, 0xf0, 0xdd, 0x9f, 0x0c, 0x5f, 0x1d, 0xf2, 0x52, 0xf6, 0xed, 0xfc, 0x34, 0xac, 0x77,
+	0x0d, 0xa2, 0x9f, 0x3b, 0x0d, 0x0f, 0x61, 0x34, 0x04, 0xe2, 0x39, 0x53, 0x87, 0x82, 0x1f, 0xa2,
+	0x0f, 0x13, 0x9f, 0x89, 0x9e, 0x99, 0xa8, 0x41, 0x45, 0xc6, 0x6c, 0x20, 0xcf, 0xdb, 0xdc, 0xe9,
+	0x03, 0x3f, 0x89, 0x43, 0xfb, 0x4f, 0x1c, 0x85, 0x91, 0xe0, 0x16, 0xf7, 0x66, 0xf1, 0x1e, 0x94,
+	0x1e, 0xe0, 0x50, 0x27, 0x0c, 0xce, 0x63, 0x64, 0x9c, 0x93, 0x7d, 0xe3, 0x2c, 0xfe, 0xc2, 0x41,
+	0xd9, 0x03, 0xf0, 0xc2, 0xff, 0x38, 0xc7, 0x07, 0x3a, 0x3b, 0x79, 0xa6, 0xce, 0x4e, 0xc5, 0x74,
+	0x36, 0xba, 0x03, 0x15, 0x67, 0x4f, 0xa5, 0x34, 0xbb, 0x67, 0x19, 0x1a, 0xb6, 0x1d, 0x3e, 0x3d,
+	0x9d, 0x8a, 0xbd, 0xbf, 0xec, 0xda, 0xad, 0xb8, 0x66, 0xe2, 0xdf, 0x49, 0x18, 0x61, 0x41, 0x34,
+	0x0c, 0xc3, 0x4f, 0x84, 0x4f, 0xfe, 0xdc, 0xfb, 0xc8, 0x3f, 0xf9, 0x41, 0xf2, 0xbf, 0x0c, 0xb0,
+	0x8f, 0x4f, 0x94, 0x8e, 0x8d, 0x77, 0xf5, 0x63, 0xaf, 0x93, 0x0b, 0xfb, 0xf8, 0x64, 0x8b, 0x09,
+	0xd0, 0x14, 0x14, 0x3a, 0xea, 0x73, 0xac, 0x38, 0xfa, 0x4b, 0x97, 0x3c, 0x32, 0x72, 0x9e, 0x0a,
+	0x5a, 0xfa, 0x4b, 0x7a, 0x15, 0x6a, 0x5b, 0x26, 0xd1, 0xcd, 0xae, 0x4a, 0x74, 0xcb, 0xf4, 0x12,
+	0x94, 0x61, 0x18, 0xa3, 0x61, 0x8d, 0x9b, 0xa5, 0x47, 0x50, 0x61, 0xdc, 0xac, 0x38, 0xd8, 0xc0,
+	0x6d, 0x62, 0xd9, 0x7c, 0x96, 0x85, 0x3f, 0xe7, 0xbb, 0xd7, 0x17, 0xa2, 0x4b, 0xeb, 0x2d, 0xcf,
+	0xd8, 0x65, 0xf7, 0xb2, 0x11, 0x96, 0x45, 0x6b, 0x9f, 0xeb, 0xa7, 0xf2, 0xfb, 0x80, 0x06, 0x21,
+	0x3e, 0x8a, 0xd1, 0x5f, 0x40, 0xb5, 0xe7, 0x94, 0xd7, 0x3f, 0xf3, 0x6c, 0x7c, 0x58, 0x9d, 0xfc,
+	0xf1, 0x19, 0x2c, 0x60, 0xcf, 0x64, 0x48, 0x96, 0x92, 0x43, 0xb2, 0x24, 0x76, 0xa1, 0xbc, 0xd3,
+	0xd1, 0x54, 0x72, 0xce, 0x41, 0xbb, 0x0d, 0x93, 0xf8, 0xb8, 0x83, 0xdb, 0x04, 0x6b, 0x4a, 0x2c,
+	0xdb, 0x8e, 0xfb, 0xea, 0xf5, 0x08, 0xeb, 0xde, 0x81, 0x8a, 0x7f, 0xad, 0x17, 0xe7, 0x60, 0x53,
+	0x73, 0x71, 0x74, 0xfd, 0x35, 0x8c, 0x6c, 0xdb, 0xaa, 0xe9, 0xec, 0x62, 0xfb, 0x7c, 0x1e, 0x4f,
+	0x41, 0xc1, 0xc4, 0x47, 0x4a, 0x78, 0xeb, 0xc9, 0x9b, 0xf8, 0x68, 0x93, 0x9e, 0xc5, 0x6f, 0xa1,
+	0xda, 0x43, 0xff, 0x0f, 0x5e, 0x36, 0xdf, 0xc0, 0x85, 0x07, 0x16, 0xf5, 0x27, 0x4a, 0x6e, 0x83,
+	0x3d, 0x32, 0x01, 0x59, 0x1b, 0xab, 0x8e, 0xe5, 0x57, 0xce, 0x3b, 0x45, 0x3b, 0x30, 0xd5, 0xcf,
+	0x3e, 0xcb, 0x30, 0x16, 0x85, 0x0f, 0x73, 0x10, 0x13, 0x69, 0x43, 0x5b, 0x28, 0xb0, 0x10, 0x7f,
+	0xe5, 0xa0, 0xf4, 0x44, 0x7d, 0x2f, 0x09, 0x46, 0xc7, 0x38, 0xd9, 0x3f, 0xc6, 0x11, 0x52, 0x48,
+	0x7d, 0x90, 0x14, 0x66, 0xa0, 0xe2, 0x10, 0xd5, 0x26, 0x8a, 0x8d, 0x0f, 0x75, 0x47, 0xb7, 0x4c,
+	0x36, 0xfa, 0x29, 0xb9, 0xcc, 0xa4, 0xb2, 0x27, 0x44, 0xc8, 0xa3, 0x20, 0x77, 0xe2, 0xd9, 0xef,
+	0x68, 0x3e, 0xb2, 0xfd, 0xf9, 0xf8, 0x0e, 0x80, 0x05, 0x22, 0x1d, 0x62, 0x93, 0xa0, 0x99, 0x10,
+	0x85, 0x55, 0xea, 0xa3, 0xbe, 0x43, 0x4c, 0x49, 0xbd, 0xf2, 0x20, 0xc3, 0xed, 0x94, 0xfc, 0x60,
+	0x3b, 0x09, 0xd4, 0xda, 0xf3, 0xda, 0x65, 0xe1, 0xe0, 0x2c, 0xde, 0x85, 0xd1, 0x87, 0xb6, 0x6a,
+	0x92, 0x66, 0xb8, 0xd6, 0x83, 0x7b, 0x17, 0x37, 0xb8, 0x77, 0x89, 0x3b, 0x80, 0xc2, 0x8f, 0x7a,
+	0x75, 0x0c, 0x6f, 0xad, 0x5c, 0x74, 0x6b, 0x3d, 0xd3, 0x3a, 0x27, 0xd6, 0x61, 0x7c, 0x0d, 0xe3,
+	0x4e, 0xc3, 0xd0, 0x0f, 0x71, 0xc4, 0xab, 0xe1, 0xc8, 0xe2, 0x3d, 0x98, 0xe8, 0x7f, 0xc6, 0x73,
+	0xe7, 0x6c, 0xa1, 0x5c, 0x03, 0x24, 0xe3, 0x43, 0x6b, 0xff, 0xcc, 0x17, 0x8e, 0xc3, 0x85, 0xc8,
+	0x03, 0xde, 0xab, 0xf9, 0x47, 0x0e, 0x2a, 0x2d, 0xec, 0xd0, 0xcc, 0x7e, 0x54, 0x2e, 0xd1, 0x55,
+	0x48, 0xd3, 0x4f, 0x23, 0xaf, 0x98, 0xb1, 0x2b, 0x0c, 0x33, 0x40, 0x0b, 0x74, 0x6d, 0x62, 0x5e,
+	0xb0, 0x52, 0x16, 0xeb, 0x13, 0xbd, 0xc2, 0x87, 0xe7, 0x55, 0xf6, 0xcd, 0xc4, 0x3f, 0x38, 0x18,
+	0x09, 0x7c, 0xfa, 0x97, 0x8a, 0x84, 0x66, 0x3d, 0x7f, 0x53, 0xef, 0xd9, 0x1a, 0x5d, 0x87, 0xaf,
+	0xf7, 0x1c, 0x4e, 0x4f, 0x73, 0xe1, 0x3d, 0xaf, 0x8f, 0x01, 0x02, 0x8f, 0xfb, 0x96, 0xcc, 0xcc,
+	0xd0, 0x25, 0x33, 0x1b, 0x5a, 0x32, 0xe7, 0xee, 0x41, 0xde, 0x1f, 0x5b, 0x54, 0x84, 0xdc, 0xce,
+	0xc6, 0xda, 0xc6, 0xe6, 0x93, 0x8d, 0x6a, 0x02, 0xe5, 0x21, 0xdd, 0xdc, 0x5c, 0x5a, 0xab, 0x72,
+	0xa8, 0x04, 0xf9, 0x2d, 0x59, 0x6a, 0x49, 0x1b, 0x4b, 0x52, 0x35, 0x89, 0xca, 0x50, 0x68, 0x49,
+	0xeb, 0x8d, 0xad, 0x95, 0x4d, 0x59, 0xaa, 0xa6, 0xe6, 0x64, 0x28, 0x04, 0x53, 0x86, 0x46, 0xa1,
+	0xec, 0x01, 0x28, 0xd2, 0x63, 0x69, 0x63, 0xbb, 0x9a, 0xa0, 0x98, 0x4b, 0xb2, 0xd4, 0xd8, 0x96,
+	0x96, 0xab, 0x1c, 0xbb, 0x60, 0x6b, 0x99, 0x1d, 0x92, 0xf4, 0xb0, 0x2c, 0x35, 0x25, 0x7a, 0x48,
+	0xd1, 0x83, 0xf4, 0x74, 0x6b, 0x55, 0x96, 0x96, 0xab, 0xe9, 0xb9, 0x19, 0xc8, 0xfb, 0xeb, 0x1c,
+	0xbd, 0x4e, 0x7a, 0xba, 0xd4, 0xdc, 0x69, 0xad, 0x3e, 0x96, 0xaa, 0x09, 0x04, 0x90, 0x6d, 0xad,
+	0x34, 0xa8, 0x19, 0x57, 0xff, 0x29, 0x07, 0xd9, 0x26, 0xfb, 0x30, 0x46, 0x37, 0x20, 0x4d, 0x7f,
+	0xa1, 0xb8, 0x0e, 0x10, 0x62, 0xd3, 0x2c, 0x26, 0xd0, 0x6d, 0xc8, 0xb0, 0x57, 0x32, 0x1a, 0x8b,
+	0xac, 0x0d, 0xfe, 0x63, 0xe3, 0x7d, 0xd2, 0xe0, 0xb9, 0x2f, 0x20, 0xe7, 0xd5, 0x00, 0x0d, 0xe9,
+	0x22, 0x61, 0x58, 0xb1, 0xc4, 0x04, 0xfa, 0x12, 0xf2, 0xfe, 0x22, 0x80, 0x26, 0x87, 0xec, 0x2b,
+	0x02, 0x3f, 0xa8, 0x08, 0x00, 0x6e, 0x41, 0xe6, 0x89, 0x1a, 0x71, 0x3b, 0xcc, 0xe8, 0x02, 0x8a,
+	0x48, 0x59, 0x6d, 0xc4, 0xc4, 0x02, 0x87, 0xee, 0x42, 0xd6, 0x7d, 0x2d, 0xa3, 0x20, 0xb0, 0xc8,
+	0x76, 0x20, 0x4c, 0xf4, 0x8b, 0xc3, 0x2e, 0xfb, 0xaf, 0xce, 0x9e, 0xcb, 0x7d, 0xaf, 0x6a, 0x81,
+	0x1f, 0x54, 0x04, 0x00, 0x6b, 0x50, 0x0a, 0xbf, 0xbc, 0xd0, 0x54, 0x10, 0xde, 0xe0, 0x1b, 0x53,
+	0xb8, 0x14, 0xaf, 0x0c, 0xc0, 0x16, 0xa1, 0x10, 0x7c, 0xea, 0x20, 0x3e, 0xe6, 0xeb, 0xc7, 0x85,
+	0xb9, 0x18, 0xa3, 0xe9, 0xc7, 0x60, 0x5f, 0x33, 0x51, 0x8c, 0xf0, 0x57, 0x92, 0x70, 0x31, 0x46,
+	0x13, 0x60, 0x48, 0x00, 0x3d, 0x1e, 0x47, 0x81, 0xe9, 0xc0, 0x6b, 0x41, 0x10, 0xe2, 0x54, 0x01,
+	0xcc, 0x23, 0xa8, 0x44, 0x39, 0x18, 0x5d, 0xf6, 0xed, 0x63, 0xf9, 0x5c, 0xa8, 0x0d, 0x53, 0x07,
+	0x90, 0x2b, 0x50, 0x0c, 0xb1, 0x2c, 0x12, 0x7a, 0xcd, 0xd8, 0xcf, 0xd5, 0xc2, 0x54, 0xac, 0x2e,
+	0x40, 0xba, 0x0f, 0x39, 0x8f, 0x03, 0x7b, 0xad, 0x1e, 0x25, 0x6a, 0x61, 0x72, 0x40, 0xee, 0x3f,
+	0x3d, 0xcb, 0x2d, 0x70, 0x8b, 0x37, 0x5f, 0xbd, 0xa9, 0x25, 0x5e, 0xbf, 0xa9, 0x25, 0xde, 0xbd,
+	0xa9, 0x71, 0xdf, 0x9f, 0xd6, 0xb8, 0x9f, 0x4f, 0x6b, 0xdc, 0x6f, 0xa7, 0x35, 0xee, 0xd5, 0x69,
+	0x8d, 0xfb, 0xfd, 0xb4, 0xc6, 0xfd, 0x75, 0x5a, 0x4b, 0xbc, 0x3b, 0xad, 0x71, 0x3f, 0xbc, 0xad,
+	0x25, 0x5e, 0xbd, 0xad, 0x25, 0x5e, 0xbf, 0xad, 0x25, 0x9e, 0x65, 0xd9, 0x7f, 0xba, 0x6e, 0xfc,
+	0x33, 0x00, 0x6e, 0x16, 0x22, 0xc2, 0xf9, 0x12, 0x00, 0x00,
 }
 
-func (m *FetchAllRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (x TypeCode) String() string {
+	s, ok := TypeCode_name[int32(x)]
+	if ok {
+		return s
 	}
-	var l int
-	_ = l
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovLocket(uint64(l))
+	return strconv.Itoa(int(x))
+}
+func (x EventType) String() string {
+	s, ok := EventType_name[int32(x)]
+	if ok {
+		return s
 	}
-	if m.TypeCode != 0 {
-		n += 1 + sovLocket(uint64(m.TypeCode))
+	return strconv.Itoa(int(x))
+}
+func (x LockMode) String() string {
+	s, ok := LockMode_name[int32(x)]
+	if ok {
+		return s
 	}
-	return n
+	return strconv.Itoa(int(x))
 }
+func (this *Resource) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *FetchAllResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*Resource)
+	if !ok {
+		that2, ok := that.(Resource)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	if len(m.Resources) > 0 {
-		for _, e := range m.Resources {
-			l = e.Size()
-			n += 1 + l + sovLocket(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Key != that1.Key {
+		return false
+	}
+	if this.Owner != that1.Owner {
+		return false
+	}
+	if this.Value != that1.Value {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if this.TypeCode != that1.TypeCode {
+		return false
+	}
+	if this.AcquiredAt != that1.AcquiredAt {
+		return false
+	}
+	if this.RenewedAt != that1.RenewedAt {
+		return false
+	}
+	if this.TtlInSeconds != that1.TtlInSeconds {
+		return false
+	}
+	if this.ExpiresAt != that1.ExpiresAt {
+		return false
+	}
+	if this.LeaseId != that1.LeaseId {
+		return false
+	}
+	if len(this.Labels) != len(that1.Labels) {
+		return false
+	}
+	for i := range this.Labels {
+		if this.Labels[i] != that1.Labels[i] {
+			return false
 		}
 	}
-	return n
-}
-
-func sovLocket(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozLocket(x uint64) (n int) {
-	return sovLocket(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *Resource) String() string {
-	if this == nil {
-		return "nil"
+	if !bytes.Equal(this.Payload, that1.Payload) {
+		return false
 	}
-	s := strings.Join([]string{`&Resource{`,
-		`Key:` + fmt.Sprintf("%v", this.Key) + `,`,
-		`Owner:` + fmt.Sprintf("%v", this.Owner) + `,`,
-		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`TypeCode:` + fmt.Sprintf("%v", this.TypeCode) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LockRequest) String() string {
-	if this == nil {
-		return "nil"
+	if this.Namespace != that1.Namespace {
+		return false
 	}
-	s := strings.Join([]string{`&LockRequest{`,
-		`Resource:` + strings.Replace(this.Resource.String(), "Resource", "Resource", 1) + `,`,
-		`TtlInSeconds:` + fmt.Sprintf("%v", this.TtlInSeconds) + `,`,
-		`}`,
-	}, "")
-	return s
+	return true
 }
-func (this *LockResponse) String() string {
-	if this == nil {
-		return "nil"
+func (this *LockRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := strings.Join([]string{`&LockResponse{`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ReleaseRequest) String() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*LockRequest)
+	if !ok {
+		that2, ok := that.(LockRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := strings.Join([]string{`&ReleaseRequest{`,
-		`Resource:` + strings.Replace(this.Resource.String(), "Resource", "Resource", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ReleaseResponse) String() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := strings.Join([]string{`&ReleaseResponse{`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *FetchRequest) String() string {
-	if this == nil {
-		return "nil"
+	if !this.Resource.Equal(that1.Resource) {
+		return false
 	}
-	s := strings.Join([]string{`&FetchRequest{`,
-		`Key:` + fmt.Sprintf("%v", this.Key) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *FetchResponse) String() string {
-	if this == nil {
-		return "nil"
+	if this.TtlInSeconds != that1.TtlInSeconds {
+		return false
 	}
-	s := strings.Join([]string{`&FetchResponse{`,
-		`Resource:` + strings.Replace(this.Resource.String(), "Resource", "Resource", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *FetchAllRequest) String() string {
-	if this == nil {
-		return "nil"
+	if this.WaitTimeoutInSeconds != that1.WaitTimeoutInSeconds {
+		return false
 	}
-	s := strings.Join([]string{`&FetchAllRequest{`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`TypeCode:` + fmt.Sprintf("%v", this.TypeCode) + `,`,
-		`}`,
-	}, "")
-	return s
+	if this.Mode != that1.Mode {
+		return false
+	}
+	if this.SemaphoreLimit != that1.SemaphoreLimit {
+		return false
+	}
+	return true
 }
-func (this *FetchAllResponse) String() string {
-	if this == nil {
-		return "nil"
+func (this *LockResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	repeatedStringForResources := "[]*Resource{"
-	for _, f := range this.Resources {
-		repeatedStringForResources += strings.Replace(f.String(), "Resource", "Resource", 1) + ","
+
+	that1, ok := that.(*LockResponse)
+	if !ok {
+		that2, ok := that.(LockResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.FencingToken != that1.FencingToken {
+		return false
+	}
+	if this.ModifiedIndex != that1.ModifiedIndex {
+		return false
+	}
+	return true
+}
+func (this *LockBatchRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LockBatchRequest)
+	if !ok {
+		that2, ok := that.(LockBatchRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Requests) != len(that1.Requests) {
+		return false
+	}
+	for i := range this.Requests {
+		if !this.Requests[i].Equal(that1.Requests[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *LockBatchResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LockBatchResult)
+	if !ok {
+		that2, ok := that.(LockBatchResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Response.Equal(that1.Response) {
+		return false
+	}
+	if this.ErrorCode != that1.ErrorCode {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	return true
+}
+func (this *LockBatchResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LockBatchResponse)
+	if !ok {
+		that2, ok := that.(LockBatchResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Results) != len(that1.Results) {
+		return false
+	}
+	for i := range this.Results {
+		if !this.Results[i].Equal(that1.Results[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *LockMultiRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LockMultiRequest)
+	if !ok {
+		that2, ok := that.(LockMultiRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Requests) != len(that1.Requests) {
+		return false
+	}
+	for i := range this.Requests {
+		if !this.Requests[i].Equal(that1.Requests[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *LockMultiResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LockMultiResponse)
+	if !ok {
+		that2, ok := that.(LockMultiResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Responses) != len(that1.Responses) {
+		return false
+	}
+	for i := range this.Responses {
+		if !this.Responses[i].Equal(that1.Responses[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *ReleaseRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ReleaseRequest)
+	if !ok {
+		that2, ok := that.(ReleaseRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Resource.Equal(that1.Resource) {
+		return false
+	}
+	if this.Mode != that1.Mode {
+		return false
+	}
+	return true
+}
+func (this *ReleaseResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ReleaseResponse)
+	if !ok {
+		that2, ok := that.(ReleaseResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
+}
+func (this *FetchRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FetchRequest)
+	if !ok {
+		that2, ok := that.(FetchRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Key != that1.Key {
+		return false
+	}
+	if this.Namespace != that1.Namespace {
+		return false
+	}
+	return true
+}
+func (this *FetchResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FetchResponse)
+	if !ok {
+		that2, ok := that.(FetchResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Resource.Equal(that1.Resource) {
+		return false
+	}
+	if this.FencingToken != that1.FencingToken {
+		return false
+	}
+	if this.ModifiedIndex != that1.ModifiedIndex {
+		return false
+	}
+	if len(this.SharedHolders) != len(that1.SharedHolders) {
+		return false
+	}
+	for i := range this.SharedHolders {
+		if !this.SharedHolders[i].Equal(that1.SharedHolders[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *FetchAllRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FetchAllRequest)
+	if !ok {
+		that2, ok := that.(FetchAllRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if this.TypeCode != that1.TypeCode {
+		return false
+	}
+	if this.KeyPrefix != that1.KeyPrefix {
+		return false
+	}
+	if this.PageSize != that1.PageSize {
+		return false
+	}
+	if this.ContinuationToken != that1.ContinuationToken {
+		return false
+	}
+	if len(this.LabelSelector) != len(that1.LabelSelector) {
+		return false
+	}
+	for i := range this.LabelSelector {
+		if this.LabelSelector[i] != that1.LabelSelector[i] {
+			return false
+		}
+	}
+	if this.Namespace != that1.Namespace {
+		return false
+	}
+	return true
+}
+func (this *FetchAllResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FetchAllResponse)
+	if !ok {
+		that2, ok := that.(FetchAllResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Resources) != len(that1.Resources) {
+		return false
+	}
+	for i := range this.Resources {
+		if !this.Resources[i].Equal(that1.Resources[i]) {
+			return false
+		}
+	}
+	if this.ContinuationToken != that1.ContinuationToken {
+		return false
+	}
+	return true
+}
+func (this *UpdateRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*UpdateRequest)
+	if !ok {
+		that2, ok := that.(UpdateRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Resource.Equal(that1.Resource) {
+		return false
+	}
+	if this.ExpectedModifiedIndex != that1.ExpectedModifiedIndex {
+		return false
+	}
+	return true
+}
+func (this *UpdateResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*UpdateResponse)
+	if !ok {
+		that2, ok := that.(UpdateResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ModifiedIndex != that1.ModifiedIndex {
+		return false
+	}
+	return true
+}
+func (this *TransferRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TransferRequest)
+	if !ok {
+		that2, ok := that.(TransferRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Resource.Equal(that1.Resource) {
+		return false
+	}
+	if this.NewOwner != that1.NewOwner {
+		return false
+	}
+	return true
+}
+func (this *TransferResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TransferResponse)
+	if !ok {
+		that2, ok := that.(TransferResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.FencingToken != that1.FencingToken {
+		return false
+	}
+	if this.ModifiedIndex != that1.ModifiedIndex {
+		return false
+	}
+	return true
+}
+func (this *ForceReleaseRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ForceReleaseRequest)
+	if !ok {
+		that2, ok := that.(ForceReleaseRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Key != that1.Key {
+		return false
+	}
+	if this.Reason != that1.Reason {
+		return false
+	}
+	if this.Namespace != that1.Namespace {
+		return false
+	}
+	return true
+}
+func (this *ForceReleaseResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ForceReleaseResponse)
+	if !ok {
+		that2, ok := that.(ForceReleaseResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Released) != len(that1.Released) {
+		return false
+	}
+	for i := range this.Released {
+		if !this.Released[i].Equal(that1.Released[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *WatchRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*WatchRequest)
+	if !ok {
+		that2, ok := that.(WatchRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Key != that1.Key {
+		return false
+	}
+	if this.KeyPrefix != that1.KeyPrefix {
+		return false
+	}
+	if this.TypeCode != that1.TypeCode {
+		return false
+	}
+	if this.StartRevision != that1.StartRevision {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if this.Namespace != that1.Namespace {
+		return false
+	}
+	return true
+}
+func (this *WatchEvent) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*WatchEvent)
+	if !ok {
+		that2, ok := that.(WatchEvent)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if !this.Resource.Equal(that1.Resource) {
+		return false
+	}
+	if this.Revision != that1.Revision {
+		return false
+	}
+	return true
+}
+func (this *GrantLeaseRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GrantLeaseRequest)
+	if !ok {
+		that2, ok := that.(GrantLeaseRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.TtlInSeconds != that1.TtlInSeconds {
+		return false
+	}
+	return true
+}
+func (this *GrantLeaseResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GrantLeaseResponse)
+	if !ok {
+		that2, ok := that.(GrantLeaseResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.LeaseId != that1.LeaseId {
+		return false
+	}
+	if this.TtlInSeconds != that1.TtlInSeconds {
+		return false
+	}
+	return true
+}
+func (this *KeepAliveLeaseRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*KeepAliveLeaseRequest)
+	if !ok {
+		that2, ok := that.(KeepAliveLeaseRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.LeaseId != that1.LeaseId {
+		return false
+	}
+	return true
+}
+func (this *KeepAliveLeaseResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*KeepAliveLeaseResponse)
+	if !ok {
+		that2, ok := that.(KeepAliveLeaseResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.TtlInSeconds != that1.TtlInSeconds {
+		return false
+	}
+	return true
+}
+func (this *RevokeLeaseRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RevokeLeaseRequest)
+	if !ok {
+		that2, ok := that.(RevokeLeaseRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.LeaseId != that1.LeaseId {
+		return false
+	}
+	return true
+}
+func (this *RevokeLeaseResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RevokeLeaseResponse)
+	if !ok {
+		that2, ok := that.(RevokeLeaseResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
+}
+func (this *SessionRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SessionRequest)
+	if !ok {
+		that2, ok := that.(SessionRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.TtlInSeconds != that1.TtlInSeconds {
+		return false
+	}
+	if !this.Lock.Equal(that1.Lock) {
+		return false
+	}
+	if !this.Release.Equal(that1.Release) {
+		return false
+	}
+	return true
+}
+func (this *SessionResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SessionResponse)
+	if !ok {
+		that2, ok := that.(SessionResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.LeaseId != that1.LeaseId {
+		return false
+	}
+	if this.TtlInSeconds != that1.TtlInSeconds {
+		return false
+	}
+	if !this.Lock.Equal(that1.Lock) {
+		return false
+	}
+	if !this.Release.Equal(that1.Release) {
+		return false
+	}
+	if this.ErrorCode != that1.ErrorCode {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	return true
+}
+func (this *Resource) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 17)
+	s = append(s, "&models.Resource{")
+	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
+	s = append(s, "Owner: "+fmt.Sprintf("%#v", this.Owner)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "TypeCode: "+fmt.Sprintf("%#v", this.TypeCode)+",\n")
+	s = append(s, "AcquiredAt: "+fmt.Sprintf("%#v", this.AcquiredAt)+",\n")
+	s = append(s, "RenewedAt: "+fmt.Sprintf("%#v", this.RenewedAt)+",\n")
+	s = append(s, "TtlInSeconds: "+fmt.Sprintf("%#v", this.TtlInSeconds)+",\n")
+	s = append(s, "ExpiresAt: "+fmt.Sprintf("%#v", this.ExpiresAt)+",\n")
+	s = append(s, "LeaseId: "+fmt.Sprintf("%#v", this.LeaseId)+",\n")
+	keysForLabels := make([]string, 0, len(this.Labels))
+	for k, _ := range this.Labels {
+		keysForLabels = append(keysForLabels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabels)
+	mapStringForLabels := "map[string]string{"
+	for _, k := range keysForLabels {
+		mapStringForLabels += fmt.Sprintf("%#v: %#v,", k, this.Labels[k])
+	}
+	mapStringForLabels += "}"
+	if this.Labels != nil {
+		s = append(s, "Labels: "+mapStringForLabels+",\n")
+	}
+	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&models.LockRequest{")
+	if this.Resource != nil {
+		s = append(s, "Resource: "+fmt.Sprintf("%#v", this.Resource)+",\n")
+	}
+	s = append(s, "TtlInSeconds: "+fmt.Sprintf("%#v", this.TtlInSeconds)+",\n")
+	s = append(s, "WaitTimeoutInSeconds: "+fmt.Sprintf("%#v", this.WaitTimeoutInSeconds)+",\n")
+	s = append(s, "Mode: "+fmt.Sprintf("%#v", this.Mode)+",\n")
+	s = append(s, "SemaphoreLimit: "+fmt.Sprintf("%#v", this.SemaphoreLimit)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.LockResponse{")
+	s = append(s, "FencingToken: "+fmt.Sprintf("%#v", this.FencingToken)+",\n")
+	s = append(s, "ModifiedIndex: "+fmt.Sprintf("%#v", this.ModifiedIndex)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockBatchRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.LockBatchRequest{")
+	if this.Requests != nil {
+		s = append(s, "Requests: "+fmt.Sprintf("%#v", this.Requests)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockBatchResult) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&models.LockBatchResult{")
+	if this.Response != nil {
+		s = append(s, "Response: "+fmt.Sprintf("%#v", this.Response)+",\n")
+	}
+	s = append(s, "ErrorCode: "+fmt.Sprintf("%#v", this.ErrorCode)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockBatchResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.LockBatchResponse{")
+	if this.Results != nil {
+		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockMultiRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.LockMultiRequest{")
+	if this.Requests != nil {
+		s = append(s, "Requests: "+fmt.Sprintf("%#v", this.Requests)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockMultiResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.LockMultiResponse{")
+	if this.Responses != nil {
+		s = append(s, "Responses: "+fmt.Sprintf("%#v", this.Responses)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ReleaseRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.ReleaseRequest{")
+	if this.Resource != nil {
+		s = append(s, "Resource: "+fmt.Sprintf("%#v", this.Resource)+",\n")
+	}
+	s = append(s, "Mode: "+fmt.Sprintf("%#v", this.Mode)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ReleaseResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&models.ReleaseResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *FetchRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.FetchRequest{")
+	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *FetchResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&models.FetchResponse{")
+	if this.Resource != nil {
+		s = append(s, "Resource: "+fmt.Sprintf("%#v", this.Resource)+",\n")
+	}
+	s = append(s, "FencingToken: "+fmt.Sprintf("%#v", this.FencingToken)+",\n")
+	s = append(s, "ModifiedIndex: "+fmt.Sprintf("%#v", this.ModifiedIndex)+",\n")
+	if this.SharedHolders != nil {
+		s = append(s, "SharedHolders: "+fmt.Sprintf("%#v", this.SharedHolders)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *FetchAllRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 11)
+	s = append(s, "&models.FetchAllRequest{")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "TypeCode: "+fmt.Sprintf("%#v", this.TypeCode)+",\n")
+	s = append(s, "KeyPrefix: "+fmt.Sprintf("%#v", this.KeyPrefix)+",\n")
+	s = append(s, "PageSize: "+fmt.Sprintf("%#v", this.PageSize)+",\n")
+	s = append(s, "ContinuationToken: "+fmt.Sprintf("%#v", this.ContinuationToken)+",\n")
+	keysForLabelSelector := make([]string, 0, len(this.LabelSelector))
+	for k, _ := range this.LabelSelector {
+		keysForLabelSelector = append(keysForLabelSelector, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelSelector)
+	mapStringForLabelSelector := "map[string]string{"
+	for _, k := range keysForLabelSelector {
+		mapStringForLabelSelector += fmt.Sprintf("%#v: %#v,", k, this.LabelSelector[k])
+	}
+	mapStringForLabelSelector += "}"
+	if this.LabelSelector != nil {
+		s = append(s, "LabelSelector: "+mapStringForLabelSelector+",\n")
+	}
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *FetchAllResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.FetchAllResponse{")
+	if this.Resources != nil {
+		s = append(s, "Resources: "+fmt.Sprintf("%#v", this.Resources)+",\n")
+	}
+	s = append(s, "ContinuationToken: "+fmt.Sprintf("%#v", this.ContinuationToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *UpdateRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.UpdateRequest{")
+	if this.Resource != nil {
+		s = append(s, "Resource: "+fmt.Sprintf("%#v", this.Resource)+",\n")
+	}
+	s = append(s, "ExpectedModifiedIndex: "+fmt.Sprintf("%#v", this.ExpectedModifiedIndex)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *UpdateResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.UpdateResponse{")
+	s = append(s, "ModifiedIndex: "+fmt.Sprintf("%#v", this.ModifiedIndex)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TransferRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.TransferRequest{")
+	if this.Resource != nil {
+		s = append(s, "Resource: "+fmt.Sprintf("%#v", this.Resource)+",\n")
+	}
+	s = append(s, "NewOwner: "+fmt.Sprintf("%#v", this.NewOwner)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TransferResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.TransferResponse{")
+	s = append(s, "FencingToken: "+fmt.Sprintf("%#v", this.FencingToken)+",\n")
+	s = append(s, "ModifiedIndex: "+fmt.Sprintf("%#v", this.ModifiedIndex)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ForceReleaseRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&models.ForceReleaseRequest{")
+	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
+	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ForceReleaseResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.ForceReleaseResponse{")
+	if this.Released != nil {
+		s = append(s, "Released: "+fmt.Sprintf("%#v", this.Released)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *WatchRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 10)
+	s = append(s, "&models.WatchRequest{")
+	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
+	s = append(s, "KeyPrefix: "+fmt.Sprintf("%#v", this.KeyPrefix)+",\n")
+	s = append(s, "TypeCode: "+fmt.Sprintf("%#v", this.TypeCode)+",\n")
+	s = append(s, "StartRevision: "+fmt.Sprintf("%#v", this.StartRevision)+",\n")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "Namespace: "+fmt.Sprintf("%#v", this.Namespace)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *WatchEvent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&models.WatchEvent{")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	if this.Resource != nil {
+		s = append(s, "Resource: "+fmt.Sprintf("%#v", this.Resource)+",\n")
+	}
+	s = append(s, "Revision: "+fmt.Sprintf("%#v", this.Revision)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GrantLeaseRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.GrantLeaseRequest{")
+	s = append(s, "TtlInSeconds: "+fmt.Sprintf("%#v", this.TtlInSeconds)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GrantLeaseResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.GrantLeaseResponse{")
+	s = append(s, "LeaseId: "+fmt.Sprintf("%#v", this.LeaseId)+",\n")
+	s = append(s, "TtlInSeconds: "+fmt.Sprintf("%#v", this.TtlInSeconds)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *KeepAliveLeaseRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.KeepAliveLeaseRequest{")
+	s = append(s, "LeaseId: "+fmt.Sprintf("%#v", this.LeaseId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *KeepAliveLeaseResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.KeepAliveLeaseResponse{")
+	s = append(s, "TtlInSeconds: "+fmt.Sprintf("%#v", this.TtlInSeconds)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RevokeLeaseRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.RevokeLeaseRequest{")
+	s = append(s, "LeaseId: "+fmt.Sprintf("%#v", this.LeaseId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RevokeLeaseResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&models.RevokeLeaseResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SessionRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&models.SessionRequest{")
+	s = append(s, "TtlInSeconds: "+fmt.Sprintf("%#v", this.TtlInSeconds)+",\n")
+	if this.Lock != nil {
+		s = append(s, "Lock: "+fmt.Sprintf("%#v", this.Lock)+",\n")
+	}
+	if this.Release != nil {
+		s = append(s, "Release: "+fmt.Sprintf("%#v", this.Release)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SessionResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 10)
+	s = append(s, "&models.SessionResponse{")
+	s = append(s, "LeaseId: "+fmt.Sprintf("%#v", this.LeaseId)+",\n")
+	s = append(s, "TtlInSeconds: "+fmt.Sprintf("%#v", this.TtlInSeconds)+",\n")
+	if this.Lock != nil {
+		s = append(s, "Lock: "+fmt.Sprintf("%#v", this.Lock)+",\n")
+	}
+	if this.Release != nil {
+		s = append(s, "Release: "+fmt.Sprintf("%#v", this.Release)+",\n")
+	}
+	s = append(s, "ErrorCode: "+fmt.Sprintf("%#v", this.ErrorCode)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringLocket(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// LocketClient is the client API for Locket service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type LocketClient interface {
+	Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error)
+	Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error)
+	Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error)
+	FetchAll(ctx context.Context, in *FetchAllRequest, opts ...grpc.CallOption) (*FetchAllResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Locket_WatchClient, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	ForceRelease(ctx context.Context, in *ForceReleaseRequest, opts ...grpc.CallOption) (*ForceReleaseResponse, error)
+	LockBatch(ctx context.Context, in *LockBatchRequest, opts ...grpc.CallOption) (*LockBatchResponse, error)
+	LockMulti(ctx context.Context, in *LockMultiRequest, opts ...grpc.CallOption) (*LockMultiResponse, error)
+	GrantLease(ctx context.Context, in *GrantLeaseRequest, opts ...grpc.CallOption) (*GrantLeaseResponse, error)
+	KeepAliveLease(ctx context.Context, in *KeepAliveLeaseRequest, opts ...grpc.CallOption) (*KeepAliveLeaseResponse, error)
+	RevokeLease(ctx context.Context, in *RevokeLeaseRequest, opts ...grpc.CallOption) (*RevokeLeaseResponse, error)
+	Session(ctx context.Context, opts ...grpc.CallOption) (Locket_SessionClient, error)
+}
+
+type locketClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLocketClient(cc *grpc.ClientConn) LocketClient {
+	return &locketClient{cc}
+}
+
+func (c *locketClient) Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*LockResponse, error) {
+	out := new(LockResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/Lock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error) {
+	out := new(FetchResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/Fetch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error) {
+	out := new(ReleaseResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/Release", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) FetchAll(ctx context.Context, in *FetchAllRequest, opts ...grpc.CallOption) (*FetchAllResponse, error) {
+	out := new(FetchAllResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/FetchAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Locket_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Locket_serviceDesc.Streams[0], "/models.Locket/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &locketWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Locket_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type locketWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *locketWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *locketClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/Transfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) ForceRelease(ctx context.Context, in *ForceReleaseRequest, opts ...grpc.CallOption) (*ForceReleaseResponse, error) {
+	out := new(ForceReleaseResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/ForceRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) LockBatch(ctx context.Context, in *LockBatchRequest, opts ...grpc.CallOption) (*LockBatchResponse, error) {
+	out := new(LockBatchResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/LockBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) LockMulti(ctx context.Context, in *LockMultiRequest, opts ...grpc.CallOption) (*LockMultiResponse, error) {
+	out := new(LockMultiResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/LockMulti", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) GrantLease(ctx context.Context, in *GrantLeaseRequest, opts ...grpc.CallOption) (*GrantLeaseResponse, error) {
+	out := new(GrantLeaseResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/GrantLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) KeepAliveLease(ctx context.Context, in *KeepAliveLeaseRequest, opts ...grpc.CallOption) (*KeepAliveLeaseResponse, error) {
+	out := new(KeepAliveLeaseResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/KeepAliveLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) RevokeLease(ctx context.Context, in *RevokeLeaseRequest, opts ...grpc.CallOption) (*RevokeLeaseResponse, error) {
+	out := new(RevokeLeaseResponse)
+	err := c.cc.Invoke(ctx, "/models.Locket/RevokeLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locketClient) Session(ctx context.Context, opts ...grpc.CallOption) (Locket_SessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Locket_serviceDesc.Streams[1], "/models.Locket/Session", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &locketSessionClient{stream}
+	return x, nil
+}
+
+type Locket_SessionClient interface {
+	Send(*SessionRequest) error
+	Recv() (*SessionResponse, error)
+	grpc.ClientStream
+}
+
+type locketSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *locketSessionClient) Send(m *SessionRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *locketSessionClient) Recv() (*SessionResponse, error) {
+	m := new(SessionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LocketServer is the server API for Locket service.
+type LocketServer interface {
+	Lock(context.Context, *LockRequest) (*LockResponse, error)
+	Fetch(context.Context, *FetchRequest) (*FetchResponse, error)
+	Release(context.Context, *ReleaseRequest) (*ReleaseResponse, error)
+	FetchAll(context.Context, *FetchAllRequest) (*FetchAllResponse, error)
+	Watch(*WatchRequest, Locket_WatchServer) error
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	ForceRelease(context.Context, *ForceReleaseRequest) (*ForceReleaseResponse, error)
+	LockBatch(context.Context, *LockBatchRequest) (*LockBatchResponse, error)
+	LockMulti(context.Context, *LockMultiRequest) (*LockMultiResponse, error)
+	GrantLease(context.Context, *GrantLeaseRequest) (*GrantLeaseResponse, error)
+	KeepAliveLease(context.Context, *KeepAliveLeaseRequest) (*KeepAliveLeaseResponse, error)
+	RevokeLease(context.Context, *RevokeLeaseRequest) (*RevokeLeaseResponse, error)
+	Session(Locket_SessionServer) error
+}
+
+// UnimplementedLocketServer can be embedded to have forward compatible implementations.
+type UnimplementedLocketServer struct {
+}
+
+func (*UnimplementedLocketServer) Lock(ctx context.Context, req *LockRequest) (*LockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lock not implemented")
+}
+func (*UnimplementedLocketServer) Fetch(ctx context.Context, req *FetchRequest) (*FetchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fetch not implemented")
+}
+func (*UnimplementedLocketServer) Release(ctx context.Context, req *ReleaseRequest) (*ReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+}
+func (*UnimplementedLocketServer) FetchAll(ctx context.Context, req *FetchAllRequest) (*FetchAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchAll not implemented")
+}
+func (*UnimplementedLocketServer) Watch(req *WatchRequest, srv Locket_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (*UnimplementedLocketServer) Update(ctx context.Context, req *UpdateRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (*UnimplementedLocketServer) Transfer(ctx context.Context, req *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
+func (*UnimplementedLocketServer) ForceRelease(ctx context.Context, req *ForceReleaseRequest) (*ForceReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceRelease not implemented")
+}
+func (*UnimplementedLocketServer) LockBatch(ctx context.Context, req *LockBatchRequest) (*LockBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockBatch not implemented")
+}
+func (*UnimplementedLocketServer) LockMulti(ctx context.Context, req *LockMultiRequest) (*LockMultiResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockMulti not implemented")
+}
+func (*UnimplementedLocketServer) GrantLease(ctx context.Context, req *GrantLeaseRequest) (*GrantLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantLease not implemented")
+}
+func (*UnimplementedLocketServer) KeepAliveLease(ctx context.Context, req *KeepAliveLeaseRequest) (*KeepAliveLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KeepAliveLease not implemented")
+}
+func (*UnimplementedLocketServer) RevokeLease(ctx context.Context, req *RevokeLeaseRequest) (*RevokeLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeLease not implemented")
+}
+func (*UnimplementedLocketServer) Session(srv Locket_SessionServer) error {
+	return status.Errorf(codes.Unimplemented, "method Session not implemented")
+}
+
+func RegisterLocketServer(s *grpc.Server, srv LocketServer) {
+	s.RegisterService(&_Locket_serviceDesc, srv)
+}
+
+func _Locket_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).Lock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/Lock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).Lock(ctx, req.(*LockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_Fetch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).Fetch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/Fetch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).Fetch(ctx, req.(*FetchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).Release(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/Release",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).Release(ctx, req.(*ReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_FetchAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).FetchAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/FetchAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).FetchAll(ctx, req.(*FetchAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LocketServer).Watch(m, &locketWatchServer{stream})
+}
+
+type Locket_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type locketWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *locketWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Locket_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/Transfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_ForceRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).ForceRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/ForceRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).ForceRelease(ctx, req.(*ForceReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_LockBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).LockBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/LockBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).LockBatch(ctx, req.(*LockBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_LockMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockMultiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).LockMulti(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/LockMulti",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).LockMulti(ctx, req.(*LockMultiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_GrantLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).GrantLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/GrantLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).GrantLease(ctx, req.(*GrantLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_KeepAliveLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeepAliveLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).KeepAliveLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/KeepAliveLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).KeepAliveLease(ctx, req.(*KeepAliveLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_RevokeLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocketServer).RevokeLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/models.Locket/RevokeLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocketServer).RevokeLease(ctx, req.(*RevokeLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Locket_Session_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LocketServer).Session(&locketSessionServer{stream})
+}
+
+type Locket_SessionServer interface {
+	Send(*SessionResponse) error
+	Recv() (*SessionRequest, error)
+	grpc.ServerStream
+}
+
+type locketSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *locketSessionServer) Send(m *SessionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *locketSessionServer) Recv() (*SessionRequest, error) {
+	m := new(SessionRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Locket_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "models.Locket",
+	HandlerType: (*LocketServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lock",
+			Handler:    _Locket_Lock_Handler,
+		},
+		{
+			MethodName: "Fetch",
+			Handler:    _Locket_Fetch_Handler,
+		},
+		{
+			MethodName: "Release",
+			Handler:    _Locket_Release_Handler,
+		},
+		{
+			MethodName: "FetchAll",
+			Handler:    _Locket_FetchAll_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _Locket_Update_Handler,
+		},
+		{
+			MethodName: "Transfer",
+			Handler:    _Locket_Transfer_Handler,
+		},
+		{
+			MethodName: "ForceRelease",
+			Handler:    _Locket_ForceRelease_Handler,
+		},
+		{
+			MethodName: "LockBatch",
+			Handler:    _Locket_LockBatch_Handler,
+		},
+		{
+			MethodName: "LockMulti",
+			Handler:    _Locket_LockMulti_Handler,
+		},
+		{
+			MethodName: "GrantLease",
+			Handler:    _Locket_GrantLease_Handler,
+		},
+		{
+			MethodName: "KeepAliveLease",
+			Handler:    _Locket_KeepAliveLease_Handler,
+		},
+		{
+			MethodName: "RevokeLease",
+			Handler:    _Locket_RevokeLease_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Locket_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Session",
+			Handler:       _Locket_Session_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "locket.proto",
+}
+
+func (m *Resource) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Resource) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Resource) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.Labels) > 0 {
+		for k := range m.Labels {
+			v := m.Labels[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintLocket(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintLocket(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintLocket(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.LeaseId) > 0 {
+		i -= len(m.LeaseId)
+		copy(dAtA[i:], m.LeaseId)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.LeaseId)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.ExpiresAt != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.ExpiresAt))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.TtlInSeconds != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.TtlInSeconds))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.RenewedAt != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.RenewedAt))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.AcquiredAt != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.AcquiredAt))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.TypeCode != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.TypeCode))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SemaphoreLimit != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.SemaphoreLimit))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Mode != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.Mode))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.WaitTimeoutInSeconds != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.WaitTimeoutInSeconds))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.TtlInSeconds != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.TtlInSeconds))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Resource != nil {
+		{
+			size, err := m.Resource.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ModifiedIndex != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.ModifiedIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.FencingToken != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.FencingToken))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockBatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockBatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockBatchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for iNdEx := len(m.Requests) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Requests[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLocket(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockBatchResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockBatchResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockBatchResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ErrorCode != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.ErrorCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Response != nil {
+		{
+			size, err := m.Response.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockBatchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockBatchResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockBatchResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLocket(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockMultiRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockMultiRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockMultiRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for iNdEx := len(m.Requests) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Requests[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLocket(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockMultiResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockMultiResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockMultiResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for iNdEx := len(m.Responses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Responses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLocket(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReleaseRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReleaseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Mode != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.Mode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Resource != nil {
+		{
+			size, err := m.Resource.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReleaseResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReleaseResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *FetchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FetchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FetchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FetchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FetchResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FetchResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.SharedHolders) > 0 {
+		for iNdEx := len(m.SharedHolders) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SharedHolders[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLocket(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.ModifiedIndex != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.ModifiedIndex))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.FencingToken != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.FencingToken))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Resource != nil {
+		{
+			size, err := m.Resource.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FetchAllRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FetchAllRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FetchAllRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.LabelSelector) > 0 {
+		for k := range m.LabelSelector {
+			v := m.LabelSelector[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintLocket(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintLocket(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintLocket(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.ContinuationToken) > 0 {
+		i -= len(m.ContinuationToken)
+		copy(dAtA[i:], m.ContinuationToken)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.ContinuationToken)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.PageSize != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.PageSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.KeyPrefix) > 0 {
+		i -= len(m.KeyPrefix)
+		copy(dAtA[i:], m.KeyPrefix)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.KeyPrefix)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.TypeCode != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.TypeCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FetchAllResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FetchAllResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FetchAllResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ContinuationToken) > 0 {
+		i -= len(m.ContinuationToken)
+		copy(dAtA[i:], m.ContinuationToken)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.ContinuationToken)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Resources) > 0 {
+		for iNdEx := len(m.Resources) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Resources[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLocket(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExpectedModifiedIndex != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.ExpectedModifiedIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Resource != nil {
+		{
+			size, err := m.Resource.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ModifiedIndex != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.ModifiedIndex))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NewOwner) > 0 {
+		i -= len(m.NewOwner)
+		copy(dAtA[i:], m.NewOwner)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.NewOwner)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Resource != nil {
+		{
+			size, err := m.Resource.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ModifiedIndex != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.ModifiedIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.FencingToken != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.FencingToken))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ForceReleaseRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ForceReleaseRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ForceReleaseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ForceReleaseResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ForceReleaseResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ForceReleaseResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Released) > 0 {
+		for iNdEx := len(m.Released) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Released[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLocket(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.StartRevision != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.StartRevision))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.TypeCode != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.TypeCode))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.KeyPrefix) > 0 {
+		i -= len(m.KeyPrefix)
+		copy(dAtA[i:], m.KeyPrefix)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.KeyPrefix)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Revision != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.Revision))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Resource != nil {
+		{
+			size, err := m.Resource.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GrantLeaseRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GrantLeaseRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GrantLeaseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TtlInSeconds != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.TtlInSeconds))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GrantLeaseResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GrantLeaseResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GrantLeaseResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TtlInSeconds != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.TtlInSeconds))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.LeaseId) > 0 {
+		i -= len(m.LeaseId)
+		copy(dAtA[i:], m.LeaseId)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.LeaseId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KeepAliveLeaseRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeepAliveLeaseRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeepAliveLeaseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LeaseId) > 0 {
+		i -= len(m.LeaseId)
+		copy(dAtA[i:], m.LeaseId)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.LeaseId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KeepAliveLeaseResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeepAliveLeaseResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeepAliveLeaseResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TtlInSeconds != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.TtlInSeconds))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RevokeLeaseRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeLeaseRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RevokeLeaseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LeaseId) > 0 {
+		i -= len(m.LeaseId)
+		copy(dAtA[i:], m.LeaseId)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.LeaseId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RevokeLeaseResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeLeaseResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RevokeLeaseResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Release != nil {
+		{
+			size, err := m.Release.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Lock != nil {
+		{
+			size, err := m.Lock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TtlInSeconds != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.TtlInSeconds))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.ErrorCode != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.ErrorCode))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Release != nil {
+		{
+			size, err := m.Release.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Lock != nil {
+		{
+			size, err := m.Lock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLocket(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.TtlInSeconds != 0 {
+		i = encodeVarintLocket(dAtA, i, uint64(m.TtlInSeconds))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.LeaseId) > 0 {
+		i -= len(m.LeaseId)
+		copy(dAtA[i:], m.LeaseId)
+		i = encodeVarintLocket(dAtA, i, uint64(len(m.LeaseId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintLocket(dAtA []byte, offset int, v uint64) int {
+	offset -= sovLocket(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Resource) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.TypeCode != 0 {
+		n += 1 + sovLocket(uint64(m.TypeCode))
+	}
+	if m.AcquiredAt != 0 {
+		n += 1 + sovLocket(uint64(m.AcquiredAt))
+	}
+	if m.RenewedAt != 0 {
+		n += 1 + sovLocket(uint64(m.RenewedAt))
+	}
+	if m.TtlInSeconds != 0 {
+		n += 1 + sovLocket(uint64(m.TtlInSeconds))
+	}
+	if m.ExpiresAt != 0 {
+		n += 1 + sovLocket(uint64(m.ExpiresAt))
+	}
+	l = len(m.LeaseId)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovLocket(uint64(len(k))) + 1 + len(v) + sovLocket(uint64(len(v)))
+			n += mapEntrySize + 1 + sovLocket(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *LockRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Resource != nil {
+		l = m.Resource.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.TtlInSeconds != 0 {
+		n += 1 + sovLocket(uint64(m.TtlInSeconds))
+	}
+	if m.WaitTimeoutInSeconds != 0 {
+		n += 1 + sovLocket(uint64(m.WaitTimeoutInSeconds))
+	}
+	if m.Mode != 0 {
+		n += 1 + sovLocket(uint64(m.Mode))
+	}
+	if m.SemaphoreLimit != 0 {
+		n += 1 + sovLocket(uint64(m.SemaphoreLimit))
+	}
+	return n
+}
+
+func (m *LockResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FencingToken != 0 {
+		n += 1 + sovLocket(uint64(m.FencingToken))
+	}
+	if m.ModifiedIndex != 0 {
+		n += 1 + sovLocket(uint64(m.ModifiedIndex))
+	}
+	return n
+}
+
+func (m *LockBatchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovLocket(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LockBatchResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Response != nil {
+		l = m.Response.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.ErrorCode != 0 {
+		n += 1 + sovLocket(uint64(m.ErrorCode))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *LockBatchResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovLocket(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LockMultiRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovLocket(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LockMultiResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovLocket(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ReleaseRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Resource != nil {
+		l = m.Resource.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.Mode != 0 {
+		n += 1 + sovLocket(uint64(m.Mode))
+	}
+	return n
+}
+
+func (m *ReleaseResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *FetchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *FetchResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Resource != nil {
+		l = m.Resource.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.FencingToken != 0 {
+		n += 1 + sovLocket(uint64(m.FencingToken))
+	}
+	if m.ModifiedIndex != 0 {
+		n += 1 + sovLocket(uint64(m.ModifiedIndex))
+	}
+	if len(m.SharedHolders) > 0 {
+		for _, e := range m.SharedHolders {
+			l = e.Size()
+			n += 1 + l + sovLocket(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *FetchAllRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.TypeCode != 0 {
+		n += 1 + sovLocket(uint64(m.TypeCode))
+	}
+	l = len(m.KeyPrefix)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.PageSize != 0 {
+		n += 1 + sovLocket(uint64(m.PageSize))
+	}
+	l = len(m.ContinuationToken)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if len(m.LabelSelector) > 0 {
+		for k, v := range m.LabelSelector {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovLocket(uint64(len(k))) + 1 + len(v) + sovLocket(uint64(len(v)))
+			n += mapEntrySize + 1 + sovLocket(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *FetchAllResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Resources) > 0 {
+		for _, e := range m.Resources {
+			l = e.Size()
+			n += 1 + l + sovLocket(uint64(l))
+		}
+	}
+	l = len(m.ContinuationToken)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *UpdateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Resource != nil {
+		l = m.Resource.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.ExpectedModifiedIndex != 0 {
+		n += 1 + sovLocket(uint64(m.ExpectedModifiedIndex))
+	}
+	return n
+}
+
+func (m *UpdateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ModifiedIndex != 0 {
+		n += 1 + sovLocket(uint64(m.ModifiedIndex))
+	}
+	return n
+}
+
+func (m *TransferRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Resource != nil {
+		l = m.Resource.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	l = len(m.NewOwner)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *TransferResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FencingToken != 0 {
+		n += 1 + sovLocket(uint64(m.FencingToken))
+	}
+	if m.ModifiedIndex != 0 {
+		n += 1 + sovLocket(uint64(m.ModifiedIndex))
+	}
+	return n
+}
+
+func (m *ForceReleaseRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *ForceReleaseResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Released) > 0 {
+		for _, e := range m.Released {
+			l = e.Size()
+			n += 1 + l + sovLocket(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *WatchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	l = len(m.KeyPrefix)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.TypeCode != 0 {
+		n += 1 + sovLocket(uint64(m.TypeCode))
+	}
+	if m.StartRevision != 0 {
+		n += 1 + sovLocket(uint64(m.StartRevision))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *WatchEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovLocket(uint64(m.Type))
+	}
+	if m.Resource != nil {
+		l = m.Resource.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.Revision != 0 {
+		n += 1 + sovLocket(uint64(m.Revision))
+	}
+	return n
+}
+
+func (m *GrantLeaseRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TtlInSeconds != 0 {
+		n += 1 + sovLocket(uint64(m.TtlInSeconds))
+	}
+	return n
+}
+
+func (m *GrantLeaseResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LeaseId)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.TtlInSeconds != 0 {
+		n += 1 + sovLocket(uint64(m.TtlInSeconds))
+	}
+	return n
+}
+
+func (m *KeepAliveLeaseRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LeaseId)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *KeepAliveLeaseResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TtlInSeconds != 0 {
+		n += 1 + sovLocket(uint64(m.TtlInSeconds))
+	}
+	return n
+}
+
+func (m *RevokeLeaseRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LeaseId)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *RevokeLeaseResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *SessionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TtlInSeconds != 0 {
+		n += 1 + sovLocket(uint64(m.TtlInSeconds))
+	}
+	if m.Lock != nil {
+		l = m.Lock.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.Release != nil {
+		l = m.Release.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func (m *SessionResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LeaseId)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.TtlInSeconds != 0 {
+		n += 1 + sovLocket(uint64(m.TtlInSeconds))
+	}
+	if m.Lock != nil {
+		l = m.Lock.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.Release != nil {
+		l = m.Release.Size()
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	if m.ErrorCode != 0 {
+		n += 1 + sovLocket(uint64(m.ErrorCode))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovLocket(uint64(l))
+	}
+	return n
+}
+
+func sovLocket(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLocket(x uint64) (n int) {
+	return sovLocket(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Resource) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForLabels := make([]string, 0, len(this.Labels))
+	for k, _ := range this.Labels {
+		keysForLabels = append(keysForLabels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabels)
+	mapStringForLabels := "map[string]string{"
+	for _, k := range keysForLabels {
+		mapStringForLabels += fmt.Sprintf("%v: %v,", k, this.Labels[k])
+	}
+	mapStringForLabels += "}"
+	s := strings.Join([]string{`&Resource{`,
+		`Key:` + fmt.Sprintf("%v", this.Key) + `,`,
+		`Owner:` + fmt.Sprintf("%v", this.Owner) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`TypeCode:` + fmt.Sprintf("%v", this.TypeCode) + `,`,
+		`AcquiredAt:` + fmt.Sprintf("%v", this.AcquiredAt) + `,`,
+		`RenewedAt:` + fmt.Sprintf("%v", this.RenewedAt) + `,`,
+		`TtlInSeconds:` + fmt.Sprintf("%v", this.TtlInSeconds) + `,`,
+		`ExpiresAt:` + fmt.Sprintf("%v", this.ExpiresAt) + `,`,
+		`LeaseId:` + fmt.Sprintf("%v", this.LeaseId) + `,`,
+		`Labels:` + mapStringForLabels + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LockRequest{`,
+		`Resource:` + strings.Replace(this.Resource.String(), "Resource", "Resource", 1) + `,`,
+		`TtlInSeconds:` + fmt.Sprintf("%v", this.TtlInSeconds) + `,`,
+		`WaitTimeoutInSeconds:` + fmt.Sprintf("%v", this.WaitTimeoutInSeconds) + `,`,
+		`Mode:` + fmt.Sprintf("%v", this.Mode) + `,`,
+		`SemaphoreLimit:` + fmt.Sprintf("%v", this.SemaphoreLimit) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LockResponse{`,
+		`FencingToken:` + fmt.Sprintf("%v", this.FencingToken) + `,`,
+		`ModifiedIndex:` + fmt.Sprintf("%v", this.ModifiedIndex) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockBatchRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRequests := "[]*LockRequest{"
+	for _, f := range this.Requests {
+		repeatedStringForRequests += strings.Replace(f.String(), "LockRequest", "LockRequest", 1) + ","
+	}
+	repeatedStringForRequests += "}"
+	s := strings.Join([]string{`&LockBatchRequest{`,
+		`Requests:` + repeatedStringForRequests + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockBatchResult) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LockBatchResult{`,
+		`Response:` + strings.Replace(this.Response.String(), "LockResponse", "LockResponse", 1) + `,`,
+		`ErrorCode:` + fmt.Sprintf("%v", this.ErrorCode) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockBatchResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForResults := "[]*LockBatchResult{"
+	for _, f := range this.Results {
+		repeatedStringForResults += strings.Replace(f.String(), "LockBatchResult", "LockBatchResult", 1) + ","
+	}
+	repeatedStringForResults += "}"
+	s := strings.Join([]string{`&LockBatchResponse{`,
+		`Results:` + repeatedStringForResults + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockMultiRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRequests := "[]*LockRequest{"
+	for _, f := range this.Requests {
+		repeatedStringForRequests += strings.Replace(f.String(), "LockRequest", "LockRequest", 1) + ","
+	}
+	repeatedStringForRequests += "}"
+	s := strings.Join([]string{`&LockMultiRequest{`,
+		`Requests:` + repeatedStringForRequests + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockMultiResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForResponses := "[]*LockResponse{"
+	for _, f := range this.Responses {
+		repeatedStringForResponses += strings.Replace(f.String(), "LockResponse", "LockResponse", 1) + ","
+	}
+	repeatedStringForResponses += "}"
+	s := strings.Join([]string{`&LockMultiResponse{`,
+		`Responses:` + repeatedStringForResponses + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReleaseRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ReleaseRequest{`,
+		`Resource:` + strings.Replace(this.Resource.String(), "Resource", "Resource", 1) + `,`,
+		`Mode:` + fmt.Sprintf("%v", this.Mode) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReleaseResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ReleaseResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FetchRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FetchRequest{`,
+		`Key:` + fmt.Sprintf("%v", this.Key) + `,`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FetchResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSharedHolders := "[]*Resource{"
+	for _, f := range this.SharedHolders {
+		repeatedStringForSharedHolders += strings.Replace(f.String(), "Resource", "Resource", 1) + ","
+	}
+	repeatedStringForSharedHolders += "}"
+	s := strings.Join([]string{`&FetchResponse{`,
+		`Resource:` + strings.Replace(this.Resource.String(), "Resource", "Resource", 1) + `,`,
+		`FencingToken:` + fmt.Sprintf("%v", this.FencingToken) + `,`,
+		`ModifiedIndex:` + fmt.Sprintf("%v", this.ModifiedIndex) + `,`,
+		`SharedHolders:` + repeatedStringForSharedHolders + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FetchAllRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForLabelSelector := make([]string, 0, len(this.LabelSelector))
+	for k, _ := range this.LabelSelector {
+		keysForLabelSelector = append(keysForLabelSelector, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelSelector)
+	mapStringForLabelSelector := "map[string]string{"
+	for _, k := range keysForLabelSelector {
+		mapStringForLabelSelector += fmt.Sprintf("%v: %v,", k, this.LabelSelector[k])
+	}
+	mapStringForLabelSelector += "}"
+	s := strings.Join([]string{`&FetchAllRequest{`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`TypeCode:` + fmt.Sprintf("%v", this.TypeCode) + `,`,
+		`KeyPrefix:` + fmt.Sprintf("%v", this.KeyPrefix) + `,`,
+		`PageSize:` + fmt.Sprintf("%v", this.PageSize) + `,`,
+		`ContinuationToken:` + fmt.Sprintf("%v", this.ContinuationToken) + `,`,
+		`LabelSelector:` + mapStringForLabelSelector + `,`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FetchAllResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForResources := "[]*Resource{"
+	for _, f := range this.Resources {
+		repeatedStringForResources += strings.Replace(f.String(), "Resource", "Resource", 1) + ","
+	}
+	repeatedStringForResources += "}"
+	s := strings.Join([]string{`&FetchAllResponse{`,
+		`Resources:` + repeatedStringForResources + `,`,
+		`ContinuationToken:` + fmt.Sprintf("%v", this.ContinuationToken) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateRequest{`,
+		`Resource:` + strings.Replace(this.Resource.String(), "Resource", "Resource", 1) + `,`,
+		`ExpectedModifiedIndex:` + fmt.Sprintf("%v", this.ExpectedModifiedIndex) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateResponse{`,
+		`ModifiedIndex:` + fmt.Sprintf("%v", this.ModifiedIndex) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TransferRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TransferRequest{`,
+		`Resource:` + strings.Replace(this.Resource.String(), "Resource", "Resource", 1) + `,`,
+		`NewOwner:` + fmt.Sprintf("%v", this.NewOwner) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TransferResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TransferResponse{`,
+		`FencingToken:` + fmt.Sprintf("%v", this.FencingToken) + `,`,
+		`ModifiedIndex:` + fmt.Sprintf("%v", this.ModifiedIndex) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ForceReleaseRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ForceReleaseRequest{`,
+		`Key:` + fmt.Sprintf("%v", this.Key) + `,`,
+		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ForceReleaseResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForReleased := "[]*Resource{"
+	for _, f := range this.Released {
+		repeatedStringForReleased += strings.Replace(f.String(), "Resource", "Resource", 1) + ","
+	}
+	repeatedStringForReleased += "}"
+	s := strings.Join([]string{`&ForceReleaseResponse{`,
+		`Released:` + repeatedStringForReleased + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WatchRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WatchRequest{`,
+		`Key:` + fmt.Sprintf("%v", this.Key) + `,`,
+		`KeyPrefix:` + fmt.Sprintf("%v", this.KeyPrefix) + `,`,
+		`TypeCode:` + fmt.Sprintf("%v", this.TypeCode) + `,`,
+		`StartRevision:` + fmt.Sprintf("%v", this.StartRevision) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WatchEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WatchEvent{`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Resource:` + strings.Replace(this.Resource.String(), "Resource", "Resource", 1) + `,`,
+		`Revision:` + fmt.Sprintf("%v", this.Revision) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GrantLeaseRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GrantLeaseRequest{`,
+		`TtlInSeconds:` + fmt.Sprintf("%v", this.TtlInSeconds) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GrantLeaseResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GrantLeaseResponse{`,
+		`LeaseId:` + fmt.Sprintf("%v", this.LeaseId) + `,`,
+		`TtlInSeconds:` + fmt.Sprintf("%v", this.TtlInSeconds) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *KeepAliveLeaseRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&KeepAliveLeaseRequest{`,
+		`LeaseId:` + fmt.Sprintf("%v", this.LeaseId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *KeepAliveLeaseResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&KeepAliveLeaseResponse{`,
+		`TtlInSeconds:` + fmt.Sprintf("%v", this.TtlInSeconds) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RevokeLeaseRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RevokeLeaseRequest{`,
+		`LeaseId:` + fmt.Sprintf("%v", this.LeaseId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RevokeLeaseResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RevokeLeaseResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SessionRequest{`,
+		`TtlInSeconds:` + fmt.Sprintf("%v", this.TtlInSeconds) + `,`,
+		`Lock:` + strings.Replace(this.Lock.String(), "LockRequest", "LockRequest", 1) + `,`,
+		`Release:` + strings.Replace(this.Release.String(), "ReleaseRequest", "ReleaseRequest", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SessionResponse{`,
+		`LeaseId:` + fmt.Sprintf("%v", this.LeaseId) + `,`,
+		`TtlInSeconds:` + fmt.Sprintf("%v", this.TtlInSeconds) + `,`,
+		`Lock:` + strings.Replace(this.Lock.String(), "LockResponse", "LockResponse", 1) + `,`,
+		`Release:` + strings.Replace(this.Release.String(), "ReleaseResponse", "ReleaseResponse", 1) + `,`,
+		`ErrorCode:` + fmt.Sprintf("%v", this.ErrorCode) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringLocket(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Resource) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Resource: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Resource: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TypeCode", wireType)
+			}
+			m.TypeCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TypeCode |= TypeCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AcquiredAt", wireType)
+			}
+			m.AcquiredAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AcquiredAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RenewedAt", wireType)
+			}
+			m.RenewedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RenewedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TtlInSeconds", wireType)
+			}
+			m.TtlInSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TtlInSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
+			}
+			m.ExpiresAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiresAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LeaseId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLocket
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLocket
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthLocket
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthLocket
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLocket
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthLocket
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthLocket
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipLocket(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthLocket
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Labels[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resource == nil {
+				m.Resource = &Resource{}
+			}
+			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TtlInSeconds", wireType)
+			}
+			m.TtlInSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TtlInSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WaitTimeoutInSeconds", wireType)
+			}
+			m.WaitTimeoutInSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WaitTimeoutInSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			m.Mode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mode |= LockMode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SemaphoreLimit", wireType)
+			}
+			m.SemaphoreLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SemaphoreLimit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FencingToken", wireType)
+			}
+			m.FencingToken = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FencingToken |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ModifiedIndex", wireType)
+			}
+			m.ModifiedIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ModifiedIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockBatchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockBatchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockBatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requests = append(m.Requests, &LockRequest{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockBatchResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockBatchResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockBatchResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Response == nil {
+				m.Response = &LockResponse{}
+			}
+			if err := m.Response.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorCode", wireType)
+			}
+			m.ErrorCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorCode |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockBatchResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockBatchResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockBatchResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Results = append(m.Results, &LockBatchResult{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockMultiRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockMultiRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockMultiRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requests = append(m.Requests, &LockRequest{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockMultiResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockMultiResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockMultiResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Responses = append(m.Responses, &LockResponse{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resource == nil {
+				m.Resource = &Resource{}
+			}
+			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			m.Mode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mode |= LockMode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resource == nil {
+				m.Resource = &Resource{}
+			}
+			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FencingToken", wireType)
+			}
+			m.FencingToken = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FencingToken |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ModifiedIndex", wireType)
+			}
+			m.ModifiedIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ModifiedIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SharedHolders", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SharedHolders = append(m.SharedHolders, &Resource{})
+			if err := m.SharedHolders[len(m.SharedHolders)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchAllRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchAllRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchAllRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TypeCode", wireType)
+			}
+			m.TypeCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TypeCode |= TypeCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyPrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyPrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageSize", wireType)
+			}
+			m.PageSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PageSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContinuationToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContinuationToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelSelector", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelSelector == nil {
+				m.LabelSelector = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowLocket
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLocket
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthLocket
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthLocket
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowLocket
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthLocket
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthLocket
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipLocket(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthLocket
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.LabelSelector[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchAllResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchAllResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchAllResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Resources = append(m.Resources, &Resource{})
+			if err := m.Resources[len(m.Resources)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContinuationToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContinuationToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resource == nil {
+				m.Resource = &Resource{}
+			}
+			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedModifiedIndex", wireType)
+			}
+			m.ExpectedModifiedIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedModifiedIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ModifiedIndex", wireType)
+			}
+			m.ModifiedIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ModifiedIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resource == nil {
+				m.Resource = &Resource{}
+			}
+			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewOwner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewOwner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FencingToken", wireType)
+			}
+			m.FencingToken = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FencingToken |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ModifiedIndex", wireType)
+			}
+			m.ModifiedIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ModifiedIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ForceReleaseRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ForceReleaseRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ForceReleaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ForceReleaseResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ForceReleaseResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ForceReleaseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Released", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Released = append(m.Released, &Resource{})
+			if err := m.Released[len(m.Released)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyPrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyPrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TypeCode", wireType)
+			}
+			m.TypeCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TypeCode |= TypeCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartRevision", wireType)
+			}
+			m.StartRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartRevision |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	repeatedStringForResources += "}"
-	s := strings.Join([]string{`&FetchAllResponse{`,
-		`Resources:` + repeatedStringForResources + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringLocket(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *Resource) Unmarshal(dAtA []byte) error {
+func (m *WatchEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1656,17 +9171,36 @@ func (m *Resource) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Resource: wiretype end group for non-group")
+			return fmt.Errorf("proto: WatchEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Resource: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WatchEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= EventType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLocket
@@ -1676,29 +9210,33 @@ func (m *Resource) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthLocket
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthLocket
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = string(dAtA[iNdEx:postIndex])
+			if m.Resource == nil {
+				m.Resource = &Resource{}
+			}
+			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
 			}
-			var stringLen uint64
+			m.Revision = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLocket
@@ -1708,29 +9246,66 @@ func (m *Resource) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Revision |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthLocket
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthLocket
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Owner = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GrantLeaseRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
 			}
-			var stringLen uint64
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GrantLeaseRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GrantLeaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TtlInSeconds", wireType)
+			}
+			m.TtlInSeconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLocket
@@ -1740,27 +9315,64 @@ func (m *Resource) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.TtlInSeconds |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthLocket
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthLocket
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GrantLeaseResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GrantLeaseResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GrantLeaseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1788,13 +9400,13 @@ func (m *Resource) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			m.LeaseId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TypeCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TtlInSeconds", wireType)
 			}
-			m.TypeCode = 0
+			m.TtlInSeconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLocket
@@ -1804,7 +9416,7 @@ func (m *Resource) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TypeCode |= TypeCode(b&0x7F) << shift
+				m.TtlInSeconds |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1830,7 +9442,7 @@ func (m *Resource) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LockRequest) Unmarshal(dAtA []byte) error {
+func (m *KeepAliveLeaseRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1853,17 +9465,17 @@ func (m *LockRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LockRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: KeepAliveLeaseRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KeepAliveLeaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLocket
@@ -1873,47 +9485,24 @@ func (m *LockRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthLocket
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthLocket
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Resource == nil {
-				m.Resource = &Resource{}
-			}
-			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.LeaseId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TtlInSeconds", wireType)
-			}
-			m.TtlInSeconds = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowLocket
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.TtlInSeconds |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLocket(dAtA[iNdEx:])
@@ -1935,7 +9524,7 @@ func (m *LockRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LockResponse) Unmarshal(dAtA []byte) error {
+func (m *KeepAliveLeaseResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1954,16 +9543,35 @@ func (m *LockResponse) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LockResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeepAliveLeaseResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeepAliveLeaseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TtlInSeconds", wireType)
+			}
+			m.TtlInSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TtlInSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLocket(dAtA[iNdEx:])
@@ -1985,7 +9593,7 @@ func (m *LockResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReleaseRequest) Unmarshal(dAtA []byte) error {
+func (m *RevokeLeaseRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2008,17 +9616,17 @@ func (m *ReleaseRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReleaseRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RevokeLeaseRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReleaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RevokeLeaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLocket
@@ -2028,27 +9636,23 @@ func (m *ReleaseRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthLocket
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthLocket
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Resource == nil {
-				m.Resource = &Resource{}
-			}
-			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.LeaseId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2071,7 +9675,7 @@ func (m *ReleaseRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReleaseResponse) Unmarshal(dAtA []byte) error {
+func (m *RevokeLeaseResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2094,10 +9698,10 @@ func (m *ReleaseResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReleaseResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: RevokeLeaseResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReleaseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RevokeLeaseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -2121,7 +9725,7 @@ func (m *ReleaseResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FetchRequest) Unmarshal(dAtA []byte) error {
+func (m *SessionRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2144,17 +9748,36 @@ func (m *FetchRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FetchRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SessionRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FetchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SessionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TtlInSeconds", wireType)
+			}
+			m.TtlInSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TtlInSeconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Lock", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLocket
@@ -2164,77 +9787,31 @@ func (m *FetchRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthLocket
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthLocket
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipLocket(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthLocket
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *FetchResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowLocket
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Lock == nil {
+				m.Lock = &LockRequest{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Lock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: FetchResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FetchResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Release", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2261,10 +9838,10 @@ func (m *FetchResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Resource == nil {
-				m.Resource = &Resource{}
+			if m.Release == nil {
+				m.Release = &ReleaseRequest{}
 			}
-			if err := m.Resource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Release.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2289,7 +9866,7 @@ func (m *FetchResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FetchAllRequest) Unmarshal(dAtA []byte) error {
+func (m *SessionResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2312,15 +9889,15 @@ func (m *FetchAllRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FetchAllRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SessionResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FetchAllRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SessionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2348,13 +9925,13 @@ func (m *FetchAllRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			m.LeaseId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TypeCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TtlInSeconds", wireType)
 			}
-			m.TypeCode = 0
+			m.TtlInSeconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowLocket
@@ -2364,64 +9941,50 @@ func (m *FetchAllRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TypeCode |= TypeCode(b&0x7F) << shift
+				m.TtlInSeconds |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipLocket(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lock", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthLocket
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if msglen < 0 {
+				return ErrInvalidLengthLocket
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *FetchAllResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowLocket
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.Lock == nil {
+				m.Lock = &LockResponse{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: FetchAllResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FetchAllResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if err := m.Lock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Release", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2448,11 +10011,64 @@ func (m *FetchAllResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Resources = append(m.Resources, &Resource{})
-			if err := m.Resources[len(m.Resources)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Release == nil {
+				m.Release = &ReleaseResponse{}
+			}
+			if err := m.Release.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorCode", wireType)
+			}
+			m.ErrorCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorCode |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLocket(dAtA[iNdEx:])
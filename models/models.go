@@ -10,9 +10,36 @@ import (
 
 const PresenceType = "presence"
 const LockType = "lock"
+const SemaphoreType = "semaphore"
+
+// MaxValueLength is the maximum length of the value of a resource. Larger
+// values can be stored in the payload of the resource.
+const MaxValueLength = 4096
+
+// MaxLabelsLength is the maximum length of the labels of a resource, encoded
+// as a JSON object.
+const MaxLabelsLength = 4096
 
 var ErrLockCollision = status.Errorf(codes.AlreadyExists, "lock-collision")
 var ErrInvalidTTL = status.Errorf(codes.InvalidArgument, "invalid-ttl")
 var ErrInvalidOwner = status.Errorf(codes.InvalidArgument, "invalid-owner")
 var ErrResourceNotFound = status.Errorf(codes.NotFound, "resource-not-found")
 var ErrInvalidType = status.Errorf(codes.NotFound, "invalid-type")
+var ErrRevisionCompacted = status.Errorf(codes.OutOfRange, "revision-compacted")
+var ErrWatcherTooSlow = status.Errorf(codes.Aborted, "watcher-too-slow")
+var ErrInvalidPageSize = status.Errorf(codes.InvalidArgument, "invalid-page-size")
+var ErrInvalidContinuationToken = status.Errorf(codes.InvalidArgument, "invalid-continuation-token")
+var ErrModifiedIndexMismatch = status.Errorf(codes.Aborted, "modified-index-mismatch")
+var ErrInvalidLockMode = status.Errorf(codes.InvalidArgument, "invalid-lock-mode")
+var ErrInvalidSemaphoreLimit = status.Errorf(codes.InvalidArgument, "invalid-semaphore-limit")
+var ErrSemaphoreLimitMismatch = status.Errorf(codes.FailedPrecondition, "semaphore-limit-mismatch")
+var ErrLeaseNotFound = status.Errorf(codes.NotFound, "lease-not-found")
+var ErrPermissionDenied = status.Errorf(codes.PermissionDenied, "permission-denied")
+var ErrInvalidReason = status.Errorf(codes.InvalidArgument, "invalid-reason")
+var ErrInvalidLabels = status.Errorf(codes.InvalidArgument, "invalid-labels")
+var ErrValueTooLarge = status.Errorf(codes.InvalidArgument, "value-too-large")
+var ErrPayloadTooLarge = status.Errorf(codes.InvalidArgument, "payload-too-large")
+var ErrInvalidNamespace = status.Errorf(codes.InvalidArgument, "invalid-namespace")
+var ErrNamespaceQuotaExceeded = status.Errorf(codes.ResourceExhausted, "namespace-quota-exceeded")
+var ErrRateLimitExceeded = status.Errorf(codes.ResourceExhausted, "rate-limit-exceeded")
+var ErrTooManyRequestsInFlight = status.Errorf(codes.ResourceExhausted, "too-many-requests-in-flight")
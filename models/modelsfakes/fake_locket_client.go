@@ -40,6 +40,51 @@ type FakeLocketClient struct {
 		result1 *models.FetchAllResponse
 		result2 error
 	}
+	ForceReleaseStub        func(context.Context, *models.ForceReleaseRequest, ...grpc.CallOption) (*models.ForceReleaseResponse, error)
+	forceReleaseMutex       sync.RWMutex
+	forceReleaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 *models.ForceReleaseRequest
+		arg3 []grpc.CallOption
+	}
+	forceReleaseReturns struct {
+		result1 *models.ForceReleaseResponse
+		result2 error
+	}
+	forceReleaseReturnsOnCall map[int]struct {
+		result1 *models.ForceReleaseResponse
+		result2 error
+	}
+	GrantLeaseStub        func(context.Context, *models.GrantLeaseRequest, ...grpc.CallOption) (*models.GrantLeaseResponse, error)
+	grantLeaseMutex       sync.RWMutex
+	grantLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 *models.GrantLeaseRequest
+		arg3 []grpc.CallOption
+	}
+	grantLeaseReturns struct {
+		result1 *models.GrantLeaseResponse
+		result2 error
+	}
+	grantLeaseReturnsOnCall map[int]struct {
+		result1 *models.GrantLeaseResponse
+		result2 error
+	}
+	KeepAliveLeaseStub        func(context.Context, *models.KeepAliveLeaseRequest, ...grpc.CallOption) (*models.KeepAliveLeaseResponse, error)
+	keepAliveLeaseMutex       sync.RWMutex
+	keepAliveLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 *models.KeepAliveLeaseRequest
+		arg3 []grpc.CallOption
+	}
+	keepAliveLeaseReturns struct {
+		result1 *models.KeepAliveLeaseResponse
+		result2 error
+	}
+	keepAliveLeaseReturnsOnCall map[int]struct {
+		result1 *models.KeepAliveLeaseResponse
+		result2 error
+	}
 	LockStub        func(context.Context, *models.LockRequest, ...grpc.CallOption) (*models.LockResponse, error)
 	lockMutex       sync.RWMutex
 	lockArgsForCall []struct {
@@ -55,6 +100,36 @@ type FakeLocketClient struct {
 		result1 *models.LockResponse
 		result2 error
 	}
+	LockBatchStub        func(context.Context, *models.LockBatchRequest, ...grpc.CallOption) (*models.LockBatchResponse, error)
+	lockBatchMutex       sync.RWMutex
+	lockBatchArgsForCall []struct {
+		arg1 context.Context
+		arg2 *models.LockBatchRequest
+		arg3 []grpc.CallOption
+	}
+	lockBatchReturns struct {
+		result1 *models.LockBatchResponse
+		result2 error
+	}
+	lockBatchReturnsOnCall map[int]struct {
+		result1 *models.LockBatchResponse
+		result2 error
+	}
+	LockMultiStub        func(context.Context, *models.LockMultiRequest, ...grpc.CallOption) (*models.LockMultiResponse, error)
+	lockMultiMutex       sync.RWMutex
+	lockMultiArgsForCall []struct {
+		arg1 context.Context
+		arg2 *models.LockMultiRequest
+		arg3 []grpc.CallOption
+	}
+	lockMultiReturns struct {
+		result1 *models.LockMultiResponse
+		result2 error
+	}
+	lockMultiReturnsOnCall map[int]struct {
+		result1 *models.LockMultiResponse
+		result2 error
+	}
 	ReleaseStub        func(context.Context, *models.ReleaseRequest, ...grpc.CallOption) (*models.ReleaseResponse, error)
 	releaseMutex       sync.RWMutex
 	releaseArgsForCall []struct {
@@ -70,6 +145,80 @@ type FakeLocketClient struct {
 		result1 *models.ReleaseResponse
 		result2 error
 	}
+	RevokeLeaseStub        func(context.Context, *models.RevokeLeaseRequest, ...grpc.CallOption) (*models.RevokeLeaseResponse, error)
+	revokeLeaseMutex       sync.RWMutex
+	revokeLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 *models.RevokeLeaseRequest
+		arg3 []grpc.CallOption
+	}
+	revokeLeaseReturns struct {
+		result1 *models.RevokeLeaseResponse
+		result2 error
+	}
+	revokeLeaseReturnsOnCall map[int]struct {
+		result1 *models.RevokeLeaseResponse
+		result2 error
+	}
+	SessionStub        func(context.Context, ...grpc.CallOption) (models.Locket_SessionClient, error)
+	sessionMutex       sync.RWMutex
+	sessionArgsForCall []struct {
+		arg1 context.Context
+		arg2 []grpc.CallOption
+	}
+	sessionReturns struct {
+		result1 models.Locket_SessionClient
+		result2 error
+	}
+	sessionReturnsOnCall map[int]struct {
+		result1 models.Locket_SessionClient
+		result2 error
+	}
+	TransferStub        func(context.Context, *models.TransferRequest, ...grpc.CallOption) (*models.TransferResponse, error)
+	transferMutex       sync.RWMutex
+	transferArgsForCall []struct {
+		arg1 context.Context
+		arg2 *models.TransferRequest
+		arg3 []grpc.CallOption
+	}
+	transferReturns struct {
+		result1 *models.TransferResponse
+		result2 error
+	}
+	transferReturnsOnCall map[int]struct {
+		result1 *models.TransferResponse
+		result2 error
+	}
+	UpdateStub        func(context.Context, *models.UpdateRequest, ...grpc.CallOption) (*models.UpdateResponse, error)
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		arg1 context.Context
+		arg2 *models.UpdateRequest
+		arg3 []grpc.CallOption
+	}
+	updateReturns struct {
+		result1 *models.UpdateResponse
+		result2 error
+	}
+	updateReturnsOnCall map[int]struct {
+		result1 *models.UpdateResponse
+		result2 error
+	}
+	WatchStub        func(context.Context, *models.WatchRequest, ...grpc.CallOption) (models.Locket_WatchClient, error)
+	watchMutex       sync.RWMutex
+	watchArgsForCall []struct {
+		arg1 context.Context
+		arg2 *models.WatchRequest
+		arg3 []grpc.CallOption
+	}
+	watchReturns struct {
+		result1 models.Locket_WatchClient
+		result2 error
+	}
+	watchReturnsOnCall map[int]struct {
+		result1 models.Locket_WatchClient
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -206,6 +355,204 @@ func (fake *FakeLocketClient) FetchAllReturnsOnCall(i int, result1 *models.Fetch
 	}{result1, result2}
 }
 
+func (fake *FakeLocketClient) ForceRelease(arg1 context.Context, arg2 *models.ForceReleaseRequest, arg3 ...grpc.CallOption) (*models.ForceReleaseResponse, error) {
+	fake.forceReleaseMutex.Lock()
+	ret, specificReturn := fake.forceReleaseReturnsOnCall[len(fake.forceReleaseArgsForCall)]
+	fake.forceReleaseArgsForCall = append(fake.forceReleaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 *models.ForceReleaseRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	stub := fake.ForceReleaseStub
+	fakeReturns := fake.forceReleaseReturns
+	fake.recordInvocation("ForceRelease", []interface{}{arg1, arg2, arg3})
+	fake.forceReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocketClient) ForceReleaseCallCount() int {
+	fake.forceReleaseMutex.RLock()
+	defer fake.forceReleaseMutex.RUnlock()
+	return len(fake.forceReleaseArgsForCall)
+}
+
+func (fake *FakeLocketClient) ForceReleaseCalls(stub func(context.Context, *models.ForceReleaseRequest, ...grpc.CallOption) (*models.ForceReleaseResponse, error)) {
+	fake.forceReleaseMutex.Lock()
+	defer fake.forceReleaseMutex.Unlock()
+	fake.ForceReleaseStub = stub
+}
+
+func (fake *FakeLocketClient) ForceReleaseArgsForCall(i int) (context.Context, *models.ForceReleaseRequest, []grpc.CallOption) {
+	fake.forceReleaseMutex.RLock()
+	defer fake.forceReleaseMutex.RUnlock()
+	argsForCall := fake.forceReleaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLocketClient) ForceReleaseReturns(result1 *models.ForceReleaseResponse, result2 error) {
+	fake.forceReleaseMutex.Lock()
+	defer fake.forceReleaseMutex.Unlock()
+	fake.ForceReleaseStub = nil
+	fake.forceReleaseReturns = struct {
+		result1 *models.ForceReleaseResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) ForceReleaseReturnsOnCall(i int, result1 *models.ForceReleaseResponse, result2 error) {
+	fake.forceReleaseMutex.Lock()
+	defer fake.forceReleaseMutex.Unlock()
+	fake.ForceReleaseStub = nil
+	if fake.forceReleaseReturnsOnCall == nil {
+		fake.forceReleaseReturnsOnCall = make(map[int]struct {
+			result1 *models.ForceReleaseResponse
+			result2 error
+		})
+	}
+	fake.forceReleaseReturnsOnCall[i] = struct {
+		result1 *models.ForceReleaseResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) GrantLease(arg1 context.Context, arg2 *models.GrantLeaseRequest, arg3 ...grpc.CallOption) (*models.GrantLeaseResponse, error) {
+	fake.grantLeaseMutex.Lock()
+	ret, specificReturn := fake.grantLeaseReturnsOnCall[len(fake.grantLeaseArgsForCall)]
+	fake.grantLeaseArgsForCall = append(fake.grantLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 *models.GrantLeaseRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	stub := fake.GrantLeaseStub
+	fakeReturns := fake.grantLeaseReturns
+	fake.recordInvocation("GrantLease", []interface{}{arg1, arg2, arg3})
+	fake.grantLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocketClient) GrantLeaseCallCount() int {
+	fake.grantLeaseMutex.RLock()
+	defer fake.grantLeaseMutex.RUnlock()
+	return len(fake.grantLeaseArgsForCall)
+}
+
+func (fake *FakeLocketClient) GrantLeaseCalls(stub func(context.Context, *models.GrantLeaseRequest, ...grpc.CallOption) (*models.GrantLeaseResponse, error)) {
+	fake.grantLeaseMutex.Lock()
+	defer fake.grantLeaseMutex.Unlock()
+	fake.GrantLeaseStub = stub
+}
+
+func (fake *FakeLocketClient) GrantLeaseArgsForCall(i int) (context.Context, *models.GrantLeaseRequest, []grpc.CallOption) {
+	fake.grantLeaseMutex.RLock()
+	defer fake.grantLeaseMutex.RUnlock()
+	argsForCall := fake.grantLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLocketClient) GrantLeaseReturns(result1 *models.GrantLeaseResponse, result2 error) {
+	fake.grantLeaseMutex.Lock()
+	defer fake.grantLeaseMutex.Unlock()
+	fake.GrantLeaseStub = nil
+	fake.grantLeaseReturns = struct {
+		result1 *models.GrantLeaseResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) GrantLeaseReturnsOnCall(i int, result1 *models.GrantLeaseResponse, result2 error) {
+	fake.grantLeaseMutex.Lock()
+	defer fake.grantLeaseMutex.Unlock()
+	fake.GrantLeaseStub = nil
+	if fake.grantLeaseReturnsOnCall == nil {
+		fake.grantLeaseReturnsOnCall = make(map[int]struct {
+			result1 *models.GrantLeaseResponse
+			result2 error
+		})
+	}
+	fake.grantLeaseReturnsOnCall[i] = struct {
+		result1 *models.GrantLeaseResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) KeepAliveLease(arg1 context.Context, arg2 *models.KeepAliveLeaseRequest, arg3 ...grpc.CallOption) (*models.KeepAliveLeaseResponse, error) {
+	fake.keepAliveLeaseMutex.Lock()
+	ret, specificReturn := fake.keepAliveLeaseReturnsOnCall[len(fake.keepAliveLeaseArgsForCall)]
+	fake.keepAliveLeaseArgsForCall = append(fake.keepAliveLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 *models.KeepAliveLeaseRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	stub := fake.KeepAliveLeaseStub
+	fakeReturns := fake.keepAliveLeaseReturns
+	fake.recordInvocation("KeepAliveLease", []interface{}{arg1, arg2, arg3})
+	fake.keepAliveLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocketClient) KeepAliveLeaseCallCount() int {
+	fake.keepAliveLeaseMutex.RLock()
+	defer fake.keepAliveLeaseMutex.RUnlock()
+	return len(fake.keepAliveLeaseArgsForCall)
+}
+
+func (fake *FakeLocketClient) KeepAliveLeaseCalls(stub func(context.Context, *models.KeepAliveLeaseRequest, ...grpc.CallOption) (*models.KeepAliveLeaseResponse, error)) {
+	fake.keepAliveLeaseMutex.Lock()
+	defer fake.keepAliveLeaseMutex.Unlock()
+	fake.KeepAliveLeaseStub = stub
+}
+
+func (fake *FakeLocketClient) KeepAliveLeaseArgsForCall(i int) (context.Context, *models.KeepAliveLeaseRequest, []grpc.CallOption) {
+	fake.keepAliveLeaseMutex.RLock()
+	defer fake.keepAliveLeaseMutex.RUnlock()
+	argsForCall := fake.keepAliveLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLocketClient) KeepAliveLeaseReturns(result1 *models.KeepAliveLeaseResponse, result2 error) {
+	fake.keepAliveLeaseMutex.Lock()
+	defer fake.keepAliveLeaseMutex.Unlock()
+	fake.KeepAliveLeaseStub = nil
+	fake.keepAliveLeaseReturns = struct {
+		result1 *models.KeepAliveLeaseResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) KeepAliveLeaseReturnsOnCall(i int, result1 *models.KeepAliveLeaseResponse, result2 error) {
+	fake.keepAliveLeaseMutex.Lock()
+	defer fake.keepAliveLeaseMutex.Unlock()
+	fake.KeepAliveLeaseStub = nil
+	if fake.keepAliveLeaseReturnsOnCall == nil {
+		fake.keepAliveLeaseReturnsOnCall = make(map[int]struct {
+			result1 *models.KeepAliveLeaseResponse
+			result2 error
+		})
+	}
+	fake.keepAliveLeaseReturnsOnCall[i] = struct {
+		result1 *models.KeepAliveLeaseResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeLocketClient) Lock(arg1 context.Context, arg2 *models.LockRequest, arg3 ...grpc.CallOption) (*models.LockResponse, error) {
 	fake.lockMutex.Lock()
 	ret, specificReturn := fake.lockReturnsOnCall[len(fake.lockArgsForCall)]
@@ -272,6 +619,138 @@ func (fake *FakeLocketClient) LockReturnsOnCall(i int, result1 *models.LockRespo
 	}{result1, result2}
 }
 
+func (fake *FakeLocketClient) LockBatch(arg1 context.Context, arg2 *models.LockBatchRequest, arg3 ...grpc.CallOption) (*models.LockBatchResponse, error) {
+	fake.lockBatchMutex.Lock()
+	ret, specificReturn := fake.lockBatchReturnsOnCall[len(fake.lockBatchArgsForCall)]
+	fake.lockBatchArgsForCall = append(fake.lockBatchArgsForCall, struct {
+		arg1 context.Context
+		arg2 *models.LockBatchRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	stub := fake.LockBatchStub
+	fakeReturns := fake.lockBatchReturns
+	fake.recordInvocation("LockBatch", []interface{}{arg1, arg2, arg3})
+	fake.lockBatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocketClient) LockBatchCallCount() int {
+	fake.lockBatchMutex.RLock()
+	defer fake.lockBatchMutex.RUnlock()
+	return len(fake.lockBatchArgsForCall)
+}
+
+func (fake *FakeLocketClient) LockBatchCalls(stub func(context.Context, *models.LockBatchRequest, ...grpc.CallOption) (*models.LockBatchResponse, error)) {
+	fake.lockBatchMutex.Lock()
+	defer fake.lockBatchMutex.Unlock()
+	fake.LockBatchStub = stub
+}
+
+func (fake *FakeLocketClient) LockBatchArgsForCall(i int) (context.Context, *models.LockBatchRequest, []grpc.CallOption) {
+	fake.lockBatchMutex.RLock()
+	defer fake.lockBatchMutex.RUnlock()
+	argsForCall := fake.lockBatchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLocketClient) LockBatchReturns(result1 *models.LockBatchResponse, result2 error) {
+	fake.lockBatchMutex.Lock()
+	defer fake.lockBatchMutex.Unlock()
+	fake.LockBatchStub = nil
+	fake.lockBatchReturns = struct {
+		result1 *models.LockBatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) LockBatchReturnsOnCall(i int, result1 *models.LockBatchResponse, result2 error) {
+	fake.lockBatchMutex.Lock()
+	defer fake.lockBatchMutex.Unlock()
+	fake.LockBatchStub = nil
+	if fake.lockBatchReturnsOnCall == nil {
+		fake.lockBatchReturnsOnCall = make(map[int]struct {
+			result1 *models.LockBatchResponse
+			result2 error
+		})
+	}
+	fake.lockBatchReturnsOnCall[i] = struct {
+		result1 *models.LockBatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) LockMulti(arg1 context.Context, arg2 *models.LockMultiRequest, arg3 ...grpc.CallOption) (*models.LockMultiResponse, error) {
+	fake.lockMultiMutex.Lock()
+	ret, specificReturn := fake.lockMultiReturnsOnCall[len(fake.lockMultiArgsForCall)]
+	fake.lockMultiArgsForCall = append(fake.lockMultiArgsForCall, struct {
+		arg1 context.Context
+		arg2 *models.LockMultiRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	stub := fake.LockMultiStub
+	fakeReturns := fake.lockMultiReturns
+	fake.recordInvocation("LockMulti", []interface{}{arg1, arg2, arg3})
+	fake.lockMultiMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocketClient) LockMultiCallCount() int {
+	fake.lockMultiMutex.RLock()
+	defer fake.lockMultiMutex.RUnlock()
+	return len(fake.lockMultiArgsForCall)
+}
+
+func (fake *FakeLocketClient) LockMultiCalls(stub func(context.Context, *models.LockMultiRequest, ...grpc.CallOption) (*models.LockMultiResponse, error)) {
+	fake.lockMultiMutex.Lock()
+	defer fake.lockMultiMutex.Unlock()
+	fake.LockMultiStub = stub
+}
+
+func (fake *FakeLocketClient) LockMultiArgsForCall(i int) (context.Context, *models.LockMultiRequest, []grpc.CallOption) {
+	fake.lockMultiMutex.RLock()
+	defer fake.lockMultiMutex.RUnlock()
+	argsForCall := fake.lockMultiArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLocketClient) LockMultiReturns(result1 *models.LockMultiResponse, result2 error) {
+	fake.lockMultiMutex.Lock()
+	defer fake.lockMultiMutex.Unlock()
+	fake.LockMultiStub = nil
+	fake.lockMultiReturns = struct {
+		result1 *models.LockMultiResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) LockMultiReturnsOnCall(i int, result1 *models.LockMultiResponse, result2 error) {
+	fake.lockMultiMutex.Lock()
+	defer fake.lockMultiMutex.Unlock()
+	fake.LockMultiStub = nil
+	if fake.lockMultiReturnsOnCall == nil {
+		fake.lockMultiReturnsOnCall = make(map[int]struct {
+			result1 *models.LockMultiResponse
+			result2 error
+		})
+	}
+	fake.lockMultiReturnsOnCall[i] = struct {
+		result1 *models.LockMultiResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeLocketClient) Release(arg1 context.Context, arg2 *models.ReleaseRequest, arg3 ...grpc.CallOption) (*models.ReleaseResponse, error) {
 	fake.releaseMutex.Lock()
 	ret, specificReturn := fake.releaseReturnsOnCall[len(fake.releaseArgsForCall)]
@@ -338,6 +817,335 @@ func (fake *FakeLocketClient) ReleaseReturnsOnCall(i int, result1 *models.Releas
 	}{result1, result2}
 }
 
+func (fake *FakeLocketClient) RevokeLease(arg1 context.Context, arg2 *models.RevokeLeaseRequest, arg3 ...grpc.CallOption) (*models.RevokeLeaseResponse, error) {
+	fake.revokeLeaseMutex.Lock()
+	ret, specificReturn := fake.revokeLeaseReturnsOnCall[len(fake.revokeLeaseArgsForCall)]
+	fake.revokeLeaseArgsForCall = append(fake.revokeLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 *models.RevokeLeaseRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeLeaseStub
+	fakeReturns := fake.revokeLeaseReturns
+	fake.recordInvocation("RevokeLease", []interface{}{arg1, arg2, arg3})
+	fake.revokeLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocketClient) RevokeLeaseCallCount() int {
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	return len(fake.revokeLeaseArgsForCall)
+}
+
+func (fake *FakeLocketClient) RevokeLeaseCalls(stub func(context.Context, *models.RevokeLeaseRequest, ...grpc.CallOption) (*models.RevokeLeaseResponse, error)) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = stub
+}
+
+func (fake *FakeLocketClient) RevokeLeaseArgsForCall(i int) (context.Context, *models.RevokeLeaseRequest, []grpc.CallOption) {
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	argsForCall := fake.revokeLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLocketClient) RevokeLeaseReturns(result1 *models.RevokeLeaseResponse, result2 error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = nil
+	fake.revokeLeaseReturns = struct {
+		result1 *models.RevokeLeaseResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) RevokeLeaseReturnsOnCall(i int, result1 *models.RevokeLeaseResponse, result2 error) {
+	fake.revokeLeaseMutex.Lock()
+	defer fake.revokeLeaseMutex.Unlock()
+	fake.RevokeLeaseStub = nil
+	if fake.revokeLeaseReturnsOnCall == nil {
+		fake.revokeLeaseReturnsOnCall = make(map[int]struct {
+			result1 *models.RevokeLeaseResponse
+			result2 error
+		})
+	}
+	fake.revokeLeaseReturnsOnCall[i] = struct {
+		result1 *models.RevokeLeaseResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) Session(arg1 context.Context, arg2 ...grpc.CallOption) (models.Locket_SessionClient, error) {
+	fake.sessionMutex.Lock()
+	ret, specificReturn := fake.sessionReturnsOnCall[len(fake.sessionArgsForCall)]
+	fake.sessionArgsForCall = append(fake.sessionArgsForCall, struct {
+		arg1 context.Context
+		arg2 []grpc.CallOption
+	}{arg1, arg2})
+	stub := fake.SessionStub
+	fakeReturns := fake.sessionReturns
+	fake.recordInvocation("Session", []interface{}{arg1, arg2})
+	fake.sessionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocketClient) SessionCallCount() int {
+	fake.sessionMutex.RLock()
+	defer fake.sessionMutex.RUnlock()
+	return len(fake.sessionArgsForCall)
+}
+
+func (fake *FakeLocketClient) SessionCalls(stub func(context.Context, ...grpc.CallOption) (models.Locket_SessionClient, error)) {
+	fake.sessionMutex.Lock()
+	defer fake.sessionMutex.Unlock()
+	fake.SessionStub = stub
+}
+
+func (fake *FakeLocketClient) SessionArgsForCall(i int) (context.Context, []grpc.CallOption) {
+	fake.sessionMutex.RLock()
+	defer fake.sessionMutex.RUnlock()
+	argsForCall := fake.sessionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLocketClient) SessionReturns(result1 models.Locket_SessionClient, result2 error) {
+	fake.sessionMutex.Lock()
+	defer fake.sessionMutex.Unlock()
+	fake.SessionStub = nil
+	fake.sessionReturns = struct {
+		result1 models.Locket_SessionClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) SessionReturnsOnCall(i int, result1 models.Locket_SessionClient, result2 error) {
+	fake.sessionMutex.Lock()
+	defer fake.sessionMutex.Unlock()
+	fake.SessionStub = nil
+	if fake.sessionReturnsOnCall == nil {
+		fake.sessionReturnsOnCall = make(map[int]struct {
+			result1 models.Locket_SessionClient
+			result2 error
+		})
+	}
+	fake.sessionReturnsOnCall[i] = struct {
+		result1 models.Locket_SessionClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) Transfer(arg1 context.Context, arg2 *models.TransferRequest, arg3 ...grpc.CallOption) (*models.TransferResponse, error) {
+	fake.transferMutex.Lock()
+	ret, specificReturn := fake.transferReturnsOnCall[len(fake.transferArgsForCall)]
+	fake.transferArgsForCall = append(fake.transferArgsForCall, struct {
+		arg1 context.Context
+		arg2 *models.TransferRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	stub := fake.TransferStub
+	fakeReturns := fake.transferReturns
+	fake.recordInvocation("Transfer", []interface{}{arg1, arg2, arg3})
+	fake.transferMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocketClient) TransferCallCount() int {
+	fake.transferMutex.RLock()
+	defer fake.transferMutex.RUnlock()
+	return len(fake.transferArgsForCall)
+}
+
+func (fake *FakeLocketClient) TransferCalls(stub func(context.Context, *models.TransferRequest, ...grpc.CallOption) (*models.TransferResponse, error)) {
+	fake.transferMutex.Lock()
+	defer fake.transferMutex.Unlock()
+	fake.TransferStub = stub
+}
+
+func (fake *FakeLocketClient) TransferArgsForCall(i int) (context.Context, *models.TransferRequest, []grpc.CallOption) {
+	fake.transferMutex.RLock()
+	defer fake.transferMutex.RUnlock()
+	argsForCall := fake.transferArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLocketClient) TransferReturns(result1 *models.TransferResponse, result2 error) {
+	fake.transferMutex.Lock()
+	defer fake.transferMutex.Unlock()
+	fake.TransferStub = nil
+	fake.transferReturns = struct {
+		result1 *models.TransferResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) TransferReturnsOnCall(i int, result1 *models.TransferResponse, result2 error) {
+	fake.transferMutex.Lock()
+	defer fake.transferMutex.Unlock()
+	fake.TransferStub = nil
+	if fake.transferReturnsOnCall == nil {
+		fake.transferReturnsOnCall = make(map[int]struct {
+			result1 *models.TransferResponse
+			result2 error
+		})
+	}
+	fake.transferReturnsOnCall[i] = struct {
+		result1 *models.TransferResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) Update(arg1 context.Context, arg2 *models.UpdateRequest, arg3 ...grpc.CallOption) (*models.UpdateResponse, error) {
+	fake.updateMutex.Lock()
+	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		arg1 context.Context
+		arg2 *models.UpdateRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateStub
+	fakeReturns := fake.updateReturns
+	fake.recordInvocation("Update", []interface{}{arg1, arg2, arg3})
+	fake.updateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocketClient) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *FakeLocketClient) UpdateCalls(stub func(context.Context, *models.UpdateRequest, ...grpc.CallOption) (*models.UpdateResponse, error)) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = stub
+}
+
+func (fake *FakeLocketClient) UpdateArgsForCall(i int) (context.Context, *models.UpdateRequest, []grpc.CallOption) {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	argsForCall := fake.updateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLocketClient) UpdateReturns(result1 *models.UpdateResponse, result2 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 *models.UpdateResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) UpdateReturnsOnCall(i int, result1 *models.UpdateResponse, result2 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	if fake.updateReturnsOnCall == nil {
+		fake.updateReturnsOnCall = make(map[int]struct {
+			result1 *models.UpdateResponse
+			result2 error
+		})
+	}
+	fake.updateReturnsOnCall[i] = struct {
+		result1 *models.UpdateResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) Watch(arg1 context.Context, arg2 *models.WatchRequest, arg3 ...grpc.CallOption) (models.Locket_WatchClient, error) {
+	fake.watchMutex.Lock()
+	ret, specificReturn := fake.watchReturnsOnCall[len(fake.watchArgsForCall)]
+	fake.watchArgsForCall = append(fake.watchArgsForCall, struct {
+		arg1 context.Context
+		arg2 *models.WatchRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	stub := fake.WatchStub
+	fakeReturns := fake.watchReturns
+	fake.recordInvocation("Watch", []interface{}{arg1, arg2, arg3})
+	fake.watchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocketClient) WatchCallCount() int {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	return len(fake.watchArgsForCall)
+}
+
+func (fake *FakeLocketClient) WatchCalls(stub func(context.Context, *models.WatchRequest, ...grpc.CallOption) (models.Locket_WatchClient, error)) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = stub
+}
+
+func (fake *FakeLocketClient) WatchArgsForCall(i int) (context.Context, *models.WatchRequest, []grpc.CallOption) {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	argsForCall := fake.watchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLocketClient) WatchReturns(result1 models.Locket_WatchClient, result2 error) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = nil
+	fake.watchReturns = struct {
+		result1 models.Locket_WatchClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocketClient) WatchReturnsOnCall(i int, result1 models.Locket_WatchClient, result2 error) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = nil
+	if fake.watchReturnsOnCall == nil {
+		fake.watchReturnsOnCall = make(map[int]struct {
+			result1 models.Locket_WatchClient
+			result2 error
+		})
+	}
+	fake.watchReturnsOnCall[i] = struct {
+		result1 models.Locket_WatchClient
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeLocketClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -345,10 +1153,30 @@ func (fake *FakeLocketClient) Invocations() map[string][][]interface{} {
 	defer fake.fetchMutex.RUnlock()
 	fake.fetchAllMutex.RLock()
 	defer fake.fetchAllMutex.RUnlock()
+	fake.forceReleaseMutex.RLock()
+	defer fake.forceReleaseMutex.RUnlock()
+	fake.grantLeaseMutex.RLock()
+	defer fake.grantLeaseMutex.RUnlock()
+	fake.keepAliveLeaseMutex.RLock()
+	defer fake.keepAliveLeaseMutex.RUnlock()
 	fake.lockMutex.RLock()
 	defer fake.lockMutex.RUnlock()
+	fake.lockBatchMutex.RLock()
+	defer fake.lockBatchMutex.RUnlock()
+	fake.lockMultiMutex.RLock()
+	defer fake.lockMultiMutex.RUnlock()
 	fake.releaseMutex.RLock()
 	defer fake.releaseMutex.RUnlock()
+	fake.revokeLeaseMutex.RLock()
+	defer fake.revokeLeaseMutex.RUnlock()
+	fake.sessionMutex.RLock()
+	defer fake.sessionMutex.RUnlock()
+	fake.transferMutex.RLock()
+	defer fake.transferMutex.RUnlock()
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NamespaceKeyPrefix prefixes the keys of the resources of a namespace once
+// qualified with the name of the namespace, which is how they are stored and
+// streamed internally. Keys of the default namespace that start with it are
+// qualified with an empty namespace name, see NamespacedKey.
+const NamespaceKeyPrefix = "namespaces/"
+
+// Namespace is a namespace registered by the operator. Resources of a
+// namespace have their own keys, and are counted and fetched separately from
+// the resources of the default namespace and of the other namespaces.
+type Namespace struct {
+	Name string `json:"name"`
+	// MaxResources limits the number of keys held in the namespace. Zero means
+	// unlimited.
+	MaxResources int `json:"max_resources,omitempty"`
+}
+
+// Namespaces holds the registered namespaces, by name.
+type Namespaces map[string]Namespace
+
+func NewNamespaces(namespaces []Namespace) (Namespaces, error) {
+	registered := Namespaces{}
+	for _, n := range namespaces {
+		if n.Name == "" {
+			return nil, fmt.Errorf("namespace without a name")
+		}
+		if strings.Contains(n.Name, "/") {
+			return nil, fmt.Errorf("namespace %q contains a slash", n.Name)
+		}
+		if _, found := registered[n.Name]; found {
+			return nil, fmt.Errorf("namespace %q is registered twice", n.Name)
+		}
+		if n.MaxResources < 0 {
+			return nil, fmt.Errorf("namespace %q has a negative resource limit", n.Name)
+		}
+		registered[n.Name] = n
+	}
+	return registered, nil
+}
+
+// Lookup returns the registered namespace with the given name. The default
+// namespace is never registered.
+func (n Namespaces) Lookup(name string) (Namespace, bool) {
+	namespace, found := n[name]
+	return namespace, found
+}
+
+// NamespacedKey qualifies the key with the namespace. Keys of the default
+// namespace are left as they are, unless they start with NamespaceKeyPrefix,
+// as older versions of locket allowed. Those are qualified with an empty
+// namespace name so that they cannot be mistaken for the keys of a namespace.
+func NamespacedKey(namespace, key string) string {
+	if namespace == "" && !strings.HasPrefix(key, NamespaceKeyPrefix) {
+		return key
+	}
+	return NamespaceKeyPrefix + namespace + "/" + key
+}
+
+// SplitNamespacedKey returns the namespace and the key of a key qualified by
+// NamespacedKey.
+func SplitNamespacedKey(namespacedKey string) (string, string) {
+	if !strings.HasPrefix(namespacedKey, NamespaceKeyPrefix) {
+		return "", namespacedKey
+	}
+
+	rest := strings.TrimPrefix(namespacedKey, NamespaceKeyPrefix)
+	i := strings.Index(rest, "/")
+	if i < 0 {
+		return "", namespacedKey
+	}
+	return rest[:i], rest[i+1:]
+}
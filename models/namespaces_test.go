@@ -0,0 +1,78 @@
+package models_test
+
+import (
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Namespaces", func() {
+	Describe("NewNamespaces", func() {
+		It("registers the namespaces by name", func() {
+			diego := models.Namespace{Name: "diego", MaxResources: 1000}
+			namespaces, err := models.NewNamespaces([]models.Namespace{diego})
+			Expect(err).NotTo(HaveOccurred())
+
+			namespace, found := namespaces.Lookup("diego")
+			Expect(found).To(BeTrue())
+			Expect(namespace).To(Equal(diego))
+
+			_, found = namespaces.Lookup("routing")
+			Expect(found).To(BeFalse())
+		})
+
+		It("rejects namespaces without a name", func() {
+			_, err := models.NewNamespaces([]models.Namespace{{}})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects namespaces containing a slash", func() {
+			_, err := models.NewNamespaces([]models.Namespace{{Name: "diego/cells"}})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects namespaces registered twice", func() {
+			_, err := models.NewNamespaces([]models.Namespace{{Name: "diego"}, {Name: "diego"}})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects namespaces with a negative resource limit", func() {
+			_, err := models.NewNamespaces([]models.Namespace{{Name: "diego", MaxResources: -1}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("NamespacedKey", func() {
+		It("qualifies the key with the namespace", func() {
+			Expect(models.NamespacedKey("diego", "cells/cell-1")).To(Equal("namespaces/diego/cells/cell-1"))
+		})
+
+		It("leaves the keys of the default namespace as they are", func() {
+			Expect(models.NamespacedKey("", "cells/cell-1")).To(Equal("cells/cell-1"))
+		})
+
+		It("qualifies the keys of the default namespace that start with the namespace prefix", func() {
+			Expect(models.NamespacedKey("", "namespaces/diego/cell-1")).To(Equal("namespaces//namespaces/diego/cell-1"))
+		})
+	})
+
+	Describe("SplitNamespacedKey", func() {
+		It("returns the namespace and the key", func() {
+			namespace, key := models.SplitNamespacedKey("namespaces/diego/cells/cell-1")
+			Expect(namespace).To(Equal("diego"))
+			Expect(key).To(Equal("cells/cell-1"))
+		})
+
+		It("returns the default namespace for keys that are not qualified", func() {
+			namespace, key := models.SplitNamespacedKey("cells/cell-1")
+			Expect(namespace).To(BeEmpty())
+			Expect(key).To(Equal("cells/cell-1"))
+		})
+
+		It("returns the default namespace for keys qualified with an empty namespace", func() {
+			namespace, key := models.SplitNamespacedKey(models.NamespacedKey("", "namespaces/diego/cell-1"))
+			Expect(namespace).To(BeEmpty())
+			Expect(key).To(Equal("namespaces/diego/cell-1"))
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package models
+
+import "fmt"
+
+// MaxPayloadLength is the largest payload size locket can be configured to
+// accept, the size of the payload column on MySQL, a MEDIUMBLOB.
+const MaxPayloadLength = 16*1024*1024 - 1
+
+// defaultMaxMessageSize is the limit of gRPC on the size of the messages it
+// receives, unless configured otherwise.
+const defaultMaxMessageSize = 4 * 1024 * 1024
+
+// messageOverhead leaves room in a message for the fields of a resource other
+// than its payload, its value and its labels, such as its key and owner.
+const messageOverhead = 64 * 1024
+
+// ValidateMaxPayloadSize returns an error when payloads of up to maxPayloadSize
+// bytes cannot be stored.
+func ValidateMaxPayloadSize(maxPayloadSize int) error {
+	if maxPayloadSize < 0 || maxPayloadSize > MaxPayloadLength {
+		return fmt.Errorf("max payload size %d is not between 0 and %d bytes", maxPayloadSize, MaxPayloadLength)
+	}
+	return nil
+}
+
+// MaxMessageSize returns the size limit of the gRPC messages carrying a
+// resource with a payload of up to maxPayloadSize bytes. It is never below the
+// default limit of gRPC, so that responses listing many resources without
+// their payloads, such as FetchAll, are not limited any further.
+func MaxMessageSize(maxPayloadSize int) int {
+	size := maxPayloadSize + MaxValueLength + MaxLabelsLength + messageOverhead
+	if size < defaultMaxMessageSize {
+		return defaultMaxMessageSize
+	}
+	return size
+}
@@ -0,0 +1,35 @@
+package models_test
+
+import (
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Payload", func() {
+	Describe("ValidateMaxPayloadSize", func() {
+		It("accepts sizes the payload column can store", func() {
+			Expect(models.ValidateMaxPayloadSize(1024 * 1024)).To(Succeed())
+			Expect(models.ValidateMaxPayloadSize(models.MaxPayloadLength)).To(Succeed())
+		})
+
+		It("rejects sizes larger than the payload column", func() {
+			Expect(models.ValidateMaxPayloadSize(models.MaxPayloadLength + 1)).NotTo(Succeed())
+		})
+
+		It("rejects negative sizes", func() {
+			Expect(models.ValidateMaxPayloadSize(-1)).NotTo(Succeed())
+		})
+	})
+
+	Describe("MaxMessageSize", func() {
+		It("leaves room for the payload and the other fields of a resource", func() {
+			Expect(models.MaxMessageSize(8 * 1024 * 1024)).To(BeNumerically(">", 8*1024*1024+models.MaxValueLength+models.MaxLabelsLength))
+		})
+
+		It("is never below the default limit of gRPC", func() {
+			Expect(models.MaxMessageSize(0)).To(Equal(4 * 1024 * 1024))
+			Expect(models.MaxMessageSize(1024 * 1024)).To(Equal(4 * 1024 * 1024))
+		})
+	})
+})
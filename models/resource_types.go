@@ -0,0 +1,60 @@
+package models
+
+import "fmt"
+
+// ResourceType is a type of resource registered by the operator in addition
+// to the built-in lock, presence and semaphore types. Resources of a
+// registered type are identified by the name of the type in their Type field,
+// and have a TypeCode of UNKNOWN. They are held exclusively, like locks.
+type ResourceType struct {
+	Name string `json:"name"`
+	// MinTTLInSeconds and MaxTTLInSeconds bound the TTL resources of the type
+	// can be acquired with. Zero means unbounded.
+	MinTTLInSeconds int64 `json:"min_ttl_in_seconds,omitempty"`
+	MaxTTLInSeconds int64 `json:"max_ttl_in_seconds,omitempty"`
+	// ActiveMetric and ExpiredMetric name the metrics emitted with the number
+	// of resources of the type currently held and expired so far. No metric is
+	// emitted when they are empty.
+	ActiveMetric  string `json:"active_metric,omitempty"`
+	ExpiredMetric string `json:"expired_metric,omitempty"`
+}
+
+func (t ResourceType) ValidTTL(ttl int64) bool {
+	if t.MinTTLInSeconds > 0 && ttl < t.MinTTLInSeconds {
+		return false
+	}
+	if t.MaxTTLInSeconds > 0 && ttl > t.MaxTTLInSeconds {
+		return false
+	}
+	return true
+}
+
+// ResourceTypes holds the registered resource types, by name.
+type ResourceTypes map[string]ResourceType
+
+func NewResourceTypes(types []ResourceType) (ResourceTypes, error) {
+	resourceTypes := ResourceTypes{}
+	for _, t := range types {
+		if t.Name == "" {
+			return nil, fmt.Errorf("resource type without a name")
+		}
+		if GetTypeCode(t.Name) != UNKNOWN {
+			return nil, fmt.Errorf("resource type %q is built-in", t.Name)
+		}
+		if _, found := resourceTypes[t.Name]; found {
+			return nil, fmt.Errorf("resource type %q is registered twice", t.Name)
+		}
+		if t.MaxTTLInSeconds > 0 && t.MinTTLInSeconds > t.MaxTTLInSeconds {
+			return nil, fmt.Errorf("resource type %q has a minimum ttl greater than its maximum ttl", t.Name)
+		}
+		resourceTypes[t.Name] = t
+	}
+	return resourceTypes, nil
+}
+
+// Lookup returns the registered type with the given name. Built-in types are
+// never registered.
+func (r ResourceTypes) Lookup(name string) (ResourceType, bool) {
+	t, found := r[name]
+	return t, found
+}
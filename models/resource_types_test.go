@@ -0,0 +1,58 @@
+package models_test
+
+import (
+	"code.cloudfoundry.org/locket/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceTypes", func() {
+	Describe("NewResourceTypes", func() {
+		It("registers the types by name", func() {
+			maintenanceWindow := models.ResourceType{Name: "maintenance-window", MaxTTLInSeconds: 3600}
+			resourceTypes, err := models.NewResourceTypes([]models.ResourceType{maintenanceWindow})
+			Expect(err).NotTo(HaveOccurred())
+
+			resourceType, found := resourceTypes.Lookup("maintenance-window")
+			Expect(found).To(BeTrue())
+			Expect(resourceType).To(Equal(maintenanceWindow))
+
+			_, found = resourceTypes.Lookup("migration")
+			Expect(found).To(BeFalse())
+		})
+
+		It("rejects types without a name", func() {
+			_, err := models.NewResourceTypes([]models.ResourceType{{}})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects built-in types", func() {
+			_, err := models.NewResourceTypes([]models.ResourceType{{Name: "presence"}})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects types registered twice", func() {
+			_, err := models.NewResourceTypes([]models.ResourceType{{Name: "migration"}, {Name: "migration"}})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects types with a minimum ttl greater than their maximum ttl", func() {
+			_, err := models.NewResourceTypes([]models.ResourceType{{Name: "migration", MinTTLInSeconds: 10, MaxTTLInSeconds: 5}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ValidTTL", func() {
+		It("checks the ttl against the bounds of the type", func() {
+			resourceType := models.ResourceType{Name: "migration", MinTTLInSeconds: 10, MaxTTLInSeconds: 60}
+			Expect(resourceType.ValidTTL(5)).To(BeFalse())
+			Expect(resourceType.ValidTTL(10)).To(BeTrue())
+			Expect(resourceType.ValidTTL(60)).To(BeTrue())
+			Expect(resourceType.ValidTTL(61)).To(BeFalse())
+		})
+
+		It("does not bound the ttl when the bounds are zero", func() {
+			Expect(models.ResourceType{Name: "migration"}.ValidTTL(100000)).To(BeTrue())
+		})
+	})
+})
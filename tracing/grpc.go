@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const clientInstrumentationName = "code.cloudfoundry.org/locket"
+
+// MetadataCarrier adapts gRPC metadata to the carrier of a propagator.
+type MetadataCarrier metadata.MD
+
+func (c MetadataCarrier) Get(key string) string {
+	vals := metadata.MD(c).Get(key)
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[0]
+}
+
+func (c MetadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Inject adds the trace context of ctx to its outgoing metadata.
+func Inject(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	Propagator.Inject(ctx, MetadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// Extract returns ctx with the trace context found in its incoming metadata,
+// if any.
+func Extract(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return Propagator.Extract(ctx, MetadataCarrier(md))
+}
+
+// RPCAttributes returns the span name and the attributes of a call to the
+// full method.
+func RPCAttributes(fullMethod string) (string, trace.SpanStartOption) {
+	method := strings.TrimPrefix(fullMethod, "/")
+	return method, trace.WithAttributes(semconv.RPCSystemNameGRPC, semconv.RPCMethod(method))
+}
+
+// UnaryClientInterceptor records a client span around each call, and passes
+// its trace context to the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		name, attributes := RPCAttributes(method)
+		ctx, span := otel.Tracer(clientInstrumentationName).Start(ctx, name, attributes, trace.WithSpanKind(trace.SpanKindClient))
+
+		err := invoker(Inject(ctx), method, req, reply, cc, opts...)
+		span.SetAttributes(semconv.RPCResponseStatusCode(status.Code(err).String()))
+		End(span, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor passes the trace context of the caller to the
+// server. Streams such as watches are open for as long as the client runs, so
+// the server records their spans and the client does not.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(Inject(ctx), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1 @@
+package tracing // import "code.cloudfoundry.org/locket/tracing"
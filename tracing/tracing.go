@@ -0,0 +1,110 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/tlsconfig"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// Propagator carries the trace context and the baggage of requests in their
+// W3C traceparent, tracestate and baggage headers. Clients and servers use it
+// whether or not the application configured a global propagator, so that
+// traces are not cut at locket.
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// Config is the configuration of the export of spans to an OpenTelemetry
+// collector over OTLP/gRPC.
+type Config struct {
+	// OTLPEndpoint is the host:port of the collector. No endpoint means no
+	// export.
+	OTLPEndpoint string `json:"otlp_endpoint,omitempty"`
+	// Insecure exports without TLS, e.g. to a collector on localhost.
+	Insecure bool `json:"insecure,omitempty"`
+	// CACertFile verifies the certificate of the collector instead of the
+	// system certificate pool.
+	CACertFile string `json:"ca_cert_file,omitempty"`
+	// SampleRatio is the ratio of the traces started by locket that are
+	// sampled, zero meaning all of them. Traces started by clients follow
+	// the sampling decision of the client.
+	SampleRatio float64 `json:"sample_ratio,omitempty"`
+}
+
+var ErrMissingOTLPEndpoint = errors.New("missing otlp endpoint")
+var ErrInvalidSampleRatio = errors.New("sample ratio must be between 0 and 1")
+
+// NewTracerProvider returns a tracer provider exporting the spans of the
+// service in batches to the collector of the config. It has to be shut down
+// to flush the remaining spans.
+func NewTracerProvider(ctx context.Context, serviceName string, config Config) (*sdktrace.TracerProvider, error) {
+	if config.OTLPEndpoint == "" {
+		return nil, ErrMissingOTLPEndpoint
+	}
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, ErrInvalidSampleRatio
+	}
+
+	options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.OTLPEndpoint)}
+	if config.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	} else {
+		var authority []tlsconfig.ClientOption
+		if config.CACertFile != "" {
+			authority = append(authority, tlsconfig.WithAuthorityFromFile(config.CACertFile))
+		}
+		tlsConfig, err := tlsconfig.Build(tlsconfig.WithInternalServiceDefaults()).Client(authority...)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sampleRatio := config.SampleRatio
+	if sampleRatio == 0 {
+		sampleRatio = 1
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	), nil
+}
+
+// End ends the span of an operation that returned err. Lock collisions and
+// modified index mismatches are recorded on the span, but they are expected
+// outcomes of contended requests and do not mark it as failed.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if err != models.ErrLockCollision && err != models.ErrModifiedIndexMismatch {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
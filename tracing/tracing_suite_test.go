@@ -0,0 +1,28 @@
+package tracing_test
+
+import (
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+var spanExporter *tracetest.InMemoryExporter
+
+var _ = BeforeSuite(func() {
+	spanExporter = tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter)))
+})
+
+var _ = BeforeEach(func() {
+	spanExporter.Reset()
+})
+
+func TestTracing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tracing Suite")
+}
@@ -0,0 +1,170 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/tracing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var _ = Describe("Tracing", func() {
+	Describe("UnaryClientInterceptor", func() {
+		var outgoing metadata.MD
+
+		invoke := func(ctx context.Context, invokeErr error) error {
+			return tracing.UnaryClientInterceptor()(ctx, "/models.Locket/Lock", &models.LockRequest{}, &models.LockResponse{}, nil,
+				func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+					outgoing, _ = metadata.FromOutgoingContext(ctx)
+					return invokeErr
+				})
+		}
+
+		It("records a client span and passes its trace context to the server", func() {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "uuid", "some-request-id")
+			Expect(invoke(ctx, nil)).To(Succeed())
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("models.Locket/Lock"))
+			Expect(spans[0].SpanKind).To(Equal(trace.SpanKindClient))
+
+			Expect(outgoing.Get("uuid")).To(ConsistOf("some-request-id"))
+			serverCtx := tracing.Extract(metadata.NewIncomingContext(context.Background(), outgoing))
+			remote := trace.SpanContextFromContext(serverCtx)
+			Expect(remote.IsRemote()).To(BeTrue())
+			Expect(remote.TraceID()).To(Equal(spans[0].SpanContext.TraceID()))
+			Expect(remote.SpanID()).To(Equal(spans[0].SpanContext.SpanID()))
+		})
+
+		It("continues the trace of the caller", func() {
+			ctx, parent := otel.Tracer("test").Start(context.Background(), "bbs-request")
+			Expect(invoke(ctx, nil)).To(Succeed())
+			parent.End()
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].Parent.SpanID()).To(Equal(parent.SpanContext().SpanID()))
+		})
+
+		It("marks the span of failed calls as failed", func() {
+			Expect(invoke(context.Background(), errors.New("boom"))).To(MatchError("boom"))
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Status.Code).To(Equal(codes.Error))
+			Expect(spans[0].Status.Description).To(Equal("boom"))
+		})
+	})
+
+	Describe("End", func() {
+		It("does not mark the span of lock collisions as failed", func() {
+			_, span := otel.Tracer("test").Start(context.Background(), "lock")
+			tracing.End(span, models.ErrLockCollision)
+
+			spans := spanExporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Status.Code).To(Equal(codes.Unset))
+			Expect(spans[0].Events).To(HaveLen(1))
+		})
+	})
+
+	Describe("NewTracerProvider", func() {
+		var (
+			collector *fakeCollector
+			server    *grpc.Server
+			config    tracing.Config
+		)
+
+		BeforeEach(func() {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+
+			collector = &fakeCollector{}
+			server = grpc.NewServer()
+			collectortrace.RegisterTraceServiceServer(server, collector)
+			go server.Serve(lis)
+
+			config = tracing.Config{OTLPEndpoint: lis.Addr().String(), Insecure: true}
+		})
+
+		AfterEach(func() {
+			server.Stop()
+		})
+
+		It("exports the spans to the collector over OTLP", func() {
+			tracerProvider, err := tracing.NewTracerProvider(context.Background(), "locket", config)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, span := tracerProvider.Tracer("test").Start(context.Background(), "models.Locket/Lock")
+			span.End()
+			Expect(tracerProvider.Shutdown(context.Background())).To(Succeed())
+
+			requests := collector.requests()
+			Expect(requests).To(HaveLen(1))
+			resourceSpans := requests[0].ResourceSpans
+			Expect(resourceSpans).To(HaveLen(1))
+
+			var serviceName string
+			for _, attr := range resourceSpans[0].Resource.Attributes {
+				if attr.Key == "service.name" {
+					serviceName = attr.Value.GetStringValue()
+				}
+			}
+			Expect(serviceName).To(Equal("locket"))
+			Expect(resourceSpans[0].ScopeSpans[0].Spans[0].Name).To(Equal("models.Locket/Lock"))
+		})
+
+		It("requires an endpoint", func() {
+			_, err := tracing.NewTracerProvider(context.Background(), "locket", tracing.Config{})
+			Expect(err).To(Equal(tracing.ErrMissingOTLPEndpoint))
+		})
+
+		It("requires a sample ratio between 0 and 1", func() {
+			config.SampleRatio = 1.5
+			_, err := tracing.NewTracerProvider(context.Background(), "locket", config)
+			Expect(err).To(Equal(tracing.ErrInvalidSampleRatio))
+		})
+
+		Context("when the ca cert file does not exist", func() {
+			It("returns an error", func() {
+				config.Insecure = false
+				config.CACertFile = "/does/not/exist"
+				_, err := tracing.NewTracerProvider(context.Background(), "locket", config)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
+
+// fakeCollector stands in for an OpenTelemetry collector, keeping the export
+// requests it receives.
+type fakeCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	lock     sync.Mutex
+	received []*collectortrace.ExportTraceServiceRequest
+}
+
+func (c *fakeCollector) Export(ctx context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.received = append(c.received, req)
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func (c *fakeCollector) requests() []*collectortrace.ExportTraceServiceRequest {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.received
+}
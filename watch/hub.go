@@ -0,0 +1,324 @@
+package watch
+
+import (
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/db"
+	"code.cloudfoundry.org/locket/models"
+)
+
+const DefaultHistorySize = 1024
+
+//go:generate counterfeiter . Hub
+type Hub interface {
+	Upsert(logger lager.Logger, lock *db.Lock)
+	Remove(logger lager.Logger, resource *models.Resource, eventType models.EventType)
+	Sync(logger lager.Logger, fetch func() ([]*db.Lock, error)) error
+	Subscribe(filter Filter, startRevision int64) (Subscription, error)
+}
+
+//go:generate counterfeiter . Subscription
+type Subscription interface {
+	Events() <-chan *models.WatchEvent
+	Err() error
+	Close()
+}
+
+type Filter struct {
+	// Namespace restricts the events to the resources of the namespace, the
+	// keys of the filter are qualified with it.
+	Namespace string
+	Key       string
+	KeyPrefix string
+	TypeCode  models.TypeCode
+	Type      string
+}
+
+func (f Filter) Matches(resource *models.Resource) bool {
+	if namespace, _ := models.SplitNamespacedKey(resource.Key); namespace != f.Namespace {
+		return false
+	}
+	if f.Key != "" && resource.Key != f.Key {
+		return false
+	}
+	if f.KeyPrefix != "" && !strings.HasPrefix(resource.Key, f.KeyPrefix) {
+		return false
+	}
+	if f.TypeCode != models.UNKNOWN && models.GetResource(resource).TypeCode != f.TypeCode {
+		return false
+	}
+	if f.TypeCode == models.UNKNOWN && f.Type != "" && models.GetResource(resource).Type != f.Type {
+		return false
+	}
+	return true
+}
+
+// Streamed reports whether the changes of a resource held in the mode are
+// streamed to watchers. Exclusive holders are, as are the holders of a
+// semaphore, which is only held in shared mode. The other shared holders are
+// not, the key stays unowned while it is held in shared mode.
+func Streamed(resource *models.Resource, mode models.LockMode) bool {
+	return mode != models.SHARED || models.GetResource(resource).TypeCode == models.SEMAPHORE
+}
+
+// resourceID identifies a resource in the hub. The holders of a semaphore
+// share its key, so they are told apart by their owner.
+func resourceID(resource *models.Resource) string {
+	if models.GetResource(resource).TypeCode == models.SEMAPHORE {
+		return resource.Key + "\x00" + resource.Owner
+	}
+	return resource.Key
+}
+
+type knownResource struct {
+	lock    *db.Lock
+	touched uint64
+}
+
+type hub struct {
+	lock *sync.Mutex
+
+	historySize int
+	revision    int64
+	history     []*models.WatchEvent
+
+	seeded      bool
+	touches     uint64
+	resources   map[string]knownResource
+	tombstones  map[string]uint64
+	subscribers map[*subscription]struct{}
+}
+
+// NewHub returns a Hub that keeps the last historySize events so that
+// watchers can resume from a recent revision. Subscribers that fall more than
+// historySize events behind are disconnected. Revisions are numbered from
+// initialRevision; seeding it with the start time keeps revisions handed out
+// by different or restarted instances from overlapping.
+func NewHub(historySize int, initialRevision int64) Hub {
+	return &hub{
+		lock:        &sync.Mutex{},
+		historySize: historySize,
+		revision:    initialRevision,
+		resources:   make(map[string]knownResource),
+		tombstones:  make(map[string]uint64),
+		subscribers: make(map[*subscription]struct{}),
+	}
+}
+
+func (h *hub) Upsert(logger lager.Logger, lock *db.Lock) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.touches++
+	delete(h.tombstones, resourceID(lock.Resource))
+	h.upsert(logger, lock, h.touches)
+}
+
+func (h *hub) Remove(logger lager.Logger, resource *models.Resource, eventType models.EventType) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.touches++
+	id := resourceID(resource)
+	h.tombstones[id] = h.touches
+
+	known, ok := h.resources[id]
+	if !ok {
+		return
+	}
+	delete(h.resources, id)
+	h.publish(logger, eventType, known.lock.Resource)
+}
+
+// Sync reconciles the hub with a snapshot of the streamed locks returned by
+// fetch. Changes made by other locket instances are only observed this way.
+// Keys that were changed locally while the snapshot was being fetched are
+// left alone, since the snapshot may predate those changes.
+func (h *hub) Sync(logger lager.Logger, fetch func() ([]*db.Lock, error)) error {
+	logger = logger.Session("sync")
+
+	h.lock.Lock()
+	since := h.touches
+	h.lock.Unlock()
+
+	locks, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	publish := h.seeded
+	h.seeded = true
+
+	fetched := make(map[string]struct{}, len(locks))
+	for _, lock := range locks {
+		id := resourceID(lock.Resource)
+		fetched[id] = struct{}{}
+
+		if known, ok := h.resources[id]; ok && known.touched > since {
+			continue
+		}
+		if touched, ok := h.tombstones[id]; ok && touched > since {
+			continue
+		}
+
+		if !publish {
+			h.resources[id] = knownResource{lock: withoutPayload(lock), touched: since}
+			continue
+		}
+		h.upsert(logger, lock, since)
+	}
+
+	for id, known := range h.resources {
+		if _, ok := fetched[id]; ok || known.touched > since {
+			continue
+		}
+		delete(h.resources, id)
+		h.publish(logger, models.DELETED, known.lock.Resource)
+	}
+
+	for id, touched := range h.tombstones {
+		if touched <= since {
+			delete(h.tombstones, id)
+		}
+	}
+
+	return nil
+}
+
+func (h *hub) Subscribe(filter Filter, startRevision int64) (Subscription, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	sub := &subscription{
+		hub:    h,
+		filter: filter,
+		events: make(chan *models.WatchEvent, h.historySize),
+	}
+
+	if startRevision > 0 {
+		if startRevision > h.revision+1 || startRevision < h.revision+1-int64(len(h.history)) {
+			return nil, models.ErrRevisionCompacted
+		}
+
+		for _, event := range h.history {
+			if event.Revision >= startRevision && filter.Matches(event.Resource) {
+				sub.events <- event
+			}
+		}
+	}
+
+	h.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+func (h *hub) upsert(logger lager.Logger, lock *db.Lock, touched uint64) {
+	lock = withoutPayload(lock)
+	id := resourceID(lock.Resource)
+	known, ok := h.resources[id]
+	if ok && known.lock.ModifiedId == lock.ModifiedId && known.lock.ModifiedIndex > lock.ModifiedIndex {
+		return
+	}
+	h.resources[id] = knownResource{lock: lock, touched: touched}
+
+	if !ok {
+		h.publish(logger, models.CREATED, lock.Resource)
+		return
+	}
+
+	if known.lock.Owner != lock.Owner || known.lock.Value != lock.Value || known.lock.Type != lock.Type || !labelsEqual(known.lock.Labels, lock.Labels) {
+		h.publish(logger, models.UPDATED, lock.Resource)
+	}
+}
+
+// withoutPayload returns the lock without its payload. Payloads can be many
+// megabytes large, so the hub neither keeps them in memory nor streams them,
+// as with FetchAll.
+func withoutPayload(lock *db.Lock) *db.Lock {
+	if lock.Payload == nil {
+		return lock
+	}
+
+	resource := *lock.Resource
+	resource.Payload = nil
+	stripped := *lock
+	stripped.Resource = &resource
+	return &stripped
+}
+
+func labelsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *hub) publish(logger lager.Logger, eventType models.EventType, resource *models.Resource) {
+	h.revision++
+	event := &models.WatchEvent{
+		Type:     eventType,
+		Resource: models.GetResource(resource),
+		Revision: h.revision,
+	}
+
+	logger.Debug("publishing-event", lager.Data{"key": resource.Key, "event-type": eventType, "revision": event.Revision})
+
+	h.history = append(h.history, event)
+	if len(h.history) > h.historySize {
+		h.history = h.history[len(h.history)-h.historySize:]
+	}
+
+	for sub := range h.subscribers {
+		if !sub.filter.Matches(event.Resource) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			logger.Info("disconnecting-slow-watcher", lager.Data{"revision": event.Revision})
+			h.unsubscribe(sub, models.ErrWatcherTooSlow)
+		}
+	}
+}
+
+func (h *hub) unsubscribe(sub *subscription, err error) {
+	if _, ok := h.subscribers[sub]; !ok {
+		return
+	}
+	delete(h.subscribers, sub)
+	sub.err = err
+	close(sub.events)
+}
+
+type subscription struct {
+	hub    *hub
+	filter Filter
+	events chan *models.WatchEvent
+	err    error
+}
+
+func (s *subscription) Events() <-chan *models.WatchEvent {
+	return s.events
+}
+
+func (s *subscription) Err() error {
+	s.hub.lock.Lock()
+	defer s.hub.lock.Unlock()
+	return s.err
+}
+
+func (s *subscription) Close() {
+	s.hub.lock.Lock()
+	defer s.hub.lock.Unlock()
+	s.hub.unsubscribe(s, nil)
+}
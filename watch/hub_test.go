@@ -0,0 +1,394 @@
+package watch_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/v3/lagertest"
+	"code.cloudfoundry.org/locket/db"
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/watch"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hub", func() {
+	var (
+		logger *lagertest.TestLogger
+		hub    watch.Hub
+		sub    watch.Subscription
+
+		lock, presence *db.Lock
+	)
+
+	receiveEvent := func(sub watch.Subscription) *models.WatchEvent {
+		var event *models.WatchEvent
+		EventuallyWithOffset(1, sub.Events()).Should(Receive(&event))
+		return event
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("hub")
+		hub = watch.NewHub(4, 0)
+
+		lock = &db.Lock{
+			Resource: &models.Resource{
+				Key:      "cells/lock",
+				Owner:    "bbs-1",
+				Value:    "value",
+				Type:     models.LockType,
+				TypeCode: models.LOCK,
+			},
+			TtlInSeconds:  15,
+			ModifiedIndex: 1,
+			ModifiedId:    "guid",
+		}
+
+		presence = &db.Lock{
+			Resource: &models.Resource{
+				Key:      "cells/cell-1",
+				Owner:    "cell-1",
+				Value:    "{}",
+				Type:     models.PresenceType,
+				TypeCode: models.PRESENCE,
+			},
+			TtlInSeconds:  15,
+			ModifiedIndex: 1,
+			ModifiedId:    "other-guid",
+		}
+
+		var err error
+		sub, err = hub.Subscribe(watch.Filter{}, 0)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		sub.Close()
+	})
+
+	Describe("Upsert", func() {
+		It("publishes a created event for a new resource", func() {
+			hub.Upsert(logger, lock)
+
+			event := receiveEvent(sub)
+			Expect(event.Type).To(Equal(models.CREATED))
+			Expect(event.Resource).To(Equal(lock.Resource))
+			Expect(event.Revision).To(BeEquivalentTo(1))
+		})
+
+		Context("when the resource is already known", func() {
+			BeforeEach(func() {
+				hub.Upsert(logger, lock)
+				receiveEvent(sub)
+			})
+
+			It("does not publish an event when only the modified index changes", func() {
+				refreshed := *lock
+				refreshed.ModifiedIndex = 2
+				hub.Upsert(logger, &refreshed)
+
+				Consistently(sub.Events()).ShouldNot(Receive())
+			})
+
+			It("publishes an updated event when the value changes", func() {
+				updated := *lock
+				updated.Resource = &models.Resource{Key: lock.Key, Owner: lock.Owner, Value: "new-value", TypeCode: models.LOCK}
+				updated.ModifiedIndex = 2
+				hub.Upsert(logger, &updated)
+
+				event := receiveEvent(sub)
+				Expect(event.Type).To(Equal(models.UPDATED))
+				Expect(event.Resource.Value).To(Equal("new-value"))
+				Expect(event.Revision).To(BeEquivalentTo(2))
+			})
+
+			It("publishes an updated event when the labels change", func() {
+				updated := *lock
+				updated.Resource = &models.Resource{Key: lock.Key, Owner: lock.Owner, Value: lock.Value, TypeCode: models.LOCK, Labels: map[string]string{"zone": "z1"}}
+				updated.ModifiedIndex = 2
+				hub.Upsert(logger, &updated)
+
+				event := receiveEvent(sub)
+				Expect(event.Type).To(Equal(models.UPDATED))
+				Expect(event.Resource.Labels).To(Equal(map[string]string{"zone": "z1"}))
+			})
+
+			It("does not publish an event when only the payload changes", func() {
+				updated := *lock
+				updated.Resource = &models.Resource{Key: lock.Key, Owner: lock.Owner, Value: lock.Value, Type: models.LockType, TypeCode: models.LOCK, Payload: []byte("manifest")}
+				updated.ModifiedIndex = 2
+				hub.Upsert(logger, &updated)
+
+				Consistently(sub.Events()).ShouldNot(Receive())
+			})
+
+			It("ignores stale updates of the same row", func() {
+				newer := *lock
+				newer.ModifiedIndex = 3
+				hub.Upsert(logger, &newer)
+
+				stale := *lock
+				stale.Resource = &models.Resource{Key: lock.Key, Owner: lock.Owner, Value: "stale", TypeCode: models.LOCK}
+				stale.ModifiedIndex = 2
+				hub.Upsert(logger, &stale)
+
+				Consistently(sub.Events()).ShouldNot(Receive())
+			})
+		})
+	})
+
+	Describe("Remove", func() {
+		It("publishes an event of the given type for a known resource", func() {
+			hub.Upsert(logger, presence)
+			receiveEvent(sub)
+
+			hub.Remove(logger, &models.Resource{Key: presence.Key}, models.EXPIRED)
+
+			event := receiveEvent(sub)
+			Expect(event.Type).To(Equal(models.EXPIRED))
+			Expect(event.Resource).To(Equal(presence.Resource))
+		})
+
+		It("does not publish anything for an unknown resource", func() {
+			hub.Remove(logger, lock.Resource, models.DELETED)
+			Consistently(sub.Events()).ShouldNot(Receive())
+		})
+	})
+
+	Context("when the resources have a payload", func() {
+		BeforeEach(func() {
+			lock.Payload = []byte("manifest")
+		})
+
+		It("does not stream or keep the payload of upserted resources", func() {
+			hub.Upsert(logger, lock)
+			event := receiveEvent(sub)
+			Expect(event.Resource.Payload).To(BeNil())
+			Expect(lock.Payload).To(Equal([]byte("manifest")))
+
+			replay, err := hub.Subscribe(watch.Filter{}, event.Revision)
+			Expect(err).NotTo(HaveOccurred())
+			defer replay.Close()
+			Expect(receiveEvent(replay).Resource.Payload).To(BeNil())
+
+			hub.Remove(logger, &models.Resource{Key: lock.Key}, models.DELETED)
+			Expect(receiveEvent(sub).Resource.Payload).To(BeNil())
+		})
+
+		It("does not keep the payload of synced resources", func() {
+			Expect(hub.Sync(logger, func() ([]*db.Lock, error) { return []*db.Lock{lock}, nil })).To(Succeed())
+			Expect(lock.Payload).To(Equal([]byte("manifest")))
+
+			hub.Remove(logger, &models.Resource{Key: lock.Key}, models.DELETED)
+			Expect(receiveEvent(sub).Resource.Payload).To(BeNil())
+
+			synced := *presence
+			synced.Resource = &models.Resource{Key: presence.Key, Owner: presence.Owner, TypeCode: models.PRESENCE, Payload: []byte("manifest")}
+			Expect(hub.Sync(logger, func() ([]*db.Lock, error) { return []*db.Lock{&synced}, nil })).To(Succeed())
+			Expect(receiveEvent(sub).Resource.Payload).To(BeNil())
+		})
+	})
+
+	Context("when the resources are holders of a semaphore", func() {
+		var holder, otherHolder *db.Lock
+
+		BeforeEach(func() {
+			holder = &db.Lock{
+				Resource: &models.Resource{Key: "uploads", Owner: "cell-1", Type: models.SemaphoreType, TypeCode: models.SEMAPHORE},
+				Mode:     models.SHARED,
+			}
+			otherHolder = &db.Lock{
+				Resource: &models.Resource{Key: "uploads", Owner: "cell-2", Type: models.SemaphoreType, TypeCode: models.SEMAPHORE},
+				Mode:     models.SHARED,
+			}
+		})
+
+		It("tells the holders of the key apart by their owner", func() {
+			hub.Upsert(logger, holder)
+			Expect(receiveEvent(sub).Type).To(Equal(models.CREATED))
+
+			hub.Upsert(logger, otherHolder)
+			event := receiveEvent(sub)
+			Expect(event.Type).To(Equal(models.CREATED))
+			Expect(event.Resource.Owner).To(Equal("cell-2"))
+
+			hub.Remove(logger, holder.Resource, models.EXPIRED)
+			event = receiveEvent(sub)
+			Expect(event.Type).To(Equal(models.EXPIRED))
+			Expect(event.Resource.Owner).To(Equal("cell-1"))
+
+			Expect(hub.Sync(logger, func() ([]*db.Lock, error) { return []*db.Lock{otherHolder}, nil })).To(Succeed())
+			Consistently(sub.Events()).ShouldNot(Receive())
+		})
+	})
+
+	Describe("Streamed", func() {
+		It("streams exclusive holders and semaphore holders", func() {
+			Expect(watch.Streamed(lock.Resource, models.EXCLUSIVE)).To(BeTrue())
+			Expect(watch.Streamed(&models.Resource{TypeCode: models.SEMAPHORE}, models.SHARED)).To(BeTrue())
+			Expect(watch.Streamed(lock.Resource, models.SHARED)).To(BeFalse())
+		})
+	})
+
+	Describe("Sync", func() {
+		fetch := func(locks ...*db.Lock) func() ([]*db.Lock, error) {
+			return func() ([]*db.Lock, error) {
+				return locks, nil
+			}
+		}
+
+		It("seeds the hub on the first sync without publishing events", func() {
+			Expect(hub.Sync(logger, fetch(lock, presence))).To(Succeed())
+			Consistently(sub.Events()).ShouldNot(Receive())
+
+			hub.Remove(logger, lock.Resource, models.DELETED)
+			event := receiveEvent(sub)
+			Expect(event.Type).To(Equal(models.DELETED))
+		})
+
+		Context("after the hub has been seeded", func() {
+			BeforeEach(func() {
+				Expect(hub.Sync(logger, fetch(lock))).To(Succeed())
+			})
+
+			It("publishes events for resources changed by other instances", func() {
+				Expect(hub.Sync(logger, fetch(presence))).To(Succeed())
+
+				events := []*models.WatchEvent{receiveEvent(sub), receiveEvent(sub)}
+				Expect(events).To(ConsistOf(
+					&models.WatchEvent{Type: models.CREATED, Resource: presence.Resource, Revision: events[0].Revision},
+					&models.WatchEvent{Type: models.DELETED, Resource: lock.Resource, Revision: events[1].Revision},
+				))
+			})
+
+			It("does not undo local changes made while fetching", func() {
+				err := hub.Sync(logger, func() ([]*db.Lock, error) {
+					hub.Remove(logger, lock.Resource, models.DELETED)
+					hub.Upsert(logger, presence)
+					return []*db.Lock{lock}, nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(receiveEvent(sub).Type).To(Equal(models.DELETED))
+				Expect(receiveEvent(sub).Type).To(Equal(models.CREATED))
+				Consistently(sub.Events()).ShouldNot(Receive())
+			})
+
+			It("returns the fetch error", func() {
+				err := hub.Sync(logger, func() ([]*db.Lock, error) {
+					return nil, errors.New("boom")
+				})
+				Expect(err).To(MatchError("boom"))
+			})
+		})
+	})
+
+	Describe("Subscribe", func() {
+		It("only delivers events matching the filter", func() {
+			filtered, err := hub.Subscribe(watch.Filter{KeyPrefix: "cells/", TypeCode: models.PRESENCE}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			defer filtered.Close()
+
+			hub.Upsert(logger, lock)
+			hub.Upsert(logger, presence)
+
+			event := receiveEvent(filtered)
+			Expect(event.Resource).To(Equal(presence.Resource))
+			Consistently(filtered.Events()).ShouldNot(Receive())
+		})
+
+		It("filters user-defined types by name", func() {
+			filtered, err := hub.Subscribe(watch.Filter{Type: "maintenance-window"}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			defer filtered.Close()
+
+			window := &db.Lock{
+				Resource:      &models.Resource{Key: "windows/az1", Owner: "operator", Type: "maintenance-window"},
+				ModifiedIndex: 1,
+			}
+
+			hub.Upsert(logger, lock)
+			hub.Upsert(logger, window)
+
+			event := receiveEvent(filtered)
+			Expect(event.Resource.Key).To(Equal("windows/az1"))
+			Consistently(filtered.Events()).ShouldNot(Receive())
+		})
+
+		It("only delivers events of the namespace of the filter", func() {
+			filtered, err := hub.Subscribe(watch.Filter{Namespace: "diego"}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			defer filtered.Close()
+
+			namespaced := &db.Lock{
+				Resource:      &models.Resource{Key: "namespaces/diego/cells/lock", Owner: "bbs-1", TypeCode: models.LOCK},
+				ModifiedIndex: 1,
+			}
+
+			hub.Upsert(logger, lock)
+			hub.Upsert(logger, namespaced)
+
+			event := receiveEvent(filtered)
+			Expect(event.Resource.Key).To(Equal("namespaces/diego/cells/lock"))
+			Consistently(filtered.Events()).ShouldNot(Receive())
+
+			Expect(receiveEvent(sub).Resource.Key).To(Equal("cells/lock"))
+			Consistently(sub.Events()).ShouldNot(Receive())
+		})
+
+		Context("when resuming from a revision", func() {
+			BeforeEach(func() {
+				hub.Upsert(logger, lock)
+				hub.Upsert(logger, presence)
+				hub.Remove(logger, lock.Resource, models.DELETED)
+			})
+
+			It("replays the retained events starting at that revision", func() {
+				resumed, err := hub.Subscribe(watch.Filter{}, 2)
+				Expect(err).NotTo(HaveOccurred())
+				defer resumed.Close()
+
+				Expect(receiveEvent(resumed).Revision).To(BeEquivalentTo(2))
+				Expect(receiveEvent(resumed).Revision).To(BeEquivalentTo(3))
+
+				hub.Remove(logger, presence.Resource, models.EXPIRED)
+				Expect(receiveEvent(resumed).Revision).To(BeEquivalentTo(4))
+			})
+
+			It("returns an error when the revision is no longer retained", func() {
+				other := *presence
+				other.Resource = &models.Resource{Key: "other", Owner: "o"}
+				hub.Upsert(logger, &other)
+				hub.Remove(logger, other.Resource, models.DELETED)
+
+				_, err := hub.Subscribe(watch.Filter{}, 1)
+				Expect(err).To(Equal(models.ErrRevisionCompacted))
+			})
+
+			It("returns an error when the revision is in the future", func() {
+				_, err := hub.Subscribe(watch.Filter{}, 5)
+				Expect(err).To(Equal(models.ErrRevisionCompacted))
+			})
+		})
+
+		It("disconnects subscribers that fall too far behind", func() {
+			for i := 0; i < 5; i++ {
+				l := *lock
+				l.Resource = &models.Resource{Key: lock.Key, Owner: lock.Owner, Value: string(rune('a' + i))}
+				l.ModifiedIndex = int64(i)
+				hub.Upsert(logger, &l)
+			}
+
+			for range 4 {
+				Expect(sub.Events()).To(Receive())
+			}
+			Eventually(sub.Events()).Should(BeClosed())
+			Expect(sub.Err()).To(Equal(models.ErrWatcherTooSlow))
+		})
+
+		It("closes the events channel when the subscription is closed", func() {
+			sub.Close()
+			Eventually(sub.Events()).Should(BeClosed())
+			Expect(sub.Err()).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1 @@
+package watch // import "code.cloudfoundry.org/locket/watch"
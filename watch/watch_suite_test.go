@@ -0,0 +1,13 @@
+package watch_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestWatch(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Watch Suite")
+}
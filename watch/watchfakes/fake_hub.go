@@ -0,0 +1,273 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package watchfakes
+
+import (
+	"sync"
+
+	lager "code.cloudfoundry.org/lager/v3"
+	"code.cloudfoundry.org/locket/db"
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/watch"
+)
+
+type FakeHub struct {
+	RemoveStub        func(lager.Logger, *models.Resource, models.EventType)
+	removeMutex       sync.RWMutex
+	removeArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *models.Resource
+		arg3 models.EventType
+	}
+	SubscribeStub        func(watch.Filter, int64) (watch.Subscription, error)
+	subscribeMutex       sync.RWMutex
+	subscribeArgsForCall []struct {
+		arg1 watch.Filter
+		arg2 int64
+	}
+	subscribeReturns struct {
+		result1 watch.Subscription
+		result2 error
+	}
+	subscribeReturnsOnCall map[int]struct {
+		result1 watch.Subscription
+		result2 error
+	}
+	SyncStub        func(lager.Logger, func() ([]*db.Lock, error)) error
+	syncMutex       sync.RWMutex
+	syncArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 func() ([]*db.Lock, error)
+	}
+	syncReturns struct {
+		result1 error
+	}
+	syncReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpsertStub        func(lager.Logger, *db.Lock)
+	upsertMutex       sync.RWMutex
+	upsertArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *db.Lock
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeHub) Remove(arg1 lager.Logger, arg2 *models.Resource, arg3 models.EventType) {
+	fake.removeMutex.Lock()
+	fake.removeArgsForCall = append(fake.removeArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *models.Resource
+		arg3 models.EventType
+	}{arg1, arg2, arg3})
+	stub := fake.RemoveStub
+	fake.recordInvocation("Remove", []interface{}{arg1, arg2, arg3})
+	fake.removeMutex.Unlock()
+	if stub != nil {
+		fake.RemoveStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeHub) RemoveCallCount() int {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	return len(fake.removeArgsForCall)
+}
+
+func (fake *FakeHub) RemoveCalls(stub func(lager.Logger, *models.Resource, models.EventType)) {
+	fake.removeMutex.Lock()
+	defer fake.removeMutex.Unlock()
+	fake.RemoveStub = stub
+}
+
+func (fake *FakeHub) RemoveArgsForCall(i int) (lager.Logger, *models.Resource, models.EventType) {
+	fake.removeMutex.RLock()
+	defer fake.removeMutex.RUnlock()
+	argsForCall := fake.removeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeHub) Subscribe(arg1 watch.Filter, arg2 int64) (watch.Subscription, error) {
+	fake.subscribeMutex.Lock()
+	ret, specificReturn := fake.subscribeReturnsOnCall[len(fake.subscribeArgsForCall)]
+	fake.subscribeArgsForCall = append(fake.subscribeArgsForCall, struct {
+		arg1 watch.Filter
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.SubscribeStub
+	fakeReturns := fake.subscribeReturns
+	fake.recordInvocation("Subscribe", []interface{}{arg1, arg2})
+	fake.subscribeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeHub) SubscribeCallCount() int {
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	return len(fake.subscribeArgsForCall)
+}
+
+func (fake *FakeHub) SubscribeCalls(stub func(watch.Filter, int64) (watch.Subscription, error)) {
+	fake.subscribeMutex.Lock()
+	defer fake.subscribeMutex.Unlock()
+	fake.SubscribeStub = stub
+}
+
+func (fake *FakeHub) SubscribeArgsForCall(i int) (watch.Filter, int64) {
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	argsForCall := fake.subscribeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHub) SubscribeReturns(result1 watch.Subscription, result2 error) {
+	fake.subscribeMutex.Lock()
+	defer fake.subscribeMutex.Unlock()
+	fake.SubscribeStub = nil
+	fake.subscribeReturns = struct {
+		result1 watch.Subscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHub) SubscribeReturnsOnCall(i int, result1 watch.Subscription, result2 error) {
+	fake.subscribeMutex.Lock()
+	defer fake.subscribeMutex.Unlock()
+	fake.SubscribeStub = nil
+	if fake.subscribeReturnsOnCall == nil {
+		fake.subscribeReturnsOnCall = make(map[int]struct {
+			result1 watch.Subscription
+			result2 error
+		})
+	}
+	fake.subscribeReturnsOnCall[i] = struct {
+		result1 watch.Subscription
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHub) Sync(arg1 lager.Logger, arg2 func() ([]*db.Lock, error)) error {
+	fake.syncMutex.Lock()
+	ret, specificReturn := fake.syncReturnsOnCall[len(fake.syncArgsForCall)]
+	fake.syncArgsForCall = append(fake.syncArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 func() ([]*db.Lock, error)
+	}{arg1, arg2})
+	stub := fake.SyncStub
+	fakeReturns := fake.syncReturns
+	fake.recordInvocation("Sync", []interface{}{arg1, arg2})
+	fake.syncMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeHub) SyncCallCount() int {
+	fake.syncMutex.RLock()
+	defer fake.syncMutex.RUnlock()
+	return len(fake.syncArgsForCall)
+}
+
+func (fake *FakeHub) SyncCalls(stub func(lager.Logger, func() ([]*db.Lock, error)) error) {
+	fake.syncMutex.Lock()
+	defer fake.syncMutex.Unlock()
+	fake.SyncStub = stub
+}
+
+func (fake *FakeHub) SyncArgsForCall(i int) (lager.Logger, func() ([]*db.Lock, error)) {
+	fake.syncMutex.RLock()
+	defer fake.syncMutex.RUnlock()
+	argsForCall := fake.syncArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHub) SyncReturns(result1 error) {
+	fake.syncMutex.Lock()
+	defer fake.syncMutex.Unlock()
+	fake.SyncStub = nil
+	fake.syncReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHub) SyncReturnsOnCall(i int, result1 error) {
+	fake.syncMutex.Lock()
+	defer fake.syncMutex.Unlock()
+	fake.SyncStub = nil
+	if fake.syncReturnsOnCall == nil {
+		fake.syncReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.syncReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHub) Upsert(arg1 lager.Logger, arg2 *db.Lock) {
+	fake.upsertMutex.Lock()
+	fake.upsertArgsForCall = append(fake.upsertArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *db.Lock
+	}{arg1, arg2})
+	stub := fake.UpsertStub
+	fake.recordInvocation("Upsert", []interface{}{arg1, arg2})
+	fake.upsertMutex.Unlock()
+	if stub != nil {
+		fake.UpsertStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeHub) UpsertCallCount() int {
+	fake.upsertMutex.RLock()
+	defer fake.upsertMutex.RUnlock()
+	return len(fake.upsertArgsForCall)
+}
+
+func (fake *FakeHub) UpsertCalls(stub func(lager.Logger, *db.Lock)) {
+	fake.upsertMutex.Lock()
+	defer fake.upsertMutex.Unlock()
+	fake.UpsertStub = stub
+}
+
+func (fake *FakeHub) UpsertArgsForCall(i int) (lager.Logger, *db.Lock) {
+	fake.upsertMutex.RLock()
+	defer fake.upsertMutex.RUnlock()
+	argsForCall := fake.upsertArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHub) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeHub) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ watch.Hub = new(FakeHub)
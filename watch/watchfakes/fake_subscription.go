@@ -0,0 +1,192 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package watchfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/locket/models"
+	"code.cloudfoundry.org/locket/watch"
+)
+
+type FakeSubscription struct {
+	CloseStub        func()
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+	}
+	ErrStub        func() error
+	errMutex       sync.RWMutex
+	errArgsForCall []struct {
+	}
+	errReturns struct {
+		result1 error
+	}
+	errReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EventsStub        func() <-chan *models.WatchEvent
+	eventsMutex       sync.RWMutex
+	eventsArgsForCall []struct {
+	}
+	eventsReturns struct {
+		result1 <-chan *models.WatchEvent
+	}
+	eventsReturnsOnCall map[int]struct {
+		result1 <-chan *models.WatchEvent
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSubscription) Close() {
+	fake.closeMutex.Lock()
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+	}{})
+	stub := fake.CloseStub
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if stub != nil {
+		fake.CloseStub()
+	}
+}
+
+func (fake *FakeSubscription) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeSubscription) CloseCalls(stub func()) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
+func (fake *FakeSubscription) Err() error {
+	fake.errMutex.Lock()
+	ret, specificReturn := fake.errReturnsOnCall[len(fake.errArgsForCall)]
+	fake.errArgsForCall = append(fake.errArgsForCall, struct {
+	}{})
+	stub := fake.ErrStub
+	fakeReturns := fake.errReturns
+	fake.recordInvocation("Err", []interface{}{})
+	fake.errMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSubscription) ErrCallCount() int {
+	fake.errMutex.RLock()
+	defer fake.errMutex.RUnlock()
+	return len(fake.errArgsForCall)
+}
+
+func (fake *FakeSubscription) ErrCalls(stub func() error) {
+	fake.errMutex.Lock()
+	defer fake.errMutex.Unlock()
+	fake.ErrStub = stub
+}
+
+func (fake *FakeSubscription) ErrReturns(result1 error) {
+	fake.errMutex.Lock()
+	defer fake.errMutex.Unlock()
+	fake.ErrStub = nil
+	fake.errReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSubscription) ErrReturnsOnCall(i int, result1 error) {
+	fake.errMutex.Lock()
+	defer fake.errMutex.Unlock()
+	fake.ErrStub = nil
+	if fake.errReturnsOnCall == nil {
+		fake.errReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.errReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSubscription) Events() <-chan *models.WatchEvent {
+	fake.eventsMutex.Lock()
+	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+	}{})
+	stub := fake.EventsStub
+	fakeReturns := fake.eventsReturns
+	fake.recordInvocation("Events", []interface{}{})
+	fake.eventsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSubscription) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeSubscription) EventsCalls(stub func() <-chan *models.WatchEvent) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = stub
+}
+
+func (fake *FakeSubscription) EventsReturns(result1 <-chan *models.WatchEvent) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = nil
+	fake.eventsReturns = struct {
+		result1 <-chan *models.WatchEvent
+	}{result1}
+}
+
+func (fake *FakeSubscription) EventsReturnsOnCall(i int, result1 <-chan *models.WatchEvent) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = nil
+	if fake.eventsReturnsOnCall == nil {
+		fake.eventsReturnsOnCall = make(map[int]struct {
+			result1 <-chan *models.WatchEvent
+		})
+	}
+	fake.eventsReturnsOnCall[i] = struct {
+		result1 <-chan *models.WatchEvent
+	}{result1}
+}
+
+func (fake *FakeSubscription) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSubscription) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ watch.Subscription = new(FakeSubscription)
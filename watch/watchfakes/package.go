@@ -0,0 +1 @@
+package watchfakes // import "code.cloudfoundry.org/locket/watch/watchfakes"